@@ -34,4 +34,26 @@ func main() {
   ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
   defer cancel()
 
+  // Call GetTeams
+  res, err := c.GetTeams(ctx, &v1.GetTeamsRequest{
+    Api:   apiVersion,
+    Limit: 5,
+  })
+  if err != nil {
+    log.Fatalf("GetTeams failed: %v", err)
+  }
+  log.Printf("GetTeams result: <%+v>\n\n", res)
+
+  // Call GetTeams for the next page
+  if res.NextPageToken != "" {
+    res, err = c.GetTeams(ctx, &v1.GetTeamsRequest{
+      Api:       apiVersion,
+      Limit:     5,
+      PageToken: res.NextPageToken,
+    })
+    if err != nil {
+      log.Fatalf("GetTeams next page failed: %v", err)
+    }
+    log.Printf("GetTeams next page result: <%+v>\n\n", res)
+  }
 }
@@ -8,7 +8,6 @@ import (
   "log"
   "net/http"
   "strings"
-  "time"
 )
 
 func main() {
@@ -16,14 +15,11 @@ func main() {
   address := flag.String("server", "http://localhost:8082", "HTTP gateway url, e.g. http://localhost:8082")
   flag.Parse()
 
-  t := time.Now().In(time.UTC)
-  pfx := t.Format(time.RFC3339Nano)
-
   var body string
   log.Printf("\nAddress received: %s\n", *address)
 
   // Call CreateTeam
-  resp, err := http.Post(*address+"/v1/teams", "application/json", strings.NewReader(fmt.Sprintf(`
+  resp, err := http.Post(*address+"/v1/teams", "application/json", strings.NewReader(`
     {
       "api":"v1",
       "team": {
@@ -36,7 +32,7 @@ func main() {
         "skills": ["frontend", "design"]
       }
     }
-  `, pfx, pfx, pfx)))
+  `))
   if err != nil {
     log.Fatalf("failed to call CreateTeam method: %v", err)
   }
@@ -64,7 +60,7 @@ func main() {
   createdTeamId := upsertTeam.Id
 
   // Call CreateTeam
-  resp, err = http.Post(*address+"/v1/teams", "application/json", strings.NewReader(fmt.Sprintf(`
+  resp, err = http.Post(*address+"/v1/teams", "application/json", strings.NewReader(`
     {
       "api":"v1",
       "team": {
@@ -77,7 +73,7 @@ func main() {
         "skills": ["frontend", "devops"]
       }
     }
-  `, pfx, pfx, pfx)))
+  `))
   if err != nil {
     log.Fatalf("failed to call CreateTeam method: %v", err)
   }
@@ -91,14 +87,14 @@ func main() {
   log.Printf("CreateTeam2 response: Code=%d, Body=%s\n\n", resp.StatusCode, body)
 
   // Call AddMember
-  resp, err = http.Post(*address+"/v1/teams/"+createdTeamId+"/members", "application/json", strings.NewReader(fmt.Sprintf(`
+  resp, err = http.Post(*address+"/v1/teams/"+createdTeamId+"/members", "application/json", strings.NewReader(`
     {
       "api":"v1",
       "member_id": "3",
       "member_email": "freddy@yahoo.com",
       "role": "frontend"
     }
-  `, pfx, pfx, pfx)))
+  `))
   if err != nil {
     log.Fatalf("failed to call AddMember method: %v", err)
   }
@@ -127,7 +123,7 @@ func main() {
   log.Printf("GetTeamByTeamId response: Code=%d, Body=%s\n\n", resp.StatusCode, body)
 
   // Call UpsertProject
-  resp, err = http.Post(*address+"/v1/teams/"+createdTeamId+"/project", "application/json", strings.NewReader(fmt.Sprintf(`
+  resp, err = http.Post(*address+"/v1/teams/"+createdTeamId+"/project", "application/json", strings.NewReader(`
     {
       "api":"v1",
       "project": {
@@ -139,7 +135,7 @@ func main() {
         "duration": 4
       }
     }
-  `, pfx, pfx, pfx)))
+  `))
   if err != nil {
     log.Fatalf("failed to call UpsertProject method: %v", err)
   }
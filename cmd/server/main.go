@@ -8,7 +8,17 @@ import (
 )
 
 func main() {
-  if err := cmd.RunServer(); err != nil {
+  run := cmd.RunServer
+  if len(os.Args) > 1 {
+    switch os.Args[1] {
+    case "migrate":
+      run = func() error { return cmd.RunMigrate(os.Args[2:]) }
+    case "reindex":
+      run = func() error { return cmd.RunReindex(os.Args[2:]) }
+    }
+  }
+
+  if err := run(); err != nil {
     fmt.Fprintf(os.Stderr, "%v\n", err)
     os.Exit(1)
   }
@@ -1,19 +1,25 @@
 module github.com/ckbball/dev-team
 
-go 1.13
+go 1.16
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.0
 	github.com/Shopify/sarama v1.24.1
 	github.com/ThreeDotsLabs/watermill v1.1.0
 	github.com/ThreeDotsLabs/watermill-kafka/v2 v2.1.0
+	github.com/alicebob/miniredis/v2 v2.14.3
+	github.com/blevesearch/bleve v1.0.14
 	github.com/ckbball/dev-user v0.0.0-20200128235309-bebec97417f0
 	github.com/go-redis/cache/v7 v7.0.2
 	github.com/go-redis/redis/v7 v7.0.0-beta.5
 	github.com/go-sql-driver/mysql v1.5.0
-	github.com/golang/protobuf v1.3.2
+	github.com/golang-jwt/jwt/v4 v4.3.0
+	github.com/golang/protobuf v1.3.3
+	github.com/grpc-ecosystem/go-grpc-middleware v1.1.0
 	github.com/grpc-ecosystem/grpc-gateway v1.12.1
 	github.com/klauspost/cpuid v1.2.2 // indirect
 	github.com/vmihailenco/msgpack/v4 v4.3.5
-	google.golang.org/genproto v0.0.0-20200108215221-bd8f9a0ef82f
-	google.golang.org/grpc v1.26.0
+	go.uber.org/zap v1.13.0
+	google.golang.org/genproto v0.0.0-20200317114155-1f3552e48f24
+	google.golang.org/grpc v1.27.0
 )
@@ -0,0 +1,1737 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: events.proto
+
+package team
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type TeamCreated struct {
+	TeamId               string    `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Leader               string    `protobuf:"bytes,2,opt,name=leader,proto3" json:"leader,omitempty"`
+	Name                 string    `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	OpenRoles            int32     `protobuf:"varint,4,opt,name=open_roles,json=openRoles,proto3" json:"open_roles,omitempty"`
+	Size                 int32     `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Skills               []string  `protobuf:"bytes,6,rep,name=skills,proto3" json:"skills,omitempty"`
+	Members              []*Member `protobuf:"bytes,7,rep,name=members,proto3" json:"members,omitempty"`
+	OccurredAt           int64     `protobuf:"varint,8,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *TeamCreated) Reset()         { *m = TeamCreated{} }
+func (m *TeamCreated) String() string { return proto.CompactTextString(m) }
+func (*TeamCreated) ProtoMessage()    {}
+func (*TeamCreated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{0}
+}
+
+func (m *TeamCreated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeamCreated.Unmarshal(m, b)
+}
+func (m *TeamCreated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeamCreated.Marshal(b, m, deterministic)
+}
+func (m *TeamCreated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamCreated.Merge(m, src)
+}
+func (m *TeamCreated) XXX_Size() int {
+	return xxx_messageInfo_TeamCreated.Size(m)
+}
+func (m *TeamCreated) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamCreated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamCreated proto.InternalMessageInfo
+
+func (m *TeamCreated) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *TeamCreated) GetLeader() string {
+	if m != nil {
+		return m.Leader
+	}
+	return ""
+}
+
+func (m *TeamCreated) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TeamCreated) GetOpenRoles() int32 {
+	if m != nil {
+		return m.OpenRoles
+	}
+	return 0
+}
+
+func (m *TeamCreated) GetSize() int32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *TeamCreated) GetSkills() []string {
+	if m != nil {
+		return m.Skills
+	}
+	return nil
+}
+
+func (m *TeamCreated) GetMembers() []*Member {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+func (m *TeamCreated) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type TeamUpdated struct {
+	TeamId string `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// paths are the fields that were updated
+	Paths                 []string `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	Name                  string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	OpenRoles             int32    `protobuf:"varint,4,opt,name=open_roles,json=openRoles,proto3" json:"open_roles,omitempty"`
+	Size                  int32    `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Skills                []string `protobuf:"bytes,6,rep,name=skills,proto3" json:"skills,omitempty"`
+	AutoCloseApplications bool     `protobuf:"varint,7,opt,name=auto_close_applications,json=autoCloseApplications,proto3" json:"auto_close_applications,omitempty"`
+	OccurredAt            int64    `protobuf:"varint,8,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *TeamUpdated) Reset()         { *m = TeamUpdated{} }
+func (m *TeamUpdated) String() string { return proto.CompactTextString(m) }
+func (*TeamUpdated) ProtoMessage()    {}
+func (*TeamUpdated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{1}
+}
+
+func (m *TeamUpdated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeamUpdated.Unmarshal(m, b)
+}
+func (m *TeamUpdated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeamUpdated.Marshal(b, m, deterministic)
+}
+func (m *TeamUpdated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamUpdated.Merge(m, src)
+}
+func (m *TeamUpdated) XXX_Size() int {
+	return xxx_messageInfo_TeamUpdated.Size(m)
+}
+func (m *TeamUpdated) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamUpdated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamUpdated proto.InternalMessageInfo
+
+func (m *TeamUpdated) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *TeamUpdated) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+func (m *TeamUpdated) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TeamUpdated) GetOpenRoles() int32 {
+	if m != nil {
+		return m.OpenRoles
+	}
+	return 0
+}
+
+func (m *TeamUpdated) GetSize() int32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *TeamUpdated) GetSkills() []string {
+	if m != nil {
+		return m.Skills
+	}
+	return nil
+}
+
+func (m *TeamUpdated) GetAutoCloseApplications() bool {
+	if m != nil {
+		return m.AutoCloseApplications
+	}
+	return false
+}
+
+func (m *TeamUpdated) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+// TeamDeleted is published when a team is moved to the trash
+type TeamDeleted struct {
+	TeamId     string `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	OccurredAt int64  `protobuf:"varint,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	DeletedBy  string `protobuf:"bytes,3,opt,name=deleted_by,json=deletedBy,proto3" json:"deleted_by,omitempty"`
+	// purge_at is when the team is deleted for good unless restored
+	PurgeAt              int64    `protobuf:"varint,4,opt,name=purge_at,json=purgeAt,proto3" json:"purge_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TeamDeleted) Reset()         { *m = TeamDeleted{} }
+func (m *TeamDeleted) String() string { return proto.CompactTextString(m) }
+func (*TeamDeleted) ProtoMessage()    {}
+func (*TeamDeleted) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{2}
+}
+
+func (m *TeamDeleted) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeamDeleted.Unmarshal(m, b)
+}
+func (m *TeamDeleted) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeamDeleted.Marshal(b, m, deterministic)
+}
+func (m *TeamDeleted) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamDeleted.Merge(m, src)
+}
+func (m *TeamDeleted) XXX_Size() int {
+	return xxx_messageInfo_TeamDeleted.Size(m)
+}
+func (m *TeamDeleted) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamDeleted.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamDeleted proto.InternalMessageInfo
+
+func (m *TeamDeleted) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *TeamDeleted) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+func (m *TeamDeleted) GetDeletedBy() string {
+	if m != nil {
+		return m.DeletedBy
+	}
+	return ""
+}
+
+func (m *TeamDeleted) GetPurgeAt() int64 {
+	if m != nil {
+		return m.PurgeAt
+	}
+	return 0
+}
+
+type TeamRestored struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	RestoredBy           string   `protobuf:"bytes,2,opt,name=restored_by,json=restoredBy,proto3" json:"restored_by,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TeamRestored) Reset()         { *m = TeamRestored{} }
+func (m *TeamRestored) String() string { return proto.CompactTextString(m) }
+func (*TeamRestored) ProtoMessage()    {}
+func (*TeamRestored) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{3}
+}
+
+func (m *TeamRestored) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeamRestored.Unmarshal(m, b)
+}
+func (m *TeamRestored) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeamRestored.Marshal(b, m, deterministic)
+}
+func (m *TeamRestored) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamRestored.Merge(m, src)
+}
+func (m *TeamRestored) XXX_Size() int {
+	return xxx_messageInfo_TeamRestored.Size(m)
+}
+func (m *TeamRestored) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamRestored.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamRestored proto.InternalMessageInfo
+
+func (m *TeamRestored) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *TeamRestored) GetRestoredBy() string {
+	if m != nil {
+		return m.RestoredBy
+	}
+	return ""
+}
+
+func (m *TeamRestored) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+// TeamPurged is published when a team is deleted for good
+type TeamPurged struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TeamPurged) Reset()         { *m = TeamPurged{} }
+func (m *TeamPurged) String() string { return proto.CompactTextString(m) }
+func (*TeamPurged) ProtoMessage()    {}
+func (*TeamPurged) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{4}
+}
+
+func (m *TeamPurged) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeamPurged.Unmarshal(m, b)
+}
+func (m *TeamPurged) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeamPurged.Marshal(b, m, deterministic)
+}
+func (m *TeamPurged) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamPurged.Merge(m, src)
+}
+func (m *TeamPurged) XXX_Size() int {
+	return xxx_messageInfo_TeamPurged.Size(m)
+}
+func (m *TeamPurged) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamPurged.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamPurged proto.InternalMessageInfo
+
+func (m *TeamPurged) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *TeamPurged) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+// TeamInactive is published when a team is flagged inactive, its owner is
+// to be told it will be archived at archive_at unless it is active again
+type TeamInactive struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Leader               string   `protobuf:"bytes,2,opt,name=leader,proto3" json:"leader,omitempty"`
+	LastActive           int64    `protobuf:"varint,3,opt,name=last_active,json=lastActive,proto3" json:"last_active,omitempty"`
+	ArchiveAt            int64    `protobuf:"varint,4,opt,name=archive_at,json=archiveAt,proto3" json:"archive_at,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TeamInactive) Reset()         { *m = TeamInactive{} }
+func (m *TeamInactive) String() string { return proto.CompactTextString(m) }
+func (*TeamInactive) ProtoMessage()    {}
+func (*TeamInactive) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{5}
+}
+
+func (m *TeamInactive) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeamInactive.Unmarshal(m, b)
+}
+func (m *TeamInactive) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeamInactive.Marshal(b, m, deterministic)
+}
+func (m *TeamInactive) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamInactive.Merge(m, src)
+}
+func (m *TeamInactive) XXX_Size() int {
+	return xxx_messageInfo_TeamInactive.Size(m)
+}
+func (m *TeamInactive) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamInactive.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamInactive proto.InternalMessageInfo
+
+func (m *TeamInactive) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *TeamInactive) GetLeader() string {
+	if m != nil {
+		return m.Leader
+	}
+	return ""
+}
+
+func (m *TeamInactive) GetLastActive() int64 {
+	if m != nil {
+		return m.LastActive
+	}
+	return 0
+}
+
+func (m *TeamInactive) GetArchiveAt() int64 {
+	if m != nil {
+		return m.ArchiveAt
+	}
+	return 0
+}
+
+func (m *TeamInactive) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+// TeamArchived is published when an inactive team is archived
+type TeamArchived struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Leader               string   `protobuf:"bytes,2,opt,name=leader,proto3" json:"leader,omitempty"`
+	LastActive           int64    `protobuf:"varint,3,opt,name=last_active,json=lastActive,proto3" json:"last_active,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TeamArchived) Reset()         { *m = TeamArchived{} }
+func (m *TeamArchived) String() string { return proto.CompactTextString(m) }
+func (*TeamArchived) ProtoMessage()    {}
+func (*TeamArchived) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{6}
+}
+
+func (m *TeamArchived) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeamArchived.Unmarshal(m, b)
+}
+func (m *TeamArchived) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeamArchived.Marshal(b, m, deterministic)
+}
+func (m *TeamArchived) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamArchived.Merge(m, src)
+}
+func (m *TeamArchived) XXX_Size() int {
+	return xxx_messageInfo_TeamArchived.Size(m)
+}
+func (m *TeamArchived) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamArchived.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamArchived proto.InternalMessageInfo
+
+func (m *TeamArchived) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *TeamArchived) GetLeader() string {
+	if m != nil {
+		return m.Leader
+	}
+	return ""
+}
+
+func (m *TeamArchived) GetLastActive() int64 {
+	if m != nil {
+		return m.LastActive
+	}
+	return 0
+}
+
+func (m *TeamArchived) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type MemberAdded struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	MemberNumber         string   `protobuf:"bytes,2,opt,name=member_number,json=memberNumber,proto3" json:"member_number,omitempty"`
+	UserId               string   `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email                string   `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Role                 string   `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	AccessRole           string   `protobuf:"bytes,7,opt,name=access_role,json=accessRole,proto3" json:"access_role,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MemberAdded) Reset()         { *m = MemberAdded{} }
+func (m *MemberAdded) String() string { return proto.CompactTextString(m) }
+func (*MemberAdded) ProtoMessage()    {}
+func (*MemberAdded) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{7}
+}
+
+func (m *MemberAdded) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemberAdded.Unmarshal(m, b)
+}
+func (m *MemberAdded) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemberAdded.Marshal(b, m, deterministic)
+}
+func (m *MemberAdded) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberAdded.Merge(m, src)
+}
+func (m *MemberAdded) XXX_Size() int {
+	return xxx_messageInfo_MemberAdded.Size(m)
+}
+func (m *MemberAdded) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberAdded.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemberAdded proto.InternalMessageInfo
+
+func (m *MemberAdded) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *MemberAdded) GetMemberNumber() string {
+	if m != nil {
+		return m.MemberNumber
+	}
+	return ""
+}
+
+func (m *MemberAdded) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *MemberAdded) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *MemberAdded) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *MemberAdded) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+func (m *MemberAdded) GetAccessRole() string {
+	if m != nil {
+		return m.AccessRole
+	}
+	return ""
+}
+
+type MemberRemoved struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	MemberNumber         string   `protobuf:"bytes,2,opt,name=member_number,json=memberNumber,proto3" json:"member_number,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MemberRemoved) Reset()         { *m = MemberRemoved{} }
+func (m *MemberRemoved) String() string { return proto.CompactTextString(m) }
+func (*MemberRemoved) ProtoMessage()    {}
+func (*MemberRemoved) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{8}
+}
+
+func (m *MemberRemoved) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemberRemoved.Unmarshal(m, b)
+}
+func (m *MemberRemoved) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemberRemoved.Marshal(b, m, deterministic)
+}
+func (m *MemberRemoved) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberRemoved.Merge(m, src)
+}
+func (m *MemberRemoved) XXX_Size() int {
+	return xxx_messageInfo_MemberRemoved.Size(m)
+}
+func (m *MemberRemoved) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberRemoved.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemberRemoved proto.InternalMessageInfo
+
+func (m *MemberRemoved) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *MemberRemoved) GetMemberNumber() string {
+	if m != nil {
+		return m.MemberNumber
+	}
+	return ""
+}
+
+func (m *MemberRemoved) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type ProjectUpserted struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	ProjectId            int64    `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Project              *Project `protobuf:"bytes,3,opt,name=project,proto3" json:"project,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProjectUpserted) Reset()         { *m = ProjectUpserted{} }
+func (m *ProjectUpserted) String() string { return proto.CompactTextString(m) }
+func (*ProjectUpserted) ProtoMessage()    {}
+func (*ProjectUpserted) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{9}
+}
+
+func (m *ProjectUpserted) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProjectUpserted.Unmarshal(m, b)
+}
+func (m *ProjectUpserted) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProjectUpserted.Marshal(b, m, deterministic)
+}
+func (m *ProjectUpserted) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProjectUpserted.Merge(m, src)
+}
+func (m *ProjectUpserted) XXX_Size() int {
+	return xxx_messageInfo_ProjectUpserted.Size(m)
+}
+func (m *ProjectUpserted) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProjectUpserted.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProjectUpserted proto.InternalMessageInfo
+
+func (m *ProjectUpserted) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *ProjectUpserted) GetProjectId() int64 {
+	if m != nil {
+		return m.ProjectId
+	}
+	return 0
+}
+
+func (m *ProjectUpserted) GetProject() *Project {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+func (m *ProjectUpserted) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type ProjectCreated struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Project              *Project `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProjectCreated) Reset()         { *m = ProjectCreated{} }
+func (m *ProjectCreated) String() string { return proto.CompactTextString(m) }
+func (*ProjectCreated) ProtoMessage()    {}
+func (*ProjectCreated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{10}
+}
+
+func (m *ProjectCreated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProjectCreated.Unmarshal(m, b)
+}
+func (m *ProjectCreated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProjectCreated.Marshal(b, m, deterministic)
+}
+func (m *ProjectCreated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProjectCreated.Merge(m, src)
+}
+func (m *ProjectCreated) XXX_Size() int {
+	return xxx_messageInfo_ProjectCreated.Size(m)
+}
+func (m *ProjectCreated) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProjectCreated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProjectCreated proto.InternalMessageInfo
+
+func (m *ProjectCreated) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *ProjectCreated) GetProject() *Project {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+func (m *ProjectCreated) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type ProjectUpdated struct {
+	TeamId string `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// paths are the fields that changed
+	Paths                []string `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	Project              *Project `protobuf:"bytes,3,opt,name=project,proto3" json:"project,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProjectUpdated) Reset()         { *m = ProjectUpdated{} }
+func (m *ProjectUpdated) String() string { return proto.CompactTextString(m) }
+func (*ProjectUpdated) ProtoMessage()    {}
+func (*ProjectUpdated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{11}
+}
+
+func (m *ProjectUpdated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProjectUpdated.Unmarshal(m, b)
+}
+func (m *ProjectUpdated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProjectUpdated.Marshal(b, m, deterministic)
+}
+func (m *ProjectUpdated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProjectUpdated.Merge(m, src)
+}
+func (m *ProjectUpdated) XXX_Size() int {
+	return xxx_messageInfo_ProjectUpdated.Size(m)
+}
+func (m *ProjectUpdated) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProjectUpdated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProjectUpdated proto.InternalMessageInfo
+
+func (m *ProjectUpdated) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *ProjectUpdated) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+func (m *ProjectUpdated) GetProject() *Project {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+func (m *ProjectUpdated) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type ProjectArchived struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	ProjectId            string   `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProjectArchived) Reset()         { *m = ProjectArchived{} }
+func (m *ProjectArchived) String() string { return proto.CompactTextString(m) }
+func (*ProjectArchived) ProtoMessage()    {}
+func (*ProjectArchived) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{12}
+}
+
+func (m *ProjectArchived) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProjectArchived.Unmarshal(m, b)
+}
+func (m *ProjectArchived) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProjectArchived.Marshal(b, m, deterministic)
+}
+func (m *ProjectArchived) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProjectArchived.Merge(m, src)
+}
+func (m *ProjectArchived) XXX_Size() int {
+	return xxx_messageInfo_ProjectArchived.Size(m)
+}
+func (m *ProjectArchived) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProjectArchived.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProjectArchived proto.InternalMessageInfo
+
+func (m *ProjectArchived) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *ProjectArchived) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+func (m *ProjectArchived) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type MilestoneCreated struct {
+	TeamId               string     `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Milestone            *Milestone `protobuf:"bytes,2,opt,name=milestone,proto3" json:"milestone,omitempty"`
+	OccurredAt           int64      `protobuf:"varint,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *MilestoneCreated) Reset()         { *m = MilestoneCreated{} }
+func (m *MilestoneCreated) String() string { return proto.CompactTextString(m) }
+func (*MilestoneCreated) ProtoMessage()    {}
+func (*MilestoneCreated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{13}
+}
+
+func (m *MilestoneCreated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MilestoneCreated.Unmarshal(m, b)
+}
+func (m *MilestoneCreated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MilestoneCreated.Marshal(b, m, deterministic)
+}
+func (m *MilestoneCreated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MilestoneCreated.Merge(m, src)
+}
+func (m *MilestoneCreated) XXX_Size() int {
+	return xxx_messageInfo_MilestoneCreated.Size(m)
+}
+func (m *MilestoneCreated) XXX_DiscardUnknown() {
+	xxx_messageInfo_MilestoneCreated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MilestoneCreated proto.InternalMessageInfo
+
+func (m *MilestoneCreated) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *MilestoneCreated) GetMilestone() *Milestone {
+	if m != nil {
+		return m.Milestone
+	}
+	return nil
+}
+
+func (m *MilestoneCreated) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type MilestoneUpdated struct {
+	TeamId string `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// paths are the fields that changed
+	Paths                []string   `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	Milestone            *Milestone `protobuf:"bytes,3,opt,name=milestone,proto3" json:"milestone,omitempty"`
+	OccurredAt           int64      `protobuf:"varint,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *MilestoneUpdated) Reset()         { *m = MilestoneUpdated{} }
+func (m *MilestoneUpdated) String() string { return proto.CompactTextString(m) }
+func (*MilestoneUpdated) ProtoMessage()    {}
+func (*MilestoneUpdated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{14}
+}
+
+func (m *MilestoneUpdated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MilestoneUpdated.Unmarshal(m, b)
+}
+func (m *MilestoneUpdated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MilestoneUpdated.Marshal(b, m, deterministic)
+}
+func (m *MilestoneUpdated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MilestoneUpdated.Merge(m, src)
+}
+func (m *MilestoneUpdated) XXX_Size() int {
+	return xxx_messageInfo_MilestoneUpdated.Size(m)
+}
+func (m *MilestoneUpdated) XXX_DiscardUnknown() {
+	xxx_messageInfo_MilestoneUpdated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MilestoneUpdated proto.InternalMessageInfo
+
+func (m *MilestoneUpdated) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *MilestoneUpdated) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+func (m *MilestoneUpdated) GetMilestone() *Milestone {
+	if m != nil {
+		return m.Milestone
+	}
+	return nil
+}
+
+func (m *MilestoneUpdated) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type MilestoneDeleted struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	ProjectId            string   `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	MilestoneId          string   `protobuf:"bytes,3,opt,name=milestone_id,json=milestoneId,proto3" json:"milestone_id,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MilestoneDeleted) Reset()         { *m = MilestoneDeleted{} }
+func (m *MilestoneDeleted) String() string { return proto.CompactTextString(m) }
+func (*MilestoneDeleted) ProtoMessage()    {}
+func (*MilestoneDeleted) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{15}
+}
+
+func (m *MilestoneDeleted) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MilestoneDeleted.Unmarshal(m, b)
+}
+func (m *MilestoneDeleted) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MilestoneDeleted.Marshal(b, m, deterministic)
+}
+func (m *MilestoneDeleted) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MilestoneDeleted.Merge(m, src)
+}
+func (m *MilestoneDeleted) XXX_Size() int {
+	return xxx_messageInfo_MilestoneDeleted.Size(m)
+}
+func (m *MilestoneDeleted) XXX_DiscardUnknown() {
+	xxx_messageInfo_MilestoneDeleted.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MilestoneDeleted proto.InternalMessageInfo
+
+func (m *MilestoneDeleted) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *MilestoneDeleted) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+func (m *MilestoneDeleted) GetMilestoneId() string {
+	if m != nil {
+		return m.MilestoneId
+	}
+	return ""
+}
+
+func (m *MilestoneDeleted) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type TaskCreated struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Task                 *Task    `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaskCreated) Reset()         { *m = TaskCreated{} }
+func (m *TaskCreated) String() string { return proto.CompactTextString(m) }
+func (*TaskCreated) ProtoMessage()    {}
+func (*TaskCreated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{16}
+}
+
+func (m *TaskCreated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskCreated.Unmarshal(m, b)
+}
+func (m *TaskCreated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskCreated.Marshal(b, m, deterministic)
+}
+func (m *TaskCreated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskCreated.Merge(m, src)
+}
+func (m *TaskCreated) XXX_Size() int {
+	return xxx_messageInfo_TaskCreated.Size(m)
+}
+func (m *TaskCreated) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskCreated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskCreated proto.InternalMessageInfo
+
+func (m *TaskCreated) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *TaskCreated) GetTask() *Task {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+func (m *TaskCreated) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type TaskUpdated struct {
+	TeamId string `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// paths are the fields that changed
+	Paths                []string `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	Task                 *Task    `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaskUpdated) Reset()         { *m = TaskUpdated{} }
+func (m *TaskUpdated) String() string { return proto.CompactTextString(m) }
+func (*TaskUpdated) ProtoMessage()    {}
+func (*TaskUpdated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{17}
+}
+
+func (m *TaskUpdated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskUpdated.Unmarshal(m, b)
+}
+func (m *TaskUpdated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskUpdated.Marshal(b, m, deterministic)
+}
+func (m *TaskUpdated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskUpdated.Merge(m, src)
+}
+func (m *TaskUpdated) XXX_Size() int {
+	return xxx_messageInfo_TaskUpdated.Size(m)
+}
+func (m *TaskUpdated) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskUpdated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskUpdated proto.InternalMessageInfo
+
+func (m *TaskUpdated) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *TaskUpdated) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+func (m *TaskUpdated) GetTask() *Task {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+func (m *TaskUpdated) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type TaskDeleted struct {
+	TeamId               string   `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	ProjectId            string   `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	TaskId               string   `protobuf:"bytes,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaskDeleted) Reset()         { *m = TaskDeleted{} }
+func (m *TaskDeleted) String() string { return proto.CompactTextString(m) }
+func (*TaskDeleted) ProtoMessage()    {}
+func (*TaskDeleted) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{18}
+}
+
+func (m *TaskDeleted) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskDeleted.Unmarshal(m, b)
+}
+func (m *TaskDeleted) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskDeleted.Marshal(b, m, deterministic)
+}
+func (m *TaskDeleted) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskDeleted.Merge(m, src)
+}
+func (m *TaskDeleted) XXX_Size() int {
+	return xxx_messageInfo_TaskDeleted.Size(m)
+}
+func (m *TaskDeleted) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskDeleted.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskDeleted proto.InternalMessageInfo
+
+func (m *TaskDeleted) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *TaskDeleted) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+func (m *TaskDeleted) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *TaskDeleted) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type LeaderChanged struct {
+	TeamId         string `protobuf:"bytes,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	PreviousLeader string `protobuf:"bytes,2,opt,name=previous_leader,json=previousLeader,proto3" json:"previous_leader,omitempty"`
+	Leader         string `protobuf:"bytes,3,opt,name=leader,proto3" json:"leader,omitempty"`
+	// set when the team was left without a leader and needs attention
+	Orphaned   bool  `protobuf:"varint,4,opt,name=orphaned,proto3" json:"orphaned,omitempty"`
+	OccurredAt int64 `protobuf:"varint,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// reason is transfer when the owner handed the team over, succession when
+	// the leader's account was removed
+	Reason               string   `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaderChanged) Reset()         { *m = LeaderChanged{} }
+func (m *LeaderChanged) String() string { return proto.CompactTextString(m) }
+func (*LeaderChanged) ProtoMessage()    {}
+func (*LeaderChanged) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{19}
+}
+
+func (m *LeaderChanged) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaderChanged.Unmarshal(m, b)
+}
+func (m *LeaderChanged) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaderChanged.Marshal(b, m, deterministic)
+}
+func (m *LeaderChanged) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaderChanged.Merge(m, src)
+}
+func (m *LeaderChanged) XXX_Size() int {
+	return xxx_messageInfo_LeaderChanged.Size(m)
+}
+func (m *LeaderChanged) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaderChanged.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaderChanged proto.InternalMessageInfo
+
+func (m *LeaderChanged) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *LeaderChanged) GetPreviousLeader() string {
+	if m != nil {
+		return m.PreviousLeader
+	}
+	return ""
+}
+
+func (m *LeaderChanged) GetLeader() string {
+	if m != nil {
+		return m.Leader
+	}
+	return ""
+}
+
+func (m *LeaderChanged) GetOrphaned() bool {
+	if m != nil {
+		return m.Orphaned
+	}
+	return false
+}
+
+func (m *LeaderChanged) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+func (m *LeaderChanged) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type MemberInvited struct {
+	InvitationId         string   `protobuf:"bytes,1,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	TeamId               string   `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Email                string   `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role                 string   `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	AccessRole           string   `protobuf:"bytes,5,opt,name=access_role,json=accessRole,proto3" json:"access_role,omitempty"`
+	InvitedBy            string   `protobuf:"bytes,6,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"`
+	ExpiresAt            int64    `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,8,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MemberInvited) Reset()         { *m = MemberInvited{} }
+func (m *MemberInvited) String() string { return proto.CompactTextString(m) }
+func (*MemberInvited) ProtoMessage()    {}
+func (*MemberInvited) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{20}
+}
+
+func (m *MemberInvited) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemberInvited.Unmarshal(m, b)
+}
+func (m *MemberInvited) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemberInvited.Marshal(b, m, deterministic)
+}
+func (m *MemberInvited) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberInvited.Merge(m, src)
+}
+func (m *MemberInvited) XXX_Size() int {
+	return xxx_messageInfo_MemberInvited.Size(m)
+}
+func (m *MemberInvited) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberInvited.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemberInvited proto.InternalMessageInfo
+
+func (m *MemberInvited) GetInvitationId() string {
+	if m != nil {
+		return m.InvitationId
+	}
+	return ""
+}
+
+func (m *MemberInvited) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *MemberInvited) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *MemberInvited) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *MemberInvited) GetAccessRole() string {
+	if m != nil {
+		return m.AccessRole
+	}
+	return ""
+}
+
+func (m *MemberInvited) GetInvitedBy() string {
+	if m != nil {
+		return m.InvitedBy
+	}
+	return ""
+}
+
+func (m *MemberInvited) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *MemberInvited) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type InvitationClosed struct {
+	InvitationId string `protobuf:"bytes,1,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	TeamId       string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// status is accepted, declined or revoked
+	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InvitationClosed) Reset()         { *m = InvitationClosed{} }
+func (m *InvitationClosed) String() string { return proto.CompactTextString(m) }
+func (*InvitationClosed) ProtoMessage()    {}
+func (*InvitationClosed) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{21}
+}
+
+func (m *InvitationClosed) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvitationClosed.Unmarshal(m, b)
+}
+func (m *InvitationClosed) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvitationClosed.Marshal(b, m, deterministic)
+}
+func (m *InvitationClosed) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvitationClosed.Merge(m, src)
+}
+func (m *InvitationClosed) XXX_Size() int {
+	return xxx_messageInfo_InvitationClosed.Size(m)
+}
+func (m *InvitationClosed) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvitationClosed.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvitationClosed proto.InternalMessageInfo
+
+func (m *InvitationClosed) GetInvitationId() string {
+	if m != nil {
+		return m.InvitationId
+	}
+	return ""
+}
+
+func (m *InvitationClosed) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *InvitationClosed) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *InvitationClosed) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type ApplicationSubmitted struct {
+	ApplicationId        string   `protobuf:"bytes,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	TeamId               string   `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	UserId               string   `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role                 string   `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplicationSubmitted) Reset()         { *m = ApplicationSubmitted{} }
+func (m *ApplicationSubmitted) String() string { return proto.CompactTextString(m) }
+func (*ApplicationSubmitted) ProtoMessage()    {}
+func (*ApplicationSubmitted) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{22}
+}
+
+func (m *ApplicationSubmitted) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplicationSubmitted.Unmarshal(m, b)
+}
+func (m *ApplicationSubmitted) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplicationSubmitted.Marshal(b, m, deterministic)
+}
+func (m *ApplicationSubmitted) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplicationSubmitted.Merge(m, src)
+}
+func (m *ApplicationSubmitted) XXX_Size() int {
+	return xxx_messageInfo_ApplicationSubmitted.Size(m)
+}
+func (m *ApplicationSubmitted) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplicationSubmitted.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplicationSubmitted proto.InternalMessageInfo
+
+func (m *ApplicationSubmitted) GetApplicationId() string {
+	if m != nil {
+		return m.ApplicationId
+	}
+	return ""
+}
+
+func (m *ApplicationSubmitted) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *ApplicationSubmitted) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *ApplicationSubmitted) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *ApplicationSubmitted) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type ApplicationClosed struct {
+	ApplicationId string `protobuf:"bytes,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	TeamId        string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	UserId        string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// status is approved, rejected, withdrawn or closed
+	Status               string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplicationClosed) Reset()         { *m = ApplicationClosed{} }
+func (m *ApplicationClosed) String() string { return proto.CompactTextString(m) }
+func (*ApplicationClosed) ProtoMessage()    {}
+func (*ApplicationClosed) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{23}
+}
+
+func (m *ApplicationClosed) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplicationClosed.Unmarshal(m, b)
+}
+func (m *ApplicationClosed) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplicationClosed.Marshal(b, m, deterministic)
+}
+func (m *ApplicationClosed) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplicationClosed.Merge(m, src)
+}
+func (m *ApplicationClosed) XXX_Size() int {
+	return xxx_messageInfo_ApplicationClosed.Size(m)
+}
+func (m *ApplicationClosed) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplicationClosed.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplicationClosed proto.InternalMessageInfo
+
+func (m *ApplicationClosed) GetApplicationId() string {
+	if m != nil {
+		return m.ApplicationId
+	}
+	return ""
+}
+
+func (m *ApplicationClosed) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *ApplicationClosed) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *ApplicationClosed) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ApplicationClosed) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*TeamCreated)(nil), "team.TeamCreated")
+	proto.RegisterType((*TeamUpdated)(nil), "team.TeamUpdated")
+	proto.RegisterType((*TeamDeleted)(nil), "team.TeamDeleted")
+	proto.RegisterType((*TeamRestored)(nil), "team.TeamRestored")
+	proto.RegisterType((*TeamPurged)(nil), "team.TeamPurged")
+	proto.RegisterType((*TeamInactive)(nil), "team.TeamInactive")
+	proto.RegisterType((*TeamArchived)(nil), "team.TeamArchived")
+	proto.RegisterType((*MemberAdded)(nil), "team.MemberAdded")
+	proto.RegisterType((*MemberRemoved)(nil), "team.MemberRemoved")
+	proto.RegisterType((*ProjectUpserted)(nil), "team.ProjectUpserted")
+	proto.RegisterType((*ProjectCreated)(nil), "team.ProjectCreated")
+	proto.RegisterType((*ProjectUpdated)(nil), "team.ProjectUpdated")
+	proto.RegisterType((*ProjectArchived)(nil), "team.ProjectArchived")
+	proto.RegisterType((*MilestoneCreated)(nil), "team.MilestoneCreated")
+	proto.RegisterType((*MilestoneUpdated)(nil), "team.MilestoneUpdated")
+	proto.RegisterType((*MilestoneDeleted)(nil), "team.MilestoneDeleted")
+	proto.RegisterType((*TaskCreated)(nil), "team.TaskCreated")
+	proto.RegisterType((*TaskUpdated)(nil), "team.TaskUpdated")
+	proto.RegisterType((*TaskDeleted)(nil), "team.TaskDeleted")
+	proto.RegisterType((*LeaderChanged)(nil), "team.LeaderChanged")
+	proto.RegisterType((*MemberInvited)(nil), "team.MemberInvited")
+	proto.RegisterType((*InvitationClosed)(nil), "team.InvitationClosed")
+	proto.RegisterType((*ApplicationSubmitted)(nil), "team.ApplicationSubmitted")
+	proto.RegisterType((*ApplicationClosed)(nil), "team.ApplicationClosed")
+}
+
+func init() { proto.RegisterFile("events.proto", fileDescriptor_8f22242cb04491f9) }
+
+var fileDescriptor_8f22242cb04491f9 = []byte{
+	// 957 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xcd, 0x8e, 0xe3, 0x44,
+	0x10, 0x96, 0xc7, 0xf9, 0x73, 0x39, 0x99, 0x59, 0xac, 0x85, 0x35, 0x2b, 0x0d, 0x13, 0xb2, 0x02,
+	0x72, 0x61, 0x0f, 0x8b, 0xc4, 0x3d, 0x33, 0x08, 0x29, 0x12, 0x8b, 0x56, 0x66, 0xf7, 0x6c, 0x75,
+	0xec, 0xd2, 0x8c, 0x77, 0xfc, 0xa7, 0xee, 0x4e, 0xc4, 0x80, 0x10, 0xe2, 0xb8, 0x17, 0x24, 0xc4,
+	0x0b, 0x70, 0x80, 0xd7, 0xe0, 0x19, 0x78, 0x0a, 0x9e, 0x00, 0x89, 0x2b, 0xaa, 0xee, 0x76, 0xe2,
+	0xc4, 0xbb, 0xf1, 0xce, 0x0c, 0x73, 0x89, 0x5c, 0x5f, 0x77, 0x75, 0x7d, 0x55, 0xd5, 0xd5, 0x55,
+	0x81, 0x21, 0xae, 0x30, 0x97, 0xe2, 0x71, 0xc9, 0x0b, 0x59, 0x78, 0x1d, 0x89, 0x2c, 0x7b, 0x08,
+	0xf4, 0xab, 0x91, 0xc9, 0xdf, 0x16, 0xb8, 0xcf, 0x91, 0x65, 0x67, 0x1c, 0x99, 0xc4, 0xd8, 0x7b,
+	0x00, 0x7d, 0x5a, 0x0d, 0x93, 0xd8, 0xb7, 0xc6, 0xd6, 0xd4, 0x09, 0x7a, 0x24, 0xce, 0x63, 0xef,
+	0x3d, 0xe8, 0xa5, 0xc8, 0x62, 0xe4, 0xfe, 0x81, 0xc6, 0xb5, 0xe4, 0x79, 0xd0, 0xc9, 0x59, 0x86,
+	0xbe, 0xad, 0x50, 0xf5, 0xed, 0x1d, 0x03, 0x14, 0x25, 0xe6, 0x21, 0x2f, 0x52, 0x14, 0x7e, 0x67,
+	0x6c, 0x4d, 0xbb, 0x81, 0x43, 0x48, 0x40, 0x00, 0xa9, 0x88, 0xe4, 0x3b, 0xf4, 0xbb, 0x6a, 0x41,
+	0x7d, 0xd3, 0xf1, 0xe2, 0x32, 0x49, 0x53, 0xe1, 0xf7, 0xc6, 0x36, 0x1d, 0xaf, 0x25, 0xef, 0x63,
+	0xe8, 0x67, 0x98, 0x2d, 0x90, 0x0b, 0xbf, 0x3f, 0xb6, 0xa7, 0xee, 0x93, 0xe1, 0x63, 0xc5, 0xfe,
+	0xa9, 0x02, 0x83, 0x6a, 0xd1, 0x3b, 0x01, 0xb7, 0x88, 0xa2, 0x25, 0xe7, 0x18, 0x87, 0x4c, 0xfa,
+	0x83, 0xb1, 0x35, 0xb5, 0x03, 0xa8, 0xa0, 0x99, 0x9c, 0xfc, 0x63, 0x1c, 0x7d, 0x51, 0xc6, 0xfb,
+	0x1d, 0xbd, 0x0f, 0xdd, 0x92, 0xc9, 0x0b, 0xe1, 0x1f, 0x28, 0x22, 0x5a, 0xb8, 0x6b, 0x37, 0x3f,
+	0x87, 0x07, 0x6c, 0x29, 0x8b, 0x30, 0x4a, 0x0b, 0x81, 0x21, 0x2b, 0xcb, 0x34, 0x89, 0x98, 0x4c,
+	0x8a, 0x9c, 0xdc, 0xb6, 0xa6, 0x83, 0xe0, 0x5d, 0x5a, 0x3e, 0xa3, 0xd5, 0x59, 0x6d, 0xb1, 0xdd,
+	0xed, 0x9f, 0x8c, 0xdb, 0x5f, 0x60, 0x8a, 0x7b, 0xdd, 0xde, 0x39, 0xe9, 0x60, 0xf7, 0x24, 0xf2,
+	0x36, 0xd6, 0x87, 0x84, 0x8b, 0x2b, 0x13, 0x07, 0xc7, 0x20, 0xa7, 0x57, 0xde, 0xfb, 0x30, 0x28,
+	0x97, 0xfc, 0x1c, 0x49, 0xb9, 0xa3, 0x94, 0xfb, 0x4a, 0x9e, 0xc9, 0x49, 0x02, 0x43, 0xa2, 0x10,
+	0xa0, 0x90, 0x05, 0x6f, 0xe1, 0xc0, 0xcd, 0x26, 0xb2, 0xa1, 0x2f, 0x1a, 0x54, 0xd0, 0xe9, 0xd5,
+	0x2e, 0x49, 0xbb, 0xe1, 0xee, 0x97, 0x00, 0x64, 0xea, 0x19, 0x59, 0xbe, 0x85, 0xb3, 0x93, 0xdf,
+	0x2c, 0xcd, 0x79, 0x9e, 0xb3, 0x48, 0x26, 0x2b, 0xbc, 0x7e, 0x5d, 0x9c, 0x80, 0x9b, 0x32, 0x21,
+	0x43, 0xad, 0x5f, 0x51, 0x25, 0x68, 0xa6, 0x4f, 0x3c, 0x06, 0x60, 0x3c, 0xba, 0x48, 0x56, 0xb5,
+	0x90, 0x39, 0x06, 0x99, 0xc9, 0x5d, 0x8a, 0xdd, 0xd7, 0x65, 0x56, 0x51, 0x9c, 0x69, 0x95, 0xf8,
+	0x0e, 0x28, 0xee, 0x70, 0xe8, 0x34, 0x38, 0xfc, 0x65, 0x81, 0xab, 0x2b, 0x71, 0x16, 0xc7, 0xfb,
+	0x28, 0x3c, 0x82, 0x91, 0xae, 0xd4, 0x30, 0x5f, 0x66, 0x8b, 0x35, 0x93, 0xa1, 0x06, 0xbf, 0x56,
+	0x18, 0x69, 0x2f, 0x05, 0x72, 0xd2, 0xd6, 0xd7, 0xab, 0x47, 0xa2, 0x2e, 0x49, 0xcc, 0x58, 0x92,
+	0x2a, 0x06, 0x4e, 0xa0, 0x05, 0xaa, 0x2f, 0xaa, 0x3c, 0x15, 0x1a, 0x27, 0x50, 0xdf, 0xbb, 0x8c,
+	0x7b, 0x8d, 0x5b, 0x7c, 0x02, 0x2e, 0x8b, 0x22, 0x14, 0x42, 0x55, 0xad, 0x2a, 0x2e, 0x27, 0x00,
+	0x0d, 0x51, 0xd9, 0x4e, 0x72, 0x18, 0x99, 0xb7, 0x05, 0xb3, 0x62, 0x75, 0x6b, 0x9f, 0x5a, 0x6f,
+	0xec, 0xaf, 0x16, 0x1c, 0x3d, 0xe3, 0xc5, 0x4b, 0x8c, 0xe4, 0x8b, 0x52, 0x20, 0xdf, 0x5b, 0xa4,
+	0xc7, 0x00, 0xa5, 0xde, 0x4b, 0x6b, 0xfa, 0xda, 0x3a, 0x06, 0x99, 0xc7, 0xde, 0x27, 0xd0, 0x37,
+	0x82, 0x32, 0xe4, 0x3e, 0x19, 0xe9, 0xc7, 0xd2, 0x9c, 0x1f, 0x54, 0xab, 0xed, 0x89, 0x15, 0x70,
+	0x68, 0x94, 0x5a, 0x1b, 0x43, 0xcd, 0xe8, 0xc1, 0x75, 0x8c, 0x36, 0x43, 0xf1, 0xca, 0x5a, 0x5b,
+	0xbd, 0xe1, 0x2b, 0xfd, 0xff, 0x05, 0xe0, 0xe5, 0x3a, 0x2b, 0xed, 0xf5, 0xd5, 0xcc, 0x8a, 0x53,
+	0xcf, 0x4a, 0xab, 0xdf, 0xdf, 0xc3, 0xbd, 0xa7, 0x49, 0x4a, 0xaf, 0x5c, 0x8e, 0xad, 0xe1, 0xfe,
+	0x14, 0x9c, 0xac, 0xda, 0x6c, 0x02, 0x7e, 0x64, 0x5a, 0x62, 0x05, 0x07, 0x9b, 0x1d, 0xed, 0xc6,
+	0x7f, 0xb1, 0x6a, 0xd6, 0x6f, 0x18, 0xf6, 0x2d, 0x4e, 0xf6, 0x75, 0x39, 0x35, 0x83, 0xff, 0x73,
+	0x9d, 0x53, 0x6b, 0xe7, 0x6a, 0x09, 0xff, 0x87, 0x30, 0x5c, 0x9b, 0xde, 0x3c, 0x2d, 0xee, 0x1a,
+	0x6b, 0x66, 0xa8, 0x49, 0xe8, 0x1c, 0xdc, 0xe7, 0x4c, 0x5c, 0xb6, 0x26, 0xe7, 0x03, 0xe8, 0x48,
+	0x26, 0x2e, 0x4d, 0x5e, 0x40, 0xc7, 0x80, 0x34, 0x03, 0x85, 0xb7, 0x67, 0xe3, 0x07, 0x6d, 0xe8,
+	0x86, 0x79, 0xa8, 0xcc, 0xdb, 0x6f, 0x67, 0xbe, 0xe9, 0xe7, 0x8f, 0xda, 0xfc, 0x6d, 0x43, 0x4e,
+	0x7a, 0x4c, 0x5c, 0xd6, 0x1e, 0x72, 0x12, 0xdf, 0x26, 0xd0, 0x7f, 0x5a, 0x30, 0xfa, 0x4a, 0x75,
+	0xa7, 0xb3, 0x0b, 0x96, 0x9f, 0xef, 0x7f, 0x77, 0x8e, 0x4a, 0x8e, 0xab, 0xa4, 0x58, 0x8a, 0x70,
+	0xab, 0xbd, 0x1d, 0x56, 0xb0, 0x3e, 0xa8, 0xd6, 0xfe, 0xec, 0xad, 0xf6, 0xf7, 0x10, 0x06, 0x05,
+	0x2f, 0x2f, 0x58, 0x8e, 0xb1, 0x62, 0x32, 0x08, 0xd6, 0x72, 0x6b, 0xf7, 0xa5, 0x43, 0x39, 0x32,
+	0x51, 0xe4, 0xaa, 0xc7, 0x38, 0x81, 0x91, 0x26, 0xff, 0x5a, 0x55, 0xff, 0x98, 0xe7, 0xab, 0x84,
+	0x82, 0xf8, 0x08, 0x46, 0x09, 0x7d, 0xaa, 0x89, 0x6d, 0xe3, 0xc6, 0x70, 0x03, 0xce, 0xb7, 0xbc,
+	0x3c, 0xd8, 0x4d, 0xb4, 0x6e, 0x7d, 0xf6, 0xeb, 0x5a, 0x5f, 0x67, 0xbb, 0xf5, 0xd5, 0x3b, 0x5b,
+	0x77, 0xb7, 0xb3, 0x51, 0xd2, 0x12, 0xcd, 0x89, 0x86, 0x2b, 0x4d, 0xdb, 0x31, 0xc8, 0xe9, 0x15,
+	0x2d, 0xe3, 0xb7, 0x65, 0xc2, 0x51, 0x90, 0xc7, 0x7d, 0xdd, 0x5b, 0x0c, 0x32, 0x93, 0xed, 0x93,
+	0xe6, 0x2b, 0x0b, 0xee, 0xcd, 0xd7, 0x3e, 0xa9, 0x51, 0xf5, 0xb6, 0xce, 0xd3, 0xb4, 0x2c, 0x99,
+	0x5c, 0x8a, 0x2a, 0x73, 0x5a, 0x6a, 0xbf, 0x46, 0xbf, 0x5b, 0x70, 0xbf, 0x36, 0x27, 0x7f, 0xb3,
+	0x5c, 0x64, 0x89, 0xa4, 0x64, 0x7c, 0x04, 0x87, 0xb5, 0xe1, 0x7a, 0x43, 0x68, 0x54, 0x43, 0xf7,
+	0x31, 0x7a, 0xe3, 0x88, 0xf2, 0x86, 0x8c, 0xec, 0x1f, 0xe1, 0xfe, 0xb0, 0xe0, 0x9d, 0x1a, 0x4d,
+	0x13, 0xb3, 0x3b, 0xe3, 0xb8, 0x09, 0x67, 0x67, 0x5f, 0x38, 0x1b, 0x3c, 0x17, 0x3d, 0xf5, 0x5f,
+	0xf1, 0xb3, 0xff, 0x06, 0x00, 0x28, 0x3b, 0x1b, 0x6f, 0x4d, 0x0e, 0x00, 0x00,
+}
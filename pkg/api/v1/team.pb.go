@@ -7,6 +7,10 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -24,10 +28,77 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type SkillMatch int32
+
+const (
+	// the team looks for at least one of the skills
+	SkillMatch_SKILL_MATCH_ANY SkillMatch = 0
+	// the team looks for every skill
+	SkillMatch_SKILL_MATCH_ALL SkillMatch = 1
+)
+
+var SkillMatch_name = map[int32]string{
+	0: "SKILL_MATCH_ANY",
+	1: "SKILL_MATCH_ALL",
+}
+
+var SkillMatch_value = map[string]int32{
+	"SKILL_MATCH_ANY": 0,
+	"SKILL_MATCH_ALL": 1,
+}
+
+func (x SkillMatch) String() string {
+	return proto.EnumName(SkillMatch_name, int32(x))
+}
+
+func (SkillMatch) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{0}
+}
+
+type TeamSort int32
+
+const (
+	// oldest teams first
+	TeamSort_TEAM_SORT_DEFAULT TeamSort = 0
+	// newest teams first
+	TeamSort_TEAM_SORT_NEWEST TeamSort = 1
+	// most recently active teams first
+	TeamSort_TEAM_SORT_LAST_ACTIVE TeamSort = 2
+	// teams with the most open roles first
+	TeamSort_TEAM_SORT_OPEN_ROLES TeamSort = 3
+	// largest teams first
+	TeamSort_TEAM_SORT_SIZE TeamSort = 4
+)
+
+var TeamSort_name = map[int32]string{
+	0: "TEAM_SORT_DEFAULT",
+	1: "TEAM_SORT_NEWEST",
+	2: "TEAM_SORT_LAST_ACTIVE",
+	3: "TEAM_SORT_OPEN_ROLES",
+	4: "TEAM_SORT_SIZE",
+}
+
+var TeamSort_value = map[string]int32{
+	"TEAM_SORT_DEFAULT":     0,
+	"TEAM_SORT_NEWEST":      1,
+	"TEAM_SORT_LAST_ACTIVE": 2,
+	"TEAM_SORT_OPEN_ROLES":  3,
+	"TEAM_SORT_SIZE":        4,
+}
+
+func (x TeamSort) String() string {
+	return proto.EnumName(TeamSort_name, int32(x))
+}
+
+func (TeamSort) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{1}
+}
+
 type TeamUpsertRequest struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Team                 *Team    `protobuf:"bytes,2,opt,name=team,proto3" json:"team,omitempty"`
-	UserId               string   `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Api  string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Team *Team  `protobuf:"bytes,2,opt,name=team,proto3" json:"team,omitempty"`
+	// Deprecated: ignored, the caller is identified by their bearer token
+	UserId               string   `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Deprecated: Do not use.
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -72,6 +143,7 @@ func (m *TeamUpsertRequest) GetTeam() *Team {
 	return nil
 }
 
+// Deprecated: Do not use.
 func (m *TeamUpsertRequest) GetUserId() string {
 	if m != nil {
 		return m.UserId
@@ -80,9 +152,11 @@ func (m *TeamUpsertRequest) GetUserId() string {
 }
 
 type TeamUpsertResponse struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Id                   string   `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Id     string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	// version of the team after the change
+	Version              int64    `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -134,10 +208,154 @@ func (m *TeamUpsertResponse) GetId() string {
 	return ""
 }
 
-type TeamDeleteRequest struct {
+func (m *TeamUpsertResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type UpdateTeamRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id  string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// team holds the new values of the fields in update_mask, others are ignored
+	Team *Team `protobuf:"bytes,3,opt,name=team,proto3" json:"team,omitempty"`
+	// update_mask lists the fields to change, the REST gateway fills it with
+	// the fields of the PATCH body
+	UpdateMask *field_mask.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateTeamRequest) Reset()         { *m = UpdateTeamRequest{} }
+func (m *UpdateTeamRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateTeamRequest) ProtoMessage()    {}
+func (*UpdateTeamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{2}
+}
+
+func (m *UpdateTeamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTeamRequest.Unmarshal(m, b)
+}
+func (m *UpdateTeamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTeamRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateTeamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTeamRequest.Merge(m, src)
+}
+func (m *UpdateTeamRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateTeamRequest.Size(m)
+}
+func (m *UpdateTeamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTeamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTeamRequest proto.InternalMessageInfo
+
+func (m *UpdateTeamRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UpdateTeamRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UpdateTeamRequest) GetTeam() *Team {
+	if m != nil {
+		return m.Team
+	}
+	return nil
+}
+
+func (m *UpdateTeamRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+func (m *UpdateTeamRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdateTeamResponse struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	TeamId               string   `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
-	UserId               string   `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Team                 *Team    `protobuf:"bytes,3,opt,name=team,proto3" json:"team,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateTeamResponse) Reset()         { *m = UpdateTeamResponse{} }
+func (m *UpdateTeamResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateTeamResponse) ProtoMessage()    {}
+func (*UpdateTeamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{3}
+}
+
+func (m *UpdateTeamResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTeamResponse.Unmarshal(m, b)
+}
+func (m *UpdateTeamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTeamResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateTeamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTeamResponse.Merge(m, src)
+}
+func (m *UpdateTeamResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateTeamResponse.Size(m)
+}
+func (m *UpdateTeamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTeamResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTeamResponse proto.InternalMessageInfo
+
+func (m *UpdateTeamResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UpdateTeamResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *UpdateTeamResponse) GetTeam() *Team {
+	if m != nil {
+		return m.Team
+	}
+	return nil
+}
+
+type TeamDeleteRequest struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// Deprecated: ignored, the caller is identified by their bearer token
+	UserId string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Deprecated: Do not use.
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -147,7 +365,7 @@ func (m *TeamDeleteRequest) Reset()         { *m = TeamDeleteRequest{} }
 func (m *TeamDeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*TeamDeleteRequest) ProtoMessage()    {}
 func (*TeamDeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{2}
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{4}
 }
 
 func (m *TeamDeleteRequest) XXX_Unmarshal(b []byte) error {
@@ -182,6 +400,7 @@ func (m *TeamDeleteRequest) GetTeamId() string {
 	return ""
 }
 
+// Deprecated: Do not use.
 func (m *TeamDeleteRequest) GetUserId() string {
 	if m != nil {
 		return m.UserId
@@ -189,6 +408,13 @@ func (m *TeamDeleteRequest) GetUserId() string {
 	return ""
 }
 
+func (m *TeamDeleteRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
 type TeamDeleteResponse struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
 	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
@@ -205,7 +431,7 @@ func (m *TeamDeleteResponse) Reset()         { *m = TeamDeleteResponse{} }
 func (m *TeamDeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*TeamDeleteResponse) ProtoMessage()    {}
 func (*TeamDeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{3}
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{5}
 }
 
 func (m *TeamDeleteResponse) XXX_Unmarshal(b []byte) error {
@@ -268,215 +494,522 @@ func (m *TeamDeleteResponse) GetId() string {
 	return ""
 }
 
-type MemberUpsertRequest struct {
+type RestoreTeamRequest struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
 	TeamId               string   `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
-	MemberId             string   `protobuf:"bytes,3,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
-	MemberEmail          string   `protobuf:"bytes,4,opt,name=member_email,json=memberEmail,proto3" json:"member_email,omitempty"`
-	Role                 string   `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
-	UserId               string   `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MemberUpsertRequest) Reset()         { *m = MemberUpsertRequest{} }
-func (m *MemberUpsertRequest) String() string { return proto.CompactTextString(m) }
-func (*MemberUpsertRequest) ProtoMessage()    {}
-func (*MemberUpsertRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{4}
+func (m *RestoreTeamRequest) Reset()         { *m = RestoreTeamRequest{} }
+func (m *RestoreTeamRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreTeamRequest) ProtoMessage()    {}
+func (*RestoreTeamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{6}
 }
 
-func (m *MemberUpsertRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MemberUpsertRequest.Unmarshal(m, b)
+func (m *RestoreTeamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTeamRequest.Unmarshal(m, b)
 }
-func (m *MemberUpsertRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MemberUpsertRequest.Marshal(b, m, deterministic)
+func (m *RestoreTeamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTeamRequest.Marshal(b, m, deterministic)
 }
-func (m *MemberUpsertRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MemberUpsertRequest.Merge(m, src)
+func (m *RestoreTeamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTeamRequest.Merge(m, src)
 }
-func (m *MemberUpsertRequest) XXX_Size() int {
-	return xxx_messageInfo_MemberUpsertRequest.Size(m)
+func (m *RestoreTeamRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreTeamRequest.Size(m)
 }
-func (m *MemberUpsertRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MemberUpsertRequest.DiscardUnknown(m)
+func (m *RestoreTeamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTeamRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MemberUpsertRequest proto.InternalMessageInfo
+var xxx_messageInfo_RestoreTeamRequest proto.InternalMessageInfo
 
-func (m *MemberUpsertRequest) GetApi() string {
+func (m *RestoreTeamRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *MemberUpsertRequest) GetTeamId() string {
+func (m *RestoreTeamRequest) GetTeamId() string {
 	if m != nil {
 		return m.TeamId
 	}
 	return ""
 }
 
-func (m *MemberUpsertRequest) GetMemberId() string {
-	if m != nil {
-		return m.MemberId
-	}
-	return ""
+type RestoreTeamResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Team                 *Team    `protobuf:"bytes,3,opt,name=team,proto3" json:"team,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MemberUpsertRequest) GetMemberEmail() string {
+func (m *RestoreTeamResponse) Reset()         { *m = RestoreTeamResponse{} }
+func (m *RestoreTeamResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreTeamResponse) ProtoMessage()    {}
+func (*RestoreTeamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{7}
+}
+
+func (m *RestoreTeamResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTeamResponse.Unmarshal(m, b)
+}
+func (m *RestoreTeamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTeamResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreTeamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTeamResponse.Merge(m, src)
+}
+func (m *RestoreTeamResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreTeamResponse.Size(m)
+}
+func (m *RestoreTeamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTeamResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTeamResponse proto.InternalMessageInfo
+
+func (m *RestoreTeamResponse) GetApi() string {
 	if m != nil {
-		return m.MemberEmail
+		return m.Api
 	}
 	return ""
 }
 
-func (m *MemberUpsertRequest) GetRole() string {
+func (m *RestoreTeamResponse) GetStatus() string {
 	if m != nil {
-		return m.Role
+		return m.Status
 	}
 	return ""
 }
 
-func (m *MemberUpsertRequest) GetUserId() string {
+func (m *RestoreTeamResponse) GetTeam() *Team {
 	if m != nil {
-		return m.UserId
+		return m.Team
 	}
-	return ""
+	return nil
 }
 
-type MemberUpsertResponse struct {
+type ListDeletedTeamsRequest struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	MemberNumber         string   `protobuf:"bytes,2,opt,name=member_number,json=memberNumber,proto3" json:"member_number,omitempty"`
-	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MemberUpsertResponse) Reset()         { *m = MemberUpsertResponse{} }
-func (m *MemberUpsertResponse) String() string { return proto.CompactTextString(m) }
-func (*MemberUpsertResponse) ProtoMessage()    {}
-func (*MemberUpsertResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{5}
+func (m *ListDeletedTeamsRequest) Reset()         { *m = ListDeletedTeamsRequest{} }
+func (m *ListDeletedTeamsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDeletedTeamsRequest) ProtoMessage()    {}
+func (*ListDeletedTeamsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{8}
 }
 
-func (m *MemberUpsertResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MemberUpsertResponse.Unmarshal(m, b)
+func (m *ListDeletedTeamsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeletedTeamsRequest.Unmarshal(m, b)
 }
-func (m *MemberUpsertResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MemberUpsertResponse.Marshal(b, m, deterministic)
+func (m *ListDeletedTeamsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeletedTeamsRequest.Marshal(b, m, deterministic)
 }
-func (m *MemberUpsertResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MemberUpsertResponse.Merge(m, src)
+func (m *ListDeletedTeamsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeletedTeamsRequest.Merge(m, src)
 }
-func (m *MemberUpsertResponse) XXX_Size() int {
-	return xxx_messageInfo_MemberUpsertResponse.Size(m)
+func (m *ListDeletedTeamsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDeletedTeamsRequest.Size(m)
 }
-func (m *MemberUpsertResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_MemberUpsertResponse.DiscardUnknown(m)
+func (m *ListDeletedTeamsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeletedTeamsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MemberUpsertResponse proto.InternalMessageInfo
+var xxx_messageInfo_ListDeletedTeamsRequest proto.InternalMessageInfo
 
-func (m *MemberUpsertResponse) GetApi() string {
+func (m *ListDeletedTeamsRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *MemberUpsertResponse) GetMemberNumber() string {
+type ListDeletedTeamsResponse struct {
+	Api                  string         `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status               string         `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Teams                []*DeletedTeam `protobuf:"bytes,3,rep,name=teams,proto3" json:"teams,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListDeletedTeamsResponse) Reset()         { *m = ListDeletedTeamsResponse{} }
+func (m *ListDeletedTeamsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDeletedTeamsResponse) ProtoMessage()    {}
+func (*ListDeletedTeamsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{9}
+}
+
+func (m *ListDeletedTeamsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeletedTeamsResponse.Unmarshal(m, b)
+}
+func (m *ListDeletedTeamsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeletedTeamsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDeletedTeamsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeletedTeamsResponse.Merge(m, src)
+}
+func (m *ListDeletedTeamsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDeletedTeamsResponse.Size(m)
+}
+func (m *ListDeletedTeamsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeletedTeamsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeletedTeamsResponse proto.InternalMessageInfo
+
+func (m *ListDeletedTeamsResponse) GetApi() string {
 	if m != nil {
-		return m.MemberNumber
+		return m.Api
 	}
 	return ""
 }
 
-func (m *MemberUpsertResponse) GetStatus() string {
+func (m *ListDeletedTeamsResponse) GetStatus() string {
 	if m != nil {
 		return m.Status
 	}
 	return ""
 }
 
-type MemberDeleteRequest struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	TeamId               string   `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
-	MemberNumber         string   `protobuf:"bytes,3,opt,name=member_number,json=memberNumber,proto3" json:"member_number,omitempty"`
-	MemberEmail          string   `protobuf:"bytes,4,opt,name=member_email,json=memberEmail,proto3" json:"member_email,omitempty"`
-	UserId               string   `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+func (m *ListDeletedTeamsResponse) GetTeams() []*DeletedTeam {
+	if m != nil {
+		return m.Teams
+	}
+	return nil
+}
+
+type DeletedTeam struct {
+	Team      *Team `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	DeletedAt int64 `protobuf:"varint,2,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// deleted_by is the user id of the caller who deleted the team
+	DeletedBy string `protobuf:"bytes,3,opt,name=deleted_by,json=deletedBy,proto3" json:"deleted_by,omitempty"`
+	// purge_at is when the team is deleted for good, it can't be restored after
+	PurgeAt              int64    `protobuf:"varint,4,opt,name=purge_at,json=purgeAt,proto3" json:"purge_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MemberDeleteRequest) Reset()         { *m = MemberDeleteRequest{} }
-func (m *MemberDeleteRequest) String() string { return proto.CompactTextString(m) }
-func (*MemberDeleteRequest) ProtoMessage()    {}
-func (*MemberDeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{6}
+func (m *DeletedTeam) Reset()         { *m = DeletedTeam{} }
+func (m *DeletedTeam) String() string { return proto.CompactTextString(m) }
+func (*DeletedTeam) ProtoMessage()    {}
+func (*DeletedTeam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{10}
 }
 
-func (m *MemberDeleteRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MemberDeleteRequest.Unmarshal(m, b)
+func (m *DeletedTeam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeletedTeam.Unmarshal(m, b)
 }
-func (m *MemberDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MemberDeleteRequest.Marshal(b, m, deterministic)
+func (m *DeletedTeam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeletedTeam.Marshal(b, m, deterministic)
 }
-func (m *MemberDeleteRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MemberDeleteRequest.Merge(m, src)
+func (m *DeletedTeam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeletedTeam.Merge(m, src)
 }
-func (m *MemberDeleteRequest) XXX_Size() int {
-	return xxx_messageInfo_MemberDeleteRequest.Size(m)
+func (m *DeletedTeam) XXX_Size() int {
+	return xxx_messageInfo_DeletedTeam.Size(m)
 }
-func (m *MemberDeleteRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MemberDeleteRequest.DiscardUnknown(m)
+func (m *DeletedTeam) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeletedTeam.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MemberDeleteRequest proto.InternalMessageInfo
+var xxx_messageInfo_DeletedTeam proto.InternalMessageInfo
 
-func (m *MemberDeleteRequest) GetApi() string {
+func (m *DeletedTeam) GetTeam() *Team {
 	if m != nil {
-		return m.Api
+		return m.Team
 	}
-	return ""
+	return nil
 }
 
-func (m *MemberDeleteRequest) GetTeamId() string {
+func (m *DeletedTeam) GetDeletedAt() int64 {
 	if m != nil {
-		return m.TeamId
+		return m.DeletedAt
 	}
-	return ""
+	return 0
 }
 
-func (m *MemberDeleteRequest) GetMemberNumber() string {
+func (m *DeletedTeam) GetDeletedBy() string {
 	if m != nil {
-		return m.MemberNumber
+		return m.DeletedBy
 	}
 	return ""
 }
 
-func (m *MemberDeleteRequest) GetMemberEmail() string {
+func (m *DeletedTeam) GetPurgeAt() int64 {
 	if m != nil {
-		return m.MemberEmail
+		return m.PurgeAt
 	}
-	return ""
+	return 0
 }
 
-func (m *MemberDeleteRequest) GetUserId() string {
-	if m != nil {
-		return m.UserId
-	}
+type MemberUpsertRequest struct {
+	Api         string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId      string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	MemberId    string `protobuf:"bytes,3,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	MemberEmail string `protobuf:"bytes,4,opt,name=member_email,json=memberEmail,proto3" json:"member_email,omitempty"`
+	Role        string `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	// Deprecated: ignored, the caller is identified by their bearer token
+	UserId string `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Deprecated: Do not use.
+	// access_role of the new member: admin, member (default) or viewer
+	AccessRole string `protobuf:"bytes,7,opt,name=access_role,json=accessRole,proto3" json:"access_role,omitempty"`
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,8,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MemberUpsertRequest) Reset()         { *m = MemberUpsertRequest{} }
+func (m *MemberUpsertRequest) String() string { return proto.CompactTextString(m) }
+func (*MemberUpsertRequest) ProtoMessage()    {}
+func (*MemberUpsertRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{11}
+}
+
+func (m *MemberUpsertRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemberUpsertRequest.Unmarshal(m, b)
+}
+func (m *MemberUpsertRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemberUpsertRequest.Marshal(b, m, deterministic)
+}
+func (m *MemberUpsertRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberUpsertRequest.Merge(m, src)
+}
+func (m *MemberUpsertRequest) XXX_Size() int {
+	return xxx_messageInfo_MemberUpsertRequest.Size(m)
+}
+func (m *MemberUpsertRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberUpsertRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemberUpsertRequest proto.InternalMessageInfo
+
+func (m *MemberUpsertRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *MemberUpsertRequest) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *MemberUpsertRequest) GetMemberId() string {
+	if m != nil {
+		return m.MemberId
+	}
+	return ""
+}
+
+func (m *MemberUpsertRequest) GetMemberEmail() string {
+	if m != nil {
+		return m.MemberEmail
+	}
+	return ""
+}
+
+func (m *MemberUpsertRequest) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+// Deprecated: Do not use.
+func (m *MemberUpsertRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *MemberUpsertRequest) GetAccessRole() string {
+	if m != nil {
+		return m.AccessRole
+	}
+	return ""
+}
+
+func (m *MemberUpsertRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type MemberUpsertResponse struct {
+	Api          string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	MemberNumber string `protobuf:"bytes,2,opt,name=member_number,json=memberNumber,proto3" json:"member_number,omitempty"`
+	Status       string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// version of the team after the change
+	Version              int64    `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MemberUpsertResponse) Reset()         { *m = MemberUpsertResponse{} }
+func (m *MemberUpsertResponse) String() string { return proto.CompactTextString(m) }
+func (*MemberUpsertResponse) ProtoMessage()    {}
+func (*MemberUpsertResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{12}
+}
+
+func (m *MemberUpsertResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemberUpsertResponse.Unmarshal(m, b)
+}
+func (m *MemberUpsertResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemberUpsertResponse.Marshal(b, m, deterministic)
+}
+func (m *MemberUpsertResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberUpsertResponse.Merge(m, src)
+}
+func (m *MemberUpsertResponse) XXX_Size() int {
+	return xxx_messageInfo_MemberUpsertResponse.Size(m)
+}
+func (m *MemberUpsertResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberUpsertResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemberUpsertResponse proto.InternalMessageInfo
+
+func (m *MemberUpsertResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *MemberUpsertResponse) GetMemberNumber() string {
+	if m != nil {
+		return m.MemberNumber
+	}
+	return ""
+}
+
+func (m *MemberUpsertResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *MemberUpsertResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type MemberDeleteRequest struct {
+	Api          string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId       string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	MemberNumber string `protobuf:"bytes,3,opt,name=member_number,json=memberNumber,proto3" json:"member_number,omitempty"`
+	MemberEmail  string `protobuf:"bytes,4,opt,name=member_email,json=memberEmail,proto3" json:"member_email,omitempty"`
+	// Deprecated: ignored, the caller is identified by their bearer token
+	UserId string `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Deprecated: Do not use.
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MemberDeleteRequest) Reset()         { *m = MemberDeleteRequest{} }
+func (m *MemberDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*MemberDeleteRequest) ProtoMessage()    {}
+func (*MemberDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{13}
+}
+
+func (m *MemberDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemberDeleteRequest.Unmarshal(m, b)
+}
+func (m *MemberDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemberDeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *MemberDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberDeleteRequest.Merge(m, src)
+}
+func (m *MemberDeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_MemberDeleteRequest.Size(m)
+}
+func (m *MemberDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemberDeleteRequest proto.InternalMessageInfo
+
+func (m *MemberDeleteRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *MemberDeleteRequest) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *MemberDeleteRequest) GetMemberNumber() string {
+	if m != nil {
+		return m.MemberNumber
+	}
+	return ""
+}
+
+func (m *MemberDeleteRequest) GetMemberEmail() string {
+	if m != nil {
+		return m.MemberEmail
+	}
+	return ""
+}
+
+// Deprecated: Do not use.
+func (m *MemberDeleteRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
 	return ""
 }
 
+func (m *MemberDeleteRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
 type MemberDeleteResponse struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Count                int64    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Count  int64  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// version of the team after the change
+	Version              int64    `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -486,7 +1019,7 @@ func (m *MemberDeleteResponse) Reset()         { *m = MemberDeleteResponse{} }
 func (m *MemberDeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*MemberDeleteResponse) ProtoMessage()    {}
 func (*MemberDeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{7}
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{14}
 }
 
 func (m *MemberDeleteResponse) XXX_Unmarshal(b []byte) error {
@@ -528,11 +1061,23 @@ func (m *MemberDeleteResponse) GetCount() int64 {
 	return 0
 }
 
+func (m *MemberDeleteResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type ProjectUpsertRequest struct {
-	Project              *Project `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
-	Api                  string   `protobuf:"bytes,2,opt,name=api,proto3" json:"api,omitempty"`
-	TeamId               string   `protobuf:"bytes,3,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
-	UserId               string   `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Project *Project `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Api     string   `protobuf:"bytes,2,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId  string   `protobuf:"bytes,3,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// Deprecated: ignored, the caller is identified by their bearer token
+	UserId string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Deprecated: Do not use.
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -542,7 +1087,7 @@ func (m *ProjectUpsertRequest) Reset()         { *m = ProjectUpsertRequest{} }
 func (m *ProjectUpsertRequest) String() string { return proto.CompactTextString(m) }
 func (*ProjectUpsertRequest) ProtoMessage()    {}
 func (*ProjectUpsertRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{8}
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{15}
 }
 
 func (m *ProjectUpsertRequest) XXX_Unmarshal(b []byte) error {
@@ -584,6 +1129,7 @@ func (m *ProjectUpsertRequest) GetTeamId() string {
 	return ""
 }
 
+// Deprecated: Do not use.
 func (m *ProjectUpsertRequest) GetUserId() string {
 	if m != nil {
 		return m.UserId
@@ -591,9 +1137,18 @@ func (m *ProjectUpsertRequest) GetUserId() string {
 	return ""
 }
 
+func (m *ProjectUpsertRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
 type ProjectUpsertResponse struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// version of the team after the change
+	Version              int64    `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -603,7 +1158,7 @@ func (m *ProjectUpsertResponse) Reset()         { *m = ProjectUpsertResponse{} }
 func (m *ProjectUpsertResponse) String() string { return proto.CompactTextString(m) }
 func (*ProjectUpsertResponse) ProtoMessage()    {}
 func (*ProjectUpsertResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{9}
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{16}
 }
 
 func (m *ProjectUpsertResponse) XXX_Unmarshal(b []byte) error {
@@ -638,1003 +1193,5803 @@ func (m *ProjectUpsertResponse) GetStatus() string {
 	return ""
 }
 
-type GetByTeamNameRequest struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+func (m *ProjectUpsertResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type CreateProjectRequest struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// project is created planning unless its status is active
+	Project *Project `protobuf:"bytes,3,opt,name=project,proto3" json:"project,omitempty"`
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetByTeamNameRequest) Reset()         { *m = GetByTeamNameRequest{} }
-func (m *GetByTeamNameRequest) String() string { return proto.CompactTextString(m) }
-func (*GetByTeamNameRequest) ProtoMessage()    {}
-func (*GetByTeamNameRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{10}
+func (m *CreateProjectRequest) Reset()         { *m = CreateProjectRequest{} }
+func (m *CreateProjectRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateProjectRequest) ProtoMessage()    {}
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{17}
 }
 
-func (m *GetByTeamNameRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetByTeamNameRequest.Unmarshal(m, b)
+func (m *CreateProjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateProjectRequest.Unmarshal(m, b)
 }
-func (m *GetByTeamNameRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetByTeamNameRequest.Marshal(b, m, deterministic)
+func (m *CreateProjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateProjectRequest.Marshal(b, m, deterministic)
 }
-func (m *GetByTeamNameRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetByTeamNameRequest.Merge(m, src)
+func (m *CreateProjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateProjectRequest.Merge(m, src)
 }
-func (m *GetByTeamNameRequest) XXX_Size() int {
-	return xxx_messageInfo_GetByTeamNameRequest.Size(m)
+func (m *CreateProjectRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateProjectRequest.Size(m)
 }
-func (m *GetByTeamNameRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetByTeamNameRequest.DiscardUnknown(m)
+func (m *CreateProjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateProjectRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetByTeamNameRequest proto.InternalMessageInfo
+var xxx_messageInfo_CreateProjectRequest proto.InternalMessageInfo
 
-func (m *GetByTeamNameRequest) GetApi() string {
+func (m *CreateProjectRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *GetByTeamNameRequest) GetName() string {
+func (m *CreateProjectRequest) GetTeamId() string {
 	if m != nil {
-		return m.Name
+		return m.TeamId
 	}
 	return ""
 }
 
-type GetByTeamNameResponse struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	Team                 *Team    `protobuf:"bytes,3,opt,name=team,proto3" json:"team,omitempty"`
-	Status               string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+func (m *CreateProjectRequest) GetProject() *Project {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+func (m *CreateProjectRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdateProjectRequest struct {
+	Api       string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId    string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	ProjectId string `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// project holds the new values of the fields in update_mask, others are ignored
+	Project *Project `protobuf:"bytes,4,opt,name=project,proto3" json:"project,omitempty"`
+	// update_mask lists the fields to change, the REST gateway fills it with
+	// the fields of the PATCH body
+	UpdateMask *field_mask.FieldMask `protobuf:"bytes,5,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetByTeamNameResponse) Reset()         { *m = GetByTeamNameResponse{} }
-func (m *GetByTeamNameResponse) String() string { return proto.CompactTextString(m) }
-func (*GetByTeamNameResponse) ProtoMessage()    {}
-func (*GetByTeamNameResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{11}
+func (m *UpdateProjectRequest) Reset()         { *m = UpdateProjectRequest{} }
+func (m *UpdateProjectRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateProjectRequest) ProtoMessage()    {}
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{18}
 }
 
-func (m *GetByTeamNameResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetByTeamNameResponse.Unmarshal(m, b)
+func (m *UpdateProjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateProjectRequest.Unmarshal(m, b)
 }
-func (m *GetByTeamNameResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetByTeamNameResponse.Marshal(b, m, deterministic)
+func (m *UpdateProjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateProjectRequest.Marshal(b, m, deterministic)
 }
-func (m *GetByTeamNameResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetByTeamNameResponse.Merge(m, src)
+func (m *UpdateProjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateProjectRequest.Merge(m, src)
 }
-func (m *GetByTeamNameResponse) XXX_Size() int {
-	return xxx_messageInfo_GetByTeamNameResponse.Size(m)
+func (m *UpdateProjectRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateProjectRequest.Size(m)
 }
-func (m *GetByTeamNameResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetByTeamNameResponse.DiscardUnknown(m)
+func (m *UpdateProjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateProjectRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetByTeamNameResponse proto.InternalMessageInfo
+var xxx_messageInfo_UpdateProjectRequest proto.InternalMessageInfo
 
-func (m *GetByTeamNameResponse) GetApi() string {
+func (m *UpdateProjectRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *GetByTeamNameResponse) GetId() string {
+func (m *UpdateProjectRequest) GetTeamId() string {
 	if m != nil {
-		return m.Id
+		return m.TeamId
 	}
 	return ""
 }
 
-func (m *GetByTeamNameResponse) GetTeam() *Team {
+func (m *UpdateProjectRequest) GetProjectId() string {
 	if m != nil {
-		return m.Team
+		return m.ProjectId
+	}
+	return ""
+}
+
+func (m *UpdateProjectRequest) GetProject() *Project {
+	if m != nil {
+		return m.Project
 	}
 	return nil
 }
 
-func (m *GetByTeamNameResponse) GetStatus() string {
+func (m *UpdateProjectRequest) GetUpdateMask() *field_mask.FieldMask {
 	if m != nil {
-		return m.Status
+		return m.UpdateMask
 	}
-	return ""
+	return nil
 }
 
-type GetByUserIdRequest struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+func (m *UpdateProjectRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type ArchiveProjectRequest struct {
+	Api       string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId    string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	ProjectId string `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetByUserIdRequest) Reset()         { *m = GetByUserIdRequest{} }
-func (m *GetByUserIdRequest) String() string { return proto.CompactTextString(m) }
-func (*GetByUserIdRequest) ProtoMessage()    {}
-func (*GetByUserIdRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{12}
+func (m *ArchiveProjectRequest) Reset()         { *m = ArchiveProjectRequest{} }
+func (m *ArchiveProjectRequest) String() string { return proto.CompactTextString(m) }
+func (*ArchiveProjectRequest) ProtoMessage()    {}
+func (*ArchiveProjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{19}
 }
 
-func (m *GetByUserIdRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetByUserIdRequest.Unmarshal(m, b)
+func (m *ArchiveProjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveProjectRequest.Unmarshal(m, b)
 }
-func (m *GetByUserIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetByUserIdRequest.Marshal(b, m, deterministic)
+func (m *ArchiveProjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveProjectRequest.Marshal(b, m, deterministic)
 }
-func (m *GetByUserIdRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetByUserIdRequest.Merge(m, src)
+func (m *ArchiveProjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveProjectRequest.Merge(m, src)
 }
-func (m *GetByUserIdRequest) XXX_Size() int {
-	return xxx_messageInfo_GetByUserIdRequest.Size(m)
+func (m *ArchiveProjectRequest) XXX_Size() int {
+	return xxx_messageInfo_ArchiveProjectRequest.Size(m)
 }
-func (m *GetByUserIdRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetByUserIdRequest.DiscardUnknown(m)
+func (m *ArchiveProjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveProjectRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetByUserIdRequest proto.InternalMessageInfo
+var xxx_messageInfo_ArchiveProjectRequest proto.InternalMessageInfo
 
-func (m *GetByUserIdRequest) GetApi() string {
+func (m *ArchiveProjectRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *GetByUserIdRequest) GetId() string {
+func (m *ArchiveProjectRequest) GetTeamId() string {
 	if m != nil {
-		return m.Id
+		return m.TeamId
 	}
 	return ""
 }
 
-type GetByUserIdResponse struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	Teams                []*Team  `protobuf:"bytes,3,rep,name=teams,proto3" json:"teams,omitempty"`
-	Status               string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+func (m *ArchiveProjectRequest) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+func (m *ArchiveProjectRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type ProjectResponse struct {
+	Api     string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status  string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Project *Project `protobuf:"bytes,3,opt,name=project,proto3" json:"project,omitempty"`
+	// version of the team after the change
+	Version              int64    `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetByUserIdResponse) Reset()         { *m = GetByUserIdResponse{} }
-func (m *GetByUserIdResponse) String() string { return proto.CompactTextString(m) }
-func (*GetByUserIdResponse) ProtoMessage()    {}
-func (*GetByUserIdResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{13}
+func (m *ProjectResponse) Reset()         { *m = ProjectResponse{} }
+func (m *ProjectResponse) String() string { return proto.CompactTextString(m) }
+func (*ProjectResponse) ProtoMessage()    {}
+func (*ProjectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{20}
 }
 
-func (m *GetByUserIdResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetByUserIdResponse.Unmarshal(m, b)
+func (m *ProjectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProjectResponse.Unmarshal(m, b)
 }
-func (m *GetByUserIdResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetByUserIdResponse.Marshal(b, m, deterministic)
+func (m *ProjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProjectResponse.Marshal(b, m, deterministic)
 }
-func (m *GetByUserIdResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetByUserIdResponse.Merge(m, src)
+func (m *ProjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProjectResponse.Merge(m, src)
 }
-func (m *GetByUserIdResponse) XXX_Size() int {
-	return xxx_messageInfo_GetByUserIdResponse.Size(m)
+func (m *ProjectResponse) XXX_Size() int {
+	return xxx_messageInfo_ProjectResponse.Size(m)
 }
-func (m *GetByUserIdResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetByUserIdResponse.DiscardUnknown(m)
+func (m *ProjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProjectResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetByUserIdResponse proto.InternalMessageInfo
+var xxx_messageInfo_ProjectResponse proto.InternalMessageInfo
 
-func (m *GetByUserIdResponse) GetApi() string {
+func (m *ProjectResponse) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *GetByUserIdResponse) GetId() string {
+func (m *ProjectResponse) GetStatus() string {
 	if m != nil {
-		return m.Id
+		return m.Status
 	}
 	return ""
 }
 
-func (m *GetByUserIdResponse) GetTeams() []*Team {
+func (m *ProjectResponse) GetProject() *Project {
 	if m != nil {
-		return m.Teams
+		return m.Project
 	}
 	return nil
 }
 
-func (m *GetByUserIdResponse) GetStatus() string {
+func (m *ProjectResponse) GetVersion() int64 {
 	if m != nil {
-		return m.Status
+		return m.Version
 	}
-	return ""
+	return 0
 }
 
-type GetTeamsRequest struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Page                 int64    `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	Limit                int64    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	Role                 string   `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
-	Level                int64    `protobuf:"varint,5,opt,name=level,proto3" json:"level,omitempty"`
-	Technology           string   `protobuf:"bytes,6,opt,name=technology,proto3" json:"technology,omitempty"`
+type ListProjectsRequest struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// statuses keeps projects in any of the statuses, every status when empty
+	Statuses []string `protobuf:"bytes,3,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	// include_archived lists the archived projects too
+	IncludeArchived      bool     `protobuf:"varint,4,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetTeamsRequest) Reset()         { *m = GetTeamsRequest{} }
-func (m *GetTeamsRequest) String() string { return proto.CompactTextString(m) }
-func (*GetTeamsRequest) ProtoMessage()    {}
-func (*GetTeamsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{14}
+func (m *ListProjectsRequest) Reset()         { *m = ListProjectsRequest{} }
+func (m *ListProjectsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListProjectsRequest) ProtoMessage()    {}
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{21}
 }
 
-func (m *GetTeamsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetTeamsRequest.Unmarshal(m, b)
+func (m *ListProjectsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListProjectsRequest.Unmarshal(m, b)
 }
-func (m *GetTeamsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetTeamsRequest.Marshal(b, m, deterministic)
+func (m *ListProjectsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListProjectsRequest.Marshal(b, m, deterministic)
 }
-func (m *GetTeamsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetTeamsRequest.Merge(m, src)
+func (m *ListProjectsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListProjectsRequest.Merge(m, src)
 }
-func (m *GetTeamsRequest) XXX_Size() int {
-	return xxx_messageInfo_GetTeamsRequest.Size(m)
+func (m *ListProjectsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListProjectsRequest.Size(m)
 }
-func (m *GetTeamsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetTeamsRequest.DiscardUnknown(m)
+func (m *ListProjectsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListProjectsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetTeamsRequest proto.InternalMessageInfo
+var xxx_messageInfo_ListProjectsRequest proto.InternalMessageInfo
 
-func (m *GetTeamsRequest) GetApi() string {
+func (m *ListProjectsRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *GetTeamsRequest) GetPage() int64 {
+func (m *ListProjectsRequest) GetTeamId() string {
 	if m != nil {
-		return m.Page
+		return m.TeamId
 	}
-	return 0
+	return ""
 }
 
-func (m *GetTeamsRequest) GetLimit() int64 {
+func (m *ListProjectsRequest) GetStatuses() []string {
 	if m != nil {
-		return m.Limit
+		return m.Statuses
 	}
-	return 0
+	return nil
 }
 
-func (m *GetTeamsRequest) GetRole() string {
+func (m *ListProjectsRequest) GetIncludeArchived() bool {
 	if m != nil {
-		return m.Role
+		return m.IncludeArchived
 	}
-	return ""
+	return false
 }
 
-func (m *GetTeamsRequest) GetLevel() int64 {
+type ListProjectsResponse struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// projects, oldest first
+	Projects             []*Project `protobuf:"bytes,3,rep,name=projects,proto3" json:"projects,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListProjectsResponse) Reset()         { *m = ListProjectsResponse{} }
+func (m *ListProjectsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListProjectsResponse) ProtoMessage()    {}
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{22}
+}
+
+func (m *ListProjectsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListProjectsResponse.Unmarshal(m, b)
+}
+func (m *ListProjectsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListProjectsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListProjectsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListProjectsResponse.Merge(m, src)
+}
+func (m *ListProjectsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListProjectsResponse.Size(m)
+}
+func (m *ListProjectsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListProjectsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListProjectsResponse proto.InternalMessageInfo
+
+func (m *ListProjectsResponse) GetApi() string {
 	if m != nil {
-		return m.Level
+		return m.Api
 	}
-	return 0
+	return ""
 }
 
-func (m *GetTeamsRequest) GetTechnology() string {
+func (m *ListProjectsResponse) GetStatus() string {
 	if m != nil {
-		return m.Technology
+		return m.Status
 	}
 	return ""
 }
 
-type GetTeamsResponse struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Teams                []*Team  `protobuf:"bytes,2,rep,name=teams,proto3" json:"teams,omitempty"`
-	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+func (m *ListProjectsResponse) GetProjects() []*Project {
+	if m != nil {
+		return m.Projects
+	}
+	return nil
+}
+
+type CreateMilestoneRequest struct {
+	Api       string     `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId    string     `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	ProjectId string     `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Milestone *Milestone `protobuf:"bytes,4,opt,name=milestone,proto3" json:"milestone,omitempty"`
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetTeamsResponse) Reset()         { *m = GetTeamsResponse{} }
-func (m *GetTeamsResponse) String() string { return proto.CompactTextString(m) }
-func (*GetTeamsResponse) ProtoMessage()    {}
-func (*GetTeamsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{15}
+func (m *CreateMilestoneRequest) Reset()         { *m = CreateMilestoneRequest{} }
+func (m *CreateMilestoneRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateMilestoneRequest) ProtoMessage()    {}
+func (*CreateMilestoneRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{23}
 }
 
-func (m *GetTeamsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetTeamsResponse.Unmarshal(m, b)
+func (m *CreateMilestoneRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateMilestoneRequest.Unmarshal(m, b)
 }
-func (m *GetTeamsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetTeamsResponse.Marshal(b, m, deterministic)
+func (m *CreateMilestoneRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateMilestoneRequest.Marshal(b, m, deterministic)
 }
-func (m *GetTeamsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetTeamsResponse.Merge(m, src)
+func (m *CreateMilestoneRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateMilestoneRequest.Merge(m, src)
 }
-func (m *GetTeamsResponse) XXX_Size() int {
-	return xxx_messageInfo_GetTeamsResponse.Size(m)
+func (m *CreateMilestoneRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateMilestoneRequest.Size(m)
 }
-func (m *GetTeamsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetTeamsResponse.DiscardUnknown(m)
+func (m *CreateMilestoneRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateMilestoneRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetTeamsResponse proto.InternalMessageInfo
+var xxx_messageInfo_CreateMilestoneRequest proto.InternalMessageInfo
 
-func (m *GetTeamsResponse) GetApi() string {
+func (m *CreateMilestoneRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *GetTeamsResponse) GetTeams() []*Team {
+func (m *CreateMilestoneRequest) GetTeamId() string {
 	if m != nil {
-		return m.Teams
+		return m.TeamId
 	}
-	return nil
+	return ""
 }
 
-func (m *GetTeamsResponse) GetStatus() string {
+func (m *CreateMilestoneRequest) GetProjectId() string {
 	if m != nil {
-		return m.Status
+		return m.ProjectId
 	}
 	return ""
 }
 
-type Team struct {
-	Leader               string    `protobuf:"bytes,1,opt,name=leader,proto3" json:"leader,omitempty"`
-	Members              []*Member `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
-	Name                 string    `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	OpenRoles            int32     `protobuf:"varint,4,opt,name=open_roles,json=openRoles,proto3" json:"open_roles,omitempty"`
-	Skills               []string  `protobuf:"bytes,5,rep,name=skills,proto3" json:"skills,omitempty"`
-	Size                 int32     `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
-	LastActive           int32     `protobuf:"varint,7,opt,name=last_active,json=lastActive,proto3" json:"last_active,omitempty"`
-	Id                   string    `protobuf:"bytes,8,opt,name=id,proto3" json:"id,omitempty"`
-	Project              *Project  `protobuf:"bytes,9,opt,name=project,proto3" json:"project,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *CreateMilestoneRequest) GetMilestone() *Milestone {
+	if m != nil {
+		return m.Milestone
+	}
+	return nil
 }
 
-func (m *Team) Reset()         { *m = Team{} }
-func (m *Team) String() string { return proto.CompactTextString(m) }
-func (*Team) ProtoMessage()    {}
-func (*Team) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{16}
+func (m *CreateMilestoneRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
 }
 
-func (m *Team) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Team.Unmarshal(m, b)
+type UpdateMilestoneRequest struct {
+	Api         string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId      string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	ProjectId   string `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	MilestoneId string `protobuf:"bytes,4,opt,name=milestone_id,json=milestoneId,proto3" json:"milestone_id,omitempty"`
+	// milestone holds the new values of the fields in update_mask, others are ignored
+	Milestone *Milestone `protobuf:"bytes,5,opt,name=milestone,proto3" json:"milestone,omitempty"`
+	// update_mask lists the fields to change, the REST gateway fills it with
+	// the fields of the PATCH body
+	UpdateMask *field_mask.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *Team) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Team.Marshal(b, m, deterministic)
+
+func (m *UpdateMilestoneRequest) Reset()         { *m = UpdateMilestoneRequest{} }
+func (m *UpdateMilestoneRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateMilestoneRequest) ProtoMessage()    {}
+func (*UpdateMilestoneRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{24}
 }
-func (m *Team) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Team.Merge(m, src)
+
+func (m *UpdateMilestoneRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateMilestoneRequest.Unmarshal(m, b)
 }
-func (m *Team) XXX_Size() int {
-	return xxx_messageInfo_Team.Size(m)
+func (m *UpdateMilestoneRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateMilestoneRequest.Marshal(b, m, deterministic)
 }
-func (m *Team) XXX_DiscardUnknown() {
-	xxx_messageInfo_Team.DiscardUnknown(m)
+func (m *UpdateMilestoneRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateMilestoneRequest.Merge(m, src)
+}
+func (m *UpdateMilestoneRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateMilestoneRequest.Size(m)
+}
+func (m *UpdateMilestoneRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateMilestoneRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Team proto.InternalMessageInfo
+var xxx_messageInfo_UpdateMilestoneRequest proto.InternalMessageInfo
 
-func (m *Team) GetLeader() string {
+func (m *UpdateMilestoneRequest) GetApi() string {
 	if m != nil {
-		return m.Leader
+		return m.Api
 	}
 	return ""
 }
 
-func (m *Team) GetMembers() []*Member {
+func (m *UpdateMilestoneRequest) GetTeamId() string {
 	if m != nil {
-		return m.Members
+		return m.TeamId
 	}
-	return nil
+	return ""
 }
 
-func (m *Team) GetName() string {
+func (m *UpdateMilestoneRequest) GetProjectId() string {
 	if m != nil {
-		return m.Name
+		return m.ProjectId
 	}
 	return ""
 }
 
-func (m *Team) GetOpenRoles() int32 {
+func (m *UpdateMilestoneRequest) GetMilestoneId() string {
 	if m != nil {
-		return m.OpenRoles
+		return m.MilestoneId
 	}
-	return 0
+	return ""
 }
 
-func (m *Team) GetSkills() []string {
+func (m *UpdateMilestoneRequest) GetMilestone() *Milestone {
 	if m != nil {
-		return m.Skills
+		return m.Milestone
 	}
 	return nil
 }
 
-func (m *Team) GetSize() int32 {
+func (m *UpdateMilestoneRequest) GetUpdateMask() *field_mask.FieldMask {
 	if m != nil {
-		return m.Size
+		return m.UpdateMask
 	}
-	return 0
+	return nil
 }
 
-func (m *Team) GetLastActive() int32 {
+func (m *UpdateMilestoneRequest) GetExpectedVersion() int64 {
 	if m != nil {
-		return m.LastActive
+		return m.ExpectedVersion
 	}
 	return 0
 }
 
-func (m *Team) GetId() string {
-	if m != nil {
-		return m.Id
+type DeleteMilestoneRequest struct {
+	Api         string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId      string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	ProjectId   string `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	MilestoneId string `protobuf:"bytes,4,opt,name=milestone_id,json=milestoneId,proto3" json:"milestone_id,omitempty"`
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteMilestoneRequest) Reset()         { *m = DeleteMilestoneRequest{} }
+func (m *DeleteMilestoneRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteMilestoneRequest) ProtoMessage()    {}
+func (*DeleteMilestoneRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{25}
+}
+
+func (m *DeleteMilestoneRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteMilestoneRequest.Unmarshal(m, b)
+}
+func (m *DeleteMilestoneRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteMilestoneRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteMilestoneRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteMilestoneRequest.Merge(m, src)
+}
+func (m *DeleteMilestoneRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteMilestoneRequest.Size(m)
+}
+func (m *DeleteMilestoneRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteMilestoneRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteMilestoneRequest proto.InternalMessageInfo
+
+func (m *DeleteMilestoneRequest) GetApi() string {
+	if m != nil {
+		return m.Api
 	}
 	return ""
 }
 
-func (m *Team) GetProject() *Project {
+func (m *DeleteMilestoneRequest) GetTeamId() string {
 	if m != nil {
-		return m.Project
+		return m.TeamId
 	}
-	return nil
+	return ""
 }
 
-type Member struct {
-	Email                string   `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Id                   int32    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
-	Role                 string   `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+func (m *DeleteMilestoneRequest) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+func (m *DeleteMilestoneRequest) GetMilestoneId() string {
+	if m != nil {
+		return m.MilestoneId
+	}
+	return ""
+}
+
+func (m *DeleteMilestoneRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type MilestoneResponse struct {
+	Api       string     `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status    string     `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Milestone *Milestone `protobuf:"bytes,3,opt,name=milestone,proto3" json:"milestone,omitempty"`
+	// version of the team after the change
+	Version              int64    `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Member) Reset()         { *m = Member{} }
-func (m *Member) String() string { return proto.CompactTextString(m) }
-func (*Member) ProtoMessage()    {}
-func (*Member) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{17}
+func (m *MilestoneResponse) Reset()         { *m = MilestoneResponse{} }
+func (m *MilestoneResponse) String() string { return proto.CompactTextString(m) }
+func (*MilestoneResponse) ProtoMessage()    {}
+func (*MilestoneResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{26}
 }
 
-func (m *Member) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Member.Unmarshal(m, b)
+func (m *MilestoneResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MilestoneResponse.Unmarshal(m, b)
 }
-func (m *Member) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Member.Marshal(b, m, deterministic)
+func (m *MilestoneResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MilestoneResponse.Marshal(b, m, deterministic)
 }
-func (m *Member) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Member.Merge(m, src)
+func (m *MilestoneResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MilestoneResponse.Merge(m, src)
 }
-func (m *Member) XXX_Size() int {
-	return xxx_messageInfo_Member.Size(m)
+func (m *MilestoneResponse) XXX_Size() int {
+	return xxx_messageInfo_MilestoneResponse.Size(m)
 }
-func (m *Member) XXX_DiscardUnknown() {
-	xxx_messageInfo_Member.DiscardUnknown(m)
+func (m *MilestoneResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MilestoneResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Member proto.InternalMessageInfo
+var xxx_messageInfo_MilestoneResponse proto.InternalMessageInfo
 
-func (m *Member) GetEmail() string {
+func (m *MilestoneResponse) GetApi() string {
 	if m != nil {
-		return m.Email
+		return m.Api
 	}
 	return ""
 }
 
-func (m *Member) GetId() int32 {
+func (m *MilestoneResponse) GetStatus() string {
 	if m != nil {
-		return m.Id
+		return m.Status
 	}
-	return 0
+	return ""
 }
 
-func (m *Member) GetRole() string {
+func (m *MilestoneResponse) GetMilestone() *Milestone {
 	if m != nil {
-		return m.Role
+		return m.Milestone
 	}
-	return ""
+	return nil
 }
 
-type Project struct {
-	Description          string   `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
-	Languages            []string `protobuf:"bytes,2,rep,name=languages,proto3" json:"languages,omitempty"`
-	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	GithubLink           string   `protobuf:"bytes,4,opt,name=github_link,json=githubLink,proto3" json:"github_link,omitempty"`
-	Complexity           int32    `protobuf:"varint,5,opt,name=complexity,proto3" json:"complexity,omitempty"`
-	Duration             int32    `protobuf:"varint,6,opt,name=duration,proto3" json:"duration,omitempty"`
+func (m *MilestoneResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type ListMilestonesRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId               string   `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	ProjectId            string   `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Project) Reset()         { *m = Project{} }
-func (m *Project) String() string { return proto.CompactTextString(m) }
-func (*Project) ProtoMessage()    {}
-func (*Project) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8b4e9e93d7b2c6bb, []int{18}
+func (m *ListMilestonesRequest) Reset()         { *m = ListMilestonesRequest{} }
+func (m *ListMilestonesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListMilestonesRequest) ProtoMessage()    {}
+func (*ListMilestonesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{27}
 }
 
-func (m *Project) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Project.Unmarshal(m, b)
+func (m *ListMilestonesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMilestonesRequest.Unmarshal(m, b)
 }
-func (m *Project) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Project.Marshal(b, m, deterministic)
+func (m *ListMilestonesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMilestonesRequest.Marshal(b, m, deterministic)
 }
-func (m *Project) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Project.Merge(m, src)
+func (m *ListMilestonesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMilestonesRequest.Merge(m, src)
 }
-func (m *Project) XXX_Size() int {
-	return xxx_messageInfo_Project.Size(m)
+func (m *ListMilestonesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListMilestonesRequest.Size(m)
 }
-func (m *Project) XXX_DiscardUnknown() {
-	xxx_messageInfo_Project.DiscardUnknown(m)
+func (m *ListMilestonesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMilestonesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Project proto.InternalMessageInfo
+var xxx_messageInfo_ListMilestonesRequest proto.InternalMessageInfo
 
-func (m *Project) GetDescription() string {
+func (m *ListMilestonesRequest) GetApi() string {
 	if m != nil {
-		return m.Description
+		return m.Api
 	}
 	return ""
 }
 
-func (m *Project) GetLanguages() []string {
+func (m *ListMilestonesRequest) GetTeamId() string {
 	if m != nil {
-		return m.Languages
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *ListMilestonesRequest) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+type ListMilestonesResponse struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// milestones in position order
+	Milestones           []*Milestone `protobuf:"bytes,3,rep,name=milestones,proto3" json:"milestones,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListMilestonesResponse) Reset()         { *m = ListMilestonesResponse{} }
+func (m *ListMilestonesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListMilestonesResponse) ProtoMessage()    {}
+func (*ListMilestonesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{28}
+}
+
+func (m *ListMilestonesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMilestonesResponse.Unmarshal(m, b)
+}
+func (m *ListMilestonesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMilestonesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListMilestonesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMilestonesResponse.Merge(m, src)
+}
+func (m *ListMilestonesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListMilestonesResponse.Size(m)
+}
+func (m *ListMilestonesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMilestonesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMilestonesResponse proto.InternalMessageInfo
+
+func (m *ListMilestonesResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListMilestonesResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ListMilestonesResponse) GetMilestones() []*Milestone {
+	if m != nil {
+		return m.Milestones
 	}
 	return nil
 }
 
-func (m *Project) GetName() string {
+type CreateTaskRequest struct {
+	Api       string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId    string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	ProjectId string `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Task      *Task  `protobuf:"bytes,4,opt,name=task,proto3" json:"task,omitempty"`
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateTaskRequest) Reset()         { *m = CreateTaskRequest{} }
+func (m *CreateTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateTaskRequest) ProtoMessage()    {}
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{29}
+}
+
+func (m *CreateTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTaskRequest.Unmarshal(m, b)
+}
+func (m *CreateTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTaskRequest.Merge(m, src)
+}
+func (m *CreateTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateTaskRequest.Size(m)
+}
+func (m *CreateTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTaskRequest proto.InternalMessageInfo
+
+func (m *CreateTaskRequest) GetApi() string {
 	if m != nil {
-		return m.Name
+		return m.Api
 	}
 	return ""
 }
 
-func (m *Project) GetGithubLink() string {
+func (m *CreateTaskRequest) GetTeamId() string {
 	if m != nil {
-		return m.GithubLink
+		return m.TeamId
 	}
 	return ""
 }
 
-func (m *Project) GetComplexity() int32 {
+func (m *CreateTaskRequest) GetProjectId() string {
 	if m != nil {
-		return m.Complexity
+		return m.ProjectId
 	}
-	return 0
+	return ""
 }
 
-func (m *Project) GetDuration() int32 {
+func (m *CreateTaskRequest) GetTask() *Task {
 	if m != nil {
-		return m.Duration
+		return m.Task
+	}
+	return nil
+}
+
+func (m *CreateTaskRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
 	}
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*TeamUpsertRequest)(nil), "team.TeamUpsertRequest")
-	proto.RegisterType((*TeamUpsertResponse)(nil), "team.TeamUpsertResponse")
-	proto.RegisterType((*TeamDeleteRequest)(nil), "team.TeamDeleteRequest")
-	proto.RegisterType((*TeamDeleteResponse)(nil), "team.TeamDeleteResponse")
-	proto.RegisterType((*MemberUpsertRequest)(nil), "team.MemberUpsertRequest")
-	proto.RegisterType((*MemberUpsertResponse)(nil), "team.MemberUpsertResponse")
-	proto.RegisterType((*MemberDeleteRequest)(nil), "team.MemberDeleteRequest")
-	proto.RegisterType((*MemberDeleteResponse)(nil), "team.MemberDeleteResponse")
-	proto.RegisterType((*ProjectUpsertRequest)(nil), "team.ProjectUpsertRequest")
-	proto.RegisterType((*ProjectUpsertResponse)(nil), "team.ProjectUpsertResponse")
-	proto.RegisterType((*GetByTeamNameRequest)(nil), "team.GetByTeamNameRequest")
-	proto.RegisterType((*GetByTeamNameResponse)(nil), "team.GetByTeamNameResponse")
-	proto.RegisterType((*GetByUserIdRequest)(nil), "team.GetByUserIdRequest")
-	proto.RegisterType((*GetByUserIdResponse)(nil), "team.GetByUserIdResponse")
-	proto.RegisterType((*GetTeamsRequest)(nil), "team.GetTeamsRequest")
-	proto.RegisterType((*GetTeamsResponse)(nil), "team.GetTeamsResponse")
-	proto.RegisterType((*Team)(nil), "team.Team")
-	proto.RegisterType((*Member)(nil), "team.Member")
-	proto.RegisterType((*Project)(nil), "team.Project")
+type UpdateTaskRequest struct {
+	Api       string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId    string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	ProjectId string `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	TaskId    string `protobuf:"bytes,4,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// task holds the new values of the fields in update_mask, others are ignored
+	Task *Task `protobuf:"bytes,5,opt,name=task,proto3" json:"task,omitempty"`
+	// update_mask lists the fields to change, the REST gateway fills it with
+	// the fields of the PATCH body
+	UpdateMask *field_mask.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("team.proto", fileDescriptor_8b4e9e93d7b2c6bb) }
+func (m *UpdateTaskRequest) Reset()         { *m = UpdateTaskRequest{} }
+func (m *UpdateTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateTaskRequest) ProtoMessage()    {}
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{30}
+}
 
-var fileDescriptor_8b4e9e93d7b2c6bb = []byte{
-	// 941 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xdd, 0x6e, 0xdc, 0x44,
-	0x18, 0x8d, 0xd7, 0xf6, 0x6e, 0xfc, 0x6d, 0x0a, 0xed, 0x34, 0x69, 0xdc, 0x2d, 0xb4, 0xc1, 0x48,
-	0xd0, 0xab, 0x5e, 0x04, 0x89, 0x1b, 0xb8, 0xd9, 0xb6, 0x28, 0x5a, 0x09, 0x56, 0xc8, 0xb4, 0x70,
-	0xd7, 0x95, 0xb3, 0xfe, 0xb4, 0x1d, 0xe2, 0xbf, 0xd8, 0xe3, 0x15, 0xe1, 0x96, 0x77, 0x00, 0x89,
-	0x3b, 0x1e, 0x80, 0x17, 0xe0, 0xe5, 0x40, 0xf3, 0x63, 0x7b, 0x66, 0x6b, 0xa7, 0x6d, 0xb8, 0x8a,
-	0xbf, 0x6f, 0x66, 0xce, 0x9c, 0x39, 0x73, 0xe6, 0x6c, 0x00, 0x18, 0x46, 0xe9, 0x93, 0xa2, 0xcc,
-	0x59, 0x4e, 0x1c, 0xfe, 0x1d, 0xbc, 0x82, 0x3b, 0x2f, 0x30, 0x4a, 0x5f, 0x16, 0x15, 0x96, 0x2c,
-	0xc4, 0xcb, 0x1a, 0x2b, 0x46, 0x6e, 0x83, 0x1d, 0x15, 0xd4, 0xb7, 0x4e, 0xac, 0xc7, 0x5e, 0xc8,
-	0x3f, 0xc9, 0x43, 0x10, 0xd3, 0xfd, 0xd1, 0x89, 0xf5, 0x78, 0x7a, 0x0a, 0x4f, 0x04, 0x0e, 0x5f,
-	0x18, 0x8a, 0x3e, 0x39, 0x86, 0x49, 0x5d, 0x61, 0xb9, 0xa2, 0xb1, 0x6f, 0x8b, 0x55, 0x63, 0x5e,
-	0x2e, 0xe2, 0x60, 0x09, 0x44, 0xc7, 0xaf, 0x8a, 0x3c, 0xab, 0xb0, 0x67, 0x83, 0x7b, 0x30, 0xae,
-	0x58, 0xc4, 0xea, 0x4a, 0x6c, 0xe1, 0x85, 0xaa, 0x22, 0x1f, 0xc0, 0xa8, 0xc5, 0x1c, 0xd1, 0x38,
-	0xf8, 0x49, 0xf2, 0x7d, 0x8e, 0x09, 0x32, 0x1c, 0xe6, 0x7b, 0x0c, 0x13, 0xce, 0x8b, 0xf3, 0x51,
-	0x78, 0xbc, 0x5c, 0xc4, 0xc3, 0x44, 0x7f, 0xb7, 0x24, 0xd3, 0x06, 0xf9, 0xbd, 0x99, 0x1e, 0x82,
-	0xcb, 0xf7, 0xa8, 0x04, 0xae, 0x1d, 0xca, 0x82, 0xf8, 0x30, 0x49, 0x31, 0x3d, 0xc7, 0xb2, 0xf2,
-	0x1d, 0xd1, 0x6f, 0x4a, 0x81, 0x73, 0x41, 0x93, 0xa4, 0xf2, 0x5d, 0x31, 0xa0, 0x2a, 0x75, 0xe2,
-	0x71, 0x7b, 0xe2, 0xbf, 0x2d, 0xb8, 0xfb, 0x9d, 0x58, 0xf3, 0xb6, 0x4b, 0x1a, 0x3c, 0xf4, 0x03,
-	0xf0, 0xe4, 0xae, 0xdd, 0xb1, 0xf7, 0x65, 0x63, 0x11, 0x93, 0x4f, 0xe0, 0x40, 0x0d, 0x62, 0x1a,
-	0xd1, 0x44, 0xd0, 0xf4, 0xc2, 0xa9, 0xec, 0x7d, 0xc3, 0x5b, 0x84, 0x80, 0x53, 0xe6, 0x09, 0x0a,
-	0xa2, 0x5e, 0x28, 0xbe, 0x75, 0x21, 0xc7, 0x86, 0x90, 0x08, 0x87, 0x26, 0xdd, 0x41, 0x25, 0x3f,
-	0x85, 0x5b, 0x6a, 0xe7, 0xac, 0xe6, 0x7f, 0x14, 0x6b, 0x45, 0x67, 0x29, 0x7a, 0x9a, 0xdc, 0xb6,
-	0x2e, 0x77, 0xf0, 0x57, 0x2b, 0xcb, 0x8d, 0xbd, 0xf0, 0xc6, 0xfe, 0x76, 0xcf, 0xfe, 0xef, 0x20,
-	0x8f, 0x26, 0x85, 0x6b, 0x48, 0xf1, 0x63, 0x23, 0xc5, 0xff, 0x31, 0xd5, 0x3a, 0xaf, 0x33, 0xd6,
-	0x98, 0x4a, 0x14, 0xc1, 0x6f, 0x16, 0x1c, 0x7e, 0x5f, 0xe6, 0x3f, 0xe3, 0x9a, 0x99, 0x9e, 0xf8,
-	0x1c, 0x26, 0x85, 0xec, 0x0b, 0xf0, 0xe9, 0xe9, 0x2d, 0xf9, 0x52, 0xd5, 0xe4, 0xb0, 0x19, 0x6d,
-	0x18, 0x8c, 0x7a, 0x55, 0xb2, 0x87, 0x5e, 0x8c, 0x63, 0x9c, 0x6e, 0x0e, 0x47, 0x3b, 0x24, 0xde,
-	0xf7, 0x78, 0xc1, 0xd7, 0x70, 0x78, 0x86, 0xec, 0xe9, 0x15, 0x7f, 0x78, 0xcb, 0x28, 0xbd, 0xe6,
-	0x12, 0x09, 0x38, 0x59, 0x94, 0xa2, 0x5a, 0x2f, 0xbe, 0x83, 0x4b, 0x38, 0xda, 0x59, 0x3d, 0x48,
-	0x40, 0x3e, 0xaa, 0x51, 0xf3, 0xa8, 0xda, 0x3c, 0xb3, 0x07, 0xf2, 0xac, 0x23, 0xec, 0x18, 0x84,
-	0xbf, 0x04, 0x22, 0xb6, 0x7c, 0x29, 0x24, 0x18, 0xa6, 0xbb, 0xb3, 0x5f, 0x70, 0x09, 0x77, 0x8d,
-	0x75, 0xef, 0x4c, 0xf4, 0xa4, 0x4b, 0x15, 0x7b, 0x87, 0xa9, 0x4a, 0x98, 0x21, 0xaa, 0x7f, 0x58,
-	0xf0, 0xe1, 0x19, 0x32, 0x3e, 0xb5, 0xba, 0x56, 0xd7, 0x22, 0xda, 0x48, 0x5d, 0xed, 0x50, 0x7c,
-	0x73, 0xd3, 0x25, 0x34, 0xa5, 0xad, 0xe9, 0x44, 0xd1, 0x86, 0x80, 0xa3, 0x85, 0x00, 0x9f, 0x89,
-	0x5b, 0x4c, 0x54, 0x84, 0xc9, 0x82, 0x3c, 0xe4, 0xbf, 0x33, 0xeb, 0xd7, 0x59, 0x9e, 0xe4, 0x9b,
-	0x2b, 0x95, 0x0e, 0x5a, 0x27, 0x78, 0x05, 0xb7, 0x3b, 0x62, 0x83, 0x4a, 0xb4, 0x27, 0x1f, 0xbd,
-	0xfd, 0xe4, 0x66, 0x34, 0xfc, 0x6b, 0x81, 0xf3, 0x42, 0xdd, 0x62, 0x82, 0x51, 0x8c, 0xa5, 0xc2,
-	0x55, 0x15, 0xf9, 0xac, 0x0b, 0x65, 0x09, 0x7e, 0x20, 0xc1, 0xe5, 0x63, 0xed, 0x22, 0xba, 0x31,
-	0x9d, 0xdd, 0x99, 0x8e, 0x7c, 0x0c, 0x90, 0x17, 0x98, 0xad, 0xf8, 0xf9, 0xa5, 0xe4, 0x6e, 0xe8,
-	0xf1, 0x4e, 0xc8, 0x1b, 0x46, 0xaa, 0xdb, 0x82, 0x93, 0x4c, 0x75, 0x02, 0x4e, 0x45, 0x7f, 0x45,
-	0xa1, 0x86, 0x1b, 0x8a, 0x6f, 0xf2, 0x08, 0xa6, 0x49, 0x54, 0xb1, 0x55, 0xb4, 0x66, 0x74, 0x8b,
-	0xfe, 0x44, 0x0c, 0x01, 0x6f, 0xcd, 0x45, 0x47, 0x99, 0x61, 0xbf, 0x35, 0x83, 0xf6, 0xbc, 0xbd,
-	0xeb, 0x9e, 0x77, 0xf0, 0x14, 0xc6, 0xf2, 0x2c, 0xfc, 0x86, 0x64, 0x6e, 0x49, 0x05, 0x64, 0xa1,
-	0xb9, 0xcc, 0x15, 0xc0, 0xcd, 0xdd, 0xda, 0xdd, 0xdd, 0x06, 0xff, 0x58, 0x30, 0x51, 0xc0, 0xe4,
-	0x04, 0xa6, 0x31, 0x56, 0xeb, 0x92, 0x16, 0x8c, 0xe6, 0x99, 0xc2, 0xd2, 0x5b, 0xe4, 0x23, 0xf0,
-	0x92, 0x28, 0xdb, 0xd4, 0xd1, 0x06, 0xa5, 0xa8, 0x5e, 0xd8, 0x35, 0x7a, 0x85, 0x7c, 0x04, 0xd3,
-	0x0d, 0x65, 0xaf, 0xeb, 0xf3, 0x55, 0x42, 0xb3, 0x0b, 0x65, 0x2b, 0x90, 0xad, 0x6f, 0x69, 0x76,
-	0xc1, 0x6d, 0xb4, 0xce, 0xd3, 0x22, 0xc1, 0x5f, 0x28, 0xbb, 0x12, 0x0e, 0x73, 0x43, 0xad, 0x43,
-	0x66, 0xb0, 0x1f, 0xd7, 0x65, 0x24, 0x18, 0x49, 0x59, 0xdb, 0xfa, 0xf4, 0x4f, 0x17, 0xa6, 0xdc,
-	0x02, 0x3f, 0x60, 0xb9, 0xa5, 0x6b, 0x24, 0x73, 0x80, 0x67, 0x25, 0x46, 0x0c, 0x85, 0x2f, 0x8e,
-	0x3b, 0x2f, 0x19, 0xf9, 0x39, 0xf3, 0xdf, 0x1c, 0x90, 0xfe, 0x0c, 0xf6, 0x38, 0x84, 0x8c, 0xf1,
-	0x5d, 0x08, 0xe3, 0xf7, 0x47, 0x87, 0x30, 0x53, 0x3f, 0xd8, 0x23, 0xcf, 0xc1, 0x9b, 0xc7, 0xb1,
-	0xba, 0x99, 0xfb, 0xba, 0xe7, 0x4c, 0x1a, 0xb3, 0xbe, 0xa1, 0x16, 0xe5, 0x0c, 0x0e, 0x42, 0x4c,
-	0xf3, 0x2d, 0xf6, 0x01, 0x99, 0x64, 0x66, 0x7d, 0x43, 0x2d, 0xd0, 0x12, 0xee, 0x48, 0x70, 0x4e,
-	0xb6, 0xb9, 0xea, 0x99, 0x61, 0x29, 0x93, 0xd7, 0x83, 0xde, 0x31, 0x1d, 0x4f, 0xbd, 0xeb, 0x2e,
-	0x93, 0x1b, 0xbc, 0xbe, 0x98, 0x6f, 0xf0, 0x7a, 0x43, 0x3c, 0xd8, 0x23, 0x8b, 0x2e, 0x27, 0x9a,
-	0xe4, 0x24, 0xbe, 0xb6, 0xc4, 0x08, 0xe1, 0xd9, 0xfd, 0x9e, 0x11, 0x8d, 0xda, 0x51, 0x07, 0xf5,
-	0xac, 0x2e, 0x4b, 0xcc, 0x18, 0x9f, 0x74, 0x53, 0xbc, 0xaf, 0x60, 0xbf, 0xc1, 0x23, 0x47, 0xed,
-	0x44, 0x3d, 0x6b, 0x67, 0xf7, 0x76, 0xdb, 0xcd, 0xe2, 0xf3, 0xb1, 0xf8, 0x07, 0xfc, 0x8b, 0xff,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0xdf, 0x70, 0x93, 0xb7, 0x8e, 0x0b, 0x00, 0x00,
+func (m *UpdateTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTaskRequest.Unmarshal(m, b)
+}
+func (m *UpdateTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTaskRequest.Merge(m, src)
+}
+func (m *UpdateTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateTaskRequest.Size(m)
+}
+func (m *UpdateTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTaskRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_UpdateTaskRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *UpdateTaskRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
 
-// TeamServiceClient is the client API for TeamService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type TeamServiceClient interface {
-	CreateTeam(ctx context.Context, in *TeamUpsertRequest, opts ...grpc.CallOption) (*TeamUpsertResponse, error)
-	DeleteTeam(ctx context.Context, in *TeamDeleteRequest, opts ...grpc.CallOption) (*TeamDeleteResponse, error)
-	AddMember(ctx context.Context, in *MemberUpsertRequest, opts ...grpc.CallOption) (*MemberUpsertResponse, error)
-	RemoveMember(ctx context.Context, in *MemberDeleteRequest, opts ...grpc.CallOption) (*MemberDeleteResponse, error)
-	UpsertTeamProject(ctx context.Context, in *ProjectUpsertRequest, opts ...grpc.CallOption) (*ProjectUpsertResponse, error)
-	GetTeamByTeamName(ctx context.Context, in *GetByTeamNameRequest, opts ...grpc.CallOption) (*GetByTeamNameResponse, error)
-	GetTeamsByUserId(ctx context.Context, in *GetByUserIdRequest, opts ...grpc.CallOption) (*GetByUserIdResponse, error)
-	GetTeamsByCurrentUser(ctx context.Context, in *GetByUserIdRequest, opts ...grpc.CallOption) (*GetByUserIdResponse, error)
-	GetTeams(ctx context.Context, in *GetTeamsRequest, opts ...grpc.CallOption) (*GetTeamsResponse, error)
+func (m *UpdateTaskRequest) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
 }
 
-type teamServiceClient struct {
-	cc *grpc.ClientConn
+func (m *UpdateTaskRequest) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
 }
 
-func NewTeamServiceClient(cc *grpc.ClientConn) TeamServiceClient {
-	return &teamServiceClient{cc}
+func (m *UpdateTaskRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
 }
 
-func (c *teamServiceClient) CreateTeam(ctx context.Context, in *TeamUpsertRequest, opts ...grpc.CallOption) (*TeamUpsertResponse, error) {
-	out := new(TeamUpsertResponse)
-	err := c.cc.Invoke(ctx, "/team.TeamService/CreateTeam", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *UpdateTaskRequest) GetTask() *Task {
+	if m != nil {
+		return m.Task
 	}
-	return out, nil
+	return nil
 }
 
-func (c *teamServiceClient) DeleteTeam(ctx context.Context, in *TeamDeleteRequest, opts ...grpc.CallOption) (*TeamDeleteResponse, error) {
-	out := new(TeamDeleteResponse)
-	err := c.cc.Invoke(ctx, "/team.TeamService/DeleteTeam", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *UpdateTaskRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+func (m *UpdateTaskRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteTaskRequest struct {
+	Api       string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId    string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	ProjectId string `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	TaskId    string `protobuf:"bytes,4,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteTaskRequest) Reset()         { *m = DeleteTaskRequest{} }
+func (m *DeleteTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteTaskRequest) ProtoMessage()    {}
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{31}
+}
+
+func (m *DeleteTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteTaskRequest.Unmarshal(m, b)
+}
+func (m *DeleteTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTaskRequest.Merge(m, src)
+}
+func (m *DeleteTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteTaskRequest.Size(m)
+}
+func (m *DeleteTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteTaskRequest proto.InternalMessageInfo
+
+func (m *DeleteTaskRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteTaskRequest) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *DeleteTaskRequest) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+func (m *DeleteTaskRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *DeleteTaskRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type TaskResponse struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Task   *Task  `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
+	// version of the team after the change
+	Version              int64    `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaskResponse) Reset()         { *m = TaskResponse{} }
+func (m *TaskResponse) String() string { return proto.CompactTextString(m) }
+func (*TaskResponse) ProtoMessage()    {}
+func (*TaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{32}
+}
+
+func (m *TaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskResponse.Unmarshal(m, b)
+}
+func (m *TaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskResponse.Marshal(b, m, deterministic)
+}
+func (m *TaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskResponse.Merge(m, src)
+}
+func (m *TaskResponse) XXX_Size() int {
+	return xxx_messageInfo_TaskResponse.Size(m)
+}
+func (m *TaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskResponse proto.InternalMessageInfo
+
+func (m *TaskResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *TaskResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *TaskResponse) GetTask() *Task {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+func (m *TaskResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type ListTasksRequest struct {
+	Api       string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId    string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	ProjectId string `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// every filter set below must match
+	MilestoneId string `protobuf:"bytes,4,opt,name=milestone_id,json=milestoneId,proto3" json:"milestone_id,omitempty"`
+	// assignee is the member number of the assigned member
+	Assignee string `protobuf:"bytes,5,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	// statuses keeps tasks in any of the statuses, every status when empty
+	Statuses             []string `protobuf:"bytes,6,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTasksRequest) Reset()         { *m = ListTasksRequest{} }
+func (m *ListTasksRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTasksRequest) ProtoMessage()    {}
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{33}
+}
+
+func (m *ListTasksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTasksRequest.Unmarshal(m, b)
+}
+func (m *ListTasksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTasksRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTasksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTasksRequest.Merge(m, src)
+}
+func (m *ListTasksRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTasksRequest.Size(m)
+}
+func (m *ListTasksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTasksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTasksRequest proto.InternalMessageInfo
+
+func (m *ListTasksRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListTasksRequest) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *ListTasksRequest) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+func (m *ListTasksRequest) GetMilestoneId() string {
+	if m != nil {
+		return m.MilestoneId
+	}
+	return ""
+}
+
+func (m *ListTasksRequest) GetAssignee() string {
+	if m != nil {
+		return m.Assignee
+	}
+	return ""
+}
+
+func (m *ListTasksRequest) GetStatuses() []string {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+type ListTasksResponse struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// tasks in position order
+	Tasks                []*Task  `protobuf:"bytes,3,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTasksResponse) Reset()         { *m = ListTasksResponse{} }
+func (m *ListTasksResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTasksResponse) ProtoMessage()    {}
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{34}
+}
+
+func (m *ListTasksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTasksResponse.Unmarshal(m, b)
+}
+func (m *ListTasksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTasksResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTasksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTasksResponse.Merge(m, src)
+}
+func (m *ListTasksResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTasksResponse.Size(m)
+}
+func (m *ListTasksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTasksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTasksResponse proto.InternalMessageInfo
+
+func (m *ListTasksResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListTasksResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ListTasksResponse) GetTasks() []*Task {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
+type GetByTeamIdRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetByTeamIdRequest) Reset()         { *m = GetByTeamIdRequest{} }
+func (m *GetByTeamIdRequest) String() string { return proto.CompactTextString(m) }
+func (*GetByTeamIdRequest) ProtoMessage()    {}
+func (*GetByTeamIdRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{35}
+}
+
+func (m *GetByTeamIdRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetByTeamIdRequest.Unmarshal(m, b)
+}
+func (m *GetByTeamIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetByTeamIdRequest.Marshal(b, m, deterministic)
+}
+func (m *GetByTeamIdRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetByTeamIdRequest.Merge(m, src)
+}
+func (m *GetByTeamIdRequest) XXX_Size() int {
+	return xxx_messageInfo_GetByTeamIdRequest.Size(m)
+}
+func (m *GetByTeamIdRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetByTeamIdRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetByTeamIdRequest proto.InternalMessageInfo
+
+func (m *GetByTeamIdRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetByTeamIdRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetByTeamIdResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Team                 *Team    `protobuf:"bytes,2,opt,name=team,proto3" json:"team,omitempty"`
+	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetByTeamIdResponse) Reset()         { *m = GetByTeamIdResponse{} }
+func (m *GetByTeamIdResponse) String() string { return proto.CompactTextString(m) }
+func (*GetByTeamIdResponse) ProtoMessage()    {}
+func (*GetByTeamIdResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{36}
+}
+
+func (m *GetByTeamIdResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetByTeamIdResponse.Unmarshal(m, b)
+}
+func (m *GetByTeamIdResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetByTeamIdResponse.Marshal(b, m, deterministic)
+}
+func (m *GetByTeamIdResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetByTeamIdResponse.Merge(m, src)
+}
+func (m *GetByTeamIdResponse) XXX_Size() int {
+	return xxx_messageInfo_GetByTeamIdResponse.Size(m)
+}
+func (m *GetByTeamIdResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetByTeamIdResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetByTeamIdResponse proto.InternalMessageInfo
+
+func (m *GetByTeamIdResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetByTeamIdResponse) GetTeam() *Team {
+	if m != nil {
+		return m.Team
+	}
+	return nil
+}
+
+func (m *GetByTeamIdResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type GetByTeamNameRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetByTeamNameRequest) Reset()         { *m = GetByTeamNameRequest{} }
+func (m *GetByTeamNameRequest) String() string { return proto.CompactTextString(m) }
+func (*GetByTeamNameRequest) ProtoMessage()    {}
+func (*GetByTeamNameRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{37}
+}
+
+func (m *GetByTeamNameRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetByTeamNameRequest.Unmarshal(m, b)
+}
+func (m *GetByTeamNameRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetByTeamNameRequest.Marshal(b, m, deterministic)
+}
+func (m *GetByTeamNameRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetByTeamNameRequest.Merge(m, src)
+}
+func (m *GetByTeamNameRequest) XXX_Size() int {
+	return xxx_messageInfo_GetByTeamNameRequest.Size(m)
+}
+func (m *GetByTeamNameRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetByTeamNameRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetByTeamNameRequest proto.InternalMessageInfo
+
+func (m *GetByTeamNameRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetByTeamNameRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type GetByTeamNameResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Team                 *Team    `protobuf:"bytes,3,opt,name=team,proto3" json:"team,omitempty"`
+	Status               string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetByTeamNameResponse) Reset()         { *m = GetByTeamNameResponse{} }
+func (m *GetByTeamNameResponse) String() string { return proto.CompactTextString(m) }
+func (*GetByTeamNameResponse) ProtoMessage()    {}
+func (*GetByTeamNameResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{38}
+}
+
+func (m *GetByTeamNameResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetByTeamNameResponse.Unmarshal(m, b)
+}
+func (m *GetByTeamNameResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetByTeamNameResponse.Marshal(b, m, deterministic)
+}
+func (m *GetByTeamNameResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetByTeamNameResponse.Merge(m, src)
+}
+func (m *GetByTeamNameResponse) XXX_Size() int {
+	return xxx_messageInfo_GetByTeamNameResponse.Size(m)
+}
+func (m *GetByTeamNameResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetByTeamNameResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetByTeamNameResponse proto.InternalMessageInfo
+
+func (m *GetByTeamNameResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetByTeamNameResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GetByTeamNameResponse) GetTeam() *Team {
+	if m != nil {
+		return m.Team
+	}
+	return nil
+}
+
+func (m *GetByTeamNameResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type GetByUserIdRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// ignored by GetTeamsByCurrentUser
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetByUserIdRequest) Reset()         { *m = GetByUserIdRequest{} }
+func (m *GetByUserIdRequest) String() string { return proto.CompactTextString(m) }
+func (*GetByUserIdRequest) ProtoMessage()    {}
+func (*GetByUserIdRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{39}
+}
+
+func (m *GetByUserIdRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetByUserIdRequest.Unmarshal(m, b)
+}
+func (m *GetByUserIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetByUserIdRequest.Marshal(b, m, deterministic)
+}
+func (m *GetByUserIdRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetByUserIdRequest.Merge(m, src)
+}
+func (m *GetByUserIdRequest) XXX_Size() int {
+	return xxx_messageInfo_GetByUserIdRequest.Size(m)
+}
+func (m *GetByUserIdRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetByUserIdRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetByUserIdRequest proto.InternalMessageInfo
+
+func (m *GetByUserIdRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetByUserIdRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetByUserIdResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Teams                []*Team  `protobuf:"bytes,3,rep,name=teams,proto3" json:"teams,omitempty"`
+	Status               string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetByUserIdResponse) Reset()         { *m = GetByUserIdResponse{} }
+func (m *GetByUserIdResponse) String() string { return proto.CompactTextString(m) }
+func (*GetByUserIdResponse) ProtoMessage()    {}
+func (*GetByUserIdResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{40}
+}
+
+func (m *GetByUserIdResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetByUserIdResponse.Unmarshal(m, b)
+}
+func (m *GetByUserIdResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetByUserIdResponse.Marshal(b, m, deterministic)
+}
+func (m *GetByUserIdResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetByUserIdResponse.Merge(m, src)
+}
+func (m *GetByUserIdResponse) XXX_Size() int {
+	return xxx_messageInfo_GetByUserIdResponse.Size(m)
+}
+func (m *GetByUserIdResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetByUserIdResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetByUserIdResponse proto.InternalMessageInfo
+
+func (m *GetByUserIdResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetByUserIdResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GetByUserIdResponse) GetTeams() []*Team {
+	if m != nil {
+		return m.Teams
+	}
+	return nil
+}
+
+func (m *GetByUserIdResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type GetTeamsRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Deprecated: page is only used when page_token is empty, prefer page_token
+	Page int64 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// limit is the page size, 20 when unset
+	Limit      int64  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Role       string `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	Level      int64  `protobuf:"varint,5,opt,name=level,proto3" json:"level,omitempty"`
+	Technology string `protobuf:"bytes,6,opt,name=technology,proto3" json:"technology,omitempty"`
+	// page_token is the next_page_token of the previous page, it must be sent
+	// with the same filters
+	PageToken string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// every filter set below must match, role, level and technology included
+	// skills the team looks for, matched according to skills_match
+	Skills      []string   `protobuf:"bytes,8,rep,name=skills,proto3" json:"skills,omitempty"`
+	SkillsMatch SkillMatch `protobuf:"varint,9,opt,name=skills_match,json=skillsMatch,proto3,enum=team.SkillMatch" json:"skills_match,omitempty"`
+	// project complexity range, bounds included, 0 for no bound
+	MinComplexity int32 `protobuf:"varint,10,opt,name=min_complexity,json=minComplexity,proto3" json:"min_complexity,omitempty"`
+	MaxComplexity int32 `protobuf:"varint,11,opt,name=max_complexity,json=maxComplexity,proto3" json:"max_complexity,omitempty"`
+	// languages of the project, any of them matches
+	Languages    []string `protobuf:"bytes,12,rep,name=languages,proto3" json:"languages,omitempty"`
+	MinOpenRoles int32    `protobuf:"varint,13,opt,name=min_open_roles,json=minOpenRoles,proto3" json:"min_open_roles,omitempty"`
+	// team size range, bounds included, 0 for no bound
+	MinSize int32 `protobuf:"varint,14,opt,name=min_size,json=minSize,proto3" json:"min_size,omitempty"`
+	MaxSize int32 `protobuf:"varint,15,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	// active_since keeps teams whose last_active is at or after it
+	ActiveSince int64 `protobuf:"varint,16,opt,name=active_since,json=activeSince,proto3" json:"active_since,omitempty"`
+	// name_prefix keeps teams whose name starts with it, case insensitive
+	NamePrefix string   `protobuf:"bytes,17,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	Sort       TeamSort `protobuf:"varint,18,opt,name=sort,proto3,enum=team.TeamSort" json:"sort,omitempty"`
+	// active_within_days keeps teams active in the last active_within_days days
+	ActiveWithinDays     int32    `protobuf:"varint,19,opt,name=active_within_days,json=activeWithinDays,proto3" json:"active_within_days,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTeamsRequest) Reset()         { *m = GetTeamsRequest{} }
+func (m *GetTeamsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTeamsRequest) ProtoMessage()    {}
+func (*GetTeamsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{41}
+}
+
+func (m *GetTeamsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTeamsRequest.Unmarshal(m, b)
+}
+func (m *GetTeamsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTeamsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTeamsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTeamsRequest.Merge(m, src)
+}
+func (m *GetTeamsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTeamsRequest.Size(m)
+}
+func (m *GetTeamsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTeamsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTeamsRequest proto.InternalMessageInfo
+
+func (m *GetTeamsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetTeamsRequest) GetPage() int64 {
+	if m != nil {
+		return m.Page
+	}
+	return 0
+}
+
+func (m *GetTeamsRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetTeamsRequest) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *GetTeamsRequest) GetLevel() int64 {
+	if m != nil {
+		return m.Level
+	}
+	return 0
+}
+
+func (m *GetTeamsRequest) GetTechnology() string {
+	if m != nil {
+		return m.Technology
+	}
+	return ""
+}
+
+func (m *GetTeamsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *GetTeamsRequest) GetSkills() []string {
+	if m != nil {
+		return m.Skills
+	}
+	return nil
+}
+
+func (m *GetTeamsRequest) GetSkillsMatch() SkillMatch {
+	if m != nil {
+		return m.SkillsMatch
+	}
+	return SkillMatch_SKILL_MATCH_ANY
+}
+
+func (m *GetTeamsRequest) GetMinComplexity() int32 {
+	if m != nil {
+		return m.MinComplexity
+	}
+	return 0
+}
+
+func (m *GetTeamsRequest) GetMaxComplexity() int32 {
+	if m != nil {
+		return m.MaxComplexity
+	}
+	return 0
+}
+
+func (m *GetTeamsRequest) GetLanguages() []string {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+func (m *GetTeamsRequest) GetMinOpenRoles() int32 {
+	if m != nil {
+		return m.MinOpenRoles
+	}
+	return 0
+}
+
+func (m *GetTeamsRequest) GetMinSize() int32 {
+	if m != nil {
+		return m.MinSize
+	}
+	return 0
+}
+
+func (m *GetTeamsRequest) GetMaxSize() int32 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+func (m *GetTeamsRequest) GetActiveSince() int64 {
+	if m != nil {
+		return m.ActiveSince
+	}
+	return 0
+}
+
+func (m *GetTeamsRequest) GetNamePrefix() string {
+	if m != nil {
+		return m.NamePrefix
+	}
+	return ""
+}
+
+func (m *GetTeamsRequest) GetSort() TeamSort {
+	if m != nil {
+		return m.Sort
+	}
+	return TeamSort_TEAM_SORT_DEFAULT
+}
+
+func (m *GetTeamsRequest) GetActiveWithinDays() int32 {
+	if m != nil {
+		return m.ActiveWithinDays
+	}
+	return 0
+}
+
+type GetTeamsResponse struct {
+	Api    string  `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Teams  []*Team `protobuf:"bytes,2,rep,name=teams,proto3" json:"teams,omitempty"`
+	Status string  `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// next_page_token fetches the following page, empty on the last page
+	NextPageToken        string   `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTeamsResponse) Reset()         { *m = GetTeamsResponse{} }
+func (m *GetTeamsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTeamsResponse) ProtoMessage()    {}
+func (*GetTeamsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{42}
+}
+
+func (m *GetTeamsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTeamsResponse.Unmarshal(m, b)
+}
+func (m *GetTeamsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTeamsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTeamsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTeamsResponse.Merge(m, src)
+}
+func (m *GetTeamsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTeamsResponse.Size(m)
+}
+func (m *GetTeamsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTeamsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTeamsResponse proto.InternalMessageInfo
+
+func (m *GetTeamsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetTeamsResponse) GetTeams() []*Team {
+	if m != nil {
+		return m.Teams
+	}
+	return nil
+}
+
+func (m *GetTeamsResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *GetTeamsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type TransferOwnershipRequest struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// new_owner_id is the user id of a member of the team
+	NewOwnerId string `protobuf:"bytes,3,opt,name=new_owner_id,json=newOwnerId,proto3" json:"new_owner_id,omitempty"`
+	// expected_version makes the request fail with ABORTED unless it is the
+	// team's current version, 0 skips the check. Over REST it may be sent as
+	// an If-Match header with the team's ETag instead
+	ExpectedVersion      int64    `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferOwnershipRequest) Reset()         { *m = TransferOwnershipRequest{} }
+func (m *TransferOwnershipRequest) String() string { return proto.CompactTextString(m) }
+func (*TransferOwnershipRequest) ProtoMessage()    {}
+func (*TransferOwnershipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{43}
+}
+
+func (m *TransferOwnershipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferOwnershipRequest.Unmarshal(m, b)
+}
+func (m *TransferOwnershipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferOwnershipRequest.Marshal(b, m, deterministic)
+}
+func (m *TransferOwnershipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferOwnershipRequest.Merge(m, src)
+}
+func (m *TransferOwnershipRequest) XXX_Size() int {
+	return xxx_messageInfo_TransferOwnershipRequest.Size(m)
+}
+func (m *TransferOwnershipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferOwnershipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferOwnershipRequest proto.InternalMessageInfo
+
+func (m *TransferOwnershipRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *TransferOwnershipRequest) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *TransferOwnershipRequest) GetNewOwnerId() string {
+	if m != nil {
+		return m.NewOwnerId
+	}
+	return ""
+}
+
+func (m *TransferOwnershipRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type TransferOwnershipResponse struct {
+	Api     string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status  string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	TeamId  string `protobuf:"bytes,3,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	OwnerId string `protobuf:"bytes,4,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	// version of the team after the change
+	Version              int64    `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferOwnershipResponse) Reset()         { *m = TransferOwnershipResponse{} }
+func (m *TransferOwnershipResponse) String() string { return proto.CompactTextString(m) }
+func (*TransferOwnershipResponse) ProtoMessage()    {}
+func (*TransferOwnershipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{44}
+}
+
+func (m *TransferOwnershipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferOwnershipResponse.Unmarshal(m, b)
+}
+func (m *TransferOwnershipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferOwnershipResponse.Marshal(b, m, deterministic)
+}
+func (m *TransferOwnershipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferOwnershipResponse.Merge(m, src)
+}
+func (m *TransferOwnershipResponse) XXX_Size() int {
+	return xxx_messageInfo_TransferOwnershipResponse.Size(m)
+}
+func (m *TransferOwnershipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferOwnershipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferOwnershipResponse proto.InternalMessageInfo
+
+func (m *TransferOwnershipResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *TransferOwnershipResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *TransferOwnershipResponse) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *TransferOwnershipResponse) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *TransferOwnershipResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type InviteMemberRequest struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// email of the invitee, the invitation is accepted by the user whose token carries it
+	Email string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	// role the invitee fills on the team
+	Role string `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	// access_role of the new member: admin, member (default) or viewer
+	AccessRole           string   `protobuf:"bytes,5,opt,name=access_role,json=accessRole,proto3" json:"access_role,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InviteMemberRequest) Reset()         { *m = InviteMemberRequest{} }
+func (m *InviteMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*InviteMemberRequest) ProtoMessage()    {}
+func (*InviteMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{45}
+}
+
+func (m *InviteMemberRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InviteMemberRequest.Unmarshal(m, b)
+}
+func (m *InviteMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InviteMemberRequest.Marshal(b, m, deterministic)
+}
+func (m *InviteMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InviteMemberRequest.Merge(m, src)
+}
+func (m *InviteMemberRequest) XXX_Size() int {
+	return xxx_messageInfo_InviteMemberRequest.Size(m)
+}
+func (m *InviteMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InviteMemberRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InviteMemberRequest proto.InternalMessageInfo
+
+func (m *InviteMemberRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *InviteMemberRequest) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *InviteMemberRequest) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *InviteMemberRequest) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *InviteMemberRequest) GetAccessRole() string {
+	if m != nil {
+		return m.AccessRole
+	}
+	return ""
+}
+
+type InviteMemberResponse struct {
+	Api                  string      `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status               string      `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Invitation           *Invitation `protobuf:"bytes,3,opt,name=invitation,proto3" json:"invitation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *InviteMemberResponse) Reset()         { *m = InviteMemberResponse{} }
+func (m *InviteMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*InviteMemberResponse) ProtoMessage()    {}
+func (*InviteMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{46}
+}
+
+func (m *InviteMemberResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InviteMemberResponse.Unmarshal(m, b)
+}
+func (m *InviteMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InviteMemberResponse.Marshal(b, m, deterministic)
+}
+func (m *InviteMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InviteMemberResponse.Merge(m, src)
+}
+func (m *InviteMemberResponse) XXX_Size() int {
+	return xxx_messageInfo_InviteMemberResponse.Size(m)
+}
+func (m *InviteMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InviteMemberResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InviteMemberResponse proto.InternalMessageInfo
+
+func (m *InviteMemberResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *InviteMemberResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *InviteMemberResponse) GetInvitation() *Invitation {
+	if m != nil {
+		return m.Invitation
+	}
+	return nil
+}
+
+type ListInvitationsRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// team_id lists the invitations of the team, empty lists the caller's
+	TeamId string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// include_closed also lists accepted, declined, revoked and expired invitations
+	IncludeClosed        bool     `protobuf:"varint,3,opt,name=include_closed,json=includeClosed,proto3" json:"include_closed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListInvitationsRequest) Reset()         { *m = ListInvitationsRequest{} }
+func (m *ListInvitationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListInvitationsRequest) ProtoMessage()    {}
+func (*ListInvitationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{47}
+}
+
+func (m *ListInvitationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListInvitationsRequest.Unmarshal(m, b)
+}
+func (m *ListInvitationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListInvitationsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListInvitationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListInvitationsRequest.Merge(m, src)
+}
+func (m *ListInvitationsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListInvitationsRequest.Size(m)
+}
+func (m *ListInvitationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListInvitationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListInvitationsRequest proto.InternalMessageInfo
+
+func (m *ListInvitationsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListInvitationsRequest) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *ListInvitationsRequest) GetIncludeClosed() bool {
+	if m != nil {
+		return m.IncludeClosed
+	}
+	return false
+}
+
+type ListInvitationsResponse struct {
+	Api                  string        `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status               string        `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Invitations          []*Invitation `protobuf:"bytes,3,rep,name=invitations,proto3" json:"invitations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListInvitationsResponse) Reset()         { *m = ListInvitationsResponse{} }
+func (m *ListInvitationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListInvitationsResponse) ProtoMessage()    {}
+func (*ListInvitationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{48}
+}
+
+func (m *ListInvitationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListInvitationsResponse.Unmarshal(m, b)
+}
+func (m *ListInvitationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListInvitationsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListInvitationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListInvitationsResponse.Merge(m, src)
+}
+func (m *ListInvitationsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListInvitationsResponse.Size(m)
+}
+func (m *ListInvitationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListInvitationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListInvitationsResponse proto.InternalMessageInfo
+
+func (m *ListInvitationsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListInvitationsResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ListInvitationsResponse) GetInvitations() []*Invitation {
+	if m != nil {
+		return m.Invitations
+	}
+	return nil
+}
+
+type InvitationRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	InvitationId         string   `protobuf:"bytes,2,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InvitationRequest) Reset()         { *m = InvitationRequest{} }
+func (m *InvitationRequest) String() string { return proto.CompactTextString(m) }
+func (*InvitationRequest) ProtoMessage()    {}
+func (*InvitationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{49}
+}
+
+func (m *InvitationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvitationRequest.Unmarshal(m, b)
+}
+func (m *InvitationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvitationRequest.Marshal(b, m, deterministic)
+}
+func (m *InvitationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvitationRequest.Merge(m, src)
+}
+func (m *InvitationRequest) XXX_Size() int {
+	return xxx_messageInfo_InvitationRequest.Size(m)
+}
+func (m *InvitationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvitationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvitationRequest proto.InternalMessageInfo
+
+func (m *InvitationRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *InvitationRequest) GetInvitationId() string {
+	if m != nil {
+		return m.InvitationId
+	}
+	return ""
+}
+
+type InvitationResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InvitationResponse) Reset()         { *m = InvitationResponse{} }
+func (m *InvitationResponse) String() string { return proto.CompactTextString(m) }
+func (*InvitationResponse) ProtoMessage()    {}
+func (*InvitationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{50}
+}
+
+func (m *InvitationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvitationResponse.Unmarshal(m, b)
+}
+func (m *InvitationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvitationResponse.Marshal(b, m, deterministic)
+}
+func (m *InvitationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvitationResponse.Merge(m, src)
+}
+func (m *InvitationResponse) XXX_Size() int {
+	return xxx_messageInfo_InvitationResponse.Size(m)
+}
+func (m *InvitationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvitationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvitationResponse proto.InternalMessageInfo
+
+func (m *InvitationResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *InvitationResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type AcceptInvitationResponse struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	TeamId string `protobuf:"bytes,3,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// member_number of the new membership
+	MemberNumber         string   `protobuf:"bytes,4,opt,name=member_number,json=memberNumber,proto3" json:"member_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcceptInvitationResponse) Reset()         { *m = AcceptInvitationResponse{} }
+func (m *AcceptInvitationResponse) String() string { return proto.CompactTextString(m) }
+func (*AcceptInvitationResponse) ProtoMessage()    {}
+func (*AcceptInvitationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{51}
+}
+
+func (m *AcceptInvitationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcceptInvitationResponse.Unmarshal(m, b)
+}
+func (m *AcceptInvitationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcceptInvitationResponse.Marshal(b, m, deterministic)
+}
+func (m *AcceptInvitationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcceptInvitationResponse.Merge(m, src)
+}
+func (m *AcceptInvitationResponse) XXX_Size() int {
+	return xxx_messageInfo_AcceptInvitationResponse.Size(m)
+}
+func (m *AcceptInvitationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcceptInvitationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcceptInvitationResponse proto.InternalMessageInfo
+
+func (m *AcceptInvitationResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *AcceptInvitationResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *AcceptInvitationResponse) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *AcceptInvitationResponse) GetMemberNumber() string {
+	if m != nil {
+		return m.MemberNumber
+	}
+	return ""
+}
+
+type Invitation struct {
+	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TeamId     string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Email      string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role       string `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	AccessRole string `protobuf:"bytes,5,opt,name=access_role,json=accessRole,proto3" json:"access_role,omitempty"`
+	// invited_by is the user id of the member who sent the invitation
+	InvitedBy string `protobuf:"bytes,6,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"`
+	// status is pending, accepted, declined, revoked or expired
+	Status               string   `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt            int64    `protobuf:"varint,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Invitation) Reset()         { *m = Invitation{} }
+func (m *Invitation) String() string { return proto.CompactTextString(m) }
+func (*Invitation) ProtoMessage()    {}
+func (*Invitation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{52}
+}
+
+func (m *Invitation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Invitation.Unmarshal(m, b)
+}
+func (m *Invitation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Invitation.Marshal(b, m, deterministic)
+}
+func (m *Invitation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Invitation.Merge(m, src)
+}
+func (m *Invitation) XXX_Size() int {
+	return xxx_messageInfo_Invitation.Size(m)
+}
+func (m *Invitation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Invitation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Invitation proto.InternalMessageInfo
+
+func (m *Invitation) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Invitation) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *Invitation) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *Invitation) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *Invitation) GetAccessRole() string {
+	if m != nil {
+		return m.AccessRole
+	}
+	return ""
+}
+
+func (m *Invitation) GetInvitedBy() string {
+	if m != nil {
+		return m.InvitedBy
+	}
+	return ""
+}
+
+func (m *Invitation) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Invitation) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Invitation) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type ApplyToTeamRequest struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TeamId string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// role the applicant wants to fill
+	Role string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	// message to the team's owner and admins
+	Message              string   `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplyToTeamRequest) Reset()         { *m = ApplyToTeamRequest{} }
+func (m *ApplyToTeamRequest) String() string { return proto.CompactTextString(m) }
+func (*ApplyToTeamRequest) ProtoMessage()    {}
+func (*ApplyToTeamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{53}
+}
+
+func (m *ApplyToTeamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyToTeamRequest.Unmarshal(m, b)
+}
+func (m *ApplyToTeamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyToTeamRequest.Marshal(b, m, deterministic)
+}
+func (m *ApplyToTeamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyToTeamRequest.Merge(m, src)
+}
+func (m *ApplyToTeamRequest) XXX_Size() int {
+	return xxx_messageInfo_ApplyToTeamRequest.Size(m)
+}
+func (m *ApplyToTeamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyToTeamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyToTeamRequest proto.InternalMessageInfo
+
+func (m *ApplyToTeamRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ApplyToTeamRequest) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *ApplyToTeamRequest) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *ApplyToTeamRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type ApplicationResponse struct {
+	Api                  string       `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status               string       `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Application          *Application `protobuf:"bytes,3,opt,name=application,proto3" json:"application,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ApplicationResponse) Reset()         { *m = ApplicationResponse{} }
+func (m *ApplicationResponse) String() string { return proto.CompactTextString(m) }
+func (*ApplicationResponse) ProtoMessage()    {}
+func (*ApplicationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{54}
+}
+
+func (m *ApplicationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplicationResponse.Unmarshal(m, b)
+}
+func (m *ApplicationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplicationResponse.Marshal(b, m, deterministic)
+}
+func (m *ApplicationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplicationResponse.Merge(m, src)
+}
+func (m *ApplicationResponse) XXX_Size() int {
+	return xxx_messageInfo_ApplicationResponse.Size(m)
+}
+func (m *ApplicationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplicationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplicationResponse proto.InternalMessageInfo
+
+func (m *ApplicationResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ApplicationResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ApplicationResponse) GetApplication() *Application {
+	if m != nil {
+		return m.Application
+	}
+	return nil
+}
+
+type ListApplicationsRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// team_id lists the applications to the team, empty lists the caller's
+	TeamId string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// statuses keeps applications in any of the statuses, pending when empty
+	Statuses             []string `protobuf:"bytes,3,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListApplicationsRequest) Reset()         { *m = ListApplicationsRequest{} }
+func (m *ListApplicationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListApplicationsRequest) ProtoMessage()    {}
+func (*ListApplicationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{55}
+}
+
+func (m *ListApplicationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListApplicationsRequest.Unmarshal(m, b)
+}
+func (m *ListApplicationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListApplicationsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListApplicationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListApplicationsRequest.Merge(m, src)
+}
+func (m *ListApplicationsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListApplicationsRequest.Size(m)
+}
+func (m *ListApplicationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListApplicationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListApplicationsRequest proto.InternalMessageInfo
+
+func (m *ListApplicationsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListApplicationsRequest) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *ListApplicationsRequest) GetStatuses() []string {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+type ListApplicationsResponse struct {
+	Api                  string         `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status               string         `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Applications         []*Application `protobuf:"bytes,3,rep,name=applications,proto3" json:"applications,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListApplicationsResponse) Reset()         { *m = ListApplicationsResponse{} }
+func (m *ListApplicationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListApplicationsResponse) ProtoMessage()    {}
+func (*ListApplicationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{56}
+}
+
+func (m *ListApplicationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListApplicationsResponse.Unmarshal(m, b)
+}
+func (m *ListApplicationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListApplicationsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListApplicationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListApplicationsResponse.Merge(m, src)
+}
+func (m *ListApplicationsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListApplicationsResponse.Size(m)
+}
+func (m *ListApplicationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListApplicationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListApplicationsResponse proto.InternalMessageInfo
+
+func (m *ListApplicationsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListApplicationsResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ListApplicationsResponse) GetApplications() []*Application {
+	if m != nil {
+		return m.Applications
+	}
+	return nil
+}
+
+type ApplicationRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	ApplicationId        string   `protobuf:"bytes,2,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplicationRequest) Reset()         { *m = ApplicationRequest{} }
+func (m *ApplicationRequest) String() string { return proto.CompactTextString(m) }
+func (*ApplicationRequest) ProtoMessage()    {}
+func (*ApplicationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{57}
+}
+
+func (m *ApplicationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplicationRequest.Unmarshal(m, b)
+}
+func (m *ApplicationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplicationRequest.Marshal(b, m, deterministic)
+}
+func (m *ApplicationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplicationRequest.Merge(m, src)
+}
+func (m *ApplicationRequest) XXX_Size() int {
+	return xxx_messageInfo_ApplicationRequest.Size(m)
+}
+func (m *ApplicationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplicationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplicationRequest proto.InternalMessageInfo
+
+func (m *ApplicationRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ApplicationRequest) GetApplicationId() string {
+	if m != nil {
+		return m.ApplicationId
+	}
+	return ""
+}
+
+type ApproveApplicationResponse struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	TeamId string `protobuf:"bytes,3,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// member_number of the new membership
+	MemberNumber         string   `protobuf:"bytes,4,opt,name=member_number,json=memberNumber,proto3" json:"member_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveApplicationResponse) Reset()         { *m = ApproveApplicationResponse{} }
+func (m *ApproveApplicationResponse) String() string { return proto.CompactTextString(m) }
+func (*ApproveApplicationResponse) ProtoMessage()    {}
+func (*ApproveApplicationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{58}
+}
+
+func (m *ApproveApplicationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveApplicationResponse.Unmarshal(m, b)
+}
+func (m *ApproveApplicationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveApplicationResponse.Marshal(b, m, deterministic)
+}
+func (m *ApproveApplicationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveApplicationResponse.Merge(m, src)
+}
+func (m *ApproveApplicationResponse) XXX_Size() int {
+	return xxx_messageInfo_ApproveApplicationResponse.Size(m)
+}
+func (m *ApproveApplicationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveApplicationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveApplicationResponse proto.InternalMessageInfo
+
+func (m *ApproveApplicationResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ApproveApplicationResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ApproveApplicationResponse) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *ApproveApplicationResponse) GetMemberNumber() string {
+	if m != nil {
+		return m.MemberNumber
+	}
+	return ""
+}
+
+type Application struct {
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TeamId string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// user_id and email of the applicant
+	UserId  string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email   string `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Role    string `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	Message string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	// status is pending, approved, rejected, withdrawn or closed when the
+	// team filled up with auto_close_applications set
+	Status    string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt int64  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DecidedAt int64  `protobuf:"varint,9,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"`
+	// decided_by is the user id of the member who approved or rejected it
+	DecidedBy            string   `protobuf:"bytes,10,opt,name=decided_by,json=decidedBy,proto3" json:"decided_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Application) Reset()         { *m = Application{} }
+func (m *Application) String() string { return proto.CompactTextString(m) }
+func (*Application) ProtoMessage()    {}
+func (*Application) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{59}
+}
+
+func (m *Application) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Application.Unmarshal(m, b)
+}
+func (m *Application) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Application.Marshal(b, m, deterministic)
+}
+func (m *Application) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Application.Merge(m, src)
+}
+func (m *Application) XXX_Size() int {
+	return xxx_messageInfo_Application.Size(m)
+}
+func (m *Application) XXX_DiscardUnknown() {
+	xxx_messageInfo_Application.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Application proto.InternalMessageInfo
+
+func (m *Application) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Application) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *Application) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *Application) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *Application) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *Application) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Application) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Application) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Application) GetDecidedAt() int64 {
+	if m != nil {
+		return m.DecidedAt
+	}
+	return 0
+}
+
+func (m *Application) GetDecidedBy() string {
+	if m != nil {
+		return m.DecidedBy
+	}
+	return ""
+}
+
+type SearchTeamsRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// query is free text, e.g. "rust game engine", every team matches an empty query
+	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	// skills keeps teams looking for every skill
+	Skills []string `protobuf:"bytes,3,rep,name=skills,proto3" json:"skills,omitempty"`
+	// languages keeps teams whose project uses any of the languages
+	Languages []string `protobuf:"bytes,4,rep,name=languages,proto3" json:"languages,omitempty"`
+	// limit is the page size, 20 when unset
+	Limit int64 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// page_token is the next_page_token of the previous page, it must be sent
+	// with the same query and filters
+	PageToken            string   `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchTeamsRequest) Reset()         { *m = SearchTeamsRequest{} }
+func (m *SearchTeamsRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchTeamsRequest) ProtoMessage()    {}
+func (*SearchTeamsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{60}
+}
+
+func (m *SearchTeamsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchTeamsRequest.Unmarshal(m, b)
+}
+func (m *SearchTeamsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchTeamsRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchTeamsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchTeamsRequest.Merge(m, src)
+}
+func (m *SearchTeamsRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchTeamsRequest.Size(m)
+}
+func (m *SearchTeamsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchTeamsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchTeamsRequest proto.InternalMessageInfo
+
+func (m *SearchTeamsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *SearchTeamsRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchTeamsRequest) GetSkills() []string {
+	if m != nil {
+		return m.Skills
+	}
+	return nil
+}
+
+func (m *SearchTeamsRequest) GetLanguages() []string {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+func (m *SearchTeamsRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *SearchTeamsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type SearchTeamsResponse struct {
+	Api    string       `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status string       `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Hits   []*SearchHit `protobuf:"bytes,3,rep,name=hits,proto3" json:"hits,omitempty"`
+	// total is the number of matching teams
+	Total int64 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	// skill_facets and language_facets count the matching teams by skill and language
+	SkillFacets    []*Facet `protobuf:"bytes,5,rep,name=skill_facets,json=skillFacets,proto3" json:"skill_facets,omitempty"`
+	LanguageFacets []*Facet `protobuf:"bytes,6,rep,name=language_facets,json=languageFacets,proto3" json:"language_facets,omitempty"`
+	// next_page_token fetches the following page, empty on the last page
+	NextPageToken        string   `protobuf:"bytes,7,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchTeamsResponse) Reset()         { *m = SearchTeamsResponse{} }
+func (m *SearchTeamsResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchTeamsResponse) ProtoMessage()    {}
+func (*SearchTeamsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{61}
+}
+
+func (m *SearchTeamsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchTeamsResponse.Unmarshal(m, b)
+}
+func (m *SearchTeamsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchTeamsResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchTeamsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchTeamsResponse.Merge(m, src)
+}
+func (m *SearchTeamsResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchTeamsResponse.Size(m)
+}
+func (m *SearchTeamsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchTeamsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchTeamsResponse proto.InternalMessageInfo
+
+func (m *SearchTeamsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *SearchTeamsResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *SearchTeamsResponse) GetHits() []*SearchHit {
+	if m != nil {
+		return m.Hits
+	}
+	return nil
+}
+
+func (m *SearchTeamsResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *SearchTeamsResponse) GetSkillFacets() []*Facet {
+	if m != nil {
+		return m.SkillFacets
+	}
+	return nil
+}
+
+func (m *SearchTeamsResponse) GetLanguageFacets() []*Facet {
+	if m != nil {
+		return m.LanguageFacets
+	}
+	return nil
+}
+
+func (m *SearchTeamsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type SearchHit struct {
+	Team  *Team   `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// highlights are fragments of the matching fields, matches wrapped in <mark></mark>
+	Highlights           []*Highlight `protobuf:"bytes,3,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SearchHit) Reset()         { *m = SearchHit{} }
+func (m *SearchHit) String() string { return proto.CompactTextString(m) }
+func (*SearchHit) ProtoMessage()    {}
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{62}
+}
+
+func (m *SearchHit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchHit.Unmarshal(m, b)
+}
+func (m *SearchHit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchHit.Marshal(b, m, deterministic)
+}
+func (m *SearchHit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchHit.Merge(m, src)
+}
+func (m *SearchHit) XXX_Size() int {
+	return xxx_messageInfo_SearchHit.Size(m)
+}
+func (m *SearchHit) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchHit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchHit proto.InternalMessageInfo
+
+func (m *SearchHit) GetTeam() *Team {
+	if m != nil {
+		return m.Team
+	}
+	return nil
+}
+
+func (m *SearchHit) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *SearchHit) GetHighlights() []*Highlight {
+	if m != nil {
+		return m.Highlights
+	}
+	return nil
+}
+
+type Highlight struct {
+	// field is name, project_name or description
+	Field                string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Fragments            []string `protobuf:"bytes,2,rep,name=fragments,proto3" json:"fragments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Highlight) Reset()         { *m = Highlight{} }
+func (m *Highlight) String() string { return proto.CompactTextString(m) }
+func (*Highlight) ProtoMessage()    {}
+func (*Highlight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{63}
+}
+
+func (m *Highlight) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Highlight.Unmarshal(m, b)
+}
+func (m *Highlight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Highlight.Marshal(b, m, deterministic)
+}
+func (m *Highlight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Highlight.Merge(m, src)
+}
+func (m *Highlight) XXX_Size() int {
+	return xxx_messageInfo_Highlight.Size(m)
+}
+func (m *Highlight) XXX_DiscardUnknown() {
+	xxx_messageInfo_Highlight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Highlight proto.InternalMessageInfo
+
+func (m *Highlight) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *Highlight) GetFragments() []string {
+	if m != nil {
+		return m.Fragments
+	}
+	return nil
+}
+
+type Facet struct {
+	Term                 string   `protobuf:"bytes,1,opt,name=term,proto3" json:"term,omitempty"`
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Facet) Reset()         { *m = Facet{} }
+func (m *Facet) String() string { return proto.CompactTextString(m) }
+func (*Facet) ProtoMessage()    {}
+func (*Facet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{64}
+}
+
+func (m *Facet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Facet.Unmarshal(m, b)
+}
+func (m *Facet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Facet.Marshal(b, m, deterministic)
+}
+func (m *Facet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Facet.Merge(m, src)
+}
+func (m *Facet) XXX_Size() int {
+	return xxx_messageInfo_Facet.Size(m)
+}
+func (m *Facet) XXX_DiscardUnknown() {
+	xxx_messageInfo_Facet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Facet proto.InternalMessageInfo
+
+func (m *Facet) GetTerm() string {
+	if m != nil {
+		return m.Term
+	}
+	return ""
+}
+
+func (m *Facet) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type ListAuditEventsRequest struct {
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// every filter set below must match
+	TeamId string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// actor is the user id of the caller who made the change
+	Actor string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	// action is e.g. update_team or add_member
+	Action string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	// time range of the events in unix seconds, bounds included, 0 for no bound
+	Since int64 `protobuf:"varint,5,opt,name=since,proto3" json:"since,omitempty"`
+	Until int64 `protobuf:"varint,6,opt,name=until,proto3" json:"until,omitempty"`
+	// limit is the page size, 50 when unset
+	Limit int64 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	// page_token is the next_page_token of the previous page, it must be sent
+	// with the same filters
+	PageToken            string   `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEventsRequest) Reset()         { *m = ListAuditEventsRequest{} }
+func (m *ListAuditEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsRequest) ProtoMessage()    {}
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{65}
+}
+
+func (m *ListAuditEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsRequest.Unmarshal(m, b)
+}
+func (m *ListAuditEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsRequest.Merge(m, src)
+}
+func (m *ListAuditEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsRequest.Size(m)
+}
+func (m *ListAuditEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsRequest proto.InternalMessageInfo
+
+func (m *ListAuditEventsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *ListAuditEventsRequest) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+func (m *ListAuditEventsRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListAuditEventsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
+	Api    string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// events, most recent first
+	Events []*AuditEvent `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	// next_page_token fetches the following page, empty on the last page
+	NextPageToken        string   `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEventsResponse) Reset()         { *m = ListAuditEventsResponse{} }
+func (m *ListAuditEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsResponse) ProtoMessage()    {}
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{66}
+}
+
+func (m *ListAuditEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsResponse.Unmarshal(m, b)
+}
+func (m *ListAuditEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsResponse.Merge(m, src)
+}
+func (m *ListAuditEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsResponse.Size(m)
+}
+func (m *ListAuditEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsResponse proto.InternalMessageInfo
+
+func (m *ListAuditEventsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListAuditEventsResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *ListAuditEventsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type AuditEvent struct {
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TeamId string `protobuf:"bytes,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	// actor is the user id of the caller, system for changes made by the
+	// service itself, e.g. when a user's account is removed
+	Actor  string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Action string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	// before and after hold the fields the change touched, before is unset
+	// when the change created them and after when it removed them
+	Before *_struct.Struct `protobuf:"bytes,5,opt,name=before,proto3" json:"before,omitempty"`
+	After  *_struct.Struct `protobuf:"bytes,6,opt,name=after,proto3" json:"after,omitempty"`
+	// request_id is the x-request-id of the request that made the change
+	RequestId            string   `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	OccurredAt           int64    `protobuf:"varint,8,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditEvent) Reset()         { *m = AuditEvent{} }
+func (m *AuditEvent) String() string { return proto.CompactTextString(m) }
+func (*AuditEvent) ProtoMessage()    {}
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{67}
+}
+
+func (m *AuditEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEvent.Unmarshal(m, b)
+}
+func (m *AuditEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEvent.Marshal(b, m, deterministic)
+}
+func (m *AuditEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEvent.Merge(m, src)
+}
+func (m *AuditEvent) XXX_Size() int {
+	return xxx_messageInfo_AuditEvent.Size(m)
+}
+func (m *AuditEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEvent proto.InternalMessageInfo
+
+func (m *AuditEvent) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetTeamId() string {
+	if m != nil {
+		return m.TeamId
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetBefore() *_struct.Struct {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *AuditEvent) GetAfter() *_struct.Struct {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+func (m *AuditEvent) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetOccurredAt() int64 {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return 0
+}
+
+type Team struct {
+	Leader    string    `protobuf:"bytes,1,opt,name=leader,proto3" json:"leader,omitempty"`
+	Members   []*Member `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	Name      string    `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	OpenRoles int32     `protobuf:"varint,4,opt,name=open_roles,json=openRoles,proto3" json:"open_roles,omitempty"`
+	Skills    []string  `protobuf:"bytes,5,rep,name=skills,proto3" json:"skills,omitempty"`
+	Size      int32     `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	// last_active is when the team or one of its members was last active, it
+	// is kept by the service and ignored on input
+	LastActive int32    `protobuf:"varint,7,opt,name=last_active,json=lastActive,proto3" json:"last_active,omitempty"`
+	Id         string   `protobuf:"bytes,8,opt,name=id,proto3" json:"id,omitempty"`
+	Project    *Project `protobuf:"bytes,9,opt,name=project,proto3" json:"project,omitempty"`
+	// auto_close_applications closes the pending applications once the team
+	// has no open roles left
+	AutoCloseApplications bool `protobuf:"varint,10,opt,name=auto_close_applications,json=autoCloseApplications,proto3" json:"auto_close_applications,omitempty"`
+	// version is incremented by every change to the team, it is the team's ETag
+	// over REST
+	Version int64 `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"`
+	// projects are the team's projects that aren't archived, oldest first
+	Projects []*Project `protobuf:"bytes,12,rep,name=projects,proto3" json:"projects,omitempty"`
+	// inactive_at is when the team was flagged inactive, it is archived once
+	// the grace period is over unless it is active again
+	InactiveAt int64 `protobuf:"varint,13,opt,name=inactive_at,json=inactiveAt,proto3" json:"inactive_at,omitempty"`
+	// archived_at is when the team was archived for inactivity, archived
+	// teams are hidden from GetTeams and SearchTeams until active again
+	ArchivedAt           int64    `protobuf:"varint,14,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Team) Reset()         { *m = Team{} }
+func (m *Team) String() string { return proto.CompactTextString(m) }
+func (*Team) ProtoMessage()    {}
+func (*Team) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{68}
+}
+
+func (m *Team) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Team.Unmarshal(m, b)
+}
+func (m *Team) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Team.Marshal(b, m, deterministic)
+}
+func (m *Team) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Team.Merge(m, src)
+}
+func (m *Team) XXX_Size() int {
+	return xxx_messageInfo_Team.Size(m)
+}
+func (m *Team) XXX_DiscardUnknown() {
+	xxx_messageInfo_Team.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Team proto.InternalMessageInfo
+
+func (m *Team) GetLeader() string {
+	if m != nil {
+		return m.Leader
+	}
+	return ""
+}
+
+func (m *Team) GetMembers() []*Member {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+func (m *Team) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Team) GetOpenRoles() int32 {
+	if m != nil {
+		return m.OpenRoles
+	}
+	return 0
+}
+
+func (m *Team) GetSkills() []string {
+	if m != nil {
+		return m.Skills
+	}
+	return nil
+}
+
+func (m *Team) GetSize() int32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Team) GetLastActive() int32 {
+	if m != nil {
+		return m.LastActive
+	}
+	return 0
+}
+
+func (m *Team) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Team) GetProject() *Project {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+func (m *Team) GetAutoCloseApplications() bool {
+	if m != nil {
+		return m.AutoCloseApplications
+	}
+	return false
+}
+
+func (m *Team) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Team) GetProjects() []*Project {
+	if m != nil {
+		return m.Projects
+	}
+	return nil
+}
+
+func (m *Team) GetInactiveAt() int64 {
+	if m != nil {
+		return m.InactiveAt
+	}
+	return 0
+}
+
+func (m *Team) GetArchivedAt() int64 {
+	if m != nil {
+		return m.ArchivedAt
+	}
+	return 0
+}
+
+type Member struct {
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Id    int32  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Role  string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	// access_role decides what the member may do: owner, admin, member or viewer
+	AccessRole string `protobuf:"bytes,4,opt,name=access_role,json=accessRole,proto3" json:"access_role,omitempty"`
+	// member_number identifies the membership, e.g. to remove the member
+	MemberNumber         string   `protobuf:"bytes,5,opt,name=member_number,json=memberNumber,proto3" json:"member_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Member) Reset()         { *m = Member{} }
+func (m *Member) String() string { return proto.CompactTextString(m) }
+func (*Member) ProtoMessage()    {}
+func (*Member) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{69}
+}
+
+func (m *Member) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Member.Unmarshal(m, b)
+}
+func (m *Member) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Member.Marshal(b, m, deterministic)
+}
+func (m *Member) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Member.Merge(m, src)
+}
+func (m *Member) XXX_Size() int {
+	return xxx_messageInfo_Member.Size(m)
+}
+func (m *Member) XXX_DiscardUnknown() {
+	xxx_messageInfo_Member.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Member proto.InternalMessageInfo
+
+func (m *Member) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *Member) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Member) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *Member) GetAccessRole() string {
+	if m != nil {
+		return m.AccessRole
+	}
+	return ""
+}
+
+func (m *Member) GetMemberNumber() string {
+	if m != nil {
+		return m.MemberNumber
+	}
+	return ""
+}
+
+// Project is a project of a team. Team.project is the active project, kept
+// for clients that predate Team.projects.
+type Project struct {
+	Description string   `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Languages   []string `protobuf:"bytes,2,rep,name=languages,proto3" json:"languages,omitempty"`
+	Name        string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	GithubLink  string   `protobuf:"bytes,4,opt,name=github_link,json=githubLink,proto3" json:"github_link,omitempty"`
+	Complexity  int32    `protobuf:"varint,5,opt,name=complexity,proto3" json:"complexity,omitempty"`
+	Duration    int32    `protobuf:"varint,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	Id          string   `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
+	// status is planning, active, completed or abandoned. Planning projects
+	// become active, active ones completed or abandoned, and a team has at
+	// most one active project
+	Status string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	// started_at and completed_at are the unix times the project became active
+	// and completed, 0 until then
+	StartedAt   int64 `protobuf:"varint,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt int64 `protobuf:"varint,10,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// archived_at is the unix time the project was archived, 0 if it isn't
+	ArchivedAt int64 `protobuf:"varint,11,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	// progress is the percentage of the project's tasks that are done, of its
+	// milestones when it has no tasks. It is computed on reads.
+	Progress             int32    `protobuf:"varint,12,opt,name=progress,proto3" json:"progress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Project) Reset()         { *m = Project{} }
+func (m *Project) String() string { return proto.CompactTextString(m) }
+func (*Project) ProtoMessage()    {}
+func (*Project) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{70}
+}
+
+func (m *Project) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Project.Unmarshal(m, b)
+}
+func (m *Project) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Project.Marshal(b, m, deterministic)
+}
+func (m *Project) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Project.Merge(m, src)
+}
+func (m *Project) XXX_Size() int {
+	return xxx_messageInfo_Project.Size(m)
+}
+func (m *Project) XXX_DiscardUnknown() {
+	xxx_messageInfo_Project.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Project proto.InternalMessageInfo
+
+func (m *Project) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Project) GetLanguages() []string {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+func (m *Project) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Project) GetGithubLink() string {
+	if m != nil {
+		return m.GithubLink
+	}
+	return ""
+}
+
+func (m *Project) GetComplexity() int32 {
+	if m != nil {
+		return m.Complexity
+	}
+	return 0
+}
+
+func (m *Project) GetDuration() int32 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *Project) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Project) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Project) GetStartedAt() int64 {
+	if m != nil {
+		return m.StartedAt
+	}
+	return 0
+}
+
+func (m *Project) GetCompletedAt() int64 {
+	if m != nil {
+		return m.CompletedAt
+	}
+	return 0
+}
+
+func (m *Project) GetArchivedAt() int64 {
+	if m != nil {
+		return m.ArchivedAt
+	}
+	return 0
+}
+
+func (m *Project) GetProgress() int32 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
+}
+
+// Milestone is a step of a project, status is todo, in_progress or done
+type Milestone struct {
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId string `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Title     string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	// assignee is the member number of the assigned member, empty if unassigned
+	Assignee string `protobuf:"bytes,4,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	// due_at is a unix time, 0 for no due date
+	DueAt  int64  `protobuf:"varint,5,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// position orders the milestones of the project from 1
+	Position  int32 `protobuf:"varint,7,opt,name=position,proto3" json:"position,omitempty"`
+	CreatedAt int64 `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// completed_at is the unix time the milestone was done, 0 until then
+	CompletedAt int64 `protobuf:"varint,9,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// progress is the percentage of the milestone's tasks that are done
+	Progress             int32    `protobuf:"varint,10,opt,name=progress,proto3" json:"progress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Milestone) Reset()         { *m = Milestone{} }
+func (m *Milestone) String() string { return proto.CompactTextString(m) }
+func (*Milestone) ProtoMessage()    {}
+func (*Milestone) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{71}
+}
+
+func (m *Milestone) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Milestone.Unmarshal(m, b)
+}
+func (m *Milestone) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Milestone.Marshal(b, m, deterministic)
+}
+func (m *Milestone) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Milestone.Merge(m, src)
+}
+func (m *Milestone) XXX_Size() int {
+	return xxx_messageInfo_Milestone.Size(m)
+}
+func (m *Milestone) XXX_DiscardUnknown() {
+	xxx_messageInfo_Milestone.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Milestone proto.InternalMessageInfo
+
+func (m *Milestone) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Milestone) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+func (m *Milestone) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Milestone) GetAssignee() string {
+	if m != nil {
+		return m.Assignee
+	}
+	return ""
+}
+
+func (m *Milestone) GetDueAt() int64 {
+	if m != nil {
+		return m.DueAt
+	}
+	return 0
+}
+
+func (m *Milestone) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Milestone) GetPosition() int32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+func (m *Milestone) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Milestone) GetCompletedAt() int64 {
+	if m != nil {
+		return m.CompletedAt
+	}
+	return 0
+}
+
+func (m *Milestone) GetProgress() int32 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
+}
+
+// Task is a piece of work of a project, status is todo, in_progress or done
+type Task struct {
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId string `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// milestone_id is the milestone the task belongs to, empty for none
+	MilestoneId string `protobuf:"bytes,3,opt,name=milestone_id,json=milestoneId,proto3" json:"milestone_id,omitempty"`
+	Title       string `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	// assignee is the member number of the assigned member, empty if unassigned
+	Assignee string `protobuf:"bytes,5,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	// due_at is a unix time, 0 for no due date
+	DueAt  int64  `protobuf:"varint,6,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Status string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	// position orders the tasks of the project from 1
+	Position  int32 `protobuf:"varint,8,opt,name=position,proto3" json:"position,omitempty"`
+	CreatedAt int64 `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// completed_at is the unix time the task was done, 0 until then
+	CompletedAt          int64    `protobuf:"varint,10,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Task) Reset()         { *m = Task{} }
+func (m *Task) String() string { return proto.CompactTextString(m) }
+func (*Task) ProtoMessage()    {}
+func (*Task) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{72}
+}
+
+func (m *Task) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Task.Unmarshal(m, b)
+}
+func (m *Task) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Task.Marshal(b, m, deterministic)
+}
+func (m *Task) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Task.Merge(m, src)
+}
+func (m *Task) XXX_Size() int {
+	return xxx_messageInfo_Task.Size(m)
+}
+func (m *Task) XXX_DiscardUnknown() {
+	xxx_messageInfo_Task.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Task proto.InternalMessageInfo
+
+func (m *Task) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Task) GetProjectId() string {
+	if m != nil {
+		return m.ProjectId
+	}
+	return ""
+}
+
+func (m *Task) GetMilestoneId() string {
+	if m != nil {
+		return m.MilestoneId
+	}
+	return ""
+}
+
+func (m *Task) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Task) GetAssignee() string {
+	if m != nil {
+		return m.Assignee
+	}
+	return ""
+}
+
+func (m *Task) GetDueAt() int64 {
+	if m != nil {
+		return m.DueAt
+	}
+	return 0
+}
+
+func (m *Task) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Task) GetPosition() int32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+func (m *Task) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Task) GetCompletedAt() int64 {
+	if m != nil {
+		return m.CompletedAt
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("team.SkillMatch", SkillMatch_name, SkillMatch_value)
+	proto.RegisterEnum("team.TeamSort", TeamSort_name, TeamSort_value)
+	proto.RegisterType((*TeamUpsertRequest)(nil), "team.TeamUpsertRequest")
+	proto.RegisterType((*TeamUpsertResponse)(nil), "team.TeamUpsertResponse")
+	proto.RegisterType((*UpdateTeamRequest)(nil), "team.UpdateTeamRequest")
+	proto.RegisterType((*UpdateTeamResponse)(nil), "team.UpdateTeamResponse")
+	proto.RegisterType((*TeamDeleteRequest)(nil), "team.TeamDeleteRequest")
+	proto.RegisterType((*TeamDeleteResponse)(nil), "team.TeamDeleteResponse")
+	proto.RegisterType((*RestoreTeamRequest)(nil), "team.RestoreTeamRequest")
+	proto.RegisterType((*RestoreTeamResponse)(nil), "team.RestoreTeamResponse")
+	proto.RegisterType((*ListDeletedTeamsRequest)(nil), "team.ListDeletedTeamsRequest")
+	proto.RegisterType((*ListDeletedTeamsResponse)(nil), "team.ListDeletedTeamsResponse")
+	proto.RegisterType((*DeletedTeam)(nil), "team.DeletedTeam")
+	proto.RegisterType((*MemberUpsertRequest)(nil), "team.MemberUpsertRequest")
+	proto.RegisterType((*MemberUpsertResponse)(nil), "team.MemberUpsertResponse")
+	proto.RegisterType((*MemberDeleteRequest)(nil), "team.MemberDeleteRequest")
+	proto.RegisterType((*MemberDeleteResponse)(nil), "team.MemberDeleteResponse")
+	proto.RegisterType((*ProjectUpsertRequest)(nil), "team.ProjectUpsertRequest")
+	proto.RegisterType((*ProjectUpsertResponse)(nil), "team.ProjectUpsertResponse")
+	proto.RegisterType((*CreateProjectRequest)(nil), "team.CreateProjectRequest")
+	proto.RegisterType((*UpdateProjectRequest)(nil), "team.UpdateProjectRequest")
+	proto.RegisterType((*ArchiveProjectRequest)(nil), "team.ArchiveProjectRequest")
+	proto.RegisterType((*ProjectResponse)(nil), "team.ProjectResponse")
+	proto.RegisterType((*ListProjectsRequest)(nil), "team.ListProjectsRequest")
+	proto.RegisterType((*ListProjectsResponse)(nil), "team.ListProjectsResponse")
+	proto.RegisterType((*CreateMilestoneRequest)(nil), "team.CreateMilestoneRequest")
+	proto.RegisterType((*UpdateMilestoneRequest)(nil), "team.UpdateMilestoneRequest")
+	proto.RegisterType((*DeleteMilestoneRequest)(nil), "team.DeleteMilestoneRequest")
+	proto.RegisterType((*MilestoneResponse)(nil), "team.MilestoneResponse")
+	proto.RegisterType((*ListMilestonesRequest)(nil), "team.ListMilestonesRequest")
+	proto.RegisterType((*ListMilestonesResponse)(nil), "team.ListMilestonesResponse")
+	proto.RegisterType((*CreateTaskRequest)(nil), "team.CreateTaskRequest")
+	proto.RegisterType((*UpdateTaskRequest)(nil), "team.UpdateTaskRequest")
+	proto.RegisterType((*DeleteTaskRequest)(nil), "team.DeleteTaskRequest")
+	proto.RegisterType((*TaskResponse)(nil), "team.TaskResponse")
+	proto.RegisterType((*ListTasksRequest)(nil), "team.ListTasksRequest")
+	proto.RegisterType((*ListTasksResponse)(nil), "team.ListTasksResponse")
+	proto.RegisterType((*GetByTeamIdRequest)(nil), "team.GetByTeamIdRequest")
+	proto.RegisterType((*GetByTeamIdResponse)(nil), "team.GetByTeamIdResponse")
+	proto.RegisterType((*GetByTeamNameRequest)(nil), "team.GetByTeamNameRequest")
+	proto.RegisterType((*GetByTeamNameResponse)(nil), "team.GetByTeamNameResponse")
+	proto.RegisterType((*GetByUserIdRequest)(nil), "team.GetByUserIdRequest")
+	proto.RegisterType((*GetByUserIdResponse)(nil), "team.GetByUserIdResponse")
+	proto.RegisterType((*GetTeamsRequest)(nil), "team.GetTeamsRequest")
+	proto.RegisterType((*GetTeamsResponse)(nil), "team.GetTeamsResponse")
+	proto.RegisterType((*TransferOwnershipRequest)(nil), "team.TransferOwnershipRequest")
+	proto.RegisterType((*TransferOwnershipResponse)(nil), "team.TransferOwnershipResponse")
+	proto.RegisterType((*InviteMemberRequest)(nil), "team.InviteMemberRequest")
+	proto.RegisterType((*InviteMemberResponse)(nil), "team.InviteMemberResponse")
+	proto.RegisterType((*ListInvitationsRequest)(nil), "team.ListInvitationsRequest")
+	proto.RegisterType((*ListInvitationsResponse)(nil), "team.ListInvitationsResponse")
+	proto.RegisterType((*InvitationRequest)(nil), "team.InvitationRequest")
+	proto.RegisterType((*InvitationResponse)(nil), "team.InvitationResponse")
+	proto.RegisterType((*AcceptInvitationResponse)(nil), "team.AcceptInvitationResponse")
+	proto.RegisterType((*Invitation)(nil), "team.Invitation")
+	proto.RegisterType((*ApplyToTeamRequest)(nil), "team.ApplyToTeamRequest")
+	proto.RegisterType((*ApplicationResponse)(nil), "team.ApplicationResponse")
+	proto.RegisterType((*ListApplicationsRequest)(nil), "team.ListApplicationsRequest")
+	proto.RegisterType((*ListApplicationsResponse)(nil), "team.ListApplicationsResponse")
+	proto.RegisterType((*ApplicationRequest)(nil), "team.ApplicationRequest")
+	proto.RegisterType((*ApproveApplicationResponse)(nil), "team.ApproveApplicationResponse")
+	proto.RegisterType((*Application)(nil), "team.Application")
+	proto.RegisterType((*SearchTeamsRequest)(nil), "team.SearchTeamsRequest")
+	proto.RegisterType((*SearchTeamsResponse)(nil), "team.SearchTeamsResponse")
+	proto.RegisterType((*SearchHit)(nil), "team.SearchHit")
+	proto.RegisterType((*Highlight)(nil), "team.Highlight")
+	proto.RegisterType((*Facet)(nil), "team.Facet")
+	proto.RegisterType((*ListAuditEventsRequest)(nil), "team.ListAuditEventsRequest")
+	proto.RegisterType((*ListAuditEventsResponse)(nil), "team.ListAuditEventsResponse")
+	proto.RegisterType((*AuditEvent)(nil), "team.AuditEvent")
+	proto.RegisterType((*Team)(nil), "team.Team")
+	proto.RegisterType((*Member)(nil), "team.Member")
+	proto.RegisterType((*Project)(nil), "team.Project")
+	proto.RegisterType((*Milestone)(nil), "team.Milestone")
+	proto.RegisterType((*Task)(nil), "team.Task")
+}
+
+func init() { proto.RegisterFile("team.proto", fileDescriptor_8b4e9e93d7b2c6bb) }
+
+var fileDescriptor_8b4e9e93d7b2c6bb = []byte{
+	// 4108 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x3a, 0x4b, 0x73, 0x1c, 0x49,
+	0x5a, 0x5b, 0xfd, 0x92, 0xfa, 0x6b, 0x3d, 0x5a, 0xa9, 0x57, 0xab, 0xfc, 0x6a, 0x97, 0x67, 0xc6,
+	0xb2, 0x66, 0xad, 0x1e, 0xcb, 0xde, 0xd9, 0x58, 0xef, 0xc2, 0x44, 0xdb, 0xa3, 0x99, 0x11, 0x2b,
+	0x3f, 0xa2, 0x24, 0xcf, 0x06, 0xc3, 0xb2, 0x4d, 0xa9, 0x2b, 0xdd, 0x2a, 0xab, 0xbb, 0xaa, 0x5d,
+	0x95, 0xad, 0xc7, 0x08, 0x2f, 0xc4, 0x2c, 0x01, 0x4b, 0xb0, 0x1c, 0x98, 0x0d, 0x43, 0x04, 0xc1,
+	0x01, 0x4e, 0xc0, 0x81, 0x08, 0x82, 0x0b, 0x17, 0xe0, 0xca, 0x95, 0x80, 0x5f, 0xb0, 0x11, 0xfc,
+	0x01, 0x0e, 0x1c, 0x21, 0x36, 0xf2, 0x51, 0x55, 0x59, 0x2f, 0x75, 0xb7, 0xe4, 0x99, 0xbd, 0x48,
+	0x9d, 0xdf, 0x97, 0x95, 0xdf, 0x33, 0x33, 0xbf, 0xfc, 0xbe, 0x0f, 0x80, 0x60, 0xa3, 0xb7, 0xde,
+	0x77, 0x1d, 0xe2, 0xa0, 0x02, 0xfd, 0xad, 0x5e, 0xee, 0x38, 0x4e, 0xa7, 0x8b, 0x1b, 0x46, 0xdf,
+	0x6a, 0x18, 0xb6, 0xed, 0x10, 0x83, 0x58, 0x8e, 0xed, 0xf1, 0x39, 0x6a, 0x5d, 0x60, 0xd9, 0x68,
+	0x6f, 0xf0, 0xbc, 0xf1, 0xdc, 0xc2, 0x5d, 0xb3, 0xd5, 0x33, 0xbc, 0x03, 0x31, 0xe3, 0x72, 0x7c,
+	0x86, 0x47, 0xdc, 0x41, 0x9b, 0x08, 0xec, 0x37, 0xd9, 0xbf, 0xf6, 0xed, 0x0e, 0xb6, 0x6f, 0x7b,
+	0x47, 0x46, 0xa7, 0x83, 0xdd, 0x86, 0xd3, 0x67, 0x14, 0x92, 0xd4, 0xb4, 0x3d, 0x98, 0xdb, 0xc5,
+	0x46, 0xef, 0x59, 0xdf, 0xc3, 0x2e, 0xd1, 0xf1, 0xcb, 0x01, 0xf6, 0x08, 0xaa, 0x42, 0xde, 0xe8,
+	0x5b, 0x35, 0xa5, 0xae, 0xac, 0x96, 0x75, 0xfa, 0x13, 0x5d, 0x05, 0xc6, 0x7a, 0x2d, 0x57, 0x57,
+	0x56, 0x2b, 0x1b, 0xb0, 0xce, 0x64, 0xa2, 0x1f, 0xea, 0x0c, 0x8e, 0x2e, 0xc1, 0xc4, 0xc0, 0xc3,
+	0x6e, 0xcb, 0x32, 0x6b, 0x79, 0xfa, 0xd5, 0x83, 0x5c, 0x4d, 0xd1, 0x4b, 0x14, 0xb4, 0x65, 0x6a,
+	0xfb, 0x80, 0x64, 0x1a, 0x5e, 0xdf, 0xb1, 0x3d, 0x9c, 0x42, 0x64, 0x09, 0x4a, 0x1e, 0x31, 0xc8,
+	0xc0, 0x63, 0x64, 0xca, 0xba, 0x18, 0xa1, 0x19, 0xc8, 0xf9, 0xeb, 0xea, 0x39, 0xcb, 0x44, 0x35,
+	0x98, 0x38, 0xc4, 0xae, 0x67, 0x39, 0x76, 0xad, 0x50, 0x57, 0x56, 0xf3, 0xba, 0x3f, 0xd4, 0xfe,
+	0x55, 0x81, 0xb9, 0x67, 0x7d, 0xd3, 0x20, 0x98, 0xf1, 0x96, 0x29, 0x0e, 0x5f, 0x31, 0x17, 0xac,
+	0xe8, 0x8b, 0x97, 0xcf, 0x10, 0xef, 0xbb, 0x50, 0x19, 0xb0, 0x65, 0x99, 0x19, 0x18, 0xd5, 0xca,
+	0x86, 0xba, 0xce, 0xed, 0xb0, 0xee, 0xdb, 0x61, 0xfd, 0x23, 0x6a, 0xa9, 0x47, 0x86, 0x77, 0xa0,
+	0x03, 0x9f, 0x4e, 0x7f, 0xa3, 0x5b, 0x50, 0xc5, 0xc7, 0x7d, 0xdc, 0x26, 0xd8, 0x6c, 0xf9, 0x7c,
+	0x17, 0x19, 0xdf, 0xb3, 0x3e, 0xfc, 0x53, 0xc1, 0xff, 0x8f, 0x00, 0xc9, 0xec, 0x8f, 0xad, 0xa9,
+	0x21, 0x72, 0x68, 0x7f, 0xa8, 0x70, 0x73, 0x7f, 0x88, 0xbb, 0x98, 0xe0, 0x6c, 0xfd, 0x2c, 0xc3,
+	0x04, 0x9d, 0xdf, 0x0a, 0x94, 0x54, 0xa2, 0xc3, 0x2d, 0xf3, 0x4c, 0x3b, 0xa7, 0x0a, 0x5a, 0x48,
+	0x17, 0xf4, 0xcf, 0x15, 0x40, 0x32, 0x23, 0x63, 0x4b, 0xba, 0x00, 0x45, 0xca, 0x92, 0xc7, 0xd8,
+	0xc8, 0xeb, 0x7c, 0x40, 0x3d, 0xa3, 0x87, 0x7b, 0x7b, 0xd8, 0xf5, 0x7c, 0xcf, 0x10, 0x43, 0xb6,
+	0xce, 0x81, 0xd5, 0xed, 0x7a, 0x42, 0xf5, 0x62, 0x24, 0x3c, 0xa1, 0xe4, 0x7b, 0x82, 0xf6, 0x01,
+	0x20, 0x1d, 0x7b, 0xc4, 0x71, 0x87, 0x78, 0x50, 0x96, 0x86, 0xb4, 0x16, 0xcc, 0x47, 0x16, 0x78,
+	0xe3, 0x36, 0x7c, 0x17, 0x96, 0xb7, 0x2d, 0x8f, 0x70, 0xcd, 0x99, 0x14, 0xe1, 0x65, 0xb2, 0xa9,
+	0xf5, 0xa0, 0x96, 0x9c, 0x3c, 0x36, 0x4b, 0x37, 0x43, 0x65, 0xe7, 0x57, 0x2b, 0x1b, 0x73, 0x9c,
+	0x27, 0x69, 0x51, 0xa1, 0x7f, 0xea, 0x5f, 0x15, 0x09, 0x1c, 0xc8, 0xa2, 0x64, 0xec, 0xab, 0x2b,
+	0x00, 0x26, 0x9f, 0xde, 0x32, 0x08, 0x23, 0x9a, 0xd7, 0xcb, 0x02, 0xd2, 0x24, 0x32, 0x7a, 0xef,
+	0x44, 0x1c, 0x00, 0x3e, 0xfa, 0xc1, 0x09, 0x5a, 0x81, 0xc9, 0xfe, 0xc0, 0xed, 0x60, 0xfa, 0xad,
+	0x30, 0x37, 0x1b, 0x37, 0x89, 0xf6, 0x7f, 0x0a, 0xcc, 0x3f, 0x62, 0xa6, 0x1f, 0x76, 0xb2, 0x9d,
+	0xe1, 0xea, 0x65, 0xee, 0x3c, 0x81, 0xb3, 0xeb, 0x93, 0x1c, 0xb0, 0x65, 0xa2, 0xeb, 0x30, 0x25,
+	0x90, 0xb8, 0x67, 0x58, 0x5d, 0x46, 0xbe, 0xac, 0x57, 0x38, 0x6c, 0x93, 0x82, 0x10, 0x82, 0x82,
+	0xeb, 0x74, 0x31, 0xf3, 0xb7, 0xb2, 0xce, 0x7e, 0xcb, 0xdb, 0xa7, 0x94, 0xd8, 0x3e, 0xd7, 0xa0,
+	0x62, 0xb4, 0xdb, 0xd8, 0xf3, 0x5a, 0xec, 0xbb, 0x09, 0xf6, 0x1d, 0x70, 0x90, 0x4e, 0xbf, 0x4e,
+	0xdb, 0x5f, 0x93, 0xe9, 0xfb, 0xeb, 0xf7, 0x60, 0x21, 0x2a, 0x7e, 0xa6, 0xcd, 0x6f, 0xc0, 0xb4,
+	0x90, 0xc4, 0x1e, 0xd0, 0x7f, 0x42, 0x0b, 0x42, 0xbc, 0xc7, 0x0c, 0x26, 0x39, 0x46, 0x3e, 0xe2,
+	0x18, 0xd9, 0x27, 0xf1, 0x7f, 0x04, 0x06, 0x38, 0xf7, 0x59, 0x93, 0xe0, 0x2c, 0x9f, 0xc2, 0xd9,
+	0x08, 0x86, 0x90, 0x94, 0x5e, 0x1c, 0xe9, 0xcc, 0x2a, 0xa5, 0xeb, 0xb4, 0x0f, 0x0b, 0x51, 0x89,
+	0xce, 0x73, 0x68, 0xb5, 0x9d, 0x81, 0x4d, 0xfc, 0x43, 0x8b, 0x0d, 0xce, 0x50, 0xe2, 0x3f, 0x29,
+	0xb0, 0xf0, 0xd4, 0x75, 0x5e, 0xe0, 0x36, 0x89, 0xba, 0xf1, 0x4d, 0x98, 0xe8, 0x73, 0xb8, 0xd8,
+	0x5a, 0xd3, 0x7c, 0x6b, 0x89, 0xc9, 0xba, 0x8f, 0xf5, 0x79, 0xcb, 0xa5, 0xaa, 0x3b, 0x9f, 0x75,
+	0xb4, 0x17, 0x46, 0x52, 0x53, 0xc6, 0x1d, 0xf6, 0x5b, 0xb0, 0x18, 0xe3, 0x79, 0x6c, 0x3d, 0x49,
+	0x1a, 0xc9, 0x47, 0x35, 0xf2, 0x5a, 0x81, 0x85, 0x87, 0x2e, 0x36, 0x08, 0xf6, 0x45, 0x1d, 0xdf,
+	0xaf, 0x24, 0xe5, 0xe5, 0xcf, 0x54, 0xde, 0x18, 0xf7, 0xd9, 0xff, 0x28, 0xb0, 0xc0, 0x6f, 0xee,
+	0xf3, 0xf3, 0x75, 0x05, 0x40, 0x50, 0x0e, 0x8d, 0x53, 0x16, 0x90, 0x28, 0xdb, 0x85, 0x33, 0xd9,
+	0x8e, 0x05, 0x2b, 0xc5, 0x0b, 0x07, 0x2b, 0x19, 0xfb, 0xe1, 0x67, 0x0a, 0x2c, 0x36, 0xdd, 0xf6,
+	0xbe, 0x75, 0xf8, 0xd5, 0x09, 0x3d, 0x86, 0x09, 0x7e, 0x0c, 0xb3, 0x01, 0x1b, 0xe7, 0xb8, 0xe1,
+	0x46, 0xf4, 0x89, 0xec, 0xcd, 0xfa, 0x47, 0x0a, 0xcc, 0xd3, 0xbb, 0x56, 0x7c, 0xe2, 0x9d, 0x43,
+	0x19, 0x2a, 0x4c, 0x72, 0x7e, 0x30, 0xbf, 0x6a, 0xcb, 0x7a, 0x30, 0xa6, 0x9a, 0xb0, 0xec, 0x76,
+	0x77, 0x60, 0xe2, 0x96, 0xc1, 0x95, 0xce, 0xf7, 0xe9, 0xa4, 0x3e, 0x2b, 0xe0, 0xc2, 0x16, 0xa6,
+	0x76, 0x00, 0x0b, 0x51, 0x46, 0xc6, 0x56, 0xc7, 0x2d, 0x98, 0x14, 0x02, 0xfb, 0x77, 0x7e, 0x4c,
+	0x1f, 0x01, 0x5a, 0xfb, 0x17, 0x05, 0x96, 0xf8, 0x8e, 0x7c, 0x64, 0x75, 0xb1, 0x47, 0x1c, 0x1b,
+	0xbf, 0x79, 0x37, 0xb8, 0x0d, 0xe5, 0x9e, 0xbf, 0xba, 0xf0, 0xfe, 0x59, 0xce, 0x50, 0x48, 0x34,
+	0x9c, 0x31, 0xce, 0x69, 0xf5, 0x57, 0x39, 0x58, 0xe2, 0x1b, 0xf7, 0x2b, 0x64, 0x9f, 0x5e, 0x52,
+	0xfe, 0xea, 0xc1, 0xf9, 0xaa, 0x57, 0x02, 0x58, 0x5c, 0xc2, 0xe2, 0x50, 0x09, 0x63, 0x7b, 0xbc,
+	0x74, 0xe1, 0x3d, 0x3e, 0x91, 0xae, 0x9e, 0x7f, 0x54, 0x60, 0x89, 0x5f, 0x77, 0xbf, 0x5a, 0xf5,
+	0x8c, 0x61, 0x51, 0xfa, 0xc6, 0x91, 0x98, 0x1d, 0xdb, 0xf7, 0x23, 0x96, 0xc8, 0x0f, 0xb5, 0x44,
+	0xf6, 0x81, 0x60, 0xc0, 0x22, 0xdd, 0x86, 0xc1, 0x57, 0xde, 0x1b, 0xd7, 0x9c, 0xe6, 0xc1, 0x52,
+	0x9c, 0xc4, 0xd8, 0xf2, 0x36, 0x00, 0x02, 0x69, 0xfc, 0xdd, 0x9e, 0x10, 0x58, 0x9a, 0xa2, 0xfd,
+	0x9d, 0x02, 0x73, 0x7c, 0xc7, 0xef, 0x52, 0xcf, 0x7a, 0xe3, 0xee, 0x40, 0x1f, 0x0d, 0xe1, 0x2b,
+	0xdb, 0x7f, 0x34, 0x50, 0x52, 0x0c, 0x3e, 0x8e, 0x2f, 0xfc, 0x7f, 0x98, 0x0f, 0xf8, 0x4a, 0x58,
+	0xa5, 0xdf, 0x19, 0xde, 0x41, 0xe8, 0xb4, 0x25, 0x3a, 0x94, 0x64, 0x28, 0x66, 0xc8, 0xf0, 0x75,
+	0xed, 0xdf, 0xbf, 0x51, 0x60, 0x8e, 0xef, 0xdf, 0xaf, 0x59, 0x01, 0x63, 0x18, 0xc9, 0x85, 0x29,
+	0xce, 0xdc, 0xb9, 0x9e, 0xca, 0x54, 0x7d, 0xf9, 0x0c, 0x2d, 0x67, 0xef, 0xcd, 0x7f, 0x56, 0xa0,
+	0x4a, 0x77, 0x0e, 0x9d, 0xec, 0xfd, 0x4a, 0x4e, 0x34, 0x15, 0x26, 0x0d, 0xcf, 0xb3, 0x3a, 0x36,
+	0xf6, 0x9f, 0x88, 0xc1, 0x38, 0x12, 0x07, 0x94, 0xa2, 0x71, 0x80, 0xd6, 0x82, 0x39, 0x89, 0xf1,
+	0xb1, 0x55, 0x56, 0x87, 0x22, 0x55, 0x8d, 0xbf, 0xd1, 0x65, 0x9d, 0x71, 0x84, 0xf6, 0x3e, 0xa0,
+	0x8f, 0x31, 0x79, 0x70, 0xb2, 0xcb, 0x24, 0x1d, 0x39, 0x87, 0x46, 0x13, 0x1f, 0x91, 0xef, 0x32,
+	0x59, 0x1b, 0x96, 0x4b, 0xcc, 0x78, 0x6c, 0x6a, 0xdf, 0x83, 0x85, 0x80, 0xc0, 0x63, 0xa3, 0x77,
+	0xc6, 0x45, 0x84, 0xa0, 0x60, 0x1b, 0x3d, 0x2c, 0x98, 0x63, 0xbf, 0xb5, 0x97, 0xb0, 0x18, 0xfb,
+	0x3a, 0x93, 0xc1, 0x71, 0xb3, 0x83, 0x21, 0xc3, 0x85, 0x08, 0xc3, 0xbe, 0x26, 0x9f, 0xb1, 0x37,
+	0xd4, 0xe8, 0x9a, 0x7c, 0x09, 0xf3, 0x91, 0xef, 0x46, 0x66, 0xb4, 0x1e, 0xcd, 0xd3, 0xc8, 0x9c,
+	0x72, 0x44, 0x26, 0xab, 0xbf, 0x28, 0xc0, 0xec, 0xc7, 0x98, 0x9c, 0x9d, 0x4d, 0xa2, 0x7a, 0xed,
+	0x1b, 0x1d, 0x2c, 0x12, 0x35, 0xec, 0x37, 0x7d, 0xd3, 0x76, 0xad, 0x9e, 0x15, 0xbc, 0x69, 0xd9,
+	0x20, 0x48, 0x7e, 0x14, 0xa4, 0xe4, 0x07, 0x9d, 0x89, 0x0f, 0x71, 0x57, 0x9c, 0x03, 0x7c, 0x80,
+	0xae, 0x02, 0x10, 0xdc, 0xde, 0xb7, 0x9d, 0xae, 0xd3, 0x39, 0x11, 0x89, 0x38, 0x09, 0xc2, 0x76,
+	0x9a, 0xd1, 0xc1, 0x2d, 0xe2, 0x1c, 0x60, 0x5b, 0x24, 0x45, 0xca, 0x14, 0xb2, 0x4b, 0x01, 0x52,
+	0x5e, 0x6f, 0x92, 0x6d, 0x14, 0x31, 0x42, 0x77, 0x61, 0x8a, 0xff, 0x6a, 0xf5, 0x0c, 0xd2, 0xde,
+	0xaf, 0x95, 0xeb, 0xca, 0xea, 0xcc, 0x46, 0x95, 0x6b, 0x64, 0x87, 0x62, 0x1e, 0x51, 0xb8, 0x5e,
+	0xe1, 0xb3, 0xd8, 0x00, 0xbd, 0x0d, 0x33, 0x3d, 0xcb, 0x6e, 0xb5, 0x9d, 0x5e, 0xbf, 0x8b, 0x8f,
+	0x2d, 0x72, 0x52, 0x83, 0xba, 0xb2, 0x5a, 0xd4, 0xa7, 0x7b, 0x96, 0xfd, 0x30, 0x00, 0xb2, 0x69,
+	0xc6, 0xb1, 0x3c, 0xad, 0x22, 0xa6, 0x19, 0xc7, 0xd2, 0xb4, 0xcb, 0x50, 0xee, 0x1a, 0x76, 0x67,
+	0x60, 0x74, 0xb0, 0x57, 0x9b, 0x62, 0xdc, 0x85, 0x00, 0xf4, 0x16, 0xa7, 0xe5, 0xf4, 0xb1, 0xcd,
+	0xf2, 0x3d, 0x5e, 0x6d, 0x9a, 0x2d, 0x32, 0xd5, 0xb3, 0xec, 0x27, 0x7d, 0x6c, 0xd3, 0x8c, 0x8f,
+	0x47, 0x53, 0x5c, 0x74, 0x96, 0x67, 0x7d, 0x8e, 0x6b, 0x33, 0x0c, 0x3f, 0xd1, 0xb3, 0xec, 0x1d,
+	0xeb, 0x73, 0xcc, 0x50, 0xc6, 0x31, 0x47, 0xcd, 0x0a, 0x94, 0x71, 0xcc, 0x50, 0xd7, 0x61, 0xca,
+	0x68, 0x13, 0xeb, 0x10, 0xb7, 0x3c, 0xcb, 0x6e, 0xe3, 0x5a, 0x95, 0x29, 0xbc, 0xc2, 0x61, 0x3b,
+	0x14, 0x44, 0x93, 0x4d, 0x74, 0x5b, 0xb4, 0xfa, 0x2e, 0x7e, 0x6e, 0x1d, 0xd7, 0xe6, 0xb8, 0xde,
+	0x29, 0xe8, 0x29, 0x83, 0x20, 0x0d, 0x0a, 0x9e, 0xe3, 0x92, 0x1a, 0x62, 0x8a, 0x9b, 0x09, 0x5d,
+	0x69, 0xc7, 0x71, 0x89, 0xce, 0x70, 0xe8, 0x9b, 0x80, 0x04, 0x9d, 0x23, 0x8b, 0xec, 0x5b, 0x76,
+	0xcb, 0x34, 0x4e, 0xbc, 0xda, 0x3c, 0x63, 0xa6, 0xca, 0x31, 0x3f, 0x60, 0x88, 0x0f, 0x8d, 0x13,
+	0x96, 0x1c, 0xac, 0x86, 0x3e, 0x96, 0xe9, 0xd4, 0x81, 0x13, 0xe7, 0x86, 0x3b, 0x71, 0x34, 0x1b,
+	0xf5, 0x0e, 0xcc, 0xda, 0xf8, 0x98, 0xb4, 0x24, 0x7f, 0xe1, 0xfe, 0x37, 0x4d, 0xc1, 0x4f, 0x7d,
+	0x9f, 0xd1, 0xbe, 0x54, 0xa0, 0xb6, 0xeb, 0x1a, 0xb6, 0xf7, 0x1c, 0xbb, 0x4f, 0x8e, 0x6c, 0xec,
+	0x7a, 0xfb, 0x56, 0xff, 0x1c, 0x97, 0x40, 0x1d, 0xa6, 0x6c, 0x7c, 0xd4, 0x72, 0xe8, 0x12, 0xe1,
+	0x35, 0x00, 0x36, 0x3e, 0x62, 0xab, 0x8e, 0xf7, 0x7c, 0xfd, 0xb9, 0x02, 0x2b, 0x29, 0x4c, 0x8d,
+	0x7d, 0xc0, 0x67, 0xe6, 0x77, 0x56, 0x60, 0x32, 0xe0, 0x94, 0xab, 0x65, 0xc2, 0x11, 0x6c, 0x4a,
+	0xf7, 0x64, 0x31, 0x7a, 0x4f, 0xfe, 0x89, 0x02, 0xf3, 0x5b, 0xf6, 0xa1, 0x45, 0x30, 0x4f, 0x7d,
+	0x9d, 0x43, 0x4b, 0x0b, 0x50, 0xe4, 0xa9, 0x39, 0xce, 0x0e, 0x1f, 0xa4, 0x1e, 0x10, 0xb1, 0x04,
+	0x68, 0x31, 0x9e, 0x00, 0xd5, 0x5c, 0x58, 0x88, 0x32, 0x33, 0xb6, 0x76, 0xde, 0x03, 0xb0, 0xe8,
+	0x0a, 0xac, 0x06, 0x26, 0x0e, 0x74, 0x71, 0x28, 0x6c, 0x05, 0x70, 0x5d, 0x9a, 0xa3, 0xbd, 0xe0,
+	0x21, 0x76, 0x88, 0x3d, 0x4f, 0xb8, 0xf0, 0x36, 0xcc, 0xf8, 0x8f, 0xf7, 0x76, 0xd7, 0xf1, 0x30,
+	0xb7, 0xcd, 0xa4, 0x3e, 0x2d, 0xa0, 0x0f, 0x19, 0x50, 0x3b, 0x82, 0xe5, 0x04, 0xad, 0xb1, 0x45,
+	0xdc, 0x80, 0x4a, 0xc8, 0xbe, 0x7f, 0x15, 0x24, 0x65, 0x94, 0x27, 0x69, 0xbf, 0x01, 0x73, 0x12,
+	0x2a, 0x53, 0xbe, 0x1b, 0x30, 0x1d, 0x7e, 0x15, 0x4a, 0x39, 0x15, 0x02, 0xb7, 0x4c, 0xed, 0xd7,
+	0x01, 0xc9, 0x6b, 0x8d, 0xcb, 0xbf, 0xf6, 0x85, 0x02, 0xb5, 0x66, 0xbb, 0x8d, 0xfb, 0xe4, 0x22,
+	0xcb, 0x64, 0xef, 0x83, 0x44, 0x5a, 0xb9, 0x90, 0x4c, 0x2b, 0x6b, 0xff, 0xab, 0x00, 0x84, 0xe4,
+	0xc5, 0x45, 0xab, 0x04, 0x17, 0xed, 0xd7, 0xe0, 0xec, 0xf4, 0xe2, 0x63, 0x7a, 0xe5, 0xc5, 0x0f,
+	0x7e, 0x31, 0x96, 0x05, 0xe4, 0xc1, 0x89, 0x24, 0xf7, 0x44, 0x44, 0xee, 0x2b, 0x00, 0x6d, 0xf6,
+	0x38, 0x63, 0x25, 0x15, 0x5e, 0x1e, 0x28, 0x0b, 0x08, 0x2f, 0xa9, 0xe0, 0xe3, 0xbe, 0xe5, 0x62,
+	0x8f, 0xa2, 0xcb, 0x1c, 0x2d, 0x20, 0x4d, 0xa2, 0xf5, 0x00, 0x35, 0xfb, 0xfd, 0xee, 0xc9, 0xae,
+	0x73, 0xbe, 0xf2, 0x57, 0x20, 0x6a, 0x5e, 0x12, 0x95, 0x55, 0xe5, 0x3c, 0xcf, 0xe8, 0xf8, 0x1a,
+	0xf0, 0x87, 0x1a, 0x81, 0x79, 0x4a, 0xce, 0x6a, 0x9f, 0xd7, 0xca, 0x77, 0xa1, 0x62, 0x84, 0x0b,
+	0x88, 0x0d, 0x2d, 0xea, 0x53, 0xf2, 0xca, 0xf2, 0x2c, 0xed, 0x77, 0xf8, 0x36, 0x93, 0xf0, 0x6f,
+	0x38, 0x59, 0xa7, 0x9d, 0x42, 0x2d, 0x49, 0x61, 0x6c, 0xe1, 0xbe, 0x05, 0x53, 0x12, 0xdb, 0xb1,
+	0xea, 0x9b, 0x2c, 0x5d, 0x64, 0x9a, 0xf6, 0x88, 0xdb, 0xd0, 0x47, 0x66, 0x4a, 0xf6, 0x36, 0xcc,
+	0x48, 0xdf, 0x85, 0x02, 0x4e, 0x4b, 0xd0, 0x2d, 0x53, 0xfb, 0x03, 0x05, 0xd4, 0x66, 0xbf, 0xef,
+	0x3a, 0x87, 0xf8, 0x62, 0xb6, 0xba, 0xd8, 0x8e, 0xfc, 0x69, 0x0e, 0x2a, 0x12, 0xfd, 0xd1, 0xb7,
+	0xe4, 0x72, 0xac, 0x64, 0x1d, 0xd4, 0x34, 0x82, 0xbd, 0x5a, 0x48, 0xdb, 0xab, 0xc5, 0x74, 0x07,
+	0x2e, 0x45, 0x1c, 0xf8, 0x02, 0xbb, 0xd0, 0xc4, 0x6d, 0xcb, 0xc4, 0xa6, 0xb4, 0x0b, 0x05, 0x24,
+	0x8a, 0xde, 0xe3, 0x31, 0x68, 0x39, 0x40, 0x3f, 0x38, 0xd1, 0xfe, 0x56, 0x01, 0xb4, 0x83, 0x69,
+	0x16, 0x78, 0x48, 0xbc, 0xbe, 0x00, 0xc5, 0x97, 0x03, 0xec, 0x9e, 0x08, 0x8d, 0xf0, 0x81, 0x14,
+	0x32, 0xe7, 0x23, 0x21, 0x73, 0x24, 0x5e, 0x2d, 0xc4, 0xe3, 0xd5, 0x20, 0xce, 0x2f, 0xca, 0x71,
+	0x7e, 0x34, 0x3a, 0x2f, 0xc5, 0xa2, 0x73, 0x6a, 0xb4, 0xf9, 0x08, 0xa7, 0x63, 0x3b, 0xcd, 0x0d,
+	0x28, 0xec, 0x5b, 0x24, 0x96, 0x97, 0xe2, 0x4b, 0x7e, 0x62, 0x11, 0x9d, 0x21, 0x29, 0x6f, 0xc4,
+	0x21, 0x46, 0x57, 0xc4, 0x56, 0x7c, 0x80, 0xd6, 0xc5, 0x13, 0xa0, 0xf5, 0xdc, 0x68, 0x63, 0x42,
+	0x0b, 0xff, 0x74, 0x89, 0x0a, 0x5f, 0xe2, 0x23, 0x0a, 0x13, 0xd1, 0x3f, 0xfb, 0xed, 0xa1, 0x7b,
+	0x30, 0xeb, 0x8b, 0xeb, 0x7f, 0x52, 0x4a, 0x7e, 0x32, 0xe3, 0xcf, 0x11, 0x5f, 0xa5, 0x04, 0x9d,
+	0x13, 0x69, 0x41, 0xa7, 0x0b, 0xe5, 0x80, 0xed, 0xa1, 0x75, 0xf1, 0x05, 0x28, 0x7a, 0x6d, 0xc7,
+	0xe5, 0x2f, 0x2d, 0x45, 0xe7, 0x03, 0x9a, 0xa9, 0xdb, 0xb7, 0x3a, 0xfb, 0x5d, 0xab, 0xb3, 0x1f,
+	0xd7, 0xc8, 0x27, 0x3e, 0x5c, 0x97, 0xa6, 0x68, 0x1f, 0x40, 0x39, 0x40, 0xd0, 0x35, 0x59, 0x27,
+	0x91, 0xd0, 0x3a, 0x1f, 0x50, 0xa3, 0x3f, 0x77, 0x8d, 0x4e, 0x0f, 0xdb, 0x84, 0x47, 0xdc, 0x65,
+	0x3d, 0x04, 0x68, 0x77, 0xa0, 0xc8, 0xc4, 0xa4, 0xbb, 0x82, 0x60, 0xb7, 0x27, 0xbe, 0x65, 0xbf,
+	0xc3, 0x6a, 0x66, 0x4e, 0xaa, 0x66, 0x6a, 0xff, 0xa9, 0xf0, 0x80, 0xa9, 0x39, 0x30, 0x2d, 0xb2,
+	0x79, 0x48, 0x97, 0x39, 0x5f, 0xd0, 0x68, 0xb4, 0x89, 0xe3, 0xd7, 0x7c, 0xf9, 0x80, 0x3a, 0x09,
+	0x7d, 0x55, 0x38, 0x7e, 0x5c, 0x2f, 0x46, 0x4c, 0x5d, 0xec, 0xa1, 0x23, 0x7c, 0x93, 0x0d, 0x28,
+	0x74, 0x60, 0x13, 0xab, 0x2b, 0xea, 0x57, 0x7c, 0x10, 0xfa, 0xf1, 0x44, 0xb6, 0x1f, 0x4f, 0xc6,
+	0xfd, 0xf8, 0xb5, 0x02, 0xcb, 0x09, 0xa1, 0xc6, 0xf6, 0xe5, 0x55, 0x28, 0x61, 0xf6, 0x6d, 0x34,
+	0x28, 0x0b, 0x17, 0xd5, 0x05, 0x7e, 0xe4, 0x97, 0xcc, 0x4f, 0x72, 0x00, 0xe1, 0xe7, 0x63, 0x85,
+	0x29, 0x63, 0xa8, 0xb7, 0x01, 0xa5, 0x3d, 0xfc, 0xdc, 0x71, 0xb9, 0x7e, 0x2b, 0x1b, 0xcb, 0x89,
+	0x3c, 0xe5, 0x0e, 0x6b, 0x40, 0xd3, 0xc5, 0x34, 0x74, 0x1b, 0x8a, 0xc6, 0x73, 0x82, 0xdd, 0x5a,
+	0xe9, 0xec, 0xf9, 0x7c, 0x16, 0x55, 0xbe, 0xcb, 0x5d, 0x84, 0x72, 0x2a, 0x9e, 0xf8, 0x02, 0xc2,
+	0xfb, 0x22, 0x9c, 0x76, 0x7b, 0xe0, 0xba, 0xf2, 0x61, 0x0a, 0x3e, 0xa8, 0x49, 0xb4, 0x7f, 0xcb,
+	0x43, 0x61, 0x57, 0x24, 0x62, 0xba, 0xd8, 0x30, 0xb1, 0x2b, 0x74, 0x20, 0x46, 0xe8, 0x9d, 0xb0,
+	0x2d, 0x88, 0x3f, 0x2a, 0xa7, 0x44, 0x7e, 0x9b, 0x01, 0xc3, 0x26, 0x21, 0x3f, 0x6f, 0x94, 0x0f,
+	0xf3, 0x46, 0x94, 0x39, 0xe9, 0x8d, 0x5e, 0x60, 0x6f, 0xdb, 0xb2, 0x13, 0x3c, 0xd0, 0xe5, 0xbe,
+	0x22, 0xf9, 0x30, 0x45, 0x50, 0x60, 0x2f, 0xf3, 0x12, 0xfb, 0x80, 0xfd, 0xa6, 0x82, 0x74, 0x0d,
+	0x8f, 0xb4, 0xf8, 0xcb, 0x98, 0x09, 0x5a, 0xd4, 0x81, 0x82, 0x9a, 0x0c, 0x22, 0xec, 0x37, 0x19,
+	0xd8, 0x4f, 0xaa, 0x4a, 0x96, 0xcf, 0xac, 0x4a, 0xbe, 0x0f, 0xcb, 0xc6, 0x80, 0x38, 0xfc, 0x71,
+	0xd1, 0x8a, 0x04, 0x0d, 0xc0, 0x1e, 0x1a, 0x8b, 0x14, 0xcd, 0x5e, 0x19, 0x72, 0x4c, 0x22, 0x3f,
+	0xfc, 0x2a, 0x91, 0x87, 0x5f, 0xa4, 0x02, 0x38, 0x75, 0x66, 0x05, 0x90, 0x8a, 0x65, 0xd9, 0x22,
+	0x0f, 0x60, 0x10, 0x96, 0xc6, 0xc8, 0xeb, 0xe0, 0x83, 0x9a, 0x84, 0x4e, 0xf0, 0x4b, 0x96, 0x74,
+	0xc2, 0x0c, 0x9f, 0xe0, 0x83, 0x9a, 0x44, 0xfb, 0xa9, 0x02, 0x25, 0x6e, 0x8b, 0xf0, 0x52, 0x56,
+	0xe4, 0x4b, 0x39, 0x4c, 0x74, 0x15, 0x99, 0x62, 0xd2, 0xa2, 0xcc, 0x58, 0x40, 0x5d, 0x48, 0x04,
+	0xd4, 0x89, 0x30, 0xa3, 0x98, 0x12, 0x66, 0xfc, 0x22, 0x07, 0x13, 0x42, 0x44, 0x54, 0x87, 0x8a,
+	0x89, 0xbd, 0xb6, 0x6b, 0xb1, 0xee, 0x49, 0xc1, 0x91, 0x0c, 0x8a, 0x5e, 0x99, 0xb9, 0xf8, 0x95,
+	0x99, 0xe6, 0x4e, 0xd7, 0xa0, 0xd2, 0xb1, 0xc8, 0xfe, 0x60, 0xaf, 0xd5, 0xb5, 0xec, 0x03, 0x9f,
+	0x4b, 0x0e, 0xda, 0xb6, 0xec, 0x03, 0x9a, 0x0f, 0x93, 0x12, 0x4b, 0x45, 0xee, 0x23, 0x21, 0x84,
+	0x86, 0x9d, 0xe6, 0xc0, 0xe5, 0xe1, 0x2e, 0x77, 0xae, 0x60, 0x2c, 0xd4, 0x34, 0x11, 0xf8, 0x4f,
+	0x78, 0x10, 0x4d, 0xc6, 0xa3, 0x13, 0x8f, 0x18, 0x2e, 0x89, 0x84, 0x1f, 0x02, 0xd2, 0x24, 0x34,
+	0x7d, 0xc4, 0x09, 0x8a, 0x09, 0xc0, 0xd3, 0x47, 0x01, 0x2c, 0x69, 0xd2, 0x4a, 0xdc, 0xa4, 0x94,
+	0xcd, 0xbe, 0xeb, 0x74, 0x5c, 0xec, 0x51, 0xff, 0x61, 0x6c, 0xfa, 0x63, 0xed, 0x75, 0x0e, 0xca,
+	0x41, 0x69, 0x29, 0x71, 0x68, 0x45, 0x53, 0xeb, 0xb9, 0x78, 0x6a, 0x9d, 0xde, 0xf5, 0x16, 0x09,
+	0x6c, 0xcf, 0x07, 0x91, 0x6c, 0x7a, 0x21, 0x96, 0x4d, 0x5f, 0x84, 0x92, 0x39, 0x60, 0xae, 0x29,
+	0xae, 0x07, 0x73, 0x40, 0xbd, 0x32, 0x54, 0x4e, 0x29, 0xa2, 0x1c, 0xca, 0xb9, 0xe3, 0x59, 0xc4,
+	0xaf, 0xaa, 0x14, 0xf5, 0x60, 0x3c, 0x2c, 0xac, 0x8b, 0x2b, 0xae, 0x9c, 0x54, 0x9c, 0xac, 0x17,
+	0x88, 0xe9, 0xe5, 0x2f, 0x72, 0x50, 0xa0, 0x99, 0xf8, 0x71, 0x55, 0x12, 0xaf, 0x36, 0xe4, 0x93,
+	0xd5, 0x86, 0x40, 0x6b, 0x85, 0x2c, 0xad, 0x15, 0x33, 0xb5, 0x56, 0x4a, 0xd7, 0xda, 0x44, 0xa6,
+	0xd6, 0x26, 0xcf, 0xd4, 0x5a, 0x79, 0x98, 0xd6, 0x92, 0xee, 0xb6, 0xf6, 0x3e, 0x40, 0x98, 0xb3,
+	0x45, 0xf3, 0x30, 0xbb, 0xf3, 0xfd, 0xad, 0xed, 0xed, 0xd6, 0xa3, 0xe6, 0xee, 0xc3, 0x4f, 0x5a,
+	0xcd, 0xc7, 0xbf, 0x59, 0xfd, 0x46, 0x02, 0xb8, 0xbd, 0x5d, 0x55, 0xd6, 0x7e, 0x5f, 0x81, 0x49,
+	0x3f, 0x67, 0x89, 0x16, 0x61, 0x6e, 0x77, 0xb3, 0xf9, 0xa8, 0xb5, 0xf3, 0x44, 0xdf, 0x6d, 0x7d,
+	0xb8, 0xf9, 0x51, 0xf3, 0xd9, 0xf6, 0x6e, 0xf5, 0x1b, 0x68, 0x01, 0xaa, 0x21, 0xf8, 0xf1, 0xe6,
+	0x0f, 0x36, 0x77, 0x76, 0xab, 0x0a, 0x5a, 0x81, 0xc5, 0x10, 0xba, 0xdd, 0xdc, 0xd9, 0x6d, 0x35,
+	0x1f, 0xee, 0x6e, 0x7d, 0xba, 0x59, 0xcd, 0xa1, 0x1a, 0x2c, 0x84, 0xa8, 0x27, 0x4f, 0x37, 0x1f,
+	0xb7, 0xf4, 0x27, 0xdb, 0x9b, 0x3b, 0xd5, 0x3c, 0x42, 0x30, 0x13, 0x62, 0x76, 0xb6, 0x3e, 0xdb,
+	0xac, 0x16, 0x36, 0xfe, 0xfd, 0x06, 0x54, 0x18, 0x0b, 0xd8, 0x3d, 0xb4, 0xda, 0x18, 0x3d, 0x03,
+	0x10, 0xc5, 0x53, 0x7a, 0x63, 0x2d, 0x87, 0xa1, 0x5f, 0xa4, 0xc3, 0x4b, 0xad, 0x25, 0x11, 0x3c,
+	0xde, 0xd0, 0x16, 0xbe, 0xf8, 0xaf, 0xff, 0xfe, 0x79, 0x6e, 0xe6, 0xbe, 0xb2, 0xa6, 0x95, 0x1b,
+	0x87, 0x77, 0x1a, 0x3c, 0x27, 0xfa, 0xdb, 0x00, 0x61, 0xe7, 0xb0, 0xbf, 0x6c, 0xa2, 0x15, 0x5a,
+	0xad, 0x25, 0x11, 0x62, 0xd9, 0xcb, 0x6c, 0xd9, 0xa5, 0xfb, 0x2c, 0x00, 0xdd, 0x98, 0x09, 0x56,
+	0x6e, 0x9c, 0x5a, 0xe6, 0x2b, 0xf4, 0x43, 0x00, 0x51, 0x46, 0x8c, 0x71, 0x1d, 0xe9, 0xee, 0x53,
+	0x6b, 0x49, 0x84, 0x58, 0xfe, 0x12, 0x5b, 0x7e, 0x71, 0x6d, 0x5e, 0x5a, 0x58, 0xc4, 0x26, 0xaf,
+	0xd0, 0x0b, 0xa8, 0x48, 0x3d, 0xb3, 0x48, 0xac, 0x92, 0xec, 0xc3, 0x55, 0x57, 0x52, 0x30, 0x82,
+	0xc0, 0x3b, 0x8c, 0x40, 0x9d, 0xaa, 0xe5, 0x52, 0x0a, 0x8d, 0x86, 0xcb, 0xbf, 0x41, 0x0e, 0x2f,
+	0xfc, 0xc9, 0x1d, 0xb1, 0xe8, 0x0a, 0x5f, 0x36, 0xa3, 0xad, 0x56, 0xbd, 0x9a, 0x85, 0x8e, 0xaa,
+	0x0e, 0x2d, 0x50, 0xba, 0x3d, 0xdc, 0x10, 0x1d, 0xaa, 0xb7, 0xb9, 0x65, 0x5e, 0x40, 0xb9, 0x69,
+	0x9a, 0xe2, 0x7a, 0x5b, 0x91, 0x03, 0x8f, 0xa8, 0xc5, 0xd5, 0x34, 0xd4, 0x88, 0xc2, 0xf9, 0x01,
+	0xcc, 0xe7, 0x30, 0xa5, 0xe3, 0x9e, 0x73, 0x88, 0xd3, 0xc8, 0x45, 0x4d, 0xa5, 0xa6, 0xa1, 0x04,
+	0xb9, 0xbb, 0x8c, 0xdc, 0xed, 0xb5, 0x77, 0xcf, 0xa0, 0xd5, 0x38, 0x8d, 0x5c, 0xa6, 0xaf, 0x10,
+	0x81, 0x39, 0xce, 0x35, 0x55, 0x8e, 0x7f, 0x85, 0xaa, 0x91, 0xa0, 0x21, 0x2a, 0xf0, 0xa5, 0x54,
+	0xdc, 0x88, 0x12, 0xfb, 0x91, 0xcf, 0x4b, 0x98, 0x8e, 0xf4, 0x03, 0xfa, 0x14, 0xd3, 0x9a, 0x04,
+	0xd5, 0xc5, 0x08, 0xc5, 0x80, 0xd6, 0x6d, 0x46, 0xeb, 0xe6, 0x7d, 0x3f, 0x9c, 0xd2, 0x2e, 0x9f,
+	0x41, 0xd1, 0x43, 0x3f, 0x86, 0xe9, 0x48, 0xab, 0x9f, 0x4f, 0x32, 0xad, 0xff, 0x2f, 0x8b, 0xe4,
+	0x7d, 0x46, 0xf2, 0x5e, 0x40, 0x72, 0xe3, 0xd6, 0x59, 0x24, 0x1b, 0xa7, 0xe1, 0xf1, 0xff, 0x0a,
+	0x7d, 0xa1, 0xc0, 0x4c, 0xb4, 0xef, 0x0e, 0x09, 0x55, 0xa6, 0x76, 0xe3, 0x65, 0xb1, 0xf0, 0x3d,
+	0xc6, 0xc2, 0xfb, 0x54, 0xc3, 0x77, 0x46, 0x26, 0xde, 0x10, 0x57, 0x3c, 0x3a, 0x80, 0x29, 0xb9,
+	0xc7, 0xcc, 0xf7, 0xb4, 0x94, 0x06, 0x38, 0x55, 0x4d, 0x43, 0x09, 0x26, 0xde, 0x62, 0x4c, 0x5c,
+	0x45, 0x67, 0x6b, 0xfc, 0xcf, 0x14, 0x98, 0x8d, 0xf5, 0x98, 0xa1, 0xcb, 0xb2, 0x9d, 0xe3, 0xcd,
+	0x49, 0xea, 0x72, 0xbc, 0x81, 0xc5, 0x27, 0xf8, 0x31, 0x23, 0xd8, 0xbc, 0x1f, 0x76, 0xf0, 0x68,
+	0xf7, 0x46, 0x97, 0x3e, 0xf8, 0xc8, 0x43, 0x7f, 0xad, 0xc0, 0x6c, 0xac, 0x71, 0xcc, 0xe7, 0x29,
+	0xbd, 0x9f, 0x2c, 0x9b, 0xa7, 0x4f, 0x19, 0x4f, 0x4f, 0x25, 0x9e, 0x36, 0x1e, 0x9e, 0x87, 0xa7,
+	0xc6, 0xa9, 0x1c, 0x06, 0xbc, 0x42, 0xaf, 0x15, 0x98, 0x8d, 0x35, 0x6f, 0xf9, 0x2c, 0xa6, 0xf7,
+	0x74, 0x65, 0xb3, 0xf8, 0x7d, 0xc6, 0xe2, 0xe6, 0xda, 0x1b, 0xe1, 0xeb, 0x8f, 0x15, 0x98, 0x89,
+	0xb6, 0x2d, 0xf9, 0x0e, 0x9c, 0xda, 0x2f, 0xa5, 0x5e, 0x4e, 0x47, 0x46, 0xfd, 0x18, 0x9d, 0xcf,
+	0x8c, 0xc7, 0xc1, 0x75, 0x4c, 0x03, 0xaf, 0x65, 0xd9, 0xa9, 0xa4, 0x8e, 0x19, 0x15, 0x49, 0x7d,
+	0x12, 0x3e, 0xe1, 0x5f, 0x63, 0x84, 0xbf, 0x7d, 0x9f, 0xf5, 0x9a, 0x68, 0x8d, 0xd1, 0xc9, 0xd3,
+	0xf9, 0x1e, 0xdd, 0xc6, 0x10, 0xf6, 0x26, 0xc5, 0xae, 0xec, 0x21, 0xa4, 0x7d, 0x2f, 0x66, 0xa4,
+	0x37, 0xbe, 0x33, 0x26, 0xe9, 0xc6, 0xa9, 0xe8, 0xd9, 0x79, 0x85, 0x7e, 0x37, 0xb8, 0xd7, 0x25,
+	0x1e, 0x12, 0x0d, 0x43, 0xa9, 0x3c, 0x34, 0x19, 0x0f, 0xdf, 0x5d, 0xbb, 0x00, 0xf5, 0x01, 0x94,
+	0x83, 0x5e, 0x16, 0xb4, 0x14, 0x5a, 0x59, 0xee, 0xca, 0x51, 0x97, 0x13, 0x70, 0xc1, 0xc0, 0xb7,
+	0x19, 0x03, 0x77, 0xd0, 0xd8, 0x9a, 0xff, 0x51, 0xd0, 0xeb, 0xe0, 0x77, 0xab, 0xf8, 0x21, 0x47,
+	0xb2, 0xf1, 0x45, 0x5d, 0x49, 0xc1, 0x08, 0x06, 0x96, 0x18, 0x03, 0x55, 0x14, 0x0f, 0x96, 0x0e,
+	0x60, 0x2e, 0xb2, 0x3e, 0x6d, 0x37, 0x41, 0x6a, 0x6c, 0x1d, 0xa9, 0x83, 0x45, 0xbd, 0x94, 0x8a,
+	0x13, 0x54, 0xae, 0x30, 0x2a, 0xcb, 0x68, 0x31, 0xa4, 0x62, 0x1b, 0x3d, 0xdc, 0x38, 0xa5, 0x7f,
+	0x5f, 0x21, 0x1c, 0x16, 0xd5, 0xfd, 0x8e, 0x91, 0x88, 0x34, 0x91, 0xe6, 0x13, 0x75, 0x25, 0x05,
+	0x93, 0x16, 0xc5, 0x70, 0x3a, 0x34, 0x51, 0x2e, 0x64, 0xda, 0x83, 0xc5, 0x90, 0xcc, 0x43, 0x9a,
+	0x79, 0xb1, 0x09, 0x5d, 0xe0, 0x7c, 0xb4, 0x44, 0x0c, 0x8b, 0xa6, 0x44, 0xc4, 0xc4, 0xc8, 0xa1,
+	0x6d, 0x98, 0xf4, 0x69, 0xa0, 0xc5, 0xe0, 0xe3, 0x48, 0x28, 0xb6, 0x14, 0x07, 0x8b, 0x05, 0xe7,
+	0xd8, 0x82, 0x15, 0x24, 0x45, 0xc4, 0x9f, 0xc3, 0x5c, 0xa2, 0x9e, 0x8e, 0x44, 0x28, 0x97, 0x55,
+	0xfd, 0x57, 0xaf, 0x65, 0xe2, 0xa3, 0x17, 0x16, 0xbd, 0x35, 0x57, 0xd2, 0xfc, 0x8e, 0x95, 0xd4,
+	0xd1, 0x4b, 0x98, 0x92, 0x0b, 0xd5, 0xfe, 0xed, 0x98, 0x52, 0x49, 0x57, 0xd5, 0x34, 0x94, 0x20,
+	0xb6, 0xc6, 0x88, 0xbd, 0x45, 0x89, 0x5d, 0x4b, 0x23, 0x26, 0x95, 0x70, 0xd1, 0x9f, 0x2a, 0x30,
+	0x1b, 0x2b, 0x1e, 0x23, 0xe9, 0xe0, 0x4c, 0xd6, 0xaf, 0xd5, 0x2b, 0x19, 0xd8, 0xe8, 0xf1, 0xf6,
+	0xd9, 0x75, 0x34, 0x94, 0x36, 0x12, 0x66, 0x94, 0x61, 0xa7, 0x50, 0x8d, 0x57, 0x71, 0xfd, 0xf3,
+	0x25, 0x51, 0x6a, 0xf6, 0x23, 0xec, 0xac, 0xb2, 0xaf, 0xb6, 0xce, 0x78, 0x59, 0xd5, 0xde, 0xa1,
+	0x84, 0x24, 0x2a, 0x8d, 0xd3, 0x48, 0x3d, 0xfa, 0x55, 0xc3, 0x60, 0x2b, 0xa0, 0x23, 0xda, 0xf5,
+	0xd8, 0xee, 0x5a, 0x36, 0x1e, 0x85, 0x7a, 0x2d, 0x89, 0x10, 0x74, 0x1b, 0x8c, 0xee, 0x2d, 0xed,
+	0xe6, 0x30, 0xba, 0x26, 0xa7, 0x86, 0x6c, 0xa8, 0xea, 0xf8, 0xd0, 0x39, 0xb8, 0x20, 0xdd, 0x9b,
+	0x8c, 0xee, 0xf5, 0xb5, 0x6b, 0x43, 0xe8, 0x22, 0x07, 0x2a, 0x52, 0xbd, 0xd6, 0xdf, 0x8c, 0xc9,
+	0x12, 0xae, 0xba, 0x12, 0x62, 0x62, 0x15, 0x3c, 0xed, 0x5d, 0x46, 0xec, 0x6d, 0xea, 0x65, 0xf5,
+	0x34, 0x4b, 0xcb, 0xe9, 0x44, 0xf4, 0xa5, 0x68, 0x9c, 0x8c, 0xa4, 0x11, 0x25, 0x4f, 0x4a, 0x29,
+	0xaa, 0xaa, 0x57, 0xb3, 0xd0, 0x82, 0x81, 0x0f, 0x18, 0x03, 0xdf, 0xf9, 0x4c, 0x43, 0xc3, 0xe9,
+	0xcf, 0x0b, 0x57, 0x8b, 0x00, 0x7f, 0xa2, 0x00, 0x4a, 0x96, 0x28, 0x65, 0x6d, 0x44, 0x8b, 0xa1,
+	0x6a, 0x3d, 0xc0, 0x64, 0x94, 0x35, 0xb5, 0x3b, 0x8c, 0xa7, 0x77, 0x35, 0x16, 0x97, 0xcb, 0xc4,
+	0x1a, 0xa7, 0xd1, 0xa2, 0x29, 0xe3, 0x8f, 0xae, 0x42, 0x0f, 0x1c, 0x1d, 0xd3, 0xab, 0x66, 0x34,
+	0x1e, 0xce, 0xb0, 0xc8, 0x7b, 0x8c, 0xf8, 0x9a, 0xb6, 0x3a, 0x9c, 0xb8, 0xcb, 0x28, 0xa2, 0x01,
+	0xcc, 0xd3, 0x4e, 0x2b, 0xd3, 0x35, 0x8e, 0x2e, 0x4c, 0xfd, 0x16, 0xa3, 0x7e, 0x63, 0xed, 0xfa,
+	0x50, 0xea, 0xe8, 0x67, 0xe2, 0xd0, 0x91, 0xea, 0x22, 0xf2, 0xa1, 0x93, 0xac, 0x01, 0xa9, 0x57,
+	0x32, 0xb0, 0xd1, 0x60, 0x2e, 0xd3, 0x15, 0xe8, 0x27, 0xb7, 0x45, 0x91, 0xa4, 0xca, 0xf8, 0x93,
+	0x21, 0x3f, 0x84, 0x8a, 0x54, 0x6d, 0xf4, 0xa5, 0x4f, 0x96, 0x4a, 0xd5, 0x95, 0x14, 0x8c, 0xe0,
+	0xa0, 0xc6, 0x38, 0x40, 0x7c, 0x75, 0x8f, 0x4d, 0xe0, 0x6c, 0x3c, 0xf8, 0x07, 0xe5, 0xcb, 0xe6,
+	0xdf, 0x2b, 0xe8, 0x13, 0x98, 0xa2, 0xe3, 0xba, 0xc7, 0x13, 0x3a, 0xda, 0xdd, 0xe8, 0x18, 0xdd,
+	0xd8, 0x27, 0xa4, 0xef, 0xdd, 0x6f, 0x34, 0x78, 0x52, 0x77, 0xbd, 0xed, 0xf4, 0x1a, 0xed, 0x83,
+	0xbd, 0x3d, 0xa3, 0xdb, 0x6d, 0x98, 0xf8, 0x90, 0x65, 0x05, 0x36, 0xf2, 0x77, 0xd6, 0xdf, 0x5b,
+	0xcb, 0x29, 0xb9, 0x8d, 0xaa, 0xa4, 0xd2, 0xc6, 0x0b, 0xcf, 0xb1, 0xef, 0x27, 0x20, 0xfa, 0xb7,
+	0x20, 0x7f, 0xef, 0xbd, 0x7b, 0x68, 0x1d, 0xde, 0xd2, 0x31, 0x19, 0xb8, 0x36, 0x36, 0xeb, 0x47,
+	0xfb, 0xd8, 0xae, 0xbb, 0xd8, 0x73, 0x06, 0x6e, 0x1b, 0xd7, 0x4d, 0x07, 0x7b, 0xf6, 0x4d, 0x52,
+	0xc7, 0xc7, 0x96, 0x47, 0x50, 0x09, 0x0a, 0x7f, 0x99, 0x53, 0x26, 0xf6, 0x4a, 0xac, 0xdc, 0x72,
+	0xf7, 0x97, 0x03, 0x00, 0xff, 0x70, 0x5a, 0x28, 0x81, 0x40, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// TeamServiceClient is the client API for TeamService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TeamServiceClient interface {
+	CreateTeam(ctx context.Context, in *TeamUpsertRequest, opts ...grpc.CallOption) (*TeamUpsertResponse, error)
+	// Patches the fields of a team listed in update_mask: name, skills,
+	// open_roles, size and auto_close_applications
+	UpdateTeam(ctx context.Context, in *UpdateTeamRequest, opts ...grpc.CallOption) (*UpdateTeamResponse, error)
+	// DeleteTeam moves the team to the trash, it is purged after the retention
+	// period unless restored
+	DeleteTeam(ctx context.Context, in *TeamDeleteRequest, opts ...grpc.CallOption) (*TeamDeleteResponse, error)
+	RestoreTeam(ctx context.Context, in *RestoreTeamRequest, opts ...grpc.CallOption) (*RestoreTeamResponse, error)
+	// ListDeletedTeams lists the caller's teams in the trash
+	ListDeletedTeams(ctx context.Context, in *ListDeletedTeamsRequest, opts ...grpc.CallOption) (*ListDeletedTeamsResponse, error)
+	AddMember(ctx context.Context, in *MemberUpsertRequest, opts ...grpc.CallOption) (*MemberUpsertResponse, error)
+	RemoveMember(ctx context.Context, in *MemberDeleteRequest, opts ...grpc.CallOption) (*MemberDeleteResponse, error)
+	// UpsertTeamProject sets the team's active project, it updates the active
+	// project in place or creates one when the team has none
+	UpsertTeamProject(ctx context.Context, in *ProjectUpsertRequest, opts ...grpc.CallOption) (*ProjectUpsertResponse, error)
+	CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
+	// Patches the fields of a project listed in update_mask: name, description,
+	// github_link, complexity, duration, languages and status
+	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
+	// ArchiveProject hides a project that isn't active from the team
+	ArchiveProject(ctx context.Context, in *ArchiveProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
+	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
+	CreateMilestone(ctx context.Context, in *CreateMilestoneRequest, opts ...grpc.CallOption) (*MilestoneResponse, error)
+	// Patches the fields of a milestone listed in update_mask: title, assignee,
+	// due_at, status and position
+	UpdateMilestone(ctx context.Context, in *UpdateMilestoneRequest, opts ...grpc.CallOption) (*MilestoneResponse, error)
+	// DeleteMilestone deletes a milestone, its tasks stay on the project
+	DeleteMilestone(ctx context.Context, in *DeleteMilestoneRequest, opts ...grpc.CallOption) (*MilestoneResponse, error)
+	ListMilestones(ctx context.Context, in *ListMilestonesRequest, opts ...grpc.CallOption) (*ListMilestonesResponse, error)
+	CreateTask(ctx context.Context, in *CreateTaskRequest, opts ...grpc.CallOption) (*TaskResponse, error)
+	// Patches the fields of a task listed in update_mask: title, milestone_id,
+	// assignee, due_at, status and position
+	UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*TaskResponse, error)
+	DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*TaskResponse, error)
+	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	GetTeamByTeamId(ctx context.Context, in *GetByTeamIdRequest, opts ...grpc.CallOption) (*GetByTeamIdResponse, error)
+	GetTeamByTeamName(ctx context.Context, in *GetByTeamNameRequest, opts ...grpc.CallOption) (*GetByTeamNameResponse, error)
+	GetTeamsByUserId(ctx context.Context, in *GetByUserIdRequest, opts ...grpc.CallOption) (*GetByUserIdResponse, error)
+	GetTeamsByCurrentUser(ctx context.Context, in *GetByUserIdRequest, opts ...grpc.CallOption) (*GetByUserIdResponse, error)
+	GetTeams(ctx context.Context, in *GetTeamsRequest, opts ...grpc.CallOption) (*GetTeamsResponse, error)
+	// Hands a team over to one of its members, the previous owner stays on
+	// the team as an admin
+	TransferOwnership(ctx context.Context, in *TransferOwnershipRequest, opts ...grpc.CallOption) (*TransferOwnershipResponse, error)
+	// Invites a user by email to join a team, the invitation is pending until
+	// the invitee accepts or declines it, it is revoked or it expires
+	InviteMember(ctx context.Context, in *InviteMemberRequest, opts ...grpc.CallOption) (*InviteMemberResponse, error)
+	// Lists the invitations of a team to its owner and admins, or the
+	// invitations sent to the caller's email when team_id is empty
+	ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*ListInvitationsResponse, error)
+	// Joins the team of a pending invitation sent to the caller's email
+	AcceptInvitation(ctx context.Context, in *InvitationRequest, opts ...grpc.CallOption) (*AcceptInvitationResponse, error)
+	DeclineInvitation(ctx context.Context, in *InvitationRequest, opts ...grpc.CallOption) (*InvitationResponse, error)
+	// Withdraws a pending invitation, allowed to the team's owner and admins
+	RevokeInvitation(ctx context.Context, in *InvitationRequest, opts ...grpc.CallOption) (*InvitationResponse, error)
+	// Applies to join a team with open roles, the application is pending
+	// until the team approves or rejects it or the applicant withdraws it
+	ApplyToTeam(ctx context.Context, in *ApplyToTeamRequest, opts ...grpc.CallOption) (*ApplicationResponse, error)
+	// Lists the applications to a team to its owner and admins, or the
+	// caller's applications when team_id is empty
+	ListApplications(ctx context.Context, in *ListApplicationsRequest, opts ...grpc.CallOption) (*ListApplicationsResponse, error)
+	// Adds the applicant of a pending application to the team
+	ApproveApplication(ctx context.Context, in *ApplicationRequest, opts ...grpc.CallOption) (*ApproveApplicationResponse, error)
+	RejectApplication(ctx context.Context, in *ApplicationRequest, opts ...grpc.CallOption) (*ApplicationResponse, error)
+	// Withdraws a pending application, allowed to the applicant
+	WithdrawApplication(ctx context.Context, in *ApplicationRequest, opts ...grpc.CallOption) (*ApplicationResponse, error)
+	// Full-text search over team names, project names and descriptions,
+	// skills and languages, most relevant teams first
+	// Lists the audit trail of a team to its owner, support may list every
+	// team's trail when team_id is empty
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	SearchTeams(ctx context.Context, in *SearchTeamsRequest, opts ...grpc.CallOption) (*SearchTeamsResponse, error)
+}
+
+type teamServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTeamServiceClient(cc *grpc.ClientConn) TeamServiceClient {
+	return &teamServiceClient{cc}
+}
+
+func (c *teamServiceClient) CreateTeam(ctx context.Context, in *TeamUpsertRequest, opts ...grpc.CallOption) (*TeamUpsertResponse, error) {
+	out := new(TeamUpsertResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/CreateTeam", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) UpdateTeam(ctx context.Context, in *UpdateTeamRequest, opts ...grpc.CallOption) (*UpdateTeamResponse, error) {
+	out := new(UpdateTeamResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/UpdateTeam", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) DeleteTeam(ctx context.Context, in *TeamDeleteRequest, opts ...grpc.CallOption) (*TeamDeleteResponse, error) {
+	out := new(TeamDeleteResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/DeleteTeam", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) RestoreTeam(ctx context.Context, in *RestoreTeamRequest, opts ...grpc.CallOption) (*RestoreTeamResponse, error) {
+	out := new(RestoreTeamResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/RestoreTeam", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) ListDeletedTeams(ctx context.Context, in *ListDeletedTeamsRequest, opts ...grpc.CallOption) (*ListDeletedTeamsResponse, error) {
+	out := new(ListDeletedTeamsResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/ListDeletedTeams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) AddMember(ctx context.Context, in *MemberUpsertRequest, opts ...grpc.CallOption) (*MemberUpsertResponse, error) {
+	out := new(MemberUpsertResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/AddMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) RemoveMember(ctx context.Context, in *MemberDeleteRequest, opts ...grpc.CallOption) (*MemberDeleteResponse, error) {
+	out := new(MemberDeleteResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/RemoveMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) UpsertTeamProject(ctx context.Context, in *ProjectUpsertRequest, opts ...grpc.CallOption) (*ProjectUpsertResponse, error) {
+	out := new(ProjectUpsertResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/UpsertTeamProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/CreateProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/UpdateProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) ArchiveProject(ctx context.Context, in *ArchiveProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/ArchiveProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error) {
+	out := new(ListProjectsResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/ListProjects", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) CreateMilestone(ctx context.Context, in *CreateMilestoneRequest, opts ...grpc.CallOption) (*MilestoneResponse, error) {
+	out := new(MilestoneResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/CreateMilestone", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) UpdateMilestone(ctx context.Context, in *UpdateMilestoneRequest, opts ...grpc.CallOption) (*MilestoneResponse, error) {
+	out := new(MilestoneResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/UpdateMilestone", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) DeleteMilestone(ctx context.Context, in *DeleteMilestoneRequest, opts ...grpc.CallOption) (*MilestoneResponse, error) {
+	out := new(MilestoneResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/DeleteMilestone", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) ListMilestones(ctx context.Context, in *ListMilestonesRequest, opts ...grpc.CallOption) (*ListMilestonesResponse, error) {
+	out := new(ListMilestonesResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/ListMilestones", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) CreateTask(ctx context.Context, in *CreateTaskRequest, opts ...grpc.CallOption) (*TaskResponse, error) {
+	out := new(TaskResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/CreateTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*TaskResponse, error) {
+	out := new(TaskResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/UpdateTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*TaskResponse, error) {
+	out := new(TaskResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/DeleteTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/ListTasks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) GetTeamByTeamId(ctx context.Context, in *GetByTeamIdRequest, opts ...grpc.CallOption) (*GetByTeamIdResponse, error) {
+	out := new(GetByTeamIdResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/GetTeamByTeamId", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) GetTeamByTeamName(ctx context.Context, in *GetByTeamNameRequest, opts ...grpc.CallOption) (*GetByTeamNameResponse, error) {
+	out := new(GetByTeamNameResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/GetTeamByTeamName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) GetTeamsByUserId(ctx context.Context, in *GetByUserIdRequest, opts ...grpc.CallOption) (*GetByUserIdResponse, error) {
+	out := new(GetByUserIdResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/GetTeamsByUserId", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) GetTeamsByCurrentUser(ctx context.Context, in *GetByUserIdRequest, opts ...grpc.CallOption) (*GetByUserIdResponse, error) {
+	out := new(GetByUserIdResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/GetTeamsByCurrentUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) GetTeams(ctx context.Context, in *GetTeamsRequest, opts ...grpc.CallOption) (*GetTeamsResponse, error) {
+	out := new(GetTeamsResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/GetTeams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) TransferOwnership(ctx context.Context, in *TransferOwnershipRequest, opts ...grpc.CallOption) (*TransferOwnershipResponse, error) {
+	out := new(TransferOwnershipResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/TransferOwnership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) InviteMember(ctx context.Context, in *InviteMemberRequest, opts ...grpc.CallOption) (*InviteMemberResponse, error) {
+	out := new(InviteMemberResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/InviteMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*ListInvitationsResponse, error) {
+	out := new(ListInvitationsResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/ListInvitations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) AcceptInvitation(ctx context.Context, in *InvitationRequest, opts ...grpc.CallOption) (*AcceptInvitationResponse, error) {
+	out := new(AcceptInvitationResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/AcceptInvitation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) DeclineInvitation(ctx context.Context, in *InvitationRequest, opts ...grpc.CallOption) (*InvitationResponse, error) {
+	out := new(InvitationResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/DeclineInvitation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) RevokeInvitation(ctx context.Context, in *InvitationRequest, opts ...grpc.CallOption) (*InvitationResponse, error) {
+	out := new(InvitationResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/RevokeInvitation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) ApplyToTeam(ctx context.Context, in *ApplyToTeamRequest, opts ...grpc.CallOption) (*ApplicationResponse, error) {
+	out := new(ApplicationResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/ApplyToTeam", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) ListApplications(ctx context.Context, in *ListApplicationsRequest, opts ...grpc.CallOption) (*ListApplicationsResponse, error) {
+	out := new(ListApplicationsResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/ListApplications", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) ApproveApplication(ctx context.Context, in *ApplicationRequest, opts ...grpc.CallOption) (*ApproveApplicationResponse, error) {
+	out := new(ApproveApplicationResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/ApproveApplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) RejectApplication(ctx context.Context, in *ApplicationRequest, opts ...grpc.CallOption) (*ApplicationResponse, error) {
+	out := new(ApplicationResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/RejectApplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) WithdrawApplication(ctx context.Context, in *ApplicationRequest, opts ...grpc.CallOption) (*ApplicationResponse, error) {
+	out := new(ApplicationResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/WithdrawApplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamServiceClient) SearchTeams(ctx context.Context, in *SearchTeamsRequest, opts ...grpc.CallOption) (*SearchTeamsResponse, error) {
+	out := new(SearchTeamsResponse)
+	err := c.cc.Invoke(ctx, "/team.TeamService/SearchTeams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TeamServiceServer is the server API for TeamService service.
+type TeamServiceServer interface {
+	CreateTeam(context.Context, *TeamUpsertRequest) (*TeamUpsertResponse, error)
+	// Patches the fields of a team listed in update_mask: name, skills,
+	// open_roles, size and auto_close_applications
+	UpdateTeam(context.Context, *UpdateTeamRequest) (*UpdateTeamResponse, error)
+	// DeleteTeam moves the team to the trash, it is purged after the retention
+	// period unless restored
+	DeleteTeam(context.Context, *TeamDeleteRequest) (*TeamDeleteResponse, error)
+	RestoreTeam(context.Context, *RestoreTeamRequest) (*RestoreTeamResponse, error)
+	// ListDeletedTeams lists the caller's teams in the trash
+	ListDeletedTeams(context.Context, *ListDeletedTeamsRequest) (*ListDeletedTeamsResponse, error)
+	AddMember(context.Context, *MemberUpsertRequest) (*MemberUpsertResponse, error)
+	RemoveMember(context.Context, *MemberDeleteRequest) (*MemberDeleteResponse, error)
+	// UpsertTeamProject sets the team's active project, it updates the active
+	// project in place or creates one when the team has none
+	UpsertTeamProject(context.Context, *ProjectUpsertRequest) (*ProjectUpsertResponse, error)
+	CreateProject(context.Context, *CreateProjectRequest) (*ProjectResponse, error)
+	// Patches the fields of a project listed in update_mask: name, description,
+	// github_link, complexity, duration, languages and status
+	UpdateProject(context.Context, *UpdateProjectRequest) (*ProjectResponse, error)
+	// ArchiveProject hides a project that isn't active from the team
+	ArchiveProject(context.Context, *ArchiveProjectRequest) (*ProjectResponse, error)
+	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
+	CreateMilestone(context.Context, *CreateMilestoneRequest) (*MilestoneResponse, error)
+	// Patches the fields of a milestone listed in update_mask: title, assignee,
+	// due_at, status and position
+	UpdateMilestone(context.Context, *UpdateMilestoneRequest) (*MilestoneResponse, error)
+	// DeleteMilestone deletes a milestone, its tasks stay on the project
+	DeleteMilestone(context.Context, *DeleteMilestoneRequest) (*MilestoneResponse, error)
+	ListMilestones(context.Context, *ListMilestonesRequest) (*ListMilestonesResponse, error)
+	CreateTask(context.Context, *CreateTaskRequest) (*TaskResponse, error)
+	// Patches the fields of a task listed in update_mask: title, milestone_id,
+	// assignee, due_at, status and position
+	UpdateTask(context.Context, *UpdateTaskRequest) (*TaskResponse, error)
+	DeleteTask(context.Context, *DeleteTaskRequest) (*TaskResponse, error)
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	GetTeamByTeamId(context.Context, *GetByTeamIdRequest) (*GetByTeamIdResponse, error)
+	GetTeamByTeamName(context.Context, *GetByTeamNameRequest) (*GetByTeamNameResponse, error)
+	GetTeamsByUserId(context.Context, *GetByUserIdRequest) (*GetByUserIdResponse, error)
+	GetTeamsByCurrentUser(context.Context, *GetByUserIdRequest) (*GetByUserIdResponse, error)
+	GetTeams(context.Context, *GetTeamsRequest) (*GetTeamsResponse, error)
+	// Hands a team over to one of its members, the previous owner stays on
+	// the team as an admin
+	TransferOwnership(context.Context, *TransferOwnershipRequest) (*TransferOwnershipResponse, error)
+	// Invites a user by email to join a team, the invitation is pending until
+	// the invitee accepts or declines it, it is revoked or it expires
+	InviteMember(context.Context, *InviteMemberRequest) (*InviteMemberResponse, error)
+	// Lists the invitations of a team to its owner and admins, or the
+	// invitations sent to the caller's email when team_id is empty
+	ListInvitations(context.Context, *ListInvitationsRequest) (*ListInvitationsResponse, error)
+	// Joins the team of a pending invitation sent to the caller's email
+	AcceptInvitation(context.Context, *InvitationRequest) (*AcceptInvitationResponse, error)
+	DeclineInvitation(context.Context, *InvitationRequest) (*InvitationResponse, error)
+	// Withdraws a pending invitation, allowed to the team's owner and admins
+	RevokeInvitation(context.Context, *InvitationRequest) (*InvitationResponse, error)
+	// Applies to join a team with open roles, the application is pending
+	// until the team approves or rejects it or the applicant withdraws it
+	ApplyToTeam(context.Context, *ApplyToTeamRequest) (*ApplicationResponse, error)
+	// Lists the applications to a team to its owner and admins, or the
+	// caller's applications when team_id is empty
+	ListApplications(context.Context, *ListApplicationsRequest) (*ListApplicationsResponse, error)
+	// Adds the applicant of a pending application to the team
+	ApproveApplication(context.Context, *ApplicationRequest) (*ApproveApplicationResponse, error)
+	RejectApplication(context.Context, *ApplicationRequest) (*ApplicationResponse, error)
+	// Withdraws a pending application, allowed to the applicant
+	WithdrawApplication(context.Context, *ApplicationRequest) (*ApplicationResponse, error)
+	// Full-text search over team names, project names and descriptions,
+	// skills and languages, most relevant teams first
+	// Lists the audit trail of a team to its owner, support may list every
+	// team's trail when team_id is empty
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	SearchTeams(context.Context, *SearchTeamsRequest) (*SearchTeamsResponse, error)
+}
+
+// UnimplementedTeamServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedTeamServiceServer struct {
+}
+
+func (*UnimplementedTeamServiceServer) CreateTeam(ctx context.Context, req *TeamUpsertRequest) (*TeamUpsertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTeam not implemented")
+}
+func (*UnimplementedTeamServiceServer) UpdateTeam(ctx context.Context, req *UpdateTeamRequest) (*UpdateTeamResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTeam not implemented")
+}
+func (*UnimplementedTeamServiceServer) DeleteTeam(ctx context.Context, req *TeamDeleteRequest) (*TeamDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTeam not implemented")
+}
+func (*UnimplementedTeamServiceServer) RestoreTeam(ctx context.Context, req *RestoreTeamRequest) (*RestoreTeamResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTeam not implemented")
+}
+func (*UnimplementedTeamServiceServer) ListDeletedTeams(ctx context.Context, req *ListDeletedTeamsRequest) (*ListDeletedTeamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedTeams not implemented")
+}
+func (*UnimplementedTeamServiceServer) AddMember(ctx context.Context, req *MemberUpsertRequest) (*MemberUpsertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMember not implemented")
+}
+func (*UnimplementedTeamServiceServer) RemoveMember(ctx context.Context, req *MemberDeleteRequest) (*MemberDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (*UnimplementedTeamServiceServer) UpsertTeamProject(ctx context.Context, req *ProjectUpsertRequest) (*ProjectUpsertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertTeamProject not implemented")
+}
+func (*UnimplementedTeamServiceServer) CreateProject(ctx context.Context, req *CreateProjectRequest) (*ProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProject not implemented")
+}
+func (*UnimplementedTeamServiceServer) UpdateProject(ctx context.Context, req *UpdateProjectRequest) (*ProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProject not implemented")
+}
+func (*UnimplementedTeamServiceServer) ArchiveProject(ctx context.Context, req *ArchiveProjectRequest) (*ProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveProject not implemented")
+}
+func (*UnimplementedTeamServiceServer) ListProjects(ctx context.Context, req *ListProjectsRequest) (*ListProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
+}
+func (*UnimplementedTeamServiceServer) CreateMilestone(ctx context.Context, req *CreateMilestoneRequest) (*MilestoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMilestone not implemented")
+}
+func (*UnimplementedTeamServiceServer) UpdateMilestone(ctx context.Context, req *UpdateMilestoneRequest) (*MilestoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMilestone not implemented")
+}
+func (*UnimplementedTeamServiceServer) DeleteMilestone(ctx context.Context, req *DeleteMilestoneRequest) (*MilestoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMilestone not implemented")
+}
+func (*UnimplementedTeamServiceServer) ListMilestones(ctx context.Context, req *ListMilestonesRequest) (*ListMilestonesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMilestones not implemented")
+}
+func (*UnimplementedTeamServiceServer) CreateTask(ctx context.Context, req *CreateTaskRequest) (*TaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTask not implemented")
+}
+func (*UnimplementedTeamServiceServer) UpdateTask(ctx context.Context, req *UpdateTaskRequest) (*TaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTask not implemented")
+}
+func (*UnimplementedTeamServiceServer) DeleteTask(ctx context.Context, req *DeleteTaskRequest) (*TaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
+}
+func (*UnimplementedTeamServiceServer) ListTasks(ctx context.Context, req *ListTasksRequest) (*ListTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (*UnimplementedTeamServiceServer) GetTeamByTeamId(ctx context.Context, req *GetByTeamIdRequest) (*GetByTeamIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeamByTeamId not implemented")
+}
+func (*UnimplementedTeamServiceServer) GetTeamByTeamName(ctx context.Context, req *GetByTeamNameRequest) (*GetByTeamNameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeamByTeamName not implemented")
+}
+func (*UnimplementedTeamServiceServer) GetTeamsByUserId(ctx context.Context, req *GetByUserIdRequest) (*GetByUserIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeamsByUserId not implemented")
+}
+func (*UnimplementedTeamServiceServer) GetTeamsByCurrentUser(ctx context.Context, req *GetByUserIdRequest) (*GetByUserIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeamsByCurrentUser not implemented")
+}
+func (*UnimplementedTeamServiceServer) GetTeams(ctx context.Context, req *GetTeamsRequest) (*GetTeamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeams not implemented")
+}
+func (*UnimplementedTeamServiceServer) TransferOwnership(ctx context.Context, req *TransferOwnershipRequest) (*TransferOwnershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferOwnership not implemented")
+}
+func (*UnimplementedTeamServiceServer) InviteMember(ctx context.Context, req *InviteMemberRequest) (*InviteMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteMember not implemented")
+}
+func (*UnimplementedTeamServiceServer) ListInvitations(ctx context.Context, req *ListInvitationsRequest) (*ListInvitationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvitations not implemented")
+}
+func (*UnimplementedTeamServiceServer) AcceptInvitation(ctx context.Context, req *InvitationRequest) (*AcceptInvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvitation not implemented")
+}
+func (*UnimplementedTeamServiceServer) DeclineInvitation(ctx context.Context, req *InvitationRequest) (*InvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineInvitation not implemented")
+}
+func (*UnimplementedTeamServiceServer) RevokeInvitation(ctx context.Context, req *InvitationRequest) (*InvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeInvitation not implemented")
+}
+func (*UnimplementedTeamServiceServer) ApplyToTeam(ctx context.Context, req *ApplyToTeamRequest) (*ApplicationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyToTeam not implemented")
+}
+func (*UnimplementedTeamServiceServer) ListApplications(ctx context.Context, req *ListApplicationsRequest) (*ListApplicationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApplications not implemented")
+}
+func (*UnimplementedTeamServiceServer) ApproveApplication(ctx context.Context, req *ApplicationRequest) (*ApproveApplicationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveApplication not implemented")
+}
+func (*UnimplementedTeamServiceServer) RejectApplication(ctx context.Context, req *ApplicationRequest) (*ApplicationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectApplication not implemented")
+}
+func (*UnimplementedTeamServiceServer) WithdrawApplication(ctx context.Context, req *ApplicationRequest) (*ApplicationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WithdrawApplication not implemented")
+}
+func (*UnimplementedTeamServiceServer) ListAuditEvents(ctx context.Context, req *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (*UnimplementedTeamServiceServer) SearchTeams(ctx context.Context, req *SearchTeamsRequest) (*SearchTeamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTeams not implemented")
+}
+
+func RegisterTeamServiceServer(s *grpc.Server, srv TeamServiceServer) {
+	s.RegisterService(&_TeamService_serviceDesc, srv)
+}
+
+func _TeamService_CreateTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TeamUpsertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).CreateTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/CreateTeam",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).CreateTeam(ctx, req.(*TeamUpsertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_UpdateTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTeamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).UpdateTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/UpdateTeam",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).UpdateTeam(ctx, req.(*UpdateTeamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_DeleteTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TeamDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).DeleteTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/DeleteTeam",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).DeleteTeam(ctx, req.(*TeamDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_RestoreTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTeamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).RestoreTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/RestoreTeam",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).RestoreTeam(ctx, req.(*RestoreTeamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_ListDeletedTeams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedTeamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).ListDeletedTeams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/ListDeletedTeams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).ListDeletedTeams(ctx, req.(*ListDeletedTeamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberUpsertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).AddMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/AddMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).AddMember(ctx, req.(*MemberUpsertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).RemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/RemoveMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).RemoveMember(ctx, req.(*MemberDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_UpsertTeamProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectUpsertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).UpsertTeamProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/UpsertTeamProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).UpsertTeamProject(ctx, req.(*ProjectUpsertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_CreateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).CreateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/CreateProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).CreateProject(ctx, req.(*CreateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_UpdateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).UpdateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/UpdateProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).UpdateProject(ctx, req.(*UpdateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_ArchiveProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).ArchiveProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/ArchiveProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).ArchiveProject(ctx, req.(*ArchiveProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).ListProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/ListProjects",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).ListProjects(ctx, req.(*ListProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_CreateMilestone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMilestoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).CreateMilestone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/CreateMilestone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).CreateMilestone(ctx, req.(*CreateMilestoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_UpdateMilestone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMilestoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).UpdateMilestone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/UpdateMilestone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).UpdateMilestone(ctx, req.(*UpdateMilestoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_DeleteMilestone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMilestoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).DeleteMilestone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/DeleteMilestone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).DeleteMilestone(ctx, req.(*DeleteMilestoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_ListMilestones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMilestonesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).ListMilestones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/ListMilestones",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).ListMilestones(ctx, req.(*ListMilestonesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_CreateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).CreateTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/CreateTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).CreateTask(ctx, req.(*CreateTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_UpdateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).UpdateTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/UpdateTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).UpdateTask(ctx, req.(*UpdateTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_DeleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).DeleteTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/DeleteTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).DeleteTask(ctx, req.(*DeleteTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/ListTasks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).ListTasks(ctx, req.(*ListTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_GetTeamByTeamId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByTeamIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).GetTeamByTeamId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/GetTeamByTeamId",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).GetTeamByTeamId(ctx, req.(*GetByTeamIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_GetTeamByTeamName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByTeamNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).GetTeamByTeamName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/GetTeamByTeamName",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).GetTeamByTeamName(ctx, req.(*GetByTeamNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeamService_GetTeamsByUserId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByUserIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServiceServer).GetTeamsByUserId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/GetTeamsByUserId",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).GetTeamsByUserId(ctx, req.(*GetByUserIdRequest))
 	}
-	return out, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *teamServiceClient) AddMember(ctx context.Context, in *MemberUpsertRequest, opts ...grpc.CallOption) (*MemberUpsertResponse, error) {
-	out := new(MemberUpsertResponse)
-	err := c.cc.Invoke(ctx, "/team.TeamService/AddMember", in, out, opts...)
-	if err != nil {
+func _TeamService_GetTeamsByCurrentUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByUserIdRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *teamServiceClient) RemoveMember(ctx context.Context, in *MemberDeleteRequest, opts ...grpc.CallOption) (*MemberDeleteResponse, error) {
-	out := new(MemberDeleteResponse)
-	err := c.cc.Invoke(ctx, "/team.TeamService/RemoveMember", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(TeamServiceServer).GetTeamsByCurrentUser(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/GetTeamsByCurrentUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).GetTeamsByCurrentUser(ctx, req.(*GetByUserIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *teamServiceClient) UpsertTeamProject(ctx context.Context, in *ProjectUpsertRequest, opts ...grpc.CallOption) (*ProjectUpsertResponse, error) {
-	out := new(ProjectUpsertResponse)
-	err := c.cc.Invoke(ctx, "/team.TeamService/UpsertTeamProject", in, out, opts...)
-	if err != nil {
+func _TeamService_GetTeams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTeamsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(TeamServiceServer).GetTeams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/GetTeams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).GetTeams(ctx, req.(*GetTeamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *teamServiceClient) GetTeamByTeamName(ctx context.Context, in *GetByTeamNameRequest, opts ...grpc.CallOption) (*GetByTeamNameResponse, error) {
-	out := new(GetByTeamNameResponse)
-	err := c.cc.Invoke(ctx, "/team.TeamService/GetTeamByTeamName", in, out, opts...)
-	if err != nil {
+func _TeamService_TransferOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferOwnershipRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(TeamServiceServer).TransferOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/TransferOwnership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).TransferOwnership(ctx, req.(*TransferOwnershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *teamServiceClient) GetTeamsByUserId(ctx context.Context, in *GetByUserIdRequest, opts ...grpc.CallOption) (*GetByUserIdResponse, error) {
-	out := new(GetByUserIdResponse)
-	err := c.cc.Invoke(ctx, "/team.TeamService/GetTeamsByUserId", in, out, opts...)
-	if err != nil {
+func _TeamService_InviteMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteMemberRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(TeamServiceServer).InviteMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/InviteMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).InviteMember(ctx, req.(*InviteMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *teamServiceClient) GetTeamsByCurrentUser(ctx context.Context, in *GetByUserIdRequest, opts ...grpc.CallOption) (*GetByUserIdResponse, error) {
-	out := new(GetByUserIdResponse)
-	err := c.cc.Invoke(ctx, "/team.TeamService/GetTeamsByCurrentUser", in, out, opts...)
-	if err != nil {
+func _TeamService_ListInvitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitationsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(TeamServiceServer).ListInvitations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/ListInvitations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).ListInvitations(ctx, req.(*ListInvitationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *teamServiceClient) GetTeams(ctx context.Context, in *GetTeamsRequest, opts ...grpc.CallOption) (*GetTeamsResponse, error) {
-	out := new(GetTeamsResponse)
-	err := c.cc.Invoke(ctx, "/team.TeamService/GetTeams", in, out, opts...)
-	if err != nil {
+func _TeamService_AcceptInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvitationRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// TeamServiceServer is the server API for TeamService service.
-type TeamServiceServer interface {
-	CreateTeam(context.Context, *TeamUpsertRequest) (*TeamUpsertResponse, error)
-	DeleteTeam(context.Context, *TeamDeleteRequest) (*TeamDeleteResponse, error)
-	AddMember(context.Context, *MemberUpsertRequest) (*MemberUpsertResponse, error)
-	RemoveMember(context.Context, *MemberDeleteRequest) (*MemberDeleteResponse, error)
-	UpsertTeamProject(context.Context, *ProjectUpsertRequest) (*ProjectUpsertResponse, error)
-	GetTeamByTeamName(context.Context, *GetByTeamNameRequest) (*GetByTeamNameResponse, error)
-	GetTeamsByUserId(context.Context, *GetByUserIdRequest) (*GetByUserIdResponse, error)
-	GetTeamsByCurrentUser(context.Context, *GetByUserIdRequest) (*GetByUserIdResponse, error)
-	GetTeams(context.Context, *GetTeamsRequest) (*GetTeamsResponse, error)
-}
-
-// UnimplementedTeamServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedTeamServiceServer struct {
-}
-
-func (*UnimplementedTeamServiceServer) CreateTeam(ctx context.Context, req *TeamUpsertRequest) (*TeamUpsertResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateTeam not implemented")
-}
-func (*UnimplementedTeamServiceServer) DeleteTeam(ctx context.Context, req *TeamDeleteRequest) (*TeamDeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteTeam not implemented")
-}
-func (*UnimplementedTeamServiceServer) AddMember(ctx context.Context, req *MemberUpsertRequest) (*MemberUpsertResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddMember not implemented")
-}
-func (*UnimplementedTeamServiceServer) RemoveMember(ctx context.Context, req *MemberDeleteRequest) (*MemberDeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
-}
-func (*UnimplementedTeamServiceServer) UpsertTeamProject(ctx context.Context, req *ProjectUpsertRequest) (*ProjectUpsertResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpsertTeamProject not implemented")
-}
-func (*UnimplementedTeamServiceServer) GetTeamByTeamName(ctx context.Context, req *GetByTeamNameRequest) (*GetByTeamNameResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTeamByTeamName not implemented")
-}
-func (*UnimplementedTeamServiceServer) GetTeamsByUserId(ctx context.Context, req *GetByUserIdRequest) (*GetByUserIdResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTeamsByUserId not implemented")
-}
-func (*UnimplementedTeamServiceServer) GetTeamsByCurrentUser(ctx context.Context, req *GetByUserIdRequest) (*GetByUserIdResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTeamsByCurrentUser not implemented")
-}
-func (*UnimplementedTeamServiceServer) GetTeams(ctx context.Context, req *GetTeamsRequest) (*GetTeamsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTeams not implemented")
-}
-
-func RegisterTeamServiceServer(s *grpc.Server, srv TeamServiceServer) {
-	s.RegisterService(&_TeamService_serviceDesc, srv)
+	if interceptor == nil {
+		return srv.(TeamServiceServer).AcceptInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.TeamService/AcceptInvitation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServiceServer).AcceptInvitation(ctx, req.(*InvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _TeamService_CreateTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TeamUpsertRequest)
+func _TeamService_DeclineInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvitationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TeamServiceServer).CreateTeam(ctx, in)
+		return srv.(TeamServiceServer).DeclineInvitation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/team.TeamService/CreateTeam",
+		FullMethod: "/team.TeamService/DeclineInvitation",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TeamServiceServer).CreateTeam(ctx, req.(*TeamUpsertRequest))
+		return srv.(TeamServiceServer).DeclineInvitation(ctx, req.(*InvitationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TeamService_DeleteTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TeamDeleteRequest)
+func _TeamService_RevokeInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvitationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TeamServiceServer).DeleteTeam(ctx, in)
+		return srv.(TeamServiceServer).RevokeInvitation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/team.TeamService/DeleteTeam",
+		FullMethod: "/team.TeamService/RevokeInvitation",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TeamServiceServer).DeleteTeam(ctx, req.(*TeamDeleteRequest))
+		return srv.(TeamServiceServer).RevokeInvitation(ctx, req.(*InvitationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TeamService_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MemberUpsertRequest)
+func _TeamService_ApplyToTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyToTeamRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TeamServiceServer).AddMember(ctx, in)
+		return srv.(TeamServiceServer).ApplyToTeam(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/team.TeamService/AddMember",
+		FullMethod: "/team.TeamService/ApplyToTeam",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TeamServiceServer).AddMember(ctx, req.(*MemberUpsertRequest))
+		return srv.(TeamServiceServer).ApplyToTeam(ctx, req.(*ApplyToTeamRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TeamService_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MemberDeleteRequest)
+func _TeamService_ListApplications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApplicationsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TeamServiceServer).RemoveMember(ctx, in)
+		return srv.(TeamServiceServer).ListApplications(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/team.TeamService/RemoveMember",
+		FullMethod: "/team.TeamService/ListApplications",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TeamServiceServer).RemoveMember(ctx, req.(*MemberDeleteRequest))
+		return srv.(TeamServiceServer).ListApplications(ctx, req.(*ListApplicationsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TeamService_UpsertTeamProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ProjectUpsertRequest)
+func _TeamService_ApproveApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplicationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TeamServiceServer).UpsertTeamProject(ctx, in)
+		return srv.(TeamServiceServer).ApproveApplication(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/team.TeamService/UpsertTeamProject",
+		FullMethod: "/team.TeamService/ApproveApplication",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TeamServiceServer).UpsertTeamProject(ctx, req.(*ProjectUpsertRequest))
+		return srv.(TeamServiceServer).ApproveApplication(ctx, req.(*ApplicationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TeamService_GetTeamByTeamName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetByTeamNameRequest)
+func _TeamService_RejectApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplicationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TeamServiceServer).GetTeamByTeamName(ctx, in)
+		return srv.(TeamServiceServer).RejectApplication(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/team.TeamService/GetTeamByTeamName",
+		FullMethod: "/team.TeamService/RejectApplication",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TeamServiceServer).GetTeamByTeamName(ctx, req.(*GetByTeamNameRequest))
+		return srv.(TeamServiceServer).RejectApplication(ctx, req.(*ApplicationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TeamService_GetTeamsByUserId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetByUserIdRequest)
+func _TeamService_WithdrawApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplicationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TeamServiceServer).GetTeamsByUserId(ctx, in)
+		return srv.(TeamServiceServer).WithdrawApplication(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/team.TeamService/GetTeamsByUserId",
+		FullMethod: "/team.TeamService/WithdrawApplication",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TeamServiceServer).GetTeamsByUserId(ctx, req.(*GetByUserIdRequest))
+		return srv.(TeamServiceServer).WithdrawApplication(ctx, req.(*ApplicationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TeamService_GetTeamsByCurrentUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetByUserIdRequest)
+func _TeamService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TeamServiceServer).GetTeamsByCurrentUser(ctx, in)
+		return srv.(TeamServiceServer).ListAuditEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/team.TeamService/GetTeamsByCurrentUser",
+		FullMethod: "/team.TeamService/ListAuditEvents",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TeamServiceServer).GetTeamsByCurrentUser(ctx, req.(*GetByUserIdRequest))
+		return srv.(TeamServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TeamService_GetTeams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTeamsRequest)
+func _TeamService_SearchTeams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTeamsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TeamServiceServer).GetTeams(ctx, in)
+		return srv.(TeamServiceServer).SearchTeams(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/team.TeamService/GetTeams",
+		FullMethod: "/team.TeamService/SearchTeams",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TeamServiceServer).GetTeams(ctx, req.(*GetTeamsRequest))
+		return srv.(TeamServiceServer).SearchTeams(ctx, req.(*SearchTeamsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1647,10 +7002,22 @@ var _TeamService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateTeam",
 			Handler:    _TeamService_CreateTeam_Handler,
 		},
+		{
+			MethodName: "UpdateTeam",
+			Handler:    _TeamService_UpdateTeam_Handler,
+		},
 		{
 			MethodName: "DeleteTeam",
 			Handler:    _TeamService_DeleteTeam_Handler,
 		},
+		{
+			MethodName: "RestoreTeam",
+			Handler:    _TeamService_RestoreTeam_Handler,
+		},
+		{
+			MethodName: "ListDeletedTeams",
+			Handler:    _TeamService_ListDeletedTeams_Handler,
+		},
 		{
 			MethodName: "AddMember",
 			Handler:    _TeamService_AddMember_Handler,
@@ -1663,6 +7030,58 @@ var _TeamService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpsertTeamProject",
 			Handler:    _TeamService_UpsertTeamProject_Handler,
 		},
+		{
+			MethodName: "CreateProject",
+			Handler:    _TeamService_CreateProject_Handler,
+		},
+		{
+			MethodName: "UpdateProject",
+			Handler:    _TeamService_UpdateProject_Handler,
+		},
+		{
+			MethodName: "ArchiveProject",
+			Handler:    _TeamService_ArchiveProject_Handler,
+		},
+		{
+			MethodName: "ListProjects",
+			Handler:    _TeamService_ListProjects_Handler,
+		},
+		{
+			MethodName: "CreateMilestone",
+			Handler:    _TeamService_CreateMilestone_Handler,
+		},
+		{
+			MethodName: "UpdateMilestone",
+			Handler:    _TeamService_UpdateMilestone_Handler,
+		},
+		{
+			MethodName: "DeleteMilestone",
+			Handler:    _TeamService_DeleteMilestone_Handler,
+		},
+		{
+			MethodName: "ListMilestones",
+			Handler:    _TeamService_ListMilestones_Handler,
+		},
+		{
+			MethodName: "CreateTask",
+			Handler:    _TeamService_CreateTask_Handler,
+		},
+		{
+			MethodName: "UpdateTask",
+			Handler:    _TeamService_UpdateTask_Handler,
+		},
+		{
+			MethodName: "DeleteTask",
+			Handler:    _TeamService_DeleteTask_Handler,
+		},
+		{
+			MethodName: "ListTasks",
+			Handler:    _TeamService_ListTasks_Handler,
+		},
+		{
+			MethodName: "GetTeamByTeamId",
+			Handler:    _TeamService_GetTeamByTeamId_Handler,
+		},
 		{
 			MethodName: "GetTeamByTeamName",
 			Handler:    _TeamService_GetTeamByTeamName_Handler,
@@ -1679,6 +7098,58 @@ var _TeamService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetTeams",
 			Handler:    _TeamService_GetTeams_Handler,
 		},
+		{
+			MethodName: "TransferOwnership",
+			Handler:    _TeamService_TransferOwnership_Handler,
+		},
+		{
+			MethodName: "InviteMember",
+			Handler:    _TeamService_InviteMember_Handler,
+		},
+		{
+			MethodName: "ListInvitations",
+			Handler:    _TeamService_ListInvitations_Handler,
+		},
+		{
+			MethodName: "AcceptInvitation",
+			Handler:    _TeamService_AcceptInvitation_Handler,
+		},
+		{
+			MethodName: "DeclineInvitation",
+			Handler:    _TeamService_DeclineInvitation_Handler,
+		},
+		{
+			MethodName: "RevokeInvitation",
+			Handler:    _TeamService_RevokeInvitation_Handler,
+		},
+		{
+			MethodName: "ApplyToTeam",
+			Handler:    _TeamService_ApplyToTeam_Handler,
+		},
+		{
+			MethodName: "ListApplications",
+			Handler:    _TeamService_ListApplications_Handler,
+		},
+		{
+			MethodName: "ApproveApplication",
+			Handler:    _TeamService_ApproveApplication_Handler,
+		},
+		{
+			MethodName: "RejectApplication",
+			Handler:    _TeamService_RejectApplication_Handler,
+		},
+		{
+			MethodName: "WithdrawApplication",
+			Handler:    _TeamService_WithdrawApplication_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _TeamService_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "SearchTeams",
+			Handler:    _TeamService_SearchTeams_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "team.proto",
@@ -0,0 +1,543 @@
+package team
+
+import (
+  "fmt"
+
+  "github.com/ckbball/dev-team/pkg/validate"
+)
+
+// column limits of sql/migrations
+const (
+  maxTeamNameLen           = 25
+  maxEmailLen              = 255
+  maxMemberRoleLen         = 40
+  maxSkillLen              = 100
+  maxGoalLen               = 1200
+  maxProjectNameLen        = 30
+  maxGithubLinkLen         = 255
+  maxLanguageLen           = 100
+  maxApplicationMessageLen = 1000
+  maxActorLen              = 255
+  maxAuditActionLen        = 40
+  maxWorkTitleLen          = 200
+  // maxPageSize caps GetTeamsRequest.limit
+  maxPageSize = 100
+  // maxPageTokenLen is far above the length of tokens GetTeams issues
+  maxPageTokenLen = 512
+  // maxFilterValues caps the values of a repeated GetTeams filter
+  maxFilterValues = 20
+  // maxQueryLen bounds the text of a search
+  maxQueryLen = 200
+)
+
+// access roles a member may be granted, owners lead the team
+var accessRoles = []string{"", "admin", "member", "viewer"}
+
+// updatableTeamFields are the Team fields UpdateTeam may change
+var updatableTeamFields = []string{"name", "skills", "open_roles", "size", "auto_close_applications"}
+
+// statuses of an application
+var applicationStatuses = []string{"pending", "approved", "rejected", "withdrawn", "closed"}
+
+// statuses of a project
+var projectStatuses = []string{"planning", "active", "completed", "abandoned"}
+
+// updatableProjectFields are the Project fields UpdateProject may change
+var updatableProjectFields = []string{"name", "description", "github_link", "complexity", "duration", "languages", "status"}
+
+// statuses of a milestone or task
+var workStatuses = []string{"todo", "in_progress", "done"}
+
+// updatableMilestoneFields are the Milestone fields UpdateMilestone may change
+var updatableMilestoneFields = []string{"title", "assignee", "due_at", "status", "position"}
+
+// updatableTaskFields are the Task fields UpdateTask may change
+var updatableTaskFields = []string{"title", "milestone_id", "assignee", "due_at", "status", "position"}
+
+// Validate checks the request is well formed, every request message of
+// team.proto implements it and the validation interceptor enforces it.
+func (m *TeamUpsertRequest) Validate() error {
+  v := validate.New()
+  if m.Team == nil {
+    v.Violation("team", "is required")
+  } else {
+    m.Team.validate(v.Nested("team"))
+  }
+  return v.Err()
+}
+
+// Validate checks the fields of team listed in update_mask, other fields
+// are ignored by UpdateTeam
+func (m *UpdateTeamRequest) Validate() error {
+  v := validate.New()
+  v.Field("id", m.Id, validate.Required, validate.Id)
+  paths := m.GetUpdateMask().GetPaths()
+  v.Field("update_mask.paths", paths, validate.Required, validate.Each(validate.OneOf(updatableTeamFields...)))
+  v.Field("expected_version", m.ExpectedVersion, validate.Min(0))
+  if m.Team == nil {
+    v.Violation("team", "is required")
+    return v.Err()
+  }
+
+  team := v.Nested("team")
+  masked := map[string]bool{}
+  for _, path := range paths {
+    masked[path] = true
+  }
+  if masked["name"] {
+    team.Field("name", m.Team.Name, validate.Required, validate.MaxLen(maxTeamNameLen))
+  }
+  if masked["skills"] {
+    team.Field("skills", m.Team.Skills, validate.Each(validate.Required, validate.MaxLen(maxSkillLen)))
+  }
+  if masked["open_roles"] {
+    team.Field("open_roles", m.Team.OpenRoles, validate.Min(0))
+  }
+  if masked["size"] {
+    team.Field("size", m.Team.Size, validate.Min(1))
+  }
+  if masked["open_roles"] && masked["size"] {
+    team.Check("open_roles", m.Team.OpenRoles <= m.Team.Size, "must not exceed size")
+  }
+  return v.Err()
+}
+
+func (m *TeamDeleteRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Required, validate.Id).
+    Field("expected_version", m.ExpectedVersion, validate.Min(0)).
+    Err()
+}
+
+func (m *RestoreTeamRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Required, validate.Id).
+    Err()
+}
+
+// Validate has nothing to check, the caller's deleted teams are listed
+func (m *ListDeletedTeamsRequest) Validate() error {
+  return nil
+}
+
+func (m *MemberUpsertRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Required, validate.Id).
+    Field("member_id", m.MemberId, validate.Required, validate.Id).
+    Field("member_email", m.MemberEmail, validate.Required, validate.MaxLen(maxEmailLen), validate.Email).
+    Field("role", m.Role, validate.Required, validate.MaxLen(maxMemberRoleLen)).
+    Field("access_role", m.AccessRole, validate.OneOf(accessRoles...)).
+    Field("expected_version", m.ExpectedVersion, validate.Min(0)).
+    Err()
+}
+
+func (m *MemberDeleteRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Required, validate.Id).
+    Field("member_number", m.MemberNumber, validate.Required, validate.Id).
+    Field("member_email", m.MemberEmail, validate.MaxLen(maxEmailLen), validate.Email).
+    Field("expected_version", m.ExpectedVersion, validate.Min(0)).
+    Err()
+}
+
+func (m *TransferOwnershipRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Required, validate.Id).
+    Field("new_owner_id", m.NewOwnerId, validate.Required, validate.Id).
+    Field("expected_version", m.ExpectedVersion, validate.Min(0)).
+    Err()
+}
+
+func (m *InviteMemberRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Required, validate.Id).
+    Field("email", m.Email, validate.Required, validate.MaxLen(maxEmailLen), validate.Email).
+    Field("role", m.Role, validate.Required, validate.MaxLen(maxMemberRoleLen)).
+    Field("access_role", m.AccessRole, validate.OneOf(accessRoles...)).
+    Err()
+}
+
+// Validate allows an empty team_id which lists the caller's invitations
+func (m *ListInvitationsRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Id).
+    Err()
+}
+
+func (m *InvitationRequest) Validate() error {
+  return validate.New().
+    Field("invitation_id", m.InvitationId, validate.Required, validate.Id).
+    Err()
+}
+
+func (m *ApplyToTeamRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Required, validate.Id).
+    Field("role", m.Role, validate.Required, validate.MaxLen(maxMemberRoleLen)).
+    Field("message", m.Message, validate.MaxLen(maxApplicationMessageLen)).
+    Err()
+}
+
+// Validate allows an empty team_id which lists the caller's applications
+func (m *ListApplicationsRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Id).
+    Field("statuses", m.Statuses, validate.MaxLen(len(applicationStatuses)), validate.Each(validate.OneOf(applicationStatuses...))).
+    Err()
+}
+
+func (m *ApplicationRequest) Validate() error {
+  return validate.New().
+    Field("application_id", m.ApplicationId, validate.Required, validate.Id).
+    Err()
+}
+
+func (m *ProjectUpsertRequest) Validate() error {
+  v := validate.New()
+  v.Field("team_id", m.TeamId, validate.Required, validate.Id)
+  v.Field("expected_version", m.ExpectedVersion, validate.Min(0))
+  if m.Project == nil {
+    v.Violation("project", "is required")
+  } else {
+    m.Project.validate(v.Nested("project"))
+  }
+  return v.Err()
+}
+
+// Validate allows creating a project planning, the default, or active
+func (m *CreateProjectRequest) Validate() error {
+  v := validate.New()
+  v.Field("team_id", m.TeamId, validate.Required, validate.Id)
+  v.Field("expected_version", m.ExpectedVersion, validate.Min(0))
+  if m.Project == nil {
+    v.Violation("project", "is required")
+    return v.Err()
+  }
+  project := v.Nested("project")
+  m.Project.validate(project)
+  project.Field("status", m.Project.Status, validate.OneOf("", "planning", "active"))
+  return v.Err()
+}
+
+// Validate checks the fields of project listed in update_mask, other fields
+// are ignored by UpdateProject
+func (m *UpdateProjectRequest) Validate() error {
+  v := validate.New()
+  v.Field("team_id", m.TeamId, validate.Required, validate.Id)
+  v.Field("project_id", m.ProjectId, validate.Required, validate.Id)
+  paths := m.GetUpdateMask().GetPaths()
+  v.Field("update_mask.paths", paths, validate.Required, validate.Each(validate.OneOf(updatableProjectFields...)))
+  v.Field("expected_version", m.ExpectedVersion, validate.Min(0))
+  if m.Project == nil {
+    v.Violation("project", "is required")
+    return v.Err()
+  }
+
+  project := v.Nested("project")
+  masked := map[string]bool{}
+  for _, path := range paths {
+    masked[path] = true
+  }
+  if masked["name"] {
+    project.Field("name", m.Project.Name, validate.Required, validate.MaxLen(maxProjectNameLen))
+  }
+  if masked["description"] {
+    project.Field("description", m.Project.Description, validate.MaxLen(maxGoalLen))
+  }
+  if masked["github_link"] {
+    project.Field("github_link", m.Project.GithubLink, validate.MaxLen(maxGithubLinkLen), validate.HostURL("github.com"))
+  }
+  if masked["complexity"] {
+    project.Field("complexity", m.Project.Complexity, validate.Min(0))
+  }
+  if masked["duration"] {
+    project.Field("duration", m.Project.Duration, validate.Min(0))
+  }
+  if masked["languages"] {
+    project.Field("languages", m.Project.Languages, validate.Each(validate.Required, validate.MaxLen(maxLanguageLen)))
+  }
+  if masked["status"] {
+    project.Field("status", m.Project.Status, validate.Required, validate.OneOf(projectStatuses...))
+  }
+  return v.Err()
+}
+
+func (m *ArchiveProjectRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Required, validate.Id).
+    Field("project_id", m.ProjectId, validate.Required, validate.Id).
+    Field("expected_version", m.ExpectedVersion, validate.Min(0)).
+    Err()
+}
+
+func (m *ListProjectsRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Required, validate.Id).
+    Field("statuses", m.Statuses, validate.MaxLen(len(projectStatuses)), validate.Each(validate.OneOf(projectStatuses...))).
+    Err()
+}
+
+func (m *CreateMilestoneRequest) Validate() error {
+  v := validate.New()
+  v.Field("team_id", m.TeamId, validate.Required, validate.Id)
+  v.Field("project_id", m.ProjectId, validate.Required, validate.Id)
+  v.Field("expected_version", m.ExpectedVersion, validate.Min(0))
+  if m.Milestone == nil {
+    v.Violation("milestone", "is required")
+  } else {
+    m.Milestone.validate(v.Nested("milestone"))
+  }
+  return v.Err()
+}
+
+// Validate checks the fields of milestone listed in update_mask, other
+// fields are ignored by UpdateMilestone
+func (m *UpdateMilestoneRequest) Validate() error {
+  v := validate.New()
+  v.Field("team_id", m.TeamId, validate.Required, validate.Id)
+  v.Field("project_id", m.ProjectId, validate.Required, validate.Id)
+  v.Field("milestone_id", m.MilestoneId, validate.Required, validate.Id)
+  paths := m.GetUpdateMask().GetPaths()
+  v.Field("update_mask.paths", paths, validate.Required, validate.Each(validate.OneOf(updatableMilestoneFields...)))
+  v.Field("expected_version", m.ExpectedVersion, validate.Min(0))
+  if m.Milestone == nil {
+    v.Violation("milestone", "is required")
+    return v.Err()
+  }
+
+  milestone := v.Nested("milestone")
+  masked := map[string]bool{}
+  for _, path := range paths {
+    masked[path] = true
+  }
+  if masked["title"] {
+    milestone.Field("title", m.Milestone.Title, validate.Required, validate.MaxLen(maxWorkTitleLen))
+  }
+  if masked["assignee"] {
+    milestone.Field("assignee", m.Milestone.Assignee, validate.Id)
+  }
+  if masked["due_at"] {
+    milestone.Field("due_at", m.Milestone.DueAt, validate.Min(0))
+  }
+  if masked["status"] {
+    milestone.Field("status", m.Milestone.Status, validate.Required, validate.OneOf(workStatuses...))
+  }
+  if masked["position"] {
+    milestone.Field("position", m.Milestone.Position, validate.Min(0))
+  }
+  return v.Err()
+}
+
+func (m *DeleteMilestoneRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Required, validate.Id).
+    Field("project_id", m.ProjectId, validate.Required, validate.Id).
+    Field("milestone_id", m.MilestoneId, validate.Required, validate.Id).
+    Field("expected_version", m.ExpectedVersion, validate.Min(0)).
+    Err()
+}
+
+func (m *ListMilestonesRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Required, validate.Id).
+    Field("project_id", m.ProjectId, validate.Required, validate.Id).
+    Err()
+}
+
+func (m *CreateTaskRequest) Validate() error {
+  v := validate.New()
+  v.Field("team_id", m.TeamId, validate.Required, validate.Id)
+  v.Field("project_id", m.ProjectId, validate.Required, validate.Id)
+  v.Field("expected_version", m.ExpectedVersion, validate.Min(0))
+  if m.Task == nil {
+    v.Violation("task", "is required")
+  } else {
+    m.Task.validate(v.Nested("task"))
+  }
+  return v.Err()
+}
+
+// Validate checks the fields of task listed in update_mask, other fields are
+// ignored by UpdateTask
+func (m *UpdateTaskRequest) Validate() error {
+  v := validate.New()
+  v.Field("team_id", m.TeamId, validate.Required, validate.Id)
+  v.Field("project_id", m.ProjectId, validate.Required, validate.Id)
+  v.Field("task_id", m.TaskId, validate.Required, validate.Id)
+  paths := m.GetUpdateMask().GetPaths()
+  v.Field("update_mask.paths", paths, validate.Required, validate.Each(validate.OneOf(updatableTaskFields...)))
+  v.Field("expected_version", m.ExpectedVersion, validate.Min(0))
+  if m.Task == nil {
+    v.Violation("task", "is required")
+    return v.Err()
+  }
+
+  task := v.Nested("task")
+  masked := map[string]bool{}
+  for _, path := range paths {
+    masked[path] = true
+  }
+  if masked["title"] {
+    task.Field("title", m.Task.Title, validate.Required, validate.MaxLen(maxWorkTitleLen))
+  }
+  if masked["milestone_id"] {
+    task.Field("milestone_id", m.Task.MilestoneId, validate.Id)
+  }
+  if masked["assignee"] {
+    task.Field("assignee", m.Task.Assignee, validate.Id)
+  }
+  if masked["due_at"] {
+    task.Field("due_at", m.Task.DueAt, validate.Min(0))
+  }
+  if masked["status"] {
+    task.Field("status", m.Task.Status, validate.Required, validate.OneOf(workStatuses...))
+  }
+  if masked["position"] {
+    task.Field("position", m.Task.Position, validate.Min(0))
+  }
+  return v.Err()
+}
+
+func (m *DeleteTaskRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Required, validate.Id).
+    Field("project_id", m.ProjectId, validate.Required, validate.Id).
+    Field("task_id", m.TaskId, validate.Required, validate.Id).
+    Field("expected_version", m.ExpectedVersion, validate.Min(0)).
+    Err()
+}
+
+func (m *ListTasksRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Required, validate.Id).
+    Field("project_id", m.ProjectId, validate.Required, validate.Id).
+    Field("milestone_id", m.MilestoneId, validate.Id).
+    Field("assignee", m.Assignee, validate.Id).
+    Field("statuses", m.Statuses, validate.MaxLen(len(workStatuses)), validate.Each(validate.OneOf(workStatuses...))).
+    Err()
+}
+
+func (m *GetByTeamIdRequest) Validate() error {
+  return validate.New().
+    Field("id", m.Id, validate.Required, validate.Id).
+    Err()
+}
+
+func (m *GetByTeamNameRequest) Validate() error {
+  return validate.New().
+    Field("name", m.Name, validate.Required, validate.MaxLen(maxTeamNameLen)).
+    Err()
+}
+
+// Validate allows an empty id since GetTeamsByCurrentUser ignores it
+func (m *GetByUserIdRequest) Validate() error {
+  return validate.New().
+    Field("id", m.Id, validate.Id).
+    Err()
+}
+
+func (m *GetTeamsRequest) Validate() error {
+  return validate.New().
+    Field("page", m.Page, validate.Min(0)).
+    Field("limit", m.Limit, validate.Min(0), validate.Max(maxPageSize)).
+    Field("role", m.Role, validate.MaxLen(maxMemberRoleLen)).
+    Field("level", m.Level, validate.Min(0)).
+    Field("technology", m.Technology, validate.MaxLen(maxLanguageLen)).
+    Field("page_token", m.PageToken, validate.MaxLen(maxPageTokenLen)).
+    Field("skills", m.Skills, validate.MaxLen(maxFilterValues), validate.Each(validate.Required, validate.MaxLen(maxSkillLen))).
+    Field("skills_match", int32(m.SkillsMatch), validate.Enum(SkillMatch_name)).
+    Field("min_complexity", m.MinComplexity, validate.Min(0)).
+    Field("max_complexity", m.MaxComplexity, validate.Min(0)).
+    Field("languages", m.Languages, validate.MaxLen(maxFilterValues), validate.Each(validate.Required, validate.MaxLen(maxLanguageLen))).
+    Field("min_open_roles", m.MinOpenRoles, validate.Min(0)).
+    Field("min_size", m.MinSize, validate.Min(0)).
+    Field("max_size", m.MaxSize, validate.Min(0)).
+    Field("active_since", m.ActiveSince, validate.Min(0)).
+    Field("active_within_days", m.ActiveWithinDays, validate.Min(0)).
+    Field("name_prefix", m.NamePrefix, validate.MaxLen(maxTeamNameLen)).
+    Field("sort", int32(m.Sort), validate.Enum(TeamSort_name)).
+    Check("max_complexity", m.MaxComplexity == 0 || m.MaxComplexity >= m.MinComplexity, "must not be lower than min_complexity").
+    Check("max_size", m.MaxSize == 0 || m.MaxSize >= m.MinSize, "must not be lower than min_size").
+    Err()
+}
+
+func (m *SearchTeamsRequest) Validate() error {
+  return validate.New().
+    Field("query", m.Query, validate.MaxLen(maxQueryLen)).
+    Field("skills", m.Skills, validate.MaxLen(maxFilterValues), validate.Each(validate.Required, validate.MaxLen(maxSkillLen))).
+    Field("languages", m.Languages, validate.MaxLen(maxFilterValues), validate.Each(validate.Required, validate.MaxLen(maxLanguageLen))).
+    Field("limit", m.Limit, validate.Min(0), validate.Max(maxPageSize)).
+    Field("page_token", m.PageToken, validate.MaxLen(maxPageTokenLen)).
+    Err()
+}
+
+func (m *ListAuditEventsRequest) Validate() error {
+  return validate.New().
+    Field("team_id", m.TeamId, validate.Id).
+    Field("actor", m.Actor, validate.MaxLen(maxActorLen)).
+    Field("action", m.Action, validate.MaxLen(maxAuditActionLen)).
+    Field("since", m.Since, validate.Min(0)).
+    Field("until", m.Until, validate.Min(0)).
+    Field("limit", m.Limit, validate.Min(0), validate.Max(maxPageSize)).
+    Field("page_token", m.PageToken, validate.MaxLen(maxPageTokenLen)).
+    Check("until", m.Until == 0 || m.Until >= m.Since, "must not be before since").
+    Err()
+}
+
+func (m *Team) validate(v *validate.Validator) {
+  v.Field("name", m.Name, validate.Required, validate.MaxLen(maxTeamNameLen)).
+    Field("open_roles", m.OpenRoles, validate.Min(0)).
+    Field("size", m.Size, validate.Min(1)).
+    Field("last_active", m.LastActive, validate.Min(0)).
+    Field("skills", m.Skills, validate.Each(validate.Required, validate.MaxLen(maxSkillLen)))
+  if m.OpenRoles > m.Size {
+    v.Violation("open_roles", "must not exceed size")
+  }
+  if int(m.Size) < len(m.Members) {
+    v.Violation("members", "must not exceed size")
+  }
+  for i, member := range m.Members {
+    field := fmt.Sprintf("members[%d]", i)
+    if member == nil {
+      v.Violation(field, "is required")
+      continue
+    }
+    member.validate(v.Nested(field))
+  }
+  if m.Project != nil {
+    m.Project.validate(v.Nested("project"))
+  }
+}
+
+func (m *Member) validate(v *validate.Validator) {
+  v.Field("id", m.Id, validate.Min(1)).
+    Field("email", m.Email, validate.Required, validate.MaxLen(maxEmailLen), validate.Email).
+    Field("role", m.Role, validate.Required, validate.MaxLen(maxMemberRoleLen)).
+    Field("access_role", m.AccessRole, validate.OneOf(append(accessRoles, "owner")...))
+}
+
+func (m *Project) validate(v *validate.Validator) {
+  v.Field("name", m.Name, validate.Required, validate.MaxLen(maxProjectNameLen)).
+    Field("description", m.Description, validate.MaxLen(maxGoalLen)).
+    Field("github_link", m.GithubLink, validate.MaxLen(maxGithubLinkLen), validate.HostURL("github.com")).
+    Field("complexity", m.Complexity, validate.Min(0)).
+    Field("duration", m.Duration, validate.Min(0)).
+    Field("languages", m.Languages, validate.Each(validate.Required, validate.MaxLen(maxLanguageLen)))
+}
+
+func (m *Milestone) validate(v *validate.Validator) {
+  v.Field("title", m.Title, validate.Required, validate.MaxLen(maxWorkTitleLen)).
+    Field("assignee", m.Assignee, validate.Id).
+    Field("due_at", m.DueAt, validate.Min(0)).
+    Field("status", m.Status, validate.OneOf("", "todo", "in_progress", "done")).
+    Field("position", m.Position, validate.Min(0))
+}
+
+func (m *Task) validate(v *validate.Validator) {
+  v.Field("title", m.Title, validate.Required, validate.MaxLen(maxWorkTitleLen)).
+    Field("milestone_id", m.MilestoneId, validate.Id).
+    Field("assignee", m.Assignee, validate.Id).
+    Field("due_at", m.DueAt, validate.Min(0)).
+    Field("status", m.Status, validate.OneOf("", "todo", "in_progress", "done")).
+    Field("position", m.Position, validate.Min(0))
+}
@@ -0,0 +1,179 @@
+package team
+
+import (
+  "reflect"
+  "strings"
+  "testing"
+
+  field_mask "google.golang.org/genproto/protobuf/field_mask"
+
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// violations returns the fields err reports by field, nil if err is nil
+func violations(t *testing.T, err error) map[string]string {
+  if err == nil {
+    return nil
+  }
+  if domainerr.ReasonOf(err) != domainerr.ReasonInvalidArgument {
+    t.Fatalf("error = %v, want an invalid argument", err)
+  }
+  fields := map[string]string{}
+  for _, violation := range err.(*domainerr.Error).Violations {
+    fields[violation.Field] = violation.Description
+  }
+  return fields
+}
+
+func hasFields(t *testing.T, err error, want ...string) {
+  got := violations(t, err)
+  if len(got) != len(want) {
+    t.Errorf("violations = %v, want %v", got, want)
+    return
+  }
+  for _, field := range want {
+    if _, ok := got[field]; !ok {
+      t.Errorf("violations = %v, want %v", got, want)
+      return
+    }
+  }
+}
+
+func TestEveryRequestIsValidated(t *testing.T) {
+  validator := reflect.TypeOf((*interface{ Validate() error })(nil)).Elem()
+  server := reflect.TypeOf((*TeamServiceServer)(nil)).Elem()
+  for i := 0; i < server.NumMethod(); i++ {
+    method := server.Method(i)
+    if method.Type.NumIn() < 2 {
+      continue
+    }
+    if req := method.Type.In(1); !req.Implements(validator) {
+      t.Errorf("%s takes %s which doesn't implement Validate", method.Name, req)
+    }
+  }
+}
+
+func validTeam() *Team {
+  return &Team{
+    Name:      "gophers",
+    OpenRoles: 1,
+    Size:      3,
+    Skills:    []string{"go"},
+    Members: []*Member{
+      {Id: 7, Email: "m@example.com", Role: "backend", AccessRole: "owner"},
+    },
+    Project: &Project{Name: "dev-team", GithubLink: "https://github.com/ckbball/dev-team"},
+  }
+}
+
+func TestTeamUpsertRequestValidate(t *testing.T) {
+  if err := (&TeamUpsertRequest{Team: validTeam()}).Validate(); err != nil {
+    t.Fatalf("Validate() = %v", err)
+  }
+
+  tests := []struct {
+    name   string
+    change func(team *Team)
+    fields []string
+  }{
+    {"no name", func(team *Team) { team.Name = "" }, []string{"team.name"}},
+    {"long name", func(team *Team) { team.Name = strings.Repeat("g", maxTeamNameLen+1) }, []string{"team.name"}},
+    {"no size", func(team *Team) { team.Size, team.OpenRoles = 0, 0 }, []string{"team.size", "team.members"}},
+    {"open roles above size", func(team *Team) { team.OpenRoles = 4 }, []string{"team.open_roles"}},
+    {"blank skill", func(team *Team) { team.Skills = []string{"go", ""} }, []string{"team.skills"}},
+    {"member without email", func(team *Team) { team.Members[0].Email = "" }, []string{"team.members[0].email"}},
+    {"member with a bad email", func(team *Team) { team.Members[0].Email = "m.example.com" }, []string{"team.members[0].email"}},
+    {"member with an unknown access role", func(team *Team) { team.Members[0].AccessRole = "root" }, []string{"team.members[0].access_role"}},
+    {"nil member", func(team *Team) { team.Members = append(team.Members, nil) }, []string{"team.members[1]"}},
+    {"project off github", func(team *Team) { team.Project.GithubLink = "https://gitlab.com/ckbball" }, []string{"team.project.github_link"}},
+    {"long project name", func(team *Team) { team.Project.Name = strings.Repeat("p", maxProjectNameLen+1) }, []string{"team.project.name"}},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      team := validTeam()
+      tt.change(team)
+      hasFields(t, (&TeamUpsertRequest{Team: team}).Validate(), tt.fields...)
+    })
+  }
+
+  hasFields(t, (&TeamUpsertRequest{}).Validate(), "team")
+}
+
+func TestUpdateTeamRequestValidatesMaskedFields(t *testing.T) {
+  mask := func(paths ...string) *field_mask.FieldMask { return &field_mask.FieldMask{Paths: paths} }
+
+  // fields outside the mask are ignored
+  req := &UpdateTeamRequest{Id: "3", Team: &Team{Name: "gophers"}, UpdateMask: mask("name")}
+  if err := req.Validate(); err != nil {
+    t.Errorf("Validate() = %v", err)
+  }
+
+  tests := []struct {
+    name   string
+    req    *UpdateTeamRequest
+    fields []string
+  }{
+    {"no mask", &UpdateTeamRequest{Id: "3", Team: &Team{}}, []string{"update_mask.paths"}},
+    {"unknown path", &UpdateTeamRequest{Id: "3", Team: &Team{Name: "g"}, UpdateMask: mask("name", "members")}, []string{"update_mask.paths"}},
+    {"blank name", &UpdateTeamRequest{Id: "3", Team: &Team{}, UpdateMask: mask("name")}, []string{"team.name"}},
+    {"no size", &UpdateTeamRequest{Id: "3", Team: &Team{}, UpdateMask: mask("size")}, []string{"team.size"}},
+    {"open roles above size", &UpdateTeamRequest{Id: "3", Team: &Team{OpenRoles: 5, Size: 4}, UpdateMask: mask("open_roles", "size")}, []string{"team.open_roles"}},
+    // the size in the request isn't the stored size unless it's masked
+    {"open roles alone", &UpdateTeamRequest{Id: "3", Team: &Team{OpenRoles: 5}, UpdateMask: mask("open_roles")}, nil},
+    {"bad id", &UpdateTeamRequest{Id: "x", Team: &Team{Name: "g"}, UpdateMask: mask("name")}, []string{"id"}},
+    {"no team", &UpdateTeamRequest{Id: "3", UpdateMask: mask("name")}, []string{"team"}},
+    {"negative version", &UpdateTeamRequest{Id: "3", Team: &Team{Name: "g"}, UpdateMask: mask("name"), ExpectedVersion: -1}, []string{"expected_version"}},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      hasFields(t, tt.req.Validate(), tt.fields...)
+    })
+  }
+}
+
+func TestGetTeamsRequestValidate(t *testing.T) {
+  tests := []struct {
+    name   string
+    req    *GetTeamsRequest
+    fields []string
+  }{
+    {"empty", &GetTeamsRequest{}, nil},
+    {"filters", &GetTeamsRequest{Page: 2, Limit: 20, Role: "backend", Level: 3, Technology: "go"}, nil},
+    {"ranges", &GetTeamsRequest{MinComplexity: 2, MaxComplexity: 4, MinSize: 3, MaxSize: 3}, nil},
+    {"open ranges", &GetTeamsRequest{MinComplexity: 2, MinSize: 3}, nil},
+    {"complexity range reversed", &GetTeamsRequest{MinComplexity: 4, MaxComplexity: 2}, []string{"max_complexity"}},
+    {"size range reversed", &GetTeamsRequest{MinSize: 4, MaxSize: 2}, []string{"max_size"}},
+    {"negative page", &GetTeamsRequest{Page: -1}, []string{"page"}},
+    {"page too large", &GetTeamsRequest{Limit: maxPageSize + 1}, []string{"limit"}},
+    {"negative level", &GetTeamsRequest{Level: -1}, []string{"level"}},
+    {"long technology", &GetTeamsRequest{Technology: strings.Repeat("t", maxLanguageLen+1)}, []string{"technology"}},
+    {"too many skills", &GetTeamsRequest{Skills: make([]string, maxFilterValues+1)}, []string{"skills"}},
+    {"unknown sort", &GetTeamsRequest{Sort: TeamSort(99)}, []string{"sort"}},
+    {"unknown skills match", &GetTeamsRequest{SkillsMatch: SkillMatch(99)}, []string{"skills_match"}},
+    {"long page token", &GetTeamsRequest{PageToken: strings.Repeat("t", maxPageTokenLen+1)}, []string{"page_token"}},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      hasFields(t, tt.req.Validate(), tt.fields...)
+    })
+  }
+}
+
+func TestRequestsListEveryViolation(t *testing.T) {
+  err := (&MemberUpsertRequest{TeamId: "0", MemberId: "a", MemberEmail: "bad", AccessRole: "owner"}).Validate()
+  hasFields(t, err, "team_id", "member_id", "member_email", "role", "access_role")
+
+  err = (&ListAuditEventsRequest{Since: 20, Until: 10}).Validate()
+  hasFields(t, err, "until")
+
+  // lists of the caller's own records take no team id
+  for _, req := range []interface{ Validate() error }{&ListInvitationsRequest{}, &ListApplicationsRequest{}, &GetByUserIdRequest{}} {
+    if err := req.Validate(); err != nil {
+      t.Errorf("%T.Validate() = %v", req, err)
+    }
+  }
+  hasFields(t, (&ListApplicationsRequest{Statuses: []string{"pending", "lost"}}).Validate(), "statuses")
+  hasFields(t, (&InviteMemberRequest{TeamId: "3", Email: "m.example.com", Role: "backend"}).Validate(), "email")
+  hasFields(t, (&ProjectUpsertRequest{TeamId: "3", Project: &Project{Name: "p", Duration: -1}}).Validate(), "project.duration")
+  hasFields(t, (&CreateProjectRequest{TeamId: "3", Project: &Project{Name: "p", Status: "completed"}}).Validate(), "project.status")
+}
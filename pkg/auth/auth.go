@@ -0,0 +1,38 @@
+package auth
+
+import (
+  "context"
+)
+
+// Identity is the authenticated caller of a request
+type Identity struct {
+  // UserId is the subject of the caller's token
+  UserId string
+  // Email is the caller's email if the token carries one
+  Email string
+  // Roles are platform wide roles granted by the token, e.g. support
+  Roles []string
+}
+
+// HasRole reports whether the identity was granted role
+func (i *Identity) HasRole(role string) bool {
+  for _, r := range i.Roles {
+    if r == role {
+      return true
+    }
+  }
+  return false
+}
+
+type identityKey struct{}
+
+// NewContext returns a copy of ctx carrying identity
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+  return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the identity carried by ctx, if any
+func FromContext(ctx context.Context) (*Identity, bool) {
+  identity, ok := ctx.Value(identityKey{}).(*Identity)
+  return identity, ok && identity != nil
+}
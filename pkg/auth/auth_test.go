@@ -0,0 +1,28 @@
+package auth
+
+import (
+  "context"
+  "testing"
+)
+
+func TestContext(t *testing.T) {
+  if _, ok := FromContext(context.Background()); ok {
+    t.Error("an empty context has an identity")
+  }
+  if _, ok := FromContext(NewContext(context.Background(), nil)); ok {
+    t.Error("a nil identity was returned")
+  }
+
+  identity := &Identity{UserId: "7", Roles: []string{"support"}}
+  got, ok := FromContext(NewContext(context.Background(), identity))
+  if !ok || got != identity {
+    t.Errorf("FromContext() = %v, %v", got, ok)
+  }
+}
+
+func TestHasRole(t *testing.T) {
+  identity := &Identity{UserId: "7", Roles: []string{"support"}}
+  if !identity.HasRole("support") || identity.HasRole("admin") {
+    t.Errorf("HasRole() doesn't match roles %v", identity.Roles)
+  }
+}
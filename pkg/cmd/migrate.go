@@ -0,0 +1,137 @@
+package cmd
+
+import (
+  "context"
+  "database/sql"
+  "flag"
+  "fmt"
+  "os"
+  "strconv"
+  "text/tabwriter"
+
+  "github.com/ckbball/dev-team/pkg/migrate"
+  "github.com/ckbball/dev-team/sql/migrations"
+)
+
+const migrateUsage = `usage: server migrate [flags] <command>
+
+commands:
+  up [n]         apply the next n pending migrations, all by default
+  down [n]       revert the last n applied migrations, 1 by default
+  status         list migrations and whether they are applied
+  force VERSION  mark migrations up to VERSION applied without running them,
+                 used after fixing a failed migration by hand
+
+flags:
+`
+
+// RunMigrate runs the migrate subcommand with args
+func RunMigrate(args []string) error {
+  // database flags default to the same environment as the server
+  var cfg Config
+  flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+  flags.StringVar(&cfg.DatastoreDBHost, "db-host", os.Getenv("DB_HOST"), "Database host")
+  flags.StringVar(&cfg.DatastoreDBUser, "db-user", os.Getenv("DB_USER"), "Database user")
+  flags.StringVar(&cfg.DatastoreDBPassword, "db-password", os.Getenv("DB_PASSWORD"), "Database password")
+  flags.StringVar(&cfg.DatastoreDBSchema, "db-schema", os.Getenv("DB_SCHEMA"), "Database schema")
+  flags.Usage = func() {
+    fmt.Fprint(flags.Output(), migrateUsage)
+    flags.PrintDefaults()
+  }
+  flags.Parse(args)
+
+  command := flags.Arg(0)
+  if command == "" {
+    flags.Usage()
+    return fmt.Errorf("missing migrate command")
+  }
+
+  db, err := openDB(cfg)
+  if err != nil {
+    return err
+  }
+  defer db.Close()
+
+  migrator, err := migrate.New(db, migrations.FS)
+  if err != nil {
+    return fmt.Errorf("failed to load migrations: %v", err)
+  }
+
+  ctx := context.Background()
+  switch command {
+  case "up":
+    n, err := countArg(flags.Arg(1), 0)
+    if err != nil {
+      return err
+    }
+    applied, err := migrator.Up(ctx, n)
+    fmt.Fprintf(os.Stderr, "%d migrations applied\n", applied)
+    return err
+  case "down":
+    n, err := countArg(flags.Arg(1), 1)
+    if err != nil {
+      return err
+    }
+    reverted, err := migrator.Down(ctx, n)
+    fmt.Fprintf(os.Stderr, "%d migrations reverted\n", reverted)
+    return err
+  case "status":
+    statuses, err := migrator.Status(ctx)
+    if err != nil {
+      return err
+    }
+    w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+    fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+    for _, s := range statuses {
+      state, appliedAt := "pending", ""
+      switch {
+      case s.Dirty:
+        state = "dirty"
+      case s.Applied && s.Up == "":
+        state = "unknown"
+      case s.Modified:
+        state = "modified"
+      case s.Applied:
+        state = "applied"
+      }
+      if s.Applied {
+        appliedAt = s.AppliedAt.UTC().Format("2006-01-02T15:04:05Z")
+      }
+      fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+    }
+    return w.Flush()
+  case "force":
+    version, err := strconv.ParseInt(flags.Arg(1), 10, 64)
+    if err != nil {
+      return fmt.Errorf("invalid version: '%s'", flags.Arg(1))
+    }
+    return migrator.Force(ctx, version)
+  default:
+    flags.Usage()
+    return fmt.Errorf("unknown migrate command: '%s'", command)
+  }
+}
+
+// migrateUp applies every pending migration
+func migrateUp(ctx context.Context, db *sql.DB) error {
+  migrator, err := migrate.New(db, migrations.FS)
+  if err != nil {
+    return fmt.Errorf("failed to load migrations: %v", err)
+  }
+  if _, err := migrator.Up(ctx, 0); err != nil {
+    return fmt.Errorf("failed to migrate database: %v", err)
+  }
+  return nil
+}
+
+// countArg parses an optional migration count
+func countArg(arg string, def int) (int, error) {
+  if arg == "" {
+    return def, nil
+  }
+  n, err := strconv.Atoi(arg)
+  if err != nil || n < 0 {
+    return 0, fmt.Errorf("invalid migration count: '%s'", arg)
+  }
+  return n, nil
+}
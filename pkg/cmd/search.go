@@ -0,0 +1,80 @@
+package cmd
+
+import (
+  "context"
+  "database/sql"
+  "flag"
+  "fmt"
+  "os"
+
+  api "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/search"
+  v1 "github.com/ckbball/dev-team/pkg/service/v1"
+)
+
+// search backends
+const (
+  searchBleve = "bleve"
+  searchNone  = "none"
+)
+
+// initSearch opens the search index selected by cfg.SearchBackend. A new or
+// in-memory index is filled from db before it is returned.
+func initSearch(ctx context.Context, cfg Config, db *sql.DB) (search.Index, error) {
+  switch cfg.SearchBackend {
+  case searchNone:
+    return search.NewNopIndex(), nil
+  case searchBleve, "":
+    index, created, err := search.NewBleveIndex(cfg.SearchIndexPath)
+    if err != nil {
+      return nil, err
+    }
+    if created {
+      count, err := v1.IndexTeams(ctx, v1.NewTeamRepository(db), index)
+      if err != nil {
+        index.Close()
+        return nil, fmt.Errorf("failed to build search index: %v", err)
+      }
+      fmt.Fprintf(os.Stderr, "search index built with %d teams\n", count)
+    }
+    return index, nil
+  default:
+    return nil, fmt.Errorf("unknown search backend: '%s'", cfg.SearchBackend)
+  }
+}
+
+// RunReindex runs the reindex subcommand with args, it rebuilds the search
+// index at SEARCH_INDEX_PATH from MySQL. The server using the index must be
+// stopped, the rebuild fails while the index is open.
+func RunReindex(args []string) error {
+  var cfg Config
+  flags := flag.NewFlagSet("reindex", flag.ExitOnError)
+  flags.StringVar(&cfg.DatastoreDBHost, "db-host", os.Getenv("DB_HOST"), "Database host")
+  flags.StringVar(&cfg.DatastoreDBUser, "db-user", os.Getenv("DB_USER"), "Database user")
+  flags.StringVar(&cfg.DatastoreDBPassword, "db-password", os.Getenv("DB_PASSWORD"), "Database password")
+  flags.StringVar(&cfg.DatastoreDBSchema, "db-schema", os.Getenv("DB_SCHEMA"), "Database schema")
+  flags.StringVar(&cfg.SearchIndexPath, "search-index-path", os.Getenv("SEARCH_INDEX_PATH"), "Directory of the search index")
+  flags.Parse(args)
+
+  // an in-memory index is rebuilt every time the server starts
+  if cfg.SearchIndexPath == "" {
+    return fmt.Errorf("reindex needs a search index path")
+  }
+
+  db, err := openDB(cfg)
+  if err != nil {
+    return err
+  }
+  defer db.Close()
+
+  ctx := context.Background()
+  repo := v1.NewTeamRepository(db)
+  count, err := search.RebuildBleveIndex(ctx, cfg.SearchIndexPath, func(fn func(*api.Team) error) error {
+    return v1.EachTeam(ctx, repo, fn)
+  })
+  if err != nil {
+    return fmt.Errorf("failed to rebuild search index: %v", err)
+  }
+  fmt.Fprintf(os.Stderr, "search index rebuilt with %d teams\n", count)
+  return nil
+}
@@ -7,18 +7,20 @@ import (
   "fmt"
   "os"
   "strconv"
+  "strings"
+  "time"
 
   // mysql driver
-  //"github.com/Shopify/sarama"
-  //"github.com/ThreeDotsLabs/watermill-kafka/v2/pkg/kafka"
-  "github.com/go-redis/cache/v7"
+  "github.com/Shopify/sarama"
+  "github.com/ThreeDotsLabs/watermill-kafka/v2/pkg/kafka"
+  "github.com/ThreeDotsLabs/watermill/message"
   "github.com/go-redis/redis/v7"
   _ "github.com/go-sql-driver/mysql"
-  "github.com/vmihailenco/msgpack/v4"
 
   "github.com/ckbball/dev-team/pkg/logger"
   teamGrpc "github.com/ckbball/dev-team/pkg/protocol/grpc"
-  // "github.com/ckbball/dev-team/pkg/protocol/rest"
+  "github.com/ckbball/dev-team/pkg/protocol/grpc/middleware"
+  "github.com/ckbball/dev-team/pkg/protocol/rest"
   v1 "github.com/ckbball/dev-team/pkg/service/v1"
 )
 
@@ -42,6 +44,14 @@ type Config struct {
   DatastoreDBSchema string
   // address for single redis node
   RedisAddress string
+  // CacheBackend is where team reads are cached: none, memory, redis or tiered
+  CacheBackend string
+  // CacheTTL is how long team reads are cached, e.g. 5m
+  CacheTTL string
+  // CacheSize is the maximum number of entries kept in process
+  CacheSize int
+  // CacheLocalTTL caps how long the tiered backend keeps entries in process
+  CacheLocalTTL string
 
   // LogLevel is global log level: Debug(-1), Info(0), Warn(1), Error(2), DPanic(3), Panic(4), Fatal(5)
   LogLevel int
@@ -50,11 +60,51 @@ type Config struct {
 
   // user service address
   UserSvcAddress string
+
+  // EventBroker selects where events are published: kafka or memory
+  EventBroker string
+  // KafkaBrokers is a comma separated list of kafka broker addresses
+  KafkaBrokers string
+  // ConsumerGroup is the kafka consumer group used to consume user events
+  ConsumerGroup string
+
+  // JWTSecret verifies HS256 bearer tokens
+  JWTSecret string
+  // JWTJWKSFile is a JWKS file with the keys verifying RS256 bearer tokens
+  JWTJWKSFile string
+  // JWTIssuer is the required iss claim, not checked when empty
+  JWTIssuer string
+  // JWTAudience is the required aud claim, not checked when empty
+  JWTAudience string
+
+  // MigrateOnStart applies pending schema migrations before serving
+  MigrateOnStart bool
+
+  // SearchBackend is the team search index: bleve or none
+  SearchBackend string
+  // SearchIndexPath is the directory of the bleve index, in memory when empty
+  SearchIndexPath string
+
+  // SuccessionPolicy picks the new leader of a team whose leader's account
+  // was removed: admin, admin-then-member, member or none
+  SuccessionPolicy string
+
+  // TrashRetention is how long deleted teams may be restored before they
+  // are purged, e.g. 720h
+  TrashRetention string
+
+  // InactiveAfter is how long a team may be inactive before it is flagged
+  // and its owner told, e.g. 2160h
+  InactiveAfter string
+  // InactiveGrace is how long a flagged team has to be active again before
+  // it is archived, e.g. 336h
+  InactiveGrace string
 }
 
 // RunServer runs gRPC server and HTTP gateway
 func RunServer() error {
-  ctx := context.Background()
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
 
   // get configuration
   var cfg Config
@@ -65,6 +115,24 @@ func RunServer() error {
   flag.StringVar(&cfg.DatastoreDBPassword, "db-password", "", "Database password")
   flag.StringVar(&cfg.DatastoreDBSchema, "db-schema", "", "Database schema")
   flag.StringVar(&cfg.RedisAddress, "redis-address", "", "Redis address")
+  flag.StringVar(&cfg.CacheBackend, "cache-backend", "", "Cache backend: none, memory, redis or tiered")
+  flag.StringVar(&cfg.CacheTTL, "cache-ttl", "5m", "How long team reads are cached")
+  flag.IntVar(&cfg.CacheSize, "cache-size", 10000, "Maximum number of entries cached in process")
+  flag.StringVar(&cfg.CacheLocalTTL, "cache-local-ttl", "30s", "How long the tiered cache keeps entries in process")
+  flag.StringVar(&cfg.EventBroker, "event-broker", "kafka", "Event broker: kafka or memory")
+  flag.StringVar(&cfg.KafkaBrokers, "kafka-brokers", "kafka:9092", "Comma separated kafka brokers")
+  flag.StringVar(&cfg.ConsumerGroup, "consumer-group", "dev-team", "Kafka consumer group")
+  flag.StringVar(&cfg.JWTSecret, "jwt-secret", "", "Secret verifying HS256 tokens")
+  flag.StringVar(&cfg.JWTJWKSFile, "jwt-jwks-file", "", "JWKS file verifying RS256 tokens")
+  flag.StringVar(&cfg.JWTIssuer, "jwt-issuer", "", "Required token issuer")
+  flag.StringVar(&cfg.JWTAudience, "jwt-audience", "", "Required token audience")
+  flag.BoolVar(&cfg.MigrateOnStart, "migrate-on-start", false, "Apply pending schema migrations before serving")
+  flag.StringVar(&cfg.SearchBackend, "search-backend", "bleve", "Search index: bleve or none")
+  flag.StringVar(&cfg.SearchIndexPath, "search-index-path", "", "Directory of the search index, in memory when empty")
+  flag.StringVar(&cfg.SuccessionPolicy, "succession-policy", "admin-then-member", "Leader succession: admin, admin-then-member, member or none")
+  flag.StringVar(&cfg.TrashRetention, "trash-retention", "720h", "How long deleted teams may be restored before they are purged")
+  flag.StringVar(&cfg.InactiveAfter, "inactive-after", "2160h", "How long a team may be inactive before it is flagged")
+  flag.StringVar(&cfg.InactiveGrace, "inactive-grace", "336h", "How long a flagged team has to be active again before it is archived")
   flag.Parse()
 
   if len(cfg.GRPCPort) == 0 {
@@ -78,6 +146,46 @@ func RunServer() error {
     cfg.UserSvcAddress = os.Getenv("USER_ADDRESS")
     cfg.LogLevel, _ = strconv.Atoi(os.Getenv("LOG_LEVEL"))
     cfg.LogTimeFormat = os.Getenv("LOG_TIME")
+    if broker := os.Getenv("EVENT_BROKER"); broker != "" {
+      cfg.EventBroker = broker
+    }
+    if brokers := os.Getenv("KAFKA_BROKERS"); brokers != "" {
+      cfg.KafkaBrokers = brokers
+    }
+    if group := os.Getenv("CONSUMER_GROUP"); group != "" {
+      cfg.ConsumerGroup = group
+    }
+    cfg.CacheBackend = os.Getenv("CACHE_BACKEND")
+    if ttl := os.Getenv("CACHE_TTL"); ttl != "" {
+      cfg.CacheTTL = ttl
+    }
+    if size, err := strconv.Atoi(os.Getenv("CACHE_SIZE")); err == nil {
+      cfg.CacheSize = size
+    }
+    if ttl := os.Getenv("CACHE_LOCAL_TTL"); ttl != "" {
+      cfg.CacheLocalTTL = ttl
+    }
+    cfg.JWTSecret = os.Getenv("JWT_SECRET")
+    cfg.JWTJWKSFile = os.Getenv("JWT_JWKS_FILE")
+    cfg.JWTIssuer = os.Getenv("JWT_ISSUER")
+    cfg.JWTAudience = os.Getenv("JWT_AUDIENCE")
+    cfg.MigrateOnStart, _ = strconv.ParseBool(os.Getenv("MIGRATE_ON_START"))
+    if backend := os.Getenv("SEARCH_BACKEND"); backend != "" {
+      cfg.SearchBackend = backend
+    }
+    cfg.SearchIndexPath = os.Getenv("SEARCH_INDEX_PATH")
+    if policy := os.Getenv("SUCCESSION_POLICY"); policy != "" {
+      cfg.SuccessionPolicy = policy
+    }
+    if retention := os.Getenv("TRASH_RETENTION"); retention != "" {
+      cfg.TrashRetention = retention
+    }
+    if after := os.Getenv("INACTIVE_AFTER"); after != "" {
+      cfg.InactiveAfter = after
+    }
+    if grace := os.Getenv("INACTIVE_GRACE"); grace != "" {
+      cfg.InactiveGrace = grace
+    }
   }
 
   if len(cfg.GRPCPort) == 0 {
@@ -88,81 +196,199 @@ func RunServer() error {
     return fmt.Errorf("invalid TCP port for http server: '%s'", cfg.HTTPPort)
   }
 
-  // add MySQL driver specific parameter to parse date/time
-  // Drop it for another database
-  param := "parseTime=true"
+  // authenticate callers with bearer tokens
+  authenticator, err := middleware.NewAuthenticator(cfg.JWTSecret, cfg.JWTJWKSFile, cfg.JWTIssuer, cfg.JWTAudience)
+  if err != nil {
+    return fmt.Errorf("failed to create authenticator: %v", err)
+  }
 
-  // for non localhost db %s:%s@tcp(%s)/%s?%s
-  // currently set for localhost
-  dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?%s",
-    cfg.DatastoreDBUser,
-    cfg.DatastoreDBPassword,
-    cfg.DatastoreDBHost,
-    cfg.DatastoreDBSchema,
-    param)
-  db, err := sql.Open("mysql", dsn)
+  db, err := openDB(cfg)
   if err != nil {
-    return fmt.Errorf("failed to open database: %v", err)
+    return err
   }
   defer db.Close()
-  db.SetMaxIdleConns(10)
-  err = db.Ping()
+
+  // bring the schema up to date before serving, replicas wait for each other
+  if cfg.MigrateOnStart {
+    if err := migrateUp(ctx, db); err != nil {
+      return err
+    }
+  }
+
+  cacheTTL, err := time.ParseDuration(cfg.CacheTTL)
+  if err != nil {
+    return fmt.Errorf("invalid cache ttl: '%s'", cfg.CacheTTL)
+  }
+
+  cacheLocalTTL, err := time.ParseDuration(cfg.CacheLocalTTL)
+  if err != nil {
+    return fmt.Errorf("invalid cache local ttl: '%s'", cfg.CacheLocalTTL)
+  }
+
+  // init pool of connections to redis cluster
+  cacheCfg := v1.CacheConfig{
+    Backend:  cfg.CacheBackend,
+    Size:     cfg.CacheSize,
+    LocalTTL: cacheLocalTTL,
+  }
+  if len(cfg.RedisAddress) > 0 {
+    cacheCfg.Redis = initRedis(cfg.RedisAddress)
+    defer cacheCfg.Redis.Close()
+  }
+  // keep the behaviour of caching in redis whenever an address is configured
+  if cacheCfg.Backend == "" && cacheCfg.Redis != nil {
+    cacheCfg.Backend = v1.CacheRedis
+  }
+  appCache, err := v1.NewAppCache(ctx, cacheCfg)
+  if err != nil {
+    return fmt.Errorf("failed to create cache: %v", err)
+  }
+
+  succession, err := v1.ParseSuccessionPolicy(cfg.SuccessionPolicy)
   if err != nil {
-    return fmt.Errorf("failed to ping database: %v", err)
+    return err
   }
 
-  // create repository
-  repository := v1.NewTeamRepository(db)
+  retention, err := time.ParseDuration(cfg.TrashRetention)
+  if err != nil || retention <= 0 {
+    return fmt.Errorf("invalid trash retention: '%s'", cfg.TrashRetention)
+  }
+
+  inactiveAfter, err := time.ParseDuration(cfg.InactiveAfter)
+  if err != nil || inactiveAfter <= 0 {
+    return fmt.Errorf("invalid inactive after: '%s'", cfg.InactiveAfter)
+  }
+
+  inactiveGrace, err := time.ParseDuration(cfg.InactiveGrace)
+  if err != nil || inactiveGrace <= 0 {
+    return fmt.Errorf("invalid inactive grace: '%s'", cfg.InactiveGrace)
+  }
+
+  // open the search index, filling it from MySQL when it is new
+  index, err := initSearch(ctx, cfg, db)
+  if err != nil {
+    return fmt.Errorf("failed to create search index: %v", err)
+  }
+  defer index.Close()
+
+  // create repository with a read-through cache in front of MySQL, every
+  // committed change is reindexed
+  repository := v1.NewCachedRepository(v1.NewIndexedRepository(v1.NewTeamRepository(db).WithSuccession(succession).WithRetention(retention).WithInactivity(inactiveAfter, inactiveGrace), index), appCache, cacheTTL)
 
   // initialize logger
   if err := logger.Init(cfg.LogLevel, cfg.LogTimeFormat); err != nil {
     return fmt.Errorf("failed to initialize logger: %v", err)
   }
 
-  // init pool of connections to redis cluster
-  // redisPool := initRedis(cfg.RedisAddress)
-  /*
-     // Make subscriber config here
-     saramaSubscriberConfig := kafka.DefaultSaramaSubscriberConfig()
-     saramaSubscriberConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+  // Make publisher and subscriber pointers here
+  publisher, subscriber, err := initPubSub(cfg)
+  if err != nil {
+    return fmt.Errorf("failed to create event broker: %v", err)
+  }
+  defer publisher.Close()
+  defer subscriber.Close()
 
-     // Make subscriber pointer here
-     subscriber := v1.InitSubscriber(saramaSubscriberConfig)
+  // consume user service events to keep member data consistent
+  router, err := v1.NewUserEventRouter(repository, subscriber)
+  if err != nil {
+    return fmt.Errorf("failed to create event router: %v", err)
+  }
+  go func() {
+    if err := router.Run(ctx); err != nil {
+      fmt.Fprintf(os.Stderr, "event router stopped: %v\n", err)
+    }
+  }()
+  defer router.Close()
+
+  // relay events committed to the outbox table to the publisher
+  relay := v1.NewOutboxRelay(db, publisher)
+  go relay.Run(ctx)
 
-     // Make publisher pointer here
-     publisher := v1.InitPublisher()
-  */
+  // delete teams for good once their retention period in the trash is over
+  purger := v1.NewTeamPurger(repository)
+  go purger.Run(ctx)
+
+  // flag teams inactive for too long and archive them after a grace period
+  sweeper := v1.NewInactivitySweeper(repository)
+  go sweeper.Run(ctx)
 
   // pass in fields of handler directly to method
-  v1API := v1.NewTeamServiceServer(repository, cfg.UserSvcAddress)
+  v1API := v1.NewTeamServiceServer(repository, index, cfg.UserSvcAddress)
 
-  // run http gateway
-  /*
-    go func() {
-      _ = rest.RunServer(ctx, cfg.GRPCPort, cfg.HTTPPort)
-    }()
-  */
+  // run http gateway, it shuts down once the gRPC server has stopped
+  gatewayErr := make(chan error, 1)
+  go func() {
+    gatewayErr <- rest.RunServer(ctx, cfg.GRPCPort, cfg.HTTPPort)
+  }()
 
-  return teamGrpc.RunServer(ctx, v1API, cfg.GRPCPort)
+  err = teamGrpc.RunServer(ctx, v1API, cfg.GRPCPort, authenticator.Interceptors())
+  cancel()
+  if gwErr := <-gatewayErr; gwErr != nil {
+    fmt.Fprintf(os.Stderr, "HTTP/REST gateway stopped: %v\n", gwErr)
+  }
+  return err
 }
 
-func initRedis(address string) *cache.Codec {
-  ring := redis.NewRing(&redis.RingOptions{
-    Addrs: map[string]string{
-      "server1": ":" + address,
-    },
-  })
+// initPubSub creates the publisher and subscriber selected by cfg.EventBroker
+func initPubSub(cfg Config) (message.Publisher, message.Subscriber, error) {
+  switch cfg.EventBroker {
+  case "memory":
+    pubSub := v1.InitMemoryPubSub()
+    return pubSub, pubSub, nil
+  case "kafka", "":
+    brokers := strings.Split(cfg.KafkaBrokers, ",")
 
-  codec := &cache.Codec{
-    Redis: ring,
+    publisher, err := v1.InitPublisher(brokers)
+    if err != nil {
+      return nil, nil, err
+    }
 
-    Marshal: func(v interface{}) ([]byte, error) {
-      return msgpack.Marshal(v)
-    },
-    Unmarshal: func(b []byte, v interface{}) error {
-      return msgpack.Unmarshal(b, v)
-    },
+    // Make subscriber config here
+    saramaSubscriberConfig := kafka.DefaultSaramaSubscriberConfig()
+    saramaSubscriberConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+    subscriber, err := v1.InitSubscriber(saramaSubscriberConfig, brokers, cfg.ConsumerGroup)
+    if err != nil {
+      publisher.Close()
+      return nil, nil, err
+    }
+    return publisher, subscriber, nil
+  default:
+    return nil, nil, fmt.Errorf("unknown event broker: '%s'", cfg.EventBroker)
+  }
+}
+
+// openDB opens and pings the MySQL database of cfg
+func openDB(cfg Config) (*sql.DB, error) {
+  // add MySQL driver specific parameter to parse date/time
+  // Drop it for another database
+  param := "parseTime=true"
+
+  // for non localhost db %s:%s@tcp(%s)/%s?%s
+  // currently set for localhost
+  dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?%s",
+    cfg.DatastoreDBUser,
+    cfg.DatastoreDBPassword,
+    cfg.DatastoreDBHost,
+    cfg.DatastoreDBSchema,
+    param)
+  db, err := sql.Open("mysql", dsn)
+  if err != nil {
+    return nil, fmt.Errorf("failed to open database: %v", err)
+  }
+  db.SetMaxIdleConns(10)
+  err = db.Ping()
+  if err != nil {
+    db.Close()
+    return nil, fmt.Errorf("failed to ping database: %v", err)
   }
+  return db, nil
+}
 
-  return codec
+func initRedis(address string) *redis.Ring {
+  return redis.NewRing(&redis.RingOptions{
+    Addrs: map[string]string{
+      "server1": ":" + address,
+    },
+  })
 }
@@ -0,0 +1,192 @@
+// Package domainerr defines the errors the team service returns to clients.
+// Every error carries a machine readable reason and converts to a gRPC status
+// with google.rpc.ErrorInfo and, for invalid arguments, google.rpc.BadRequest
+// details so gRPC and REST clients can branch on the reason instead of the
+// message.
+package domainerr
+
+import (
+  "errors"
+  "fmt"
+
+  "google.golang.org/genproto/googleapis/rpc/errdetails"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+// Domain is the ErrorInfo domain of every error of the service
+const Domain = "team.dev-team"
+
+// reasons of the errors returned by the service
+const (
+  ReasonTeamNotFound             = "TEAM_NOT_FOUND"
+  ReasonMemberNotFound           = "MEMBER_NOT_FOUND"
+  ReasonTeamNameTaken            = "TEAM_NAME_TAKEN"
+  ReasonAlreadyMember            = "ALREADY_MEMBER"
+  ReasonTeamLimitReached         = "TEAM_LIMIT_REACHED"
+  ReasonTeamFull                 = "TEAM_FULL"
+  ReasonVersionMismatch          = "VERSION_MISMATCH"
+  ReasonOwnerMustTransfer        = "OWNER_MUST_TRANSFER"
+  ReasonInvitationNotFound       = "INVITATION_NOT_FOUND"
+  ReasonAlreadyInvited           = "ALREADY_INVITED"
+  ReasonInvitationClosed         = "INVITATION_CLOSED"
+  ReasonInvitationExpired        = "INVITATION_EXPIRED"
+  ReasonApplicationNotFound      = "APPLICATION_NOT_FOUND"
+  ReasonAlreadyApplied           = "ALREADY_APPLIED"
+  ReasonApplicationClosed        = "APPLICATION_CLOSED"
+  ReasonProjectNotFound          = "PROJECT_NOT_FOUND"
+  ReasonActiveProjectExists      = "ACTIVE_PROJECT_EXISTS"
+  ReasonInvalidProjectTransition = "INVALID_PROJECT_TRANSITION"
+  ReasonProjectActive            = "PROJECT_ACTIVE"
+  ReasonProjectArchived          = "PROJECT_ARCHIVED"
+  ReasonMilestoneNotFound        = "MILESTONE_NOT_FOUND"
+  ReasonTaskNotFound             = "TASK_NOT_FOUND"
+  ReasonPermissionDenied         = "PERMISSION_DENIED"
+  ReasonInvalidArgument          = "INVALID_ARGUMENT"
+  ReasonUnauthenticated          = "UNAUTHENTICATED"
+  ReasonInternal                 = "INTERNAL"
+)
+
+// FieldViolation describes an invalid request field
+type FieldViolation struct {
+  Field       string
+  Description string
+}
+
+// Error is an error of the team domain
+type Error struct {
+  // Code is the gRPC code the error maps to
+  Code codes.Code
+  // Reason is the machine readable reason, one of the Reason constants
+  Reason string
+  // Message is safe to show to clients
+  Message string
+  // Metadata is additional context sent in the ErrorInfo detail
+  Metadata map[string]string
+  // Violations lists the invalid fields of an InvalidArgument error
+  Violations []FieldViolation
+  // cause is logged but never sent to clients
+  cause error
+}
+
+func (e *Error) Error() string {
+  if e.cause != nil {
+    return fmt.Sprintf("%s: %v", e.Message, e.cause)
+  }
+  return e.Message
+}
+
+func (e *Error) Unwrap() error {
+  return e.cause
+}
+
+// GRPCStatus converts the error to a status with its details attached
+func (e *Error) GRPCStatus() *status.Status {
+  st := status.New(e.Code, e.Message)
+
+  info := &errdetails.ErrorInfo{
+    Reason:   e.Reason,
+    Domain:   Domain,
+    Metadata: e.Metadata,
+  }
+  withDetails, err := st.WithDetails(info)
+  if err != nil {
+    return st
+  }
+  st = withDetails
+
+  if len(e.Violations) > 0 {
+    badRequest := &errdetails.BadRequest{}
+    for _, v := range e.Violations {
+      badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+        Field:       v.Field,
+        Description: v.Description,
+      })
+    }
+    if withDetails, err := st.WithDetails(badRequest); err == nil {
+      st = withDetails
+    }
+  }
+  return st
+}
+
+// With returns a copy of e with key set to value in its metadata
+func (e *Error) With(key, value string) *Error {
+  c := *e
+  c.Metadata = map[string]string{}
+  for k, v := range e.Metadata {
+    c.Metadata[k] = v
+  }
+  c.Metadata[key] = value
+  return &c
+}
+
+func newError(code codes.Code, reason, format string, args ...interface{}) *Error {
+  return &Error{
+    Code:    code,
+    Reason:  reason,
+    Message: fmt.Sprintf(format, args...),
+  }
+}
+
+// NotFound is returned when the requested resource doesn't exist
+func NotFound(reason, format string, args ...interface{}) *Error {
+  return newError(codes.NotFound, reason, format, args...)
+}
+
+// AlreadyExists is returned when creating a resource that exists
+func AlreadyExists(reason, format string, args ...interface{}) *Error {
+  return newError(codes.AlreadyExists, reason, format, args...)
+}
+
+// FailedPrecondition is returned when the state of a resource prevents a change
+func FailedPrecondition(reason, format string, args ...interface{}) *Error {
+  return newError(codes.FailedPrecondition, reason, format, args...)
+}
+
+// Aborted is returned when a change conflicts with a concurrent one, the
+// client should read the resource again before retrying
+func Aborted(reason, format string, args ...interface{}) *Error {
+  return newError(codes.Aborted, reason, format, args...)
+}
+
+// PermissionDenied is returned when the caller may not perform a change
+func PermissionDenied(format string, args ...interface{}) *Error {
+  return newError(codes.PermissionDenied, ReasonPermissionDenied, format, args...)
+}
+
+// Unauthenticated is returned when the caller could not be identified
+func Unauthenticated(format string, args ...interface{}) *Error {
+  return newError(codes.Unauthenticated, ReasonUnauthenticated, format, args...)
+}
+
+// InvalidArgument is returned when request fields are invalid
+func InvalidArgument(violations ...FieldViolation) *Error {
+  e := newError(codes.InvalidArgument, ReasonInvalidArgument, "invalid request")
+  if len(violations) == 1 {
+    e.Message = fmt.Sprintf("invalid %s: %s", violations[0].Field, violations[0].Description)
+  }
+  e.Violations = violations
+  return e
+}
+
+// Internal wraps an unexpected error, the cause is never sent to clients
+func Internal(cause error) *Error {
+  e := newError(codes.Internal, ReasonInternal, "internal error")
+  e.cause = cause
+  return e
+}
+
+// ReasonOf returns the reason of err if it is or wraps an *Error
+func ReasonOf(err error) string {
+  var e *Error
+  if errors.As(err, &e) {
+    return e.Reason
+  }
+  return ""
+}
+
+// Is reports whether err is or wraps an *Error with reason
+func Is(err error, reason string) bool {
+  return err != nil && ReasonOf(err) == reason
+}
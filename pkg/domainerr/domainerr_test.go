@@ -0,0 +1,120 @@
+package domainerr
+
+import (
+  "errors"
+  "fmt"
+  "reflect"
+  "testing"
+
+  "google.golang.org/genproto/googleapis/rpc/errdetails"
+  "google.golang.org/grpc/codes"
+)
+
+func TestGRPCStatus(t *testing.T) {
+  err := NotFound(ReasonTeamNotFound, "team '%s' not found", "3").With("team_id", "3")
+
+  st := err.GRPCStatus()
+  if st.Code() != codes.NotFound || st.Message() != "team '3' not found" {
+    t.Errorf("status = %s %q", st.Code(), st.Message())
+  }
+  details := st.Details()
+  if len(details) != 1 {
+    t.Fatalf("details = %v, want the ErrorInfo", details)
+  }
+  info, ok := details[0].(*errdetails.ErrorInfo)
+  if !ok || info.Reason != ReasonTeamNotFound || info.Domain != Domain || info.Metadata["team_id"] != "3" {
+    t.Errorf("ErrorInfo = %v", details[0])
+  }
+}
+
+func TestInvalidArgument(t *testing.T) {
+  one := InvalidArgument(FieldViolation{Field: "team.name", Description: "is required"})
+  if one.Message != "invalid team.name: is required" {
+    t.Errorf("Message = %q", one.Message)
+  }
+
+  two := InvalidArgument(
+    FieldViolation{Field: "team.name", Description: "is required"},
+    FieldViolation{Field: "team.size", Description: "must be positive"},
+  )
+  if two.Message != "invalid request" {
+    t.Errorf("Message = %q", two.Message)
+  }
+  st := two.GRPCStatus()
+  if st.Code() != codes.InvalidArgument {
+    t.Errorf("code = %s", st.Code())
+  }
+  details := st.Details()
+  if len(details) != 2 {
+    t.Fatalf("details = %v, want ErrorInfo and BadRequest", details)
+  }
+  badRequest, ok := details[1].(*errdetails.BadRequest)
+  if !ok {
+    t.Fatalf("details[1] = %T", details[1])
+  }
+  fields := []string{}
+  for _, v := range badRequest.FieldViolations {
+    fields = append(fields, v.Field)
+  }
+  if !reflect.DeepEqual(fields, []string{"team.name", "team.size"}) {
+    t.Errorf("violations = %v", fields)
+  }
+}
+
+func TestWithCopies(t *testing.T) {
+  base := NotFound(ReasonTeamNotFound, "not found").With("team_id", "3")
+  derived := base.With("user_id", "7")
+
+  if len(base.Metadata) != 1 {
+    t.Errorf("With changed the original metadata: %v", base.Metadata)
+  }
+  if !reflect.DeepEqual(derived.Metadata, map[string]string{"team_id": "3", "user_id": "7"}) {
+    t.Errorf("Metadata = %v", derived.Metadata)
+  }
+}
+
+func TestInternalHidesTheCause(t *testing.T) {
+  cause := errors.New("Error 1146: Table 'teams' doesn't exist")
+  err := Internal(cause)
+
+  if st := err.GRPCStatus(); st.Code() != codes.Internal || st.Message() != "internal error" {
+    t.Errorf("status = %s %q, the cause must not be sent", st.Code(), st.Message())
+  }
+  // the cause is still logged and unwrapped
+  if !errors.Is(err, cause) || err.Error() != "internal error: "+cause.Error() {
+    t.Errorf("Error() = %q", err.Error())
+  }
+}
+
+func TestReasonOf(t *testing.T) {
+  err := FailedPrecondition(ReasonTeamFull, "team is full")
+  wrapped := fmt.Errorf("adding member: %w", err)
+
+  if ReasonOf(err) != ReasonTeamFull || ReasonOf(wrapped) != ReasonTeamFull {
+    t.Errorf("ReasonOf() = %q, %q", ReasonOf(err), ReasonOf(wrapped))
+  }
+  if ReasonOf(errors.New("boom")) != "" || ReasonOf(nil) != "" {
+    t.Error("errors of other types have no reason")
+  }
+  if !Is(wrapped, ReasonTeamFull) || Is(wrapped, ReasonTeamNotFound) || Is(nil, "") {
+    t.Error("Is() doesn't match the reason")
+  }
+}
+
+func TestConstructorCodes(t *testing.T) {
+  tests := map[codes.Code]*Error{
+    codes.NotFound:           NotFound(ReasonTeamNotFound, ""),
+    codes.AlreadyExists:      AlreadyExists(ReasonTeamNameTaken, ""),
+    codes.FailedPrecondition: FailedPrecondition(ReasonTeamFull, ""),
+    codes.Aborted:            Aborted(ReasonVersionMismatch, ""),
+    codes.PermissionDenied:   PermissionDenied(""),
+    codes.Unauthenticated:    Unauthenticated(""),
+    codes.InvalidArgument:    InvalidArgument(),
+    codes.Internal:           Internal(nil),
+  }
+  for code, err := range tests {
+    if got := err.GRPCStatus().Code(); got != code {
+      t.Errorf("%s error has code %s", err.Reason, got)
+    }
+  }
+}
@@ -0,0 +1,433 @@
+// Package migrate applies versioned schema migrations to a MySQL database.
+//
+// Migrations are pairs of NNNN_description.up.sql and NNNN_description.down.sql
+// files. Applied versions are recorded in the schema_migrations table together
+// with the checksum of their up file, so editing a migration after it ran is
+// detected. A MySQL advisory lock makes sure only one process migrates a
+// database at a time.
+package migrate
+
+import (
+  "context"
+  "crypto/sha256"
+  "database/sql"
+  "encoding/hex"
+  "errors"
+  "fmt"
+  "io"
+  "io/fs"
+  "os"
+  "regexp"
+  "sort"
+  "strconv"
+  "strings"
+  "time"
+)
+
+// lockName is the advisory lock held while migrating
+const lockName = "dev-team:schema_migrations"
+
+// DefaultLockTimeout is how long to wait for another process to finish migrating
+const DefaultLockTimeout = 5 * time.Minute
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is one version of the schema
+type Migration struct {
+  Version int64
+  Name    string
+  Up      string
+  Down    string
+  // Checksum is the sha256 of Up
+  Checksum string
+}
+
+// Status is the state of one migration in a database
+type Status struct {
+  Migration
+  Applied   bool
+  AppliedAt time.Time
+  // Dirty is set when the migration failed part way and must be fixed by hand
+  Dirty bool
+  // Modified is set when the migration file changed after it was applied
+  Modified bool
+}
+
+// Migrator applies migrations to a database
+type Migrator struct {
+  db          *sql.DB
+  migrations  []Migration
+  LockTimeout time.Duration
+  // Log receives a line per applied or reverted migration
+  Log io.Writer
+}
+
+// New returns a Migrator applying the migrations found in fsys
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+  migrations, err := Load(fsys)
+  if err != nil {
+    return nil, err
+  }
+  return &Migrator{
+    db:          db,
+    migrations:  migrations,
+    LockTimeout: DefaultLockTimeout,
+    Log:         os.Stderr,
+  }, nil
+}
+
+// Load reads the migrations in the root of fsys ordered by version
+func Load(fsys fs.FS) ([]Migration, error) {
+  entries, err := fs.ReadDir(fsys, ".")
+  if err != nil {
+    return nil, err
+  }
+
+  byVersion := map[int64]*Migration{}
+  for _, entry := range entries {
+    match := fileName.FindStringSubmatch(entry.Name())
+    if entry.IsDir() || match == nil {
+      continue
+    }
+    version, err := strconv.ParseInt(match[1], 10, 64)
+    if err != nil {
+      return nil, fmt.Errorf("invalid migration version '%s': %v", entry.Name(), err)
+    }
+    b, err := fs.ReadFile(fsys, entry.Name())
+    if err != nil {
+      return nil, err
+    }
+
+    m, ok := byVersion[version]
+    if !ok {
+      m = &Migration{Version: version, Name: match[2]}
+      byVersion[version] = m
+    } else if m.Name != match[2] {
+      return nil, fmt.Errorf("migration %d has two names: '%s' and '%s'", version, m.Name, match[2])
+    }
+    if match[3] == "up" {
+      m.Up = string(b)
+      sum := sha256.Sum256(b)
+      m.Checksum = hex.EncodeToString(sum[:])
+    } else {
+      m.Down = string(b)
+    }
+  }
+
+  migrations := []Migration{}
+  for _, m := range byVersion {
+    if m.Up == "" {
+      return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+    }
+    migrations = append(migrations, *m)
+  }
+  sort.Slice(migrations, func(i, j int) bool {
+    return migrations[i].Version < migrations[j].Version
+  })
+  return migrations, nil
+}
+
+// Up applies the next n pending migrations, every pending one if n <= 0, and
+// returns how many were applied
+func (m *Migrator) Up(ctx context.Context, n int) (int, error) {
+  count := 0
+  err := m.locked(ctx, func(conn *sql.Conn) error {
+    statuses, err := m.status(ctx, conn)
+    if err != nil {
+      return err
+    }
+    if err := verify(statuses); err != nil {
+      return err
+    }
+
+    for _, s := range statuses {
+      if s.Applied {
+        continue
+      }
+      if n > 0 && count == n {
+        break
+      }
+      if err := m.apply(ctx, conn, s.Migration, true); err != nil {
+        return err
+      }
+      count++
+    }
+    return nil
+  })
+  return count, err
+}
+
+// Down reverts the last n applied migrations and returns how many were reverted
+func (m *Migrator) Down(ctx context.Context, n int) (int, error) {
+  count := 0
+  err := m.locked(ctx, func(conn *sql.Conn) error {
+    statuses, err := m.status(ctx, conn)
+    if err != nil {
+      return err
+    }
+    if err := verify(statuses); err != nil {
+      return err
+    }
+
+    for i := len(statuses) - 1; i >= 0 && count < n; i-- {
+      s := statuses[i]
+      if !s.Applied {
+        continue
+      }
+      if s.Down == "" {
+        return fmt.Errorf("migration %d_%s can't be reverted, it has no down file", s.Version, s.Name)
+      }
+      if err := m.apply(ctx, conn, s.Migration, false); err != nil {
+        return err
+      }
+      count++
+    }
+    return nil
+  })
+  return count, err
+}
+
+// Status returns the state of every known migration
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+  conn, err := m.db.Conn(ctx)
+  if err != nil {
+    return nil, err
+  }
+  defer conn.Close()
+
+  if err := createTable(ctx, conn); err != nil {
+    return nil, err
+  }
+  return m.status(ctx, conn)
+}
+
+// Force marks every migration up to version as applied and clean, and every
+// later one as pending, without running them. It is used after fixing a
+// failed migration by hand.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+  return m.locked(ctx, func(conn *sql.Conn) error {
+    if _, err := conn.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version > ?`, version); err != nil {
+      return err
+    }
+    for _, migration := range m.migrations {
+      if migration.Version > version {
+        break
+      }
+      _, err := conn.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, checksum, applied_at, dirty)
+        VALUES (?, ?, ?, ?, 0)
+        ON DUPLICATE KEY UPDATE name = VALUES(name), checksum = VALUES(checksum), dirty = 0`,
+        migration.Version, migration.Name, migration.Checksum, time.Now().Unix())
+      if err != nil {
+        return err
+      }
+    }
+    return nil
+  })
+}
+
+// apply runs the up or down statements of migration and records it. MySQL
+// commits DDL implicitly so the migration is marked dirty until every
+// statement succeeded.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration, up bool) error {
+  script, direction := migration.Up, "up"
+  if !up {
+    script, direction = migration.Down, "down"
+  }
+
+  _, err := conn.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, checksum, applied_at, dirty)
+    VALUES (?, ?, ?, ?, 1)
+    ON DUPLICATE KEY UPDATE dirty = 1`,
+    migration.Version, migration.Name, migration.Checksum, time.Now().Unix())
+  if err != nil {
+    return err
+  }
+
+  for _, stmt := range Statements(script) {
+    if _, err := conn.ExecContext(ctx, stmt); err != nil {
+      return fmt.Errorf("migration %d_%s %s failed, fix the schema and run `migrate force`: %v", migration.Version, migration.Name, direction, err)
+    }
+  }
+
+  if up {
+    _, err = conn.ExecContext(ctx, `UPDATE schema_migrations SET dirty = 0, applied_at = ? WHERE version = ?`, time.Now().Unix(), migration.Version)
+  } else {
+    _, err = conn.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = ?`, migration.Version)
+  }
+  if err != nil {
+    return err
+  }
+
+  fmt.Fprintf(m.Log, "migration %d_%s %s\n", migration.Version, migration.Name, direction)
+  return nil
+}
+
+// status joins the known migrations with the versions recorded in the database
+func (m *Migrator) status(ctx context.Context, conn *sql.Conn) ([]Status, error) {
+  rows, err := conn.QueryContext(ctx, `SELECT version, name, checksum, applied_at, dirty FROM schema_migrations`)
+  if err != nil {
+    return nil, err
+  }
+  defer rows.Close()
+
+  applied := map[int64]Status{}
+  for rows.Next() {
+    var s Status
+    var appliedAt int64
+    if err := rows.Scan(&s.Version, &s.Name, &s.Checksum, &appliedAt, &s.Dirty); err != nil {
+      return nil, err
+    }
+    s.Applied = true
+    s.AppliedAt = time.Unix(appliedAt, 0)
+    applied[s.Version] = s
+  }
+  if err := rows.Err(); err != nil {
+    return nil, err
+  }
+
+  statuses := []Status{}
+  for _, migration := range m.migrations {
+    s := Status{Migration: migration}
+    if a, ok := applied[migration.Version]; ok {
+      s.Applied = true
+      s.AppliedAt = a.AppliedAt
+      s.Dirty = a.Dirty
+      s.Modified = a.Checksum != migration.Checksum
+      delete(applied, migration.Version)
+    }
+    statuses = append(statuses, s)
+  }
+
+  // versions applied by a newer binary are reported so they can be verified
+  for _, a := range applied {
+    statuses = append(statuses, a)
+  }
+  sort.Slice(statuses, func(i, j int) bool {
+    return statuses[i].Version < statuses[j].Version
+  })
+  return statuses, nil
+}
+
+// verify refuses to migrate a database with dirty, modified or unknown migrations
+func verify(statuses []Status) error {
+  for _, s := range statuses {
+    switch {
+    case s.Dirty:
+      return fmt.Errorf("migration %d_%s is dirty, fix the schema and run `migrate force`", s.Version, s.Name)
+    case s.Up == "":
+      return fmt.Errorf("migration %d_%s is applied but unknown to this binary", s.Version, s.Name)
+    case s.Modified:
+      return fmt.Errorf("migration %d_%s changed after it was applied, checksum mismatch", s.Version, s.Name)
+    }
+  }
+  return nil
+}
+
+// locked runs fn holding the advisory lock. MySQL locks belong to a
+// connection so fn must run every statement on conn.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+  conn, err := m.db.Conn(ctx)
+  if err != nil {
+    return err
+  }
+  defer conn.Close()
+
+  var got sql.NullInt64
+  err = conn.QueryRowContext(ctx, `SELECT GET_LOCK(?, ?)`, lockName, int(m.LockTimeout.Seconds())).Scan(&got)
+  if err != nil {
+    return err
+  }
+  if !got.Valid || got.Int64 != 1 {
+    return errors.New("timed out waiting for another process to finish migrating")
+  }
+  defer conn.ExecContext(context.Background(), `SELECT RELEASE_LOCK(?)`, lockName)
+
+  if err := createTable(ctx, conn); err != nil {
+    return err
+  }
+  return fn(conn)
+}
+
+func createTable(ctx context.Context, conn *sql.Conn) error {
+  _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version bigint not null PRIMARY key,
+    name varchar(255) not null,
+    checksum char(64) not null,
+    applied_at bigint not null,
+    dirty tinyint(1) not null default 0
+  )`)
+  return err
+}
+
+// Statements splits a script into statements. Statements end with a ; that
+// isn't inside quotes, backquotes or a comment. -- and # comments are
+// dropped, /* */ comments are kept. DELIMITER isn't supported, so a script
+// can't create stored programs whose bodies contain ;.
+func Statements(script string) []string {
+  statements := []string{}
+  var current strings.Builder
+  flush := func() {
+    if stmt := strings.TrimSpace(current.String()); stmt != "" {
+      statements = append(statements, stmt)
+    }
+    current.Reset()
+  }
+
+  for i := 0; i < len(script); i++ {
+    c := script[i]
+    switch {
+    case c == '\'' || c == '"' || c == '`':
+      end := quoteEnd(script, i)
+      current.WriteString(script[i:end])
+      i = end - 1
+    case c == '#' || lineComment(script[i:]):
+      // the comment runs to the end of the line, the newline is kept
+      end := strings.IndexByte(script[i:], '\n')
+      if end < 0 {
+        end = len(script) - i
+      }
+      i += end - 1
+    case strings.HasPrefix(script[i:], "/*"):
+      end := strings.Index(script[i+2:], "*/")
+      if end < 0 {
+        end = len(script)
+      } else {
+        end = i + 2 + end + 2
+      }
+      current.WriteString(script[i:end])
+      i = end - 1
+    case c == ';':
+      flush()
+    default:
+      current.WriteByte(c)
+    }
+  }
+  flush()
+  return statements
+}
+
+// lineComment reports whether s starts with a -- comment, MySQL requires
+// whitespace after the dashes
+func lineComment(s string) bool {
+  return strings.HasPrefix(s, "--") && (len(s) == 2 || strings.ContainsRune(" \t\r\n", rune(s[2])))
+}
+
+// quoteEnd returns the index after the quote opened at script[start]. Quotes
+// are escaped by doubling them or, outside backquotes, by a backslash.
+func quoteEnd(script string, start int) int {
+  quote := script[start]
+  for i := start + 1; i < len(script); i++ {
+    switch script[i] {
+    case '\\':
+      if quote != '`' {
+        i++
+      }
+    case quote:
+      if i+1 < len(script) && script[i+1] == quote {
+        i++
+        continue
+      }
+      return i + 1
+    }
+  }
+  return len(script)
+}
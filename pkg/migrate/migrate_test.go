@@ -0,0 +1,295 @@
+package migrate
+
+import (
+  "context"
+  "io/ioutil"
+  "reflect"
+  "regexp"
+  "strings"
+  "testing"
+  "testing/fstest"
+
+  "github.com/DATA-DOG/go-sqlmock"
+
+  "github.com/ckbball/dev-team/sql/migrations"
+)
+
+func TestStatements(t *testing.T) {
+  tests := []struct {
+    name   string
+    script string
+    want   []string
+  }{
+    {
+      name:   "one per line",
+      script: "CREATE TABLE a (id int);\nDROP TABLE b;\n",
+      want:   []string{"CREATE TABLE a (id int)", "DROP TABLE b"},
+    },
+    {
+      name:   "statement over several lines",
+      script: "CREATE TABLE a (\n  id int\n);",
+      want:   []string{"CREATE TABLE a (\n  id int\n)"},
+    },
+    {
+      name:   "several on one line",
+      script: "SET @a = 1; SET @b = 2;",
+      want:   []string{"SET @a = 1", "SET @b = 2"},
+    },
+    {
+      name:   "last statement without ;",
+      script: "DROP TABLE a",
+      want:   []string{"DROP TABLE a"},
+    },
+    {
+      name:   "; inside quotes",
+      script: "INSERT INTO a VALUES ('x;y', \"z;\", `c;`);",
+      want:   []string{"INSERT INTO a VALUES ('x;y', \"z;\", `c;`)"},
+    },
+    {
+      name:   "escaped quotes",
+      script: "SET @s = 'it''s; \\'quoted;\\'';\nDO 0;",
+      want:   []string{"SET @s = 'it''s; \\'quoted;\\''", "DO 0"},
+    },
+    {
+      name:   "line comments are dropped",
+      script: "-- drop it;\nDROP TABLE a; # done;\n--also;\n",
+      want:   []string{"DROP TABLE a", "--also"},
+    },
+    {
+      name:   "block comments are kept",
+      script: "/* a; b */ DROP TABLE a;",
+      want:   []string{"/* a; b */ DROP TABLE a"},
+    },
+    {
+      name:   "comment only",
+      script: "-- nothing to do\n",
+      want:   []string{},
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      if got := Statements(tt.script); !reflect.DeepEqual(got, tt.want) {
+        t.Errorf("Statements() = %q, want %q", got, tt.want)
+      }
+    })
+  }
+}
+
+func TestLoad(t *testing.T) {
+  fsys := fstest.MapFS{
+    "0002_second.up.sql":  {Data: []byte("CREATE TABLE b (id int);")},
+    "0001_first.up.sql":   {Data: []byte("CREATE TABLE a (id int);")},
+    "0001_first.down.sql": {Data: []byte("DROP TABLE a;")},
+    "README.md":           {Data: []byte("not a migration")},
+  }
+
+  got, err := Load(fsys)
+  if err != nil {
+    t.Fatalf("Load() error = %v", err)
+  }
+  if len(got) != 2 {
+    t.Fatalf("Load() returned %d migrations, want 2", len(got))
+  }
+  if got[0].Version != 1 || got[0].Name != "first" || got[0].Down != "DROP TABLE a;" {
+    t.Errorf("Load()[0] = %+v", got[0])
+  }
+  if got[1].Version != 2 || got[1].Down != "" {
+    t.Errorf("Load()[1] = %+v", got[1])
+  }
+  if len(got[0].Checksum) != 64 || got[0].Checksum == got[1].Checksum {
+    t.Errorf("Load() checksums = %s, %s", got[0].Checksum, got[1].Checksum)
+  }
+}
+
+func TestLoadErrors(t *testing.T) {
+  tests := []struct {
+    name string
+    fsys fstest.MapFS
+  }{
+    {
+      name: "no up file",
+      fsys: fstest.MapFS{"0001_first.down.sql": {Data: []byte("DROP TABLE a;")}},
+    },
+    {
+      name: "two names",
+      fsys: fstest.MapFS{
+        "0001_first.up.sql":   {Data: []byte("CREATE TABLE a (id int);")},
+        "0001_other.down.sql": {Data: []byte("DROP TABLE a;")},
+      },
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      if _, err := Load(tt.fsys); err == nil {
+        t.Error("Load() error = nil, want an error")
+      }
+    })
+  }
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+  got, err := Load(migrations.FS)
+  if err != nil {
+    t.Fatalf("Load() error = %v", err)
+  }
+  for i, m := range got {
+    if m.Version != int64(i+1) {
+      t.Errorf("migration %d_%s, want version %d", m.Version, m.Name, i+1)
+    }
+    if m.Down == "" {
+      t.Errorf("migration %d_%s has no down file", m.Version, m.Name)
+    }
+    if len(Statements(m.Up)) == 0 {
+      t.Errorf("migration %d_%s has no statements", m.Version, m.Name)
+    }
+  }
+
+  // the columns tables.sql created are only added when missing
+  for _, m := range got[2:4] {
+    stmts := Statements(m.Up)
+    if len(stmts) != 4 || !strings.Contains(stmts[0], "information_schema.columns") {
+      t.Errorf("migration %d_%s isn't guarded: %q", m.Version, m.Name, stmts)
+    }
+  }
+}
+
+func TestVerify(t *testing.T) {
+  clean := Status{Migration: Migration{Version: 1, Name: "first", Up: "DO 0"}, Applied: true}
+  dirty, modified, unknown := clean, clean, clean
+  dirty.Dirty = true
+  modified.Modified = true
+  unknown.Up = ""
+
+  if err := verify([]Status{clean}); err != nil {
+    t.Errorf("verify(clean) error = %v", err)
+  }
+  for name, s := range map[string]Status{"dirty": dirty, "modified": modified, "unknown": unknown} {
+    if err := verify([]Status{clean, s}); err == nil {
+      t.Errorf("verify(%s) error = nil, want an error", name)
+    }
+  }
+}
+
+// newMigrator returns a Migrator of fsys on a mock database expecting the
+// advisory lock and the schema_migrations table
+func newMigrator(t *testing.T, fsys fstest.MapFS) (*Migrator, sqlmock.Sqlmock) {
+  db, mock, err := sqlmock.New()
+  if err != nil {
+    t.Fatal(err)
+  }
+  t.Cleanup(func() { db.Close() })
+
+  m, err := New(db, fsys)
+  if err != nil {
+    t.Fatal(err)
+  }
+  m.Log = ioutil.Discard
+
+  mock.ExpectQuery(regexp.QuoteMeta("SELECT GET_LOCK(?, ?)")).WithArgs(lockName, int(DefaultLockTimeout.Seconds())).
+    WillReturnRows(sqlmock.NewRows([]string{"got"}).AddRow(1))
+  mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+  return m, mock
+}
+
+func TestUpAppliesPendingMigrations(t *testing.T) {
+  fsys := fstest.MapFS{
+    "0001_first.up.sql":  {Data: []byte("CREATE TABLE a (id int);")},
+    "0002_second.up.sql": {Data: []byte("CREATE TABLE b (id int);\nCREATE TABLE c (id int);")},
+  }
+  m, mock := newMigrator(t, fsys)
+
+  mock.ExpectQuery("SELECT version, name, checksum, applied_at, dirty FROM schema_migrations").
+    WillReturnRows(sqlmock.NewRows([]string{"version", "name", "checksum", "applied_at", "dirty"}).
+      AddRow(1, "first", m.migrations[0].Checksum, 1, false))
+  mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(2, "second", m.migrations[1].Checksum, sqlmock.AnyArg()).
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE b (id int)")).WillReturnResult(sqlmock.NewResult(0, 0))
+  mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE c (id int)")).WillReturnResult(sqlmock.NewResult(0, 0))
+  mock.ExpectExec("UPDATE schema_migrations SET dirty = 0").WithArgs(sqlmock.AnyArg(), 2).
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(regexp.QuoteMeta("SELECT RELEASE_LOCK(?)")).WithArgs(lockName).WillReturnResult(sqlmock.NewResult(0, 0))
+
+  count, err := m.Up(context.Background(), 0)
+  if err != nil {
+    t.Fatalf("Up() error = %v", err)
+  }
+  if count != 1 {
+    t.Errorf("Up() = %d, want 1", count)
+  }
+  if err := mock.ExpectationsWereMet(); err != nil {
+    t.Error(err)
+  }
+}
+
+func TestUpRefusesModifiedMigrations(t *testing.T) {
+  fsys := fstest.MapFS{
+    "0001_first.up.sql": {Data: []byte("CREATE TABLE a (id int);")},
+  }
+  m, mock := newMigrator(t, fsys)
+
+  mock.ExpectQuery("SELECT version, name, checksum, applied_at, dirty FROM schema_migrations").
+    WillReturnRows(sqlmock.NewRows([]string{"version", "name", "checksum", "applied_at", "dirty"}).
+      AddRow(1, "first", "checksum of an older file", 1, false))
+  mock.ExpectExec(regexp.QuoteMeta("SELECT RELEASE_LOCK(?)")).WithArgs(lockName).WillReturnResult(sqlmock.NewResult(0, 0))
+
+  if _, err := m.Up(context.Background(), 0); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+    t.Errorf("Up() error = %v, want a checksum mismatch", err)
+  }
+  if err := mock.ExpectationsWereMet(); err != nil {
+    t.Error(err)
+  }
+}
+
+func TestDownRevertsLastMigration(t *testing.T) {
+  fsys := fstest.MapFS{
+    "0001_first.up.sql":    {Data: []byte("CREATE TABLE a (id int);")},
+    "0001_first.down.sql":  {Data: []byte("DROP TABLE a;")},
+    "0002_second.up.sql":   {Data: []byte("CREATE TABLE b (id int);")},
+    "0002_second.down.sql": {Data: []byte("DROP TABLE b;")},
+  }
+  m, mock := newMigrator(t, fsys)
+
+  mock.ExpectQuery("SELECT version, name, checksum, applied_at, dirty FROM schema_migrations").
+    WillReturnRows(sqlmock.NewRows([]string{"version", "name", "checksum", "applied_at", "dirty"}).
+      AddRow(1, "first", m.migrations[0].Checksum, 1, false).
+      AddRow(2, "second", m.migrations[1].Checksum, 1, false))
+  mock.ExpectExec("INSERT INTO schema_migrations").WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec("DROP TABLE b").WillReturnResult(sqlmock.NewResult(0, 0))
+  mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = ?")).WithArgs(2).
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(regexp.QuoteMeta("SELECT RELEASE_LOCK(?)")).WithArgs(lockName).WillReturnResult(sqlmock.NewResult(0, 0))
+
+  count, err := m.Down(context.Background(), 1)
+  if err != nil {
+    t.Fatalf("Down() error = %v", err)
+  }
+  if count != 1 {
+    t.Errorf("Down() = %d, want 1", count)
+  }
+  if err := mock.ExpectationsWereMet(); err != nil {
+    t.Error(err)
+  }
+}
+
+func TestFailedStatementLeavesMigrationDirty(t *testing.T) {
+  fsys := fstest.MapFS{
+    "0001_first.up.sql": {Data: []byte("CREATE TABLE a (id int);")},
+  }
+  m, mock := newMigrator(t, fsys)
+
+  mock.ExpectQuery("SELECT version, name, checksum, applied_at, dirty FROM schema_migrations").
+    WillReturnRows(sqlmock.NewRows([]string{"version", "name", "checksum", "applied_at", "dirty"}))
+  mock.ExpectExec("INSERT INTO schema_migrations").WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec("CREATE TABLE a").WillReturnError(sqlmock.ErrCancelled)
+  mock.ExpectExec(regexp.QuoteMeta("SELECT RELEASE_LOCK(?)")).WithArgs(lockName).WillReturnResult(sqlmock.NewResult(0, 0))
+
+  if _, err := m.Up(context.Background(), 0); err == nil || !strings.Contains(err.Error(), "migrate force") {
+    t.Errorf("Up() error = %v, want a failed migration", err)
+  }
+  // the row inserted dirty is never cleaned
+  if err := mock.ExpectationsWereMet(); err != nil {
+    t.Error(err)
+  }
+}
@@ -0,0 +1,240 @@
+package middleware
+
+import (
+  "context"
+  "crypto/rsa"
+  "encoding/base64"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "io/ioutil"
+  "math/big"
+  "strings"
+  "time"
+
+  jwt "github.com/golang-jwt/jwt/v4"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/metadata"
+
+  "github.com/ckbball/dev-team/pkg/auth"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// Authenticator validates bearer JWTs signed with HS256 or RS256.
+// Requests without a token pass through unauthenticated so public reads keep
+// working, handlers that need a caller reject them. Requests with an invalid
+// token are rejected with codes.Unauthenticated.
+type Authenticator struct {
+  // secret verifies HS256 tokens
+  secret []byte
+  // keys verify RS256 tokens by key id
+  keys     map[string]*rsa.PublicKey
+  issuer   string
+  audience string
+}
+
+// NewAuthenticator creates an Authenticator accepting HS256 tokens signed with
+// secret and RS256 tokens signed by a key of the JWKS file at jwksFile. Either
+// may be empty but not both. issuer and audience are only checked when set.
+func NewAuthenticator(secret, jwksFile, issuer, audience string) (*Authenticator, error) {
+  a := &Authenticator{
+    keys:     map[string]*rsa.PublicKey{},
+    issuer:   issuer,
+    audience: audience,
+  }
+  if len(secret) > 0 {
+    a.secret = []byte(secret)
+  }
+  if len(jwksFile) > 0 {
+    keys, err := loadJWKS(jwksFile)
+    if err != nil {
+      return nil, fmt.Errorf("failed to load JWKS file: %v", err)
+    }
+    a.keys = keys
+  }
+  if a.secret == nil && len(a.keys) == 0 {
+    return nil, errors.New("no JWT secret or JWKS keys configured")
+  }
+  return a, nil
+}
+
+// Interceptors returns the unary and stream interceptors authenticating requests
+func (a *Authenticator) Interceptors() Interceptors {
+  return Interceptors{
+    Unary:  []grpc.UnaryServerInterceptor{a.unaryServerInterceptor},
+    Stream: []grpc.StreamServerInterceptor{a.streamServerInterceptor},
+  }
+}
+
+func (a *Authenticator) unaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+  ctx, err := a.authenticate(ctx)
+  if err != nil {
+    return nil, err
+  }
+  return handler(ctx, req)
+}
+
+func (a *Authenticator) streamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+  ctx, err := a.authenticate(stream.Context())
+  if err != nil {
+    return err
+  }
+  return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+}
+
+// authenticate returns ctx carrying the identity of the bearer token sent in
+// the authorization metadata
+func (a *Authenticator) authenticate(ctx context.Context) (context.Context, error) {
+  md, ok := metadata.FromIncomingContext(ctx)
+  if !ok {
+    return ctx, nil
+  }
+  values := md.Get("authorization")
+  if len(values) == 0 {
+    return ctx, nil
+  }
+
+  parts := strings.SplitN(values[0], " ", 2)
+  if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+    return nil, domainerr.Unauthenticated("authorization must be a bearer token")
+  }
+
+  identity, err := a.parse(strings.TrimSpace(parts[1]))
+  if err != nil {
+    return nil, domainerr.Unauthenticated("invalid token: %v", err)
+  }
+  return auth.NewContext(ctx, identity), nil
+}
+
+// parse verifies token and returns the identity it carries
+func (a *Authenticator) parse(token string) (*auth.Identity, error) {
+  claims := jwt.MapClaims{}
+  _, err := jwt.ParseWithClaims(token, claims, a.key)
+  if err != nil {
+    return nil, err
+  }
+  // parsing only rejects expired tokens, tokens that never expire are too
+  if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+    return nil, errors.New("token has no expiry")
+  }
+
+  if len(a.issuer) > 0 && !claims.VerifyIssuer(a.issuer, true) {
+    return nil, errors.New("unexpected issuer")
+  }
+  if len(a.audience) > 0 && !verifyAudience(claims, a.audience) {
+    return nil, errors.New("unexpected audience")
+  }
+
+  identity := &auth.Identity{}
+  identity.UserId, _ = claims["sub"].(string)
+  if len(identity.UserId) == 0 {
+    return nil, errors.New("token has no subject")
+  }
+  identity.Email, _ = claims["email"].(string)
+  if roles, ok := claims["roles"].([]interface{}); ok {
+    for _, role := range roles {
+      if r, ok := role.(string); ok {
+        identity.Roles = append(identity.Roles, r)
+      }
+    }
+  }
+  return identity, nil
+}
+
+// key returns the key verifying token, the algorithm must match the key type
+// so an RS256 public key can never be used as an HS256 secret
+func (a *Authenticator) key(token *jwt.Token) (interface{}, error) {
+  switch token.Method.Alg() {
+  case jwt.SigningMethodHS256.Alg():
+    if a.secret == nil {
+      return nil, errors.New("HS256 tokens are not accepted")
+    }
+    return a.secret, nil
+  case jwt.SigningMethodRS256.Alg():
+    kid, _ := token.Header["kid"].(string)
+    if key, ok := a.keys[kid]; ok {
+      return key, nil
+    }
+    // tokens without a key id are accepted when there is a single key
+    if len(kid) == 0 && len(a.keys) == 1 {
+      for _, key := range a.keys {
+        return key, nil
+      }
+    }
+    return nil, fmt.Errorf("unknown key id '%s'", kid)
+  default:
+    return nil, fmt.Errorf("unexpected signing method %v", token.Method.Alg())
+  }
+}
+
+// verifyAudience checks aud, which may be a single string or a list
+func verifyAudience(claims jwt.MapClaims, audience string) bool {
+  switch aud := claims["aud"].(type) {
+  case string:
+    return aud == audience
+  case []interface{}:
+    for _, a := range aud {
+      if s, ok := a.(string); ok && s == audience {
+        return true
+      }
+    }
+  }
+  return false
+}
+
+// jwks is the JSON Web Key Set document format
+type jwks struct {
+  Keys []struct {
+    Kty string `json:"kty"`
+    Kid string `json:"kid"`
+    Use string `json:"use"`
+    N   string `json:"n"`
+    E   string `json:"e"`
+  } `json:"keys"`
+}
+
+// loadJWKS reads the RSA signing keys of the JWKS file at path
+func loadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+  b, err := ioutil.ReadFile(path)
+  if err != nil {
+    return nil, err
+  }
+
+  set := jwks{}
+  if err := json.Unmarshal(b, &set); err != nil {
+    return nil, err
+  }
+
+  keys := map[string]*rsa.PublicKey{}
+  for _, k := range set.Keys {
+    if k.Kty != "RSA" || (len(k.Use) > 0 && k.Use != "sig") {
+      continue
+    }
+    n, err := base64.RawURLEncoding.DecodeString(k.N)
+    if err != nil {
+      return nil, fmt.Errorf("key '%s': invalid modulus: %v", k.Kid, err)
+    }
+    e, err := base64.RawURLEncoding.DecodeString(k.E)
+    if err != nil {
+      return nil, fmt.Errorf("key '%s': invalid exponent: %v", k.Kid, err)
+    }
+    keys[k.Kid] = &rsa.PublicKey{
+      N: new(big.Int).SetBytes(n),
+      E: int(new(big.Int).SetBytes(e).Int64()),
+    }
+  }
+  if len(keys) == 0 {
+    return nil, errors.New("no RSA signing keys found")
+  }
+  return keys, nil
+}
+
+// authenticatedStream overrides the context of a stream with the authenticated one
+type authenticatedStream struct {
+  grpc.ServerStream
+  ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+  return s.ctx
+}
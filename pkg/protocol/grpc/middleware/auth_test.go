@@ -0,0 +1,290 @@
+package middleware
+
+import (
+  "context"
+  "crypto/rand"
+  "crypto/rsa"
+  "crypto/x509"
+  "encoding/base64"
+  "encoding/json"
+  "encoding/pem"
+  "io/ioutil"
+  "math/big"
+  "path/filepath"
+  "reflect"
+  "testing"
+  "time"
+
+  jwt "github.com/golang-jwt/jwt/v4"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+
+  "github.com/ckbball/dev-team/pkg/auth"
+)
+
+const testSecret = "secret"
+
+// writeJWKS writes a JWKS file with the public keys of keys by key id
+func writeJWKS(t *testing.T, keys map[string]*rsa.PrivateKey) string {
+  set := map[string][]map[string]string{}
+  for kid, key := range keys {
+    set["keys"] = append(set["keys"], map[string]string{
+      "kty": "RSA",
+      "kid": kid,
+      "use": "sig",
+      "n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+      "e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+    })
+  }
+  b, err := json.Marshal(set)
+  if err != nil {
+    t.Fatal(err)
+  }
+  path := filepath.Join(t.TempDir(), "jwks.json")
+  if err := ioutil.WriteFile(path, b, 0600); err != nil {
+    t.Fatal(err)
+  }
+  return path
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+  key, err := rsa.GenerateKey(rand.Reader, 2048)
+  if err != nil {
+    t.Fatal(err)
+  }
+  return key
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+  token := jwt.NewWithClaims(method, claims)
+  if kid != "" {
+    token.Header["kid"] = kid
+  }
+  s, err := token.SignedString(key)
+  if err != nil {
+    t.Fatal(err)
+  }
+  return s
+}
+
+func bearer(token string) context.Context {
+  return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func claims(extra jwt.MapClaims) jwt.MapClaims {
+  c := jwt.MapClaims{"sub": "7", "exp": time.Now().Add(time.Hour).Unix()}
+  for k, v := range extra {
+    c[k] = v
+  }
+  return c
+}
+
+func TestAuthenticate(t *testing.T) {
+  key := newRSAKey(t)
+  other := newRSAKey(t)
+  a, err := NewAuthenticator(testSecret, writeJWKS(t, map[string]*rsa.PrivateKey{"k1": key}), "", "")
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  tests := []struct {
+    name  string
+    token string
+    ok    bool
+  }{
+    {"HS256", sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), claims(nil)), true},
+    {"RS256 with a key id", sign(t, jwt.SigningMethodRS256, "k1", key, claims(nil)), true},
+    {"RS256 without a key id", sign(t, jwt.SigningMethodRS256, "", key, claims(nil)), true},
+    {"HS256 with another secret", sign(t, jwt.SigningMethodHS256, "", []byte("other"), claims(nil)), false},
+    {"RS256 with an unknown key id", sign(t, jwt.SigningMethodRS256, "k2", key, claims(nil)), false},
+    {"RS256 signed by another key", sign(t, jwt.SigningMethodRS256, "k1", other, claims(nil)), false},
+    {"HS512", sign(t, jwt.SigningMethodHS512, "", []byte(testSecret), claims(nil)), false},
+    {"expired", sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), claims(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()})), false},
+    {"no expiry", sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), jwt.MapClaims{"sub": "7"}), false},
+    {"no subject", sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()}), false},
+    {"malformed", "not.a.token", false},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      ctx, err := a.authenticate(bearer(tt.token))
+      if !tt.ok {
+        if status.Code(err) != codes.Unauthenticated {
+          t.Errorf("authenticate() error = %v, want Unauthenticated", err)
+        }
+        return
+      }
+      if err != nil {
+        t.Fatal(err)
+      }
+      if identity, ok := auth.FromContext(ctx); !ok || identity.UserId != "7" {
+        t.Errorf("identity = %v", identity)
+      }
+    })
+  }
+}
+
+func TestAuthenticateRejectsKeyConfusion(t *testing.T) {
+  key := newRSAKey(t)
+  a, err := NewAuthenticator("", writeJWKS(t, map[string]*rsa.PrivateKey{"k1": key}), "", "")
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  // the public key used as an HS256 secret must not verify
+  der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+  if err != nil {
+    t.Fatal(err)
+  }
+  public := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+  token := sign(t, jwt.SigningMethodHS256, "k1", public, claims(nil))
+  if _, err := a.authenticate(bearer(token)); status.Code(err) != codes.Unauthenticated {
+    t.Errorf("authenticate() error = %v, want Unauthenticated", err)
+  }
+
+  token = sign(t, jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType, claims(nil))
+  if _, err := a.authenticate(bearer(token)); status.Code(err) != codes.Unauthenticated {
+    t.Errorf("unsigned token error = %v, want Unauthenticated", err)
+  }
+}
+
+func TestAuthenticateIdentity(t *testing.T) {
+  a, err := NewAuthenticator(testSecret, "", "", "")
+  if err != nil {
+    t.Fatal(err)
+  }
+  token := sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), claims(jwt.MapClaims{
+    "email": "m@example.com",
+    "roles": []interface{}{"support", 3, "admin"},
+  }))
+
+  ctx, err := a.authenticate(bearer(token))
+  if err != nil {
+    t.Fatal(err)
+  }
+  identity, _ := auth.FromContext(ctx)
+  want := &auth.Identity{UserId: "7", Email: "m@example.com", Roles: []string{"support", "admin"}}
+  if !reflect.DeepEqual(identity, want) {
+    t.Errorf("identity = %+v, want %+v", identity, want)
+  }
+}
+
+func TestAuthenticateIssuerAndAudience(t *testing.T) {
+  a, err := NewAuthenticator(testSecret, "", "https://users.example.com", "dev-team")
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  tests := []struct {
+    name   string
+    claims jwt.MapClaims
+    ok     bool
+  }{
+    {"matching", jwt.MapClaims{"iss": "https://users.example.com", "aud": "dev-team"}, true},
+    {"audience list", jwt.MapClaims{"iss": "https://users.example.com", "aud": []interface{}{"web", "dev-team"}}, true},
+    {"other issuer", jwt.MapClaims{"iss": "https://evil.example.com", "aud": "dev-team"}, false},
+    {"no issuer", jwt.MapClaims{"aud": "dev-team"}, false},
+    {"other audience", jwt.MapClaims{"iss": "https://users.example.com", "aud": "web"}, false},
+    {"no audience", jwt.MapClaims{"iss": "https://users.example.com"}, false},
+  }
+  for _, tt := range tests {
+    token := sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), claims(tt.claims))
+    _, err := a.authenticate(bearer(token))
+    if ok := err == nil; ok != tt.ok {
+      t.Errorf("%s: authenticate() error = %v", tt.name, err)
+    }
+  }
+}
+
+func TestAuthenticateWithoutToken(t *testing.T) {
+  a, err := NewAuthenticator(testSecret, "", "", "")
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  // public reads pass through without an identity
+  for _, ctx := range []context.Context{
+    context.Background(),
+    metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "1")),
+  } {
+    ctx, err := a.authenticate(ctx)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if _, ok := auth.FromContext(ctx); ok {
+      t.Error("a request without a token has an identity")
+    }
+  }
+
+  ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic dXNlcjpwYXNz"))
+  if _, err := a.authenticate(ctx); status.Code(err) != codes.Unauthenticated {
+    t.Errorf("basic auth error = %v, want Unauthenticated", err)
+  }
+}
+
+func TestNewAuthenticator(t *testing.T) {
+  if _, err := NewAuthenticator("", "", "", ""); err == nil {
+    t.Error("an authenticator without keys must fail")
+  }
+  if _, err := NewAuthenticator("", filepath.Join(t.TempDir(), "missing.json"), "", ""); err == nil {
+    t.Error("a missing JWKS file must fail")
+  }
+
+  // encryption keys and other key types are skipped
+  path := filepath.Join(t.TempDir(), "jwks.json")
+  ioutil.WriteFile(path, []byte(`{"keys": [{"kty": "EC", "kid": "1"}, {"kty": "RSA", "kid": "2", "use": "enc", "n": "AQAB", "e": "AQAB"}]}`), 0600)
+  if _, err := NewAuthenticator("", path, "", ""); err == nil {
+    t.Error("a JWKS file without RSA signing keys must fail")
+  }
+}
+
+func TestAuthInterceptors(t *testing.T) {
+  a, err := NewAuthenticator(testSecret, "", "", "")
+  if err != nil {
+    t.Fatal(err)
+  }
+  ctx := bearer(sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), claims(nil)))
+  interceptors := a.Interceptors()
+
+  var caller string
+  _, err = interceptors.Unary[0](ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+    identity, _ := auth.FromContext(ctx)
+    caller = identity.UserId
+    return nil, nil
+  })
+  if err != nil || caller != "7" {
+    t.Errorf("unary handler saw caller %q, error %v", caller, err)
+  }
+
+  caller = ""
+  err = interceptors.Stream[0](nil, &contextStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+    identity, _ := auth.FromContext(stream.Context())
+    caller = identity.UserId
+    return nil
+  })
+  if err != nil || caller != "7" {
+    t.Errorf("stream handler saw caller %q, error %v", caller, err)
+  }
+
+  // rejected requests never reach the handler
+  bad := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer bad"))
+  _, err = interceptors.Unary[0](bad, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+    t.Error("the handler was reached")
+    return nil, nil
+  })
+  if status.Code(err) != codes.Unauthenticated {
+    t.Errorf("error = %v, want Unauthenticated", err)
+  }
+}
+
+// contextStream is a server stream carrying ctx
+type contextStream struct {
+  grpc.ServerStream
+  ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+  return s.ctx
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+  "context"
+  "errors"
+
+  "go.uber.org/zap"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// Errors returns the interceptors converting handler errors to gRPC statuses.
+// Domain errors keep their code and details, context errors map to Canceled
+// and DeadlineExceeded and anything else, e.g. a raw SQL error, is logged and
+// replaced by Internal so its text never reaches clients.
+func Errors(logger *zap.Logger) Interceptors {
+  return Interceptors{
+    Unary: []grpc.UnaryServerInterceptor{
+      func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+        resp, err := handler(ctx, req)
+        if err != nil {
+          return nil, toStatusError(logger, info.FullMethod, err)
+        }
+        return resp, nil
+      },
+    },
+    Stream: []grpc.StreamServerInterceptor{
+      func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+        if err := handler(srv, stream); err != nil {
+          return toStatusError(logger, info.FullMethod, err)
+        }
+        return nil
+      },
+    },
+  }
+}
+
+func toStatusError(logger *zap.Logger, method string, err error) error {
+  var domainErr *domainerr.Error
+  if errors.As(err, &domainErr) {
+    if domainErr.Code == codes.Internal {
+      logger.Error("internal error", zap.String("method", method), zap.Error(err))
+    }
+    return domainErr.GRPCStatus().Err()
+  }
+
+  // errors created with the status package are already meant for clients
+  if _, ok := status.FromError(err); ok {
+    return err
+  }
+
+  switch {
+  case errors.Is(err, context.Canceled):
+    return status.Error(codes.Canceled, "request canceled")
+  case errors.Is(err, context.DeadlineExceeded):
+    return status.Error(codes.DeadlineExceeded, "deadline exceeded")
+  }
+
+  logger.Error("internal error", zap.String("method", method), zap.Error(err))
+  return domainerr.Internal(err).GRPCStatus().Err()
+}
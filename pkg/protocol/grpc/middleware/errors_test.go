@@ -0,0 +1,78 @@
+package middleware
+
+import (
+  "context"
+  "errors"
+  "fmt"
+  "testing"
+
+  "go.uber.org/zap"
+  "go.uber.org/zap/zapcore"
+  "go.uber.org/zap/zaptest/observer"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+func TestErrorsInterceptor(t *testing.T) {
+  sqlErr := errors.New("Error 1062: Duplicate entry 'gophers' for key 'team_name'")
+
+  tests := []struct {
+    name    string
+    err     error
+    code    codes.Code
+    message string
+    reason  string
+    logged  bool
+  }{
+    {"domain error", domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '3' not found"), codes.NotFound, "team '3' not found", domainerr.ReasonTeamNotFound, false},
+    {"wrapped domain error", fmt.Errorf("loading: %w", domainerr.FailedPrecondition(domainerr.ReasonTeamFull, "team is full")), codes.FailedPrecondition, "team is full", domainerr.ReasonTeamFull, false},
+    {"status error", status.Error(codes.Unimplemented, "not implemented"), codes.Unimplemented, "not implemented", "", false},
+    {"canceled", fmt.Errorf("query: %w", context.Canceled), codes.Canceled, "request canceled", "", false},
+    {"deadline", context.DeadlineExceeded, codes.DeadlineExceeded, "deadline exceeded", "", false},
+    {"raw error", sqlErr, codes.Internal, "internal error", domainerr.ReasonInternal, true},
+    {"internal domain error", domainerr.Internal(sqlErr), codes.Internal, "internal error", domainerr.ReasonInternal, true},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      core, logs := observer.New(zapcore.ErrorLevel)
+      interceptor := Errors(zap.New(core)).Unary[0]
+
+      _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/v1.TeamService/CreateTeam"},
+        func(ctx context.Context, req interface{}) (interface{}, error) { return nil, tt.err })
+
+      st, _ := status.FromError(err)
+      if st.Code() != tt.code || st.Message() != tt.message {
+        t.Errorf("status = %s %q, want %s %q", st.Code(), st.Message(), tt.code, tt.message)
+      }
+      if reason := statusReason(st); reason != tt.reason {
+        t.Errorf("reason = %q, want %q", reason, tt.reason)
+      }
+      if logged := logs.Len() > 0; logged != tt.logged {
+        t.Errorf("logged = %v, want %v", logged, tt.logged)
+      }
+    })
+  }
+}
+
+func TestErrorsInterceptorPassesResponses(t *testing.T) {
+  interceptor := Errors(zap.NewNop()).Unary[0]
+  resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{},
+    func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil })
+  if err != nil || resp != "ok" {
+    t.Errorf("interceptor() = %v, %v", resp, err)
+  }
+}
+
+// statusReason returns the ErrorInfo reason of st, "" if it has none
+func statusReason(st *status.Status) string {
+  for _, detail := range st.Details() {
+    if info, ok := detail.(interface{ GetReason() string }); ok {
+      return info.GetReason()
+    }
+  }
+  return ""
+}
@@ -1,7 +1,6 @@
 package middleware
 
 import (
-  "github.com/grpc-ecosystem/go-grpc-middleware"
   "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
   "github.com/grpc-ecosystem/go-grpc-middleware/tags"
   "go.uber.org/zap"
@@ -20,8 +19,8 @@ func codeToLevel(code codes.Code) zapcore.Level {
   return grpc_zap.DefaultCodeToLevel(code)
 }
 
-// AddLogging returns grpc.Server config option that turn on logging.
-func AddLogging(logger *zap.Logger, opts []grpc.ServerOption) []grpc.ServerOption {
+// Logging returns the interceptors that turn on logging.
+func Logging(logger *zap.Logger) Interceptors {
   // Shared options for the logger, with a custom gRPC code to log level function.
   o := []grpc_zap.Option{
     grpc_zap.WithLevels(codeToLevel),
@@ -29,17 +28,21 @@ func AddLogging(logger *zap.Logger, opts []grpc.ServerOption) []grpc.ServerOptio
   // Make sure that log statements internal to gRPC library are logged using the zapLogger as well.
   grpc_zap.ReplaceGrpcLogger(logger)
 
-  // Add unary interceptor
-  opts = append(opts, grpc_middleware.WithUnaryServerChain(
-    grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
-    grpc_zap.UnaryServerInterceptor(logger, o...),
-  ))
-
-  // Add stream interceptor (added as an example here)
-  opts = append(opts, grpc_middleware.WithStreamServerChain(
-    grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
-    grpc_zap.StreamServerInterceptor(logger, o...),
-  ))
+  return Interceptors{
+    Unary: []grpc.UnaryServerInterceptor{
+      grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
+      grpc_zap.UnaryServerInterceptor(logger, o...),
+    },
+    // stream interceptors (added as an example here)
+    Stream: []grpc.StreamServerInterceptor{
+      grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
+      grpc_zap.StreamServerInterceptor(logger, o...),
+    },
+  }
+}
 
-  return opts
+// AddLogging returns grpc.Server config option that turn on logging.
+// Servers adding other interceptors must use AddInterceptors with Logging instead.
+func AddLogging(logger *zap.Logger, opts []grpc.ServerOption) []grpc.ServerOption {
+  return AddInterceptors(opts, Logging(logger))
 }
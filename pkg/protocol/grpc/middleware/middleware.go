@@ -0,0 +1,28 @@
+package middleware
+
+import (
+  "github.com/grpc-ecosystem/go-grpc-middleware"
+  "google.golang.org/grpc"
+)
+
+// Interceptors groups the unary and stream interceptors of one middleware
+type Interceptors struct {
+  Unary  []grpc.UnaryServerInterceptor
+  Stream []grpc.StreamServerInterceptor
+}
+
+// AddInterceptors returns grpc.Server config options running every
+// interceptor of chain in order. gRPC accepts a single unary and stream
+// interceptor per server so all middlewares must be added in one call.
+func AddInterceptors(opts []grpc.ServerOption, chain ...Interceptors) []grpc.ServerOption {
+  unary := []grpc.UnaryServerInterceptor{}
+  stream := []grpc.StreamServerInterceptor{}
+  for _, i := range chain {
+    unary = append(unary, i.Unary...)
+    stream = append(stream, i.Stream...)
+  }
+
+  opts = append(opts, grpc_middleware.WithUnaryServerChain(unary...))
+  opts = append(opts, grpc_middleware.WithStreamServerChain(stream...))
+  return opts
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+  "context"
+  "crypto/rand"
+  "encoding/hex"
+
+  "github.com/grpc-ecosystem/go-grpc-middleware"
+  "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/metadata"
+)
+
+// RequestIdMetadata identifies a request in logs and in the audit trail,
+// callers may send their own
+const RequestIdMetadata = "x-request-id"
+
+// maxRequestIdLen caps request ids sent by callers, longer ones are replaced
+const maxRequestIdLen = 64
+
+// RequestId returns the interceptors making sure every request carries an
+// x-request-id in its incoming metadata. The id is generated when missing,
+// sent back in the response headers and added to the log tags.
+func RequestId() Interceptors {
+  return Interceptors{
+    Unary: []grpc.UnaryServerInterceptor{
+      func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+        ctx = withRequestId(ctx)
+        return handler(ctx, req)
+      },
+    },
+    Stream: []grpc.StreamServerInterceptor{
+      func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+        wrapped := grpc_middleware.WrapServerStream(stream)
+        wrapped.WrappedContext = withRequestId(stream.Context())
+        return handler(srv, wrapped)
+      },
+    },
+  }
+}
+
+// withRequestId returns ctx with the request id set in its incoming metadata
+func withRequestId(ctx context.Context) context.Context {
+  md, ok := metadata.FromIncomingContext(ctx)
+  if !ok {
+    md = metadata.MD{}
+  }
+  md = md.Copy()
+
+  id := ""
+  if values := md.Get(RequestIdMetadata); len(values) > 0 && len(values[0]) <= maxRequestIdLen {
+    id = values[0]
+  }
+  if id == "" {
+    id = newRequestId()
+    md.Set(RequestIdMetadata, id)
+  }
+
+  grpc.SetHeader(ctx, metadata.Pairs(RequestIdMetadata, id))
+  grpc_ctxtags.Extract(ctx).Set("request_id", id)
+  return metadata.NewIncomingContext(ctx, md)
+}
+
+// newRequestId returns a random 128 bit id
+func newRequestId() string {
+  b := make([]byte, 16)
+  rand.Read(b)
+  return hex.EncodeToString(b)
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+  "context"
+
+  "google.golang.org/grpc"
+)
+
+// validator is implemented by request messages with validation rules
+type validator interface {
+  Validate() error
+}
+
+// Validation returns the interceptors rejecting invalid requests before they
+// reach a handler. Validate errors are InvalidArgument domain errors listing
+// every invalid field.
+func Validation() Interceptors {
+  return Interceptors{
+    Unary: []grpc.UnaryServerInterceptor{
+      func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+        if v, ok := req.(validator); ok {
+          if err := v.Validate(); err != nil {
+            return nil, err
+          }
+        }
+        return handler(ctx, req)
+      },
+    },
+    Stream: []grpc.StreamServerInterceptor{
+      func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+        return handler(srv, &validatingStream{ServerStream: stream})
+      },
+    },
+  }
+}
+
+// validatingStream validates every message received on a stream
+type validatingStream struct {
+  grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+  if err := s.ServerStream.RecvMsg(m); err != nil {
+    return err
+  }
+  if v, ok := m.(validator); ok {
+    return v.Validate()
+  }
+  return nil
+}
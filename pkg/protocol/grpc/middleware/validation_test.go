@@ -0,0 +1,125 @@
+package middleware
+
+import (
+  "context"
+  "net"
+  "testing"
+
+  "go.uber.org/zap"
+  "google.golang.org/genproto/googleapis/rpc/errdetails"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+func TestValidationRejectsBeforeTheHandler(t *testing.T) {
+  interceptor := Validation().Unary[0]
+
+  _, err := interceptor(context.Background(), &v1.MemberUpsertRequest{TeamId: "x"}, &grpc.UnaryServerInfo{},
+    func(ctx context.Context, req interface{}) (interface{}, error) {
+      t.Error("the handler was reached")
+      return nil, nil
+    })
+  st := status.Convert(err)
+  if st.Code() != codes.InvalidArgument {
+    t.Fatalf("error = %v, want InvalidArgument", err)
+  }
+  // every invalid field is listed
+  var fields []string
+  for _, detail := range st.Details() {
+    if br, ok := detail.(*errdetails.BadRequest); ok {
+      for _, violation := range br.GetFieldViolations() {
+        fields = append(fields, violation.GetField())
+      }
+    }
+  }
+  if len(fields) != 4 {
+    t.Errorf("field violations = %v, want team_id, member_id, member_email and role", fields)
+  }
+
+  valid := &v1.GetByTeamIdRequest{Id: "3"}
+  resp, err := interceptor(context.Background(), valid, &grpc.UnaryServerInfo{},
+    func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil })
+  if err != nil || resp != valid {
+    t.Errorf("interceptor() = %v, %v", resp, err)
+  }
+}
+
+func TestValidationChecksStreamedMessages(t *testing.T) {
+  stream := &recvStream{msgs: []*v1.GetByTeamIdRequest{{Id: "3"}, {Id: "-1"}}}
+  err := Validation().Stream[0](nil, stream, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+    for i := 0; ; i++ {
+      if err := stream.RecvMsg(&v1.GetByTeamIdRequest{}); err != nil {
+        if i != 1 {
+          t.Errorf("message %d was rejected", i)
+        }
+        return err
+      }
+    }
+  })
+  if status.Code(err) != codes.InvalidArgument {
+    t.Errorf("error = %v, want InvalidArgument", err)
+  }
+}
+
+// recvStream is a server stream receiving msgs
+type recvStream struct {
+  grpc.ServerStream
+  msgs []*v1.GetByTeamIdRequest
+}
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+  if len(s.msgs) == 0 {
+    return status.Error(codes.OutOfRange, "no more messages")
+  }
+  *m.(*v1.GetByTeamIdRequest) = *s.msgs[0]
+  s.msgs = s.msgs[1:]
+  return nil
+}
+
+// teamServer answers GetTeamByTeamId
+type teamServer struct {
+  v1.UnimplementedTeamServiceServer
+}
+
+func (s *teamServer) GetTeamByTeamId(ctx context.Context, req *v1.GetByTeamIdRequest) (*v1.GetByTeamIdResponse, error) {
+  return &v1.GetByTeamIdResponse{}, nil
+}
+
+func TestValidationRunsAfterAuthentication(t *testing.T) {
+  a, err := NewAuthenticator(testSecret, "", "", "")
+  if err != nil {
+    t.Fatal(err)
+  }
+  lis, err := net.Listen("tcp", "127.0.0.1:0")
+  if err != nil {
+    t.Fatal(err)
+  }
+  // the order of the chain of the gRPC server
+  server := grpc.NewServer(AddInterceptors(nil, Errors(zap.NewNop()), a.Interceptors(), Validation())...)
+  v1.RegisterTeamServiceServer(server, &teamServer{})
+  go server.Serve(lis)
+  defer server.Stop()
+
+  conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer conn.Close()
+  client := v1.NewTeamServiceClient(conn)
+
+  // an invalid request with a bad token is unauthenticated rather than invalid
+  bad := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer bad")
+  if _, err := client.GetTeamByTeamId(bad, &v1.GetByTeamIdRequest{}); status.Code(err) != codes.Unauthenticated {
+    t.Errorf("error = %v, want Unauthenticated", err)
+  }
+  if _, err := client.GetTeamByTeamId(context.Background(), &v1.GetByTeamIdRequest{}); status.Code(err) != codes.InvalidArgument {
+    t.Errorf("error = %v, want InvalidArgument", err)
+  }
+  if _, err := client.GetTeamByTeamId(context.Background(), &v1.GetByTeamIdRequest{Id: "3"}); err != nil {
+    t.Errorf("error = %v", err)
+  }
+}
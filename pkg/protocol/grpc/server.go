@@ -6,6 +6,7 @@ import (
   "net"
   "os"
   "os/signal"
+  "syscall"
 
   "google.golang.org/grpc"
 
@@ -14,8 +15,11 @@ import (
   "github.com/ckbball/dev-team/pkg/protocol/grpc/middleware"
 )
 
-// RunServer runs gRPC service to publish Team service
-func RunServer(ctx context.Context, v1API v1.TeamServiceServer, port string) error {
+// RunServer runs gRPC service to publish Team service. interceptors, e.g.
+// authentication, run after logging, request ids and error conversion and
+// before request validation, so unauthenticated callers learn nothing about
+// the shape of valid requests.
+func RunServer(ctx context.Context, v1API v1.TeamServiceServer, port string, interceptors ...middleware.Interceptors) error {
   listen, err := net.Listen("tcp", ":"+port)
   if err != nil {
     return err
@@ -23,7 +27,10 @@ func RunServer(ctx context.Context, v1API v1.TeamServiceServer, port string) err
 
   opts := []grpc.ServerOption{}
 
-  opts = middleware.AddLogging(logger.Log, opts)
+  chain := []middleware.Interceptors{middleware.Logging(logger.Log), middleware.RequestId(), middleware.Errors(logger.Log)}
+  chain = append(chain, interceptors...)
+  chain = append(chain, middleware.Validation())
+  opts = middleware.AddInterceptors(opts, chain...)
 
   // register service
   server := grpc.NewServer(opts...)
@@ -31,10 +38,10 @@ func RunServer(ctx context.Context, v1API v1.TeamServiceServer, port string) err
 
   // graceful shutdown
   c := make(chan os.Signal, 1)
-  signal.Notify(c, os.Interrupt)
+  signal.Notify(c, os.Interrupt, syscall.SIGTERM)
   go func() {
     for range c {
-      // sig is a ^C, handle it
+      // sig is a ^C or a termination request, handle it
       log.Println("shutting down gRPC server...")
 
       server.GracefulStop()
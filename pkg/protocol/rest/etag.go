@@ -0,0 +1,116 @@
+package rest
+
+import (
+  "context"
+  "net/http"
+  "strconv"
+  "strings"
+
+  "github.com/golang/protobuf/proto"
+  "github.com/grpc-ecosystem/grpc-gateway/runtime"
+  "google.golang.org/genproto/googleapis/rpc/errdetails"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// expectedVersionMetadata is read by the service as the expected_version of
+// mutating requests that don't set it
+const expectedVersionMetadata = "expected-version"
+
+// etag returns the ETag of a team version
+func etag(version int64) string {
+  return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatchVersion returns the version of the If-Match header of r, "" when
+// it is missing or "*" which only requires the team to exist. ok is false
+// when the header can't match any team: If-Match uses the strong comparison
+// so weak tags never match, and a team has a single numeric version.
+func ifMatchVersion(r *http.Request) (version string, ok bool) {
+  tag := strings.TrimSpace(r.Header.Get("If-Match"))
+  if tag == "" || tag == "*" {
+    return "", true
+  }
+  if len(tag) < 3 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+    return "", false
+  }
+  version = tag[1 : len(tag)-1]
+  if v, err := strconv.ParseInt(version, 10, 64); err != nil || v <= 0 {
+    return "", false
+  }
+  return version, true
+}
+
+// ifMatch forwards the version of an If-Match header to the service
+func ifMatch(ctx context.Context, r *http.Request) metadata.MD {
+  version, ok := ifMatchVersion(r)
+  if !ok || version == "" {
+    return nil
+  }
+  return metadata.Pairs(expectedVersionMetadata, version)
+}
+
+// checkIfMatch answers 412 Precondition Failed to requests whose If-Match
+// header can't match any team before they reach the service
+func checkIfMatch(mux *runtime.ServeMux) http.Handler {
+  return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    if _, ok := ifMatchVersion(r); !ok {
+      _, outbound := runtime.MarshalerForRequest(mux, r)
+      err := domainerr.Aborted(domainerr.ReasonVersionMismatch, "If-Match %s doesn't match any team version, tags are compared strongly", r.Header.Get("If-Match"))
+      versionError(r.Context(), mux, outbound, w, r, err)
+      return
+    }
+    mux.ServeHTTP(w, r)
+  })
+}
+
+// setETag sets the ETag header of responses carrying a team or a team version
+func setETag(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
+  var version int64
+  switch m := resp.(type) {
+  case interface{ GetTeam() *v1.Team }:
+    version = m.GetTeam().GetVersion()
+  case interface{ GetVersion() int64 }:
+    version = m.GetVersion()
+  }
+  if version > 0 {
+    w.Header().Set("ETag", etag(version))
+  }
+  return nil
+}
+
+// versionError answers 412 Precondition Failed instead of 409 Conflict when
+// the version of an If-Match header is not the team's current one, and
+// instead of 404 Not Found when the team of an If-Match: * request is missing
+func versionError(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+  tag := strings.TrimSpace(r.Header.Get("If-Match"))
+  code := status.Code(err)
+  if tag != "" && code == codes.Aborted || tag == "*" && code == codes.NotFound && reason(err) == domainerr.ReasonTeamNotFound {
+    w = &statusWriter{ResponseWriter: w, code: http.StatusPreconditionFailed}
+  }
+  runtime.DefaultHTTPError(ctx, mux, marshaler, w, r, err)
+}
+
+// reason returns the ErrorInfo reason of the status of err
+func reason(err error) string {
+  for _, detail := range status.Convert(err).Details() {
+    if info, ok := detail.(*errdetails.ErrorInfo); ok {
+      return info.Reason
+    }
+  }
+  return ""
+}
+
+// statusWriter replaces the status code written to a response
+type statusWriter struct {
+  http.ResponseWriter
+  code int
+}
+
+func (w *statusWriter) WriteHeader(int) {
+  w.ResponseWriter.WriteHeader(w.code)
+}
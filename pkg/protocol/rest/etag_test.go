@@ -0,0 +1,136 @@
+package rest
+
+import (
+  "context"
+  "errors"
+  "net/http"
+  "net/http/httptest"
+  "reflect"
+  "testing"
+
+  "github.com/golang/protobuf/proto"
+  "github.com/grpc-ecosystem/grpc-gateway/runtime"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+func request(ifMatch string) *http.Request {
+  r := httptest.NewRequest(http.MethodPatch, "/v1/teams/1", nil)
+  if ifMatch != "" {
+    r.Header.Set("If-Match", ifMatch)
+  }
+  return r
+}
+
+func TestIfMatchVersion(t *testing.T) {
+  tests := []struct {
+    header  string
+    version string
+    ok      bool
+  }{
+    {header: "", version: "", ok: true},
+    {header: "*", version: "", ok: true},
+    {header: `"3"`, version: "3", ok: true},
+    {header: ` "3" `, version: "3", ok: true},
+    {header: `W/"3"`, ok: false},
+    {header: `3`, ok: false},
+    {header: `"three"`, ok: false},
+    {header: `"0"`, ok: false},
+    {header: `"3", "4"`, ok: false},
+  }
+
+  for _, tt := range tests {
+    version, ok := ifMatchVersion(request(tt.header))
+    if version != tt.version || ok != tt.ok {
+      t.Errorf("ifMatchVersion(%s) = %q, %v, want %q, %v", tt.header, version, ok, tt.version, tt.ok)
+    }
+  }
+}
+
+func TestIfMatchForwardsStrongTags(t *testing.T) {
+  md := ifMatch(context.Background(), request(`"7"`))
+  if !reflect.DeepEqual(md.Get(expectedVersionMetadata), []string{"7"}) {
+    t.Errorf("ifMatch() = %v", md)
+  }
+  for _, header := range []string{"", "*", `W/"7"`} {
+    if md := ifMatch(context.Background(), request(header)); md != nil {
+      t.Errorf("ifMatch(%s) = %v, want nil", header, md)
+    }
+  }
+}
+
+func TestCheckIfMatch(t *testing.T) {
+  mux := runtime.NewServeMux(runtime.WithProtoErrorHandler(versionError))
+  reached := false
+  mux.Handle(http.MethodPatch, runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2}, []string{"v1", "teams", "id"}, "")),
+    func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+      reached = true
+    })
+  handler := checkIfMatch(mux)
+
+  w := httptest.NewRecorder()
+  handler.ServeHTTP(w, request(`W/"7"`))
+  if w.Code != http.StatusPreconditionFailed || reached {
+    t.Errorf("weak tag answered %d, reached the service: %v", w.Code, reached)
+  }
+
+  w = httptest.NewRecorder()
+  handler.ServeHTTP(w, request(`"7"`))
+  if w.Code != http.StatusOK || !reached {
+    t.Errorf("strong tag answered %d, reached the service: %v", w.Code, reached)
+  }
+}
+
+func TestVersionError(t *testing.T) {
+  mismatch := domainerr.Aborted(domainerr.ReasonVersionMismatch, "team '1' is at version 8, not 7")
+  missing := domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '1' not found")
+  memberMissing := domainerr.NotFound(domainerr.ReasonMemberNotFound, "member not found")
+
+  tests := []struct {
+    name   string
+    header string
+    err    error
+    code   int
+  }{
+    {name: "stale tag", header: `"7"`, err: mismatch, code: http.StatusPreconditionFailed},
+    {name: "stale expected_version", err: mismatch, code: http.StatusConflict},
+    {name: "any tag on a missing team", header: "*", err: missing, code: http.StatusPreconditionFailed},
+    {name: "any tag on a missing member", header: "*", err: memberMissing, code: http.StatusNotFound},
+    {name: "missing team", header: `"7"`, err: missing, code: http.StatusNotFound},
+    {name: "other errors", header: `"7"`, err: errors.New("boom"), code: http.StatusInternalServerError},
+  }
+
+  mux := runtime.NewServeMux()
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      w := httptest.NewRecorder()
+      versionError(context.Background(), mux, &runtime.JSONPb{}, w, request(tt.header), tt.err)
+      if w.Code != tt.code {
+        t.Errorf("versionError() answered %d, want %d", w.Code, tt.code)
+      }
+    })
+  }
+}
+
+func TestSetETag(t *testing.T) {
+  tests := []struct {
+    name string
+    resp proto.Message
+    etag string
+  }{
+    {name: "team", resp: &v1.GetByTeamIdResponse{Team: &v1.Team{Version: 4}}, etag: `"4"`},
+    {name: "version", resp: &v1.TeamUpsertResponse{Version: 5}, etag: `"5"`},
+    {name: "no version", resp: &v1.GetTeamsResponse{}, etag: ""},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      w := httptest.NewRecorder()
+      setETag(context.Background(), w, tt.resp)
+      if got := w.Header().Get("ETag"); got != tt.etag {
+        t.Errorf("ETag = %q, want %q", got, tt.etag)
+      }
+    })
+  }
+}
@@ -0,0 +1,31 @@
+package rest
+
+import (
+  "net/textproto"
+
+  "github.com/grpc-ecosystem/grpc-gateway/runtime"
+
+  "github.com/ckbball/dev-team/pkg/protocol/grpc/middleware"
+)
+
+// requestIdHeader is passed through as the x-request-id metadata the gRPC
+// server generates when it is missing
+const requestIdHeader = "X-Request-Id"
+
+// incomingHeader forwards X-Request-Id along with the headers the gateway
+// forwards by default
+func incomingHeader(key string) (string, bool) {
+  if textproto.CanonicalMIMEHeaderKey(key) == requestIdHeader {
+    return middleware.RequestIdMetadata, true
+  }
+  return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeader answers the request id as X-Request-Id instead of
+// Grpc-Metadata-X-Request-Id
+func outgoingHeader(key string) (string, bool) {
+  if textproto.CanonicalMIMEHeaderKey(key) == requestIdHeader {
+    return requestIdHeader, true
+  }
+  return runtime.MetadataHeaderPrefix + key, true
+}
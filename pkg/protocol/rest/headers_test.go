@@ -0,0 +1,60 @@
+package rest
+
+import (
+  "net/http"
+  "net/http/httptest"
+  "testing"
+
+  "github.com/grpc-ecosystem/grpc-gateway/runtime"
+
+  "github.com/ckbball/dev-team/pkg/protocol/grpc/middleware"
+)
+
+func TestIncomingHeader(t *testing.T) {
+  tests := []struct {
+    header string
+    key    string
+    ok     bool
+  }{
+    {header: "X-Request-Id", key: middleware.RequestIdMetadata, ok: true},
+    {header: "x-request-id", key: middleware.RequestIdMetadata, ok: true},
+    {header: "Accept-Language", key: "grpcgateway-Accept-Language", ok: true},
+    {header: "Grpc-Metadata-Trace", key: "Trace", ok: true},
+    {header: "X-Forwarded-Proto", ok: false},
+  }
+  for _, tt := range tests {
+    key, ok := incomingHeader(tt.header)
+    if ok != tt.ok || (ok && key != tt.key) {
+      t.Errorf("incomingHeader(%s) = %s, %v, want %s, %v", tt.header, key, ok, tt.key, tt.ok)
+    }
+  }
+}
+
+func TestOutgoingHeader(t *testing.T) {
+  if key, ok := outgoingHeader(middleware.RequestIdMetadata); !ok || key != requestIdHeader {
+    t.Errorf("outgoingHeader(x-request-id) = %s, %v", key, ok)
+  }
+  if key, ok := outgoingHeader("trace"); !ok || key != runtime.MetadataHeaderPrefix+"trace" {
+    t.Errorf("outgoingHeader(trace) = %s, %v", key, ok)
+  }
+}
+
+func TestGatewayPassesRequestIdsThrough(t *testing.T) {
+  srv := &recordingServer{}
+  gateway := newGateway(t, srv)
+
+  r := httptest.NewRequest(http.MethodGet, "/v1/teams/users/8", nil)
+  r.Header.Set("X-Request-Id", "req-1")
+  w := httptest.NewRecorder()
+  gateway.ServeHTTP(w, r)
+
+  if srv.requestId != "req-1" {
+    t.Errorf("the service got request id %q", srv.requestId)
+  }
+  if id := w.Header().Get(requestIdHeader); id != "req-1" {
+    t.Errorf("X-Request-Id = %q, headers %v", id, w.Header())
+  }
+  if id := w.Header().Get(runtime.MetadataHeaderPrefix + middleware.RequestIdMetadata); id != "" {
+    t.Errorf("the request id was also answered as %s", runtime.MetadataHeaderPrefix+middleware.RequestIdMetadata)
+  }
+}
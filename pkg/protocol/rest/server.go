@@ -4,8 +4,6 @@ import (
   "context"
   "log"
   "net/http"
-  "os"
-  "os/signal"
   "time"
 
   "github.com/grpc-ecosystem/grpc-gateway/runtime"
@@ -14,37 +12,46 @@ import (
   v1 "github.com/ckbball/dev-team/pkg/api/v1"
 )
 
-// RunServer runs HTTP/REST gateway
+// RunServer runs HTTP/REST gateway until ctx is cancelled
 func RunServer(ctx context.Context, grpcPort, httpPort string) error {
   ctx, cancel := context.WithCancel(ctx)
   defer cancel()
 
-  mux := runtime.NewServeMux()
+  // team versions are sent as ETags and read back from If-Match headers,
+  // request ids are passed through both ways
+  mux := runtime.NewServeMux(
+    runtime.WithIncomingHeaderMatcher(incomingHeader),
+    runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+    runtime.WithMetadata(ifMatch),
+    runtime.WithForwardResponseOption(setETag),
+    runtime.WithProtoErrorHandler(versionError),
+  )
   opts := []grpc.DialOption{grpc.WithInsecure()}
   // have to change this for production maybe?
   if err := v1.RegisterTeamServiceHandlerFromEndpoint(ctx, mux, "localhost:"+grpcPort, opts); err != nil {
-    log.Fatalf("failed to start HTTP gateway: %v", err)
+    return err
   }
 
   srv := &http.Server{
     Addr:    ":" + httpPort,
-    Handler: mux,
+    Handler: checkIfMatch(mux),
   }
 
-  // graceful shutdown
-  c := make(chan os.Signal, 1)
-  signal.Notify(c, os.Interrupt)
+  // graceful shutdown, in-flight requests get 5 seconds to finish
   go func() {
-    for range c {
-      // sig is a ^C, handle it
-    }
+    <-ctx.Done()
 
-    _, cancel := context.WithTimeout(ctx, 5*time.Second)
+    log.Println("shutting down HTTP/REST gateway...")
+
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
     defer cancel()
 
-    _ = srv.Shutdown(ctx)
+    _ = srv.Shutdown(shutdownCtx)
   }()
 
   log.Println("starting HTTP/REST gateway...")
-  return srv.ListenAndServe()
+  if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+    return err
+  }
+  return nil
 }
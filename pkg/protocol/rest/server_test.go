@@ -0,0 +1,161 @@
+package rest
+
+import (
+  "context"
+  "net"
+  "net/http"
+  "net/http/httptest"
+  "strings"
+  "testing"
+  "time"
+
+  "github.com/golang/protobuf/proto"
+  "github.com/grpc-ecosystem/grpc-gateway/runtime"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/metadata"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/protocol/grpc/middleware"
+)
+
+// recordingServer records the last request reaching each method and answers
+// the request id it was sent
+type recordingServer struct {
+  v1.UnimplementedTeamServiceServer
+  method    string
+  req       proto.Message
+  requestId string
+}
+
+func (s *recordingServer) record(ctx context.Context, method string, req proto.Message) {
+  s.method = method
+  s.req = req
+  md, _ := metadata.FromIncomingContext(ctx)
+  if ids := md.Get(middleware.RequestIdMetadata); len(ids) > 0 {
+    s.requestId = ids[0]
+    grpc.SetHeader(ctx, metadata.Pairs(middleware.RequestIdMetadata, ids[0]))
+  }
+}
+
+func (s *recordingServer) CreateTeam(ctx context.Context, req *v1.TeamUpsertRequest) (*v1.TeamUpsertResponse, error) {
+  s.record(ctx, "CreateTeam", req)
+  return &v1.TeamUpsertResponse{}, nil
+}
+
+func (s *recordingServer) AddMember(ctx context.Context, req *v1.MemberUpsertRequest) (*v1.MemberUpsertResponse, error) {
+  s.record(ctx, "AddMember", req)
+  return &v1.MemberUpsertResponse{}, nil
+}
+
+func (s *recordingServer) RemoveMember(ctx context.Context, req *v1.MemberDeleteRequest) (*v1.MemberDeleteResponse, error) {
+  s.record(ctx, "RemoveMember", req)
+  return &v1.MemberDeleteResponse{}, nil
+}
+
+func (s *recordingServer) GetTeamsByUserId(ctx context.Context, req *v1.GetByUserIdRequest) (*v1.GetByUserIdResponse, error) {
+  s.record(ctx, "GetTeamsByUserId", req)
+  return &v1.GetByUserIdResponse{}, nil
+}
+
+func (s *recordingServer) GetTeams(ctx context.Context, req *v1.GetTeamsRequest) (*v1.GetTeamsResponse, error) {
+  s.record(ctx, "GetTeams", req)
+  return &v1.GetTeamsResponse{}, nil
+}
+
+// newGateway returns the REST gateway on a gRPC server serving srv
+func newGateway(t *testing.T, srv v1.TeamServiceServer) http.Handler {
+  lis, err := net.Listen("tcp", "127.0.0.1:0")
+  if err != nil {
+    t.Fatal(err)
+  }
+  server := grpc.NewServer()
+  v1.RegisterTeamServiceServer(server, srv)
+  go server.Serve(lis)
+  t.Cleanup(server.Stop)
+
+  ctx, cancel := context.WithCancel(context.Background())
+  t.Cleanup(cancel)
+  mux := runtime.NewServeMux(
+    runtime.WithIncomingHeaderMatcher(incomingHeader),
+    runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+  )
+  if err := v1.RegisterTeamServiceHandlerFromEndpoint(ctx, mux, lis.Addr().String(), []grpc.DialOption{grpc.WithInsecure()}); err != nil {
+    t.Fatal(err)
+  }
+  return mux
+}
+
+func TestGatewayRoutes(t *testing.T) {
+  srv := &recordingServer{}
+  gateway := newGateway(t, srv)
+
+  tests := []struct {
+    method string
+    path   string
+    body   string
+    rpc    string
+    want   proto.Message
+  }{
+    {http.MethodPost, "/v1/teams", `{"team": {"name": "gophers"}}`, "CreateTeam",
+      &v1.TeamUpsertRequest{Team: &v1.Team{Name: "gophers"}}},
+    {http.MethodPost, "/v1/teams/3/members", `{"member_id": "8", "role": "backend"}`, "AddMember",
+      &v1.MemberUpsertRequest{TeamId: "3", MemberId: "8", Role: "backend"}},
+    {http.MethodDelete, "/v1/teams/3/members/5", "", "RemoveMember",
+      &v1.MemberDeleteRequest{TeamId: "3", MemberNumber: "5"}},
+    {http.MethodGet, "/v1/teams/users/8", "", "GetTeamsByUserId",
+      &v1.GetByUserIdRequest{Id: "8"}},
+    {http.MethodGet, "/v1/teams?page=2&limit=10", "", "GetTeams",
+      &v1.GetTeamsRequest{Page: 2, Limit: 10}},
+  }
+
+  for _, tt := range tests {
+    r := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+    w := httptest.NewRecorder()
+    gateway.ServeHTTP(w, r)
+
+    if w.Code != http.StatusOK {
+      t.Errorf("%s %s answered %d: %s", tt.method, tt.path, w.Code, w.Body)
+      continue
+    }
+    if srv.method != tt.rpc || !proto.Equal(srv.req, tt.want) {
+      t.Errorf("%s %s called %s(%v), want %s(%v)", tt.method, tt.path, srv.method, srv.req, tt.rpc, tt.want)
+    }
+  }
+}
+
+func TestRunServerShutsDownGracefully(t *testing.T) {
+  lis, err := net.Listen("tcp", "127.0.0.1:0")
+  if err != nil {
+    t.Fatal(err)
+  }
+  _, port, _ := net.SplitHostPort(lis.Addr().String())
+  lis.Close()
+
+  ctx, cancel := context.WithCancel(context.Background())
+  done := make(chan error)
+  go func() { done <- RunServer(ctx, "0", port) }()
+
+  // the gateway answers once it listens, the gRPC server isn't needed for that
+  deadline := time.Now().Add(5 * time.Second)
+  for {
+    res, err := http.Get("http://127.0.0.1:" + port + "/v1/unknown")
+    if err == nil {
+      res.Body.Close()
+      break
+    }
+    if time.Now().After(deadline) {
+      t.Fatal(err)
+    }
+    time.Sleep(10 * time.Millisecond)
+  }
+
+  cancel()
+  select {
+  case err := <-done:
+    if err != nil {
+      t.Errorf("RunServer() error = %v, want nil after shutdown", err)
+    }
+  case <-time.After(10 * time.Second):
+    t.Fatal("RunServer() didn't return after ctx was cancelled")
+  }
+}
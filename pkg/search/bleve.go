@@ -0,0 +1,263 @@
+package search
+
+import (
+  "context"
+  "errors"
+  "os"
+  "sort"
+  "strings"
+
+  "github.com/blevesearch/bleve"
+  "github.com/blevesearch/bleve/analysis/analyzer/custom"
+  "github.com/blevesearch/bleve/analysis/lang/en"
+  "github.com/blevesearch/bleve/analysis/token/lowercase"
+  "github.com/blevesearch/bleve/analysis/tokenizer/single"
+  "github.com/blevesearch/bleve/mapping"
+  bsearch "github.com/blevesearch/bleve/search"
+  "github.com/blevesearch/bleve/search/query"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+// termAnalyzer indexes skills and languages as whole lower case terms so
+// they can be filtered and faceted on
+const termAnalyzer = "lower_term"
+
+// field boosts, a match in a team's name ranks above one in its project
+var textFields = map[string]float64{
+  "name":         3,
+  "project_name": 2,
+  "description":  1,
+}
+
+var termFields = map[string]float64{
+  "skills":    2,
+  "languages": 2,
+}
+
+type bleveIndex struct {
+  index bleve.Index
+}
+
+// NewBleveIndex opens the bleve index at path, creating it if missing. An
+// empty path creates an in-memory index. created is set when the index is
+// new and must be filled from the database.
+func NewBleveIndex(path string) (index Index, created bool, err error) {
+  if path == "" {
+    idx, err := bleve.NewMemOnly(newMapping())
+    if err != nil {
+      return nil, false, err
+    }
+    return &bleveIndex{index: idx}, true, nil
+  }
+
+  idx, err := bleve.Open(path)
+  if err == bleve.ErrorIndexPathDoesNotExist {
+    idx, err = bleve.New(path, newMapping())
+    created = true
+  }
+  if err != nil {
+    return nil, false, err
+  }
+  return &bleveIndex{index: idx}, created, nil
+}
+
+// ErrIndexInUse is returned by RebuildBleveIndex when a running server has
+// the index open
+var ErrIndexInUse = errors.New("search index is open, stop the server before rebuilding it")
+
+// RebuildBleveIndex builds a new bleve index at path from every team listed
+// by each and replaces the index at path with it once complete. The index
+// must not be open, ErrIndexInUse is returned without touching it while a
+// server has it open.
+func RebuildBleveIndex(ctx context.Context, path string, each func(fn func(*v1.Team) error) error) (int, error) {
+  // hold the lock of the current index until it is replaced
+  lock, err := lockIndex(path)
+  if err != nil {
+    return 0, err
+  }
+  if lock != nil {
+    defer lock.Close()
+  }
+
+  tmp := path + ".rebuild"
+  os.RemoveAll(tmp)
+  idx, err := bleve.New(tmp, newMapping())
+  if err != nil {
+    return 0, err
+  }
+
+  count := 0
+  batch := idx.NewBatch()
+  err = each(func(team *v1.Team) error {
+    if err := batch.Index(team.Id, NewDocument(team)); err != nil {
+      return err
+    }
+    count++
+    if batch.Size() >= 500 {
+      if err := idx.Batch(batch); err != nil {
+        return err
+      }
+      batch.Reset()
+    }
+    return nil
+  })
+  if err == nil {
+    err = idx.Batch(batch)
+  }
+  if closeErr := idx.Close(); err == nil {
+    err = closeErr
+  }
+  if err != nil {
+    os.RemoveAll(tmp)
+    return 0, err
+  }
+
+  if err := os.RemoveAll(path); err != nil {
+    return 0, err
+  }
+  return count, os.Rename(tmp, path)
+}
+
+func newMapping() mapping.IndexMapping {
+  m := bleve.NewIndexMapping()
+  m.AddCustomAnalyzer(termAnalyzer, map[string]interface{}{
+    "type":          custom.Name,
+    "tokenizer":     single.Name,
+    "token_filters": []string{lowercase.Name},
+  })
+
+  doc := bleve.NewDocumentStaticMapping()
+  for field := range textFields {
+    text := bleve.NewTextFieldMapping()
+    text.Analyzer = en.AnalyzerName
+    doc.AddFieldMappingsAt(field, text)
+  }
+  for field := range termFields {
+    term := bleve.NewTextFieldMapping()
+    term.Analyzer = termAnalyzer
+    doc.AddFieldMappingsAt(field, term)
+  }
+  m.DefaultMapping = doc
+  return m
+}
+
+func (b *bleveIndex) Index(ctx context.Context, team *v1.Team) error {
+  return b.index.Index(team.Id, NewDocument(team))
+}
+
+func (b *bleveIndex) Delete(ctx context.Context, id string) error {
+  return b.index.Delete(id)
+}
+
+func (b *bleveIndex) Search(ctx context.Context, q Query) (*Result, error) {
+  req := bleve.NewSearchRequestOptions(buildQuery(q), q.Size, q.From, false)
+  req.Highlight = bleve.NewHighlightWithStyle("html")
+  for field := range textFields {
+    req.Highlight.AddField(field)
+  }
+  if q.FacetSize > 0 {
+    req.AddFacet("skills", bleve.NewFacetRequest("skills", q.FacetSize))
+    req.AddFacet("languages", bleve.NewFacetRequest("languages", q.FacetSize))
+  }
+
+  res, err := b.index.SearchInContext(ctx, req)
+  if err != nil {
+    return nil, err
+  }
+
+  result := &Result{Total: res.Total}
+  for _, hit := range res.Hits {
+    result.Hits = append(result.Hits, Hit{
+      TeamId:     hit.ID,
+      Score:      hit.Score,
+      Highlights: matched(hit.Fragments),
+    })
+  }
+  if facet, ok := res.Facets["skills"]; ok {
+    result.Skills = facets(facet)
+  }
+  if facet, ok := res.Facets["languages"]; ok {
+    result.Languages = facets(facet)
+  }
+  return result, nil
+}
+
+func (b *bleveIndex) Close() error {
+  return b.index.Close()
+}
+
+// buildQuery matches q.Text against every field and keeps the teams passing
+// the skill and language filters
+func buildQuery(q Query) query.Query {
+  must := []query.Query{}
+
+  words := strings.Fields(strings.ToLower(q.Text))
+  if len(words) > 0 {
+    should := []query.Query{}
+    for field, boost := range textFields {
+      match := bleve.NewMatchQuery(q.Text)
+      match.SetField(field)
+      match.SetBoost(boost)
+      should = append(should, match)
+    }
+    for field, boost := range termFields {
+      for _, word := range words {
+        term := bleve.NewTermQuery(word)
+        term.SetField(field)
+        term.SetBoost(boost)
+        should = append(should, term)
+      }
+    }
+    must = append(must, bleve.NewDisjunctionQuery(should...))
+  }
+
+  for _, skill := range q.Skills {
+    term := bleve.NewTermQuery(strings.ToLower(skill))
+    term.SetField("skills")
+    must = append(must, term)
+  }
+  if len(q.Languages) > 0 {
+    any := []query.Query{}
+    for _, language := range q.Languages {
+      term := bleve.NewTermQuery(strings.ToLower(language))
+      term.SetField("languages")
+      any = append(any, term)
+    }
+    must = append(must, bleve.NewDisjunctionQuery(any...))
+  }
+
+  if len(must) == 0 {
+    return bleve.NewMatchAllQuery()
+  }
+  return bleve.NewConjunctionQuery(must...)
+}
+
+// matched drops the fragments without a highlighted match, bleve returns a
+// fragment of every highlighted field of a hit
+func matched(fragments map[string][]string) map[string][]string {
+  out := map[string][]string{}
+  for field, frags := range fragments {
+    for _, frag := range frags {
+      if strings.Contains(frag, "<mark>") {
+        out[field] = append(out[field], frag)
+      }
+    }
+  }
+  return out
+}
+
+// facets returns the term counts of facet, most frequent first
+func facets(facet *bsearch.FacetResult) []Facet {
+  out := []Facet{}
+  if facet.Terms == nil {
+    return out
+  }
+  for _, term := range facet.Terms {
+    out = append(out, Facet{Term: term.Term, Count: term.Count})
+  }
+  sort.SliceStable(out, func(i, j int) bool {
+    return out[i].Count > out[j].Count
+  })
+  return out
+}
@@ -0,0 +1,209 @@
+package search
+
+import (
+  "context"
+  "path/filepath"
+  "reflect"
+  "testing"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+var teams = []*v1.Team{
+  {
+    Id:     "1",
+    Name:   "Gophers",
+    Skills: []string{"Go", "SQL"},
+    Projects: []*v1.Project{
+      {Name: "Tracker", Description: "a bug tracker", Languages: []string{"Go", "go"}},
+    },
+  },
+  {
+    Id:     "2",
+    Name:   "Web folks",
+    Skills: []string{"CSS"},
+    Projects: []*v1.Project{
+      {Name: "Gopher site", Description: "a landing page", Languages: []string{"TypeScript"}},
+    },
+  },
+  {
+    Id:     "3",
+    Name:   "Data",
+    Skills: []string{"SQL", "Go"},
+  },
+}
+
+// each lists teams like the repository does for a rebuild
+func each(fn func(*v1.Team) error) error {
+  for _, team := range teams {
+    if err := fn(team); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+func newMemIndex(t *testing.T) Index {
+  index, created, err := NewBleveIndex("")
+  if err != nil {
+    t.Fatal(err)
+  }
+  if !created {
+    t.Error("an in-memory index must be filled")
+  }
+  t.Cleanup(func() { index.Close() })
+
+  ctx := context.Background()
+  for _, team := range teams {
+    if err := index.Index(ctx, team); err != nil {
+      t.Fatal(err)
+    }
+  }
+  return index
+}
+
+func hitIds(res *Result) []string {
+  ids := []string{}
+  for _, hit := range res.Hits {
+    ids = append(ids, hit.TeamId)
+  }
+  return ids
+}
+
+func TestNewDocumentCountsLanguagesOnce(t *testing.T) {
+  doc := NewDocument(teams[0])
+  if !reflect.DeepEqual(doc.Languages, []string{"Go"}) {
+    t.Errorf("Languages = %v", doc.Languages)
+  }
+  if !reflect.DeepEqual(doc.ProjectNames, []string{"Tracker"}) {
+    t.Errorf("ProjectNames = %v", doc.ProjectNames)
+  }
+}
+
+func TestSearchRanksNamesFirst(t *testing.T) {
+  index := newMemIndex(t)
+
+  res, err := index.Search(context.Background(), Query{Text: "gophers", Size: 10})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if got := hitIds(res); !reflect.DeepEqual(got, []string{"1", "2"}) {
+    t.Fatalf("hits = %v, want the name match before the project match", got)
+  }
+  if frags := res.Hits[0].Highlights["name"]; len(frags) != 1 || frags[0] != "<mark>Gophers</mark>" {
+    t.Errorf("highlights = %v", res.Hits[0].Highlights)
+  }
+}
+
+func TestSearchFilters(t *testing.T) {
+  index := newMemIndex(t)
+  ctx := context.Background()
+
+  res, err := index.Search(ctx, Query{Skills: []string{"go", "SQL"}, Size: 10})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if res.Total != 2 {
+    t.Errorf("teams with every skill = %v", hitIds(res))
+  }
+
+  res, err = index.Search(ctx, Query{Languages: []string{"typescript", "rust"}, Size: 10})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if got := hitIds(res); !reflect.DeepEqual(got, []string{"2"}) {
+    t.Errorf("teams with any language = %v", got)
+  }
+}
+
+func TestSearchFacets(t *testing.T) {
+  index := newMemIndex(t)
+
+  res, err := index.Search(context.Background(), Query{Size: 10, FacetSize: 5})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if res.Total != 3 {
+    t.Errorf("Total = %d, want every team", res.Total)
+  }
+  want := []Facet{{Term: "go", Count: 2}, {Term: "sql", Count: 2}, {Term: "css", Count: 1}}
+  if len(res.Skills) != 3 || res.Skills[2] != want[2] || res.Skills[0].Count != 2 || res.Skills[1].Count != 2 {
+    t.Errorf("skill facets = %v, want %v", res.Skills, want)
+  }
+}
+
+func TestDelete(t *testing.T) {
+  index := newMemIndex(t)
+  ctx := context.Background()
+
+  if err := index.Delete(ctx, "1"); err != nil {
+    t.Fatal(err)
+  }
+  // missing teams are ignored
+  if err := index.Delete(ctx, "42"); err != nil {
+    t.Fatal(err)
+  }
+  res, err := index.Search(ctx, Query{Text: "gophers", Size: 10})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if got := hitIds(res); !reflect.DeepEqual(got, []string{"2"}) {
+    t.Errorf("hits = %v", got)
+  }
+}
+
+func TestRebuildBleveIndex(t *testing.T) {
+  path := filepath.Join(t.TempDir(), "index")
+
+  count, err := RebuildBleveIndex(context.Background(), path, each)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if count != len(teams) {
+    t.Errorf("count = %d, want %d", count, len(teams))
+  }
+
+  index, created, err := NewBleveIndex(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer index.Close()
+  if created {
+    t.Error("the rebuilt index must be opened, not created")
+  }
+  res, err := index.Search(context.Background(), Query{Size: 10})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if res.Total != uint64(len(teams)) {
+    t.Errorf("Total = %d, want %d", res.Total, len(teams))
+  }
+}
+
+func TestRebuildRefusesAnOpenIndex(t *testing.T) {
+  path := filepath.Join(t.TempDir(), "index")
+  index, _, err := NewBleveIndex(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if err := index.Index(context.Background(), teams[0]); err != nil {
+    t.Fatal(err)
+  }
+
+  if _, err := RebuildBleveIndex(context.Background(), path, each); err != ErrIndexInUse {
+    t.Fatalf("RebuildBleveIndex() error = %v, want ErrIndexInUse", err)
+  }
+  // the open index is untouched
+  res, err := index.Search(context.Background(), Query{Size: 10})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if res.Total != 1 {
+    t.Errorf("Total = %d, want 1", res.Total)
+  }
+
+  index.Close()
+  if _, err := RebuildBleveIndex(context.Background(), path, each); err != nil {
+    t.Errorf("RebuildBleveIndex() after closing error = %v", err)
+  }
+}
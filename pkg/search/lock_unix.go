@@ -0,0 +1,30 @@
+// +build !windows
+
+package search
+
+import (
+  "os"
+  "path/filepath"
+  "syscall"
+)
+
+// lockIndex takes the lock bolt holds on the store of the bleve index at
+// path while it is open, ErrIndexInUse when a server has it open. Closing
+// the returned file releases it, it is nil when there is no index at path.
+func lockIndex(path string) (*os.File, error) {
+  f, err := os.OpenFile(filepath.Join(path, "store"), os.O_RDWR, 0)
+  if os.IsNotExist(err) {
+    return nil, nil
+  } else if err != nil {
+    return nil, err
+  }
+
+  if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+    f.Close()
+    if err == syscall.EWOULDBLOCK {
+      return nil, ErrIndexInUse
+    }
+    return nil, err
+  }
+  return f, nil
+}
@@ -0,0 +1,11 @@
+package search
+
+import (
+  "os"
+)
+
+// lockIndex can't see the lock bolt holds on Windows, the server must be
+// stopped before rebuilding its index
+func lockIndex(path string) (*os.File, error) {
+  return nil, nil
+}
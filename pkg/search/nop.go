@@ -0,0 +1,27 @@
+package search
+
+import (
+  "context"
+  "errors"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+// ErrDisabled is returned by searches when no index is configured
+var ErrDisabled = errors.New("search is disabled")
+
+type nopIndex struct{}
+
+// NewNopIndex returns an index that ignores updates and fails every search
+// with ErrDisabled
+func NewNopIndex() Index {
+  return nopIndex{}
+}
+
+func (nopIndex) Index(ctx context.Context, team *v1.Team) error { return nil }
+
+func (nopIndex) Delete(ctx context.Context, id string) error { return nil }
+
+func (nopIndex) Search(ctx context.Context, q Query) (*Result, error) { return nil, ErrDisabled }
+
+func (nopIndex) Close() error { return nil }
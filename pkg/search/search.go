@@ -0,0 +1,93 @@
+// Package search indexes teams for full-text search. Index is the
+// extension point for search engines, NewBleveIndex is an embedded engine
+// for single node deployments and development.
+package search
+
+import (
+  "context"
+  "strings"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+// Query is a full-text team search
+type Query struct {
+  // Text is matched against team names, project names and descriptions,
+  // skills and languages, every team matches an empty text
+  Text string
+  // Skills keeps teams looking for every skill
+  Skills []string
+  // Languages keeps teams using any of the languages
+  Languages []string
+  // From is the offset of the first hit
+  From int
+  // Size is the maximum number of hits
+  Size int
+  // FacetSize is the maximum number of skill and language facets
+  FacetSize int
+}
+
+// Hit is a team matching a query
+type Hit struct {
+  TeamId string
+  Score  float64
+  // Highlights are fragments of the matching fields with the matching
+  // terms wrapped in <mark></mark>, by field name
+  Highlights map[string][]string
+}
+
+// Facet counts the matching teams with a term
+type Facet struct {
+  Term  string
+  Count int
+}
+
+// Result is the page of hits of a query, most relevant first
+type Result struct {
+  // Total is the number of matching teams
+  Total     uint64
+  Hits      []Hit
+  Skills    []Facet
+  Languages []Facet
+}
+
+// Index is a search index of teams
+type Index interface {
+  // Index adds or replaces team
+  Index(ctx context.Context, team *v1.Team) error
+  // Delete removes the team with id, missing teams are ignored
+  Delete(ctx context.Context, id string) error
+  Search(ctx context.Context, q Query) (*Result, error)
+  Close() error
+}
+
+// Document is what is indexed of a team
+type Document struct {
+  Name         string   `json:"name"`
+  ProjectNames []string `json:"project_name"`
+  Descriptions []string `json:"description"`
+  Skills       []string `json:"skills"`
+  Languages    []string `json:"languages"`
+}
+
+// NewDocument returns the document indexed for team, every project that
+// isn't archived included
+func NewDocument(team *v1.Team) *Document {
+  doc := &Document{
+    Name:   team.Name,
+    Skills: team.Skills,
+  }
+  // a language used by several projects is indexed once so facets count teams
+  seen := map[string]bool{}
+  for _, project := range team.Projects {
+    doc.ProjectNames = append(doc.ProjectNames, project.Name)
+    doc.Descriptions = append(doc.Descriptions, project.Description)
+    for _, language := range project.Languages {
+      if key := strings.ToLower(language); !seen[key] {
+        seen[key] = true
+        doc.Languages = append(doc.Languages, language)
+      }
+    }
+  }
+  return doc
+}
@@ -0,0 +1,232 @@
+package v1
+
+import (
+  "context"
+  "database/sql"
+  "strconv"
+  "time"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+// DefaultInactiveAfter is how long a team may be inactive before it is
+// flagged unless the repository is configured otherwise
+const DefaultInactiveAfter = 90 * 24 * time.Hour
+
+// DefaultInactiveGrace is how long a flagged team has to be active again
+// before it is archived unless the repository is configured otherwise
+const DefaultInactiveGrace = 14 * 24 * time.Hour
+
+// activityResolution is how far activity events must move last_active
+// forward to rewrite it, so frequent events don't rewrite teams each time
+const activityResolution = time.Hour
+
+// how many teams FlagInactiveTeams and ArchiveInactiveTeams handle per call
+const sweepBatchSize = 100
+
+// Marks the teams user userId is a member of active at unix time at. Teams
+// already active within activityResolution of it are left as they are, the
+// version of the teams it takes out of the inactive and archived teams is
+// incremented.
+// output ON SUCCESS: int64 - number of teams marked active, error - nil
+// output ON FAILURE: int64 - -1, error - the error object from whatever created the error
+func (r *teamRepository) RecordUserActivity(ctx context.Context, userId string, at int64) (int64, error) {
+  // assignments are evaluated left to right, version reads the flags first
+  activeStmt := `UPDATE teams SET version = version + (inactive_at IS NOT NULL OR archived_at IS NOT NULL), last_active=?, inactive_at=NULL, archived_at=NULL WHERE deleted_at IS NULL AND (last_active IS NULL OR last_active < ?) AND id IN (SELECT team_id FROM members WHERE user_id=?)`
+
+  result, err := r.db.ExecContext(ctx, activeStmt, at, at-int64(activityResolution/time.Second), userId)
+  if err != nil {
+    return -1, err
+  }
+  return result.RowsAffected()
+}
+
+// Flags a batch of the teams that haven't been active for the inactivity
+// period and publishes team_inactive so their owners are told they will be
+// archived
+// output ON SUCCESS: []string - ids of the teams flagged, less than a batch once none are left
+// output ON FAILURE: []string - teams flagged before the error, error - the error object from whatever created the error
+func (r *teamRepository) FlagInactiveTeams(ctx context.Context) ([]string, error) {
+  selectStmt := `SELECT id FROM teams WHERE deleted_at IS NULL AND inactive_at IS NULL AND archived_at IS NULL AND last_active < ? ORDER BY last_active LIMIT ?`
+
+  cutoff := time.Now().Add(-r.inactiveAfter).Unix()
+  ids, err := r.sweepIds(ctx, selectStmt, cutoff)
+  if err != nil {
+    return nil, err
+  }
+
+  flagged := []string{}
+  for _, id := range ids {
+    ok, err := r.flagTeam(ctx, id, cutoff)
+    if err != nil {
+      return flagged, err
+    }
+    if ok {
+      flagged = append(flagged, strconv.FormatInt(id, 10))
+    }
+  }
+  return flagged, nil
+}
+
+// Archives a batch of the teams flagged inactive for longer than the grace
+// period, archived teams are hidden from discovery until active again
+// output ON SUCCESS: []string - ids of the teams archived, less than a batch once none are left
+// output ON FAILURE: []string - teams archived before the error, error - the error object from whatever created the error
+func (r *teamRepository) ArchiveInactiveTeams(ctx context.Context) ([]string, error) {
+  selectStmt := `SELECT id FROM teams WHERE deleted_at IS NULL AND archived_at IS NULL AND inactive_at < ? ORDER BY inactive_at LIMIT ?`
+
+  cutoff := time.Now().Add(-r.inactiveGrace).Unix()
+  ids, err := r.sweepIds(ctx, selectStmt, cutoff)
+  if err != nil {
+    return nil, err
+  }
+
+  archived := []string{}
+  for _, id := range ids {
+    ok, err := r.archiveTeam(ctx, id, cutoff)
+    if err != nil {
+      return archived, err
+    }
+    if ok {
+      archived = append(archived, strconv.FormatInt(id, 10))
+    }
+  }
+  return archived, nil
+}
+
+// ---------------------------- HELPER FUNCTIONS -------------------------------
+
+// touchTeam marks team teamId active now within tx, taking it out of the
+// inactive and archived teams
+func touchTeam(ctx context.Context, tx *sql.Tx, teamId string) error {
+  activeStmt := `UPDATE teams SET last_active=?, inactive_at=NULL, archived_at=NULL WHERE id=?`
+
+  _, err := tx.ExecContext(ctx, activeStmt, time.Now().Unix(), teamId)
+  return err
+}
+
+// sweepIds returns a batch of the ids selectStmt selects before cutoff
+func (r *teamRepository) sweepIds(ctx context.Context, selectStmt string, cutoff int64) ([]int64, error) {
+  rows, err := r.db.QueryContext(ctx, selectStmt, cutoff, sweepBatchSize)
+  if err != nil {
+    return nil, err
+  }
+  defer rows.Close()
+
+  ids := []int64{}
+  for rows.Next() {
+    var id int64
+    if err := rows.Scan(&id); err != nil {
+      return nil, err
+    }
+    ids = append(ids, id)
+  }
+  return ids, rows.Err()
+}
+
+// flagTeam flags team id inactive if it was last active before cutoff, false
+// if it was active, flagged or deleted meanwhile
+func (r *teamRepository) flagTeam(ctx context.Context, id, cutoff int64) (bool, error) {
+  teamStmt := `SELECT leader, last_active FROM teams WHERE id=? AND deleted_at IS NULL AND inactive_at IS NULL AND archived_at IS NULL AND last_active < ? FOR UPDATE`
+  flagStmt := `UPDATE teams SET inactive_at=?, version = version + 1 WHERE id=?`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return false, err
+  }
+  defer tx.Rollback()
+
+  // lock the team, a concurrent change marking it active wins
+  var leader string
+  var lastActive int64
+  err = tx.QueryRowContext(ctx, teamStmt, id, cutoff).Scan(&leader, &lastActive)
+  if err == sql.ErrNoRows {
+    return false, nil
+  } else if err != nil {
+    return false, err
+  }
+
+  now := time.Now()
+  if _, err := tx.ExecContext(ctx, flagStmt, now.Unix(), id); err != nil {
+    return false, err
+  }
+
+  teamId := strconv.FormatInt(id, 10)
+  archiveAt := now.Add(r.inactiveGrace).Unix()
+  // record team_inactive event in the outbox
+  err = insertOutboxEvent(tx, TeamInactiveTopic, &v1.TeamInactive{
+    TeamId:     teamId,
+    Leader:     leader,
+    LastActive: lastActive,
+    ArchiveAt:  archiveAt,
+    OccurredAt: now.Unix(),
+  })
+  if err != nil {
+    return false, err
+  }
+  err = insertAuditEvent(ctx, tx, teamId, AuditFlagInactive, nil, auditState{
+    "last_active": lastActive,
+    "inactive_at": now.Unix(),
+    "archive_at":  archiveAt,
+  })
+  if err != nil {
+    return false, err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return false, err
+  }
+  return true, nil
+}
+
+// archiveTeam archives team id if it was flagged inactive before cutoff,
+// false if it was active, archived or deleted meanwhile
+func (r *teamRepository) archiveTeam(ctx context.Context, id, cutoff int64) (bool, error) {
+  teamStmt := `SELECT leader, last_active FROM teams WHERE id=? AND deleted_at IS NULL AND archived_at IS NULL AND inactive_at < ? FOR UPDATE`
+  archiveStmt := `UPDATE teams SET archived_at=?, version = version + 1 WHERE id=?`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return false, err
+  }
+  defer tx.Rollback()
+
+  // lock the team, a concurrent change marking it active wins
+  var leader string
+  var lastActive int64
+  err = tx.QueryRowContext(ctx, teamStmt, id, cutoff).Scan(&leader, &lastActive)
+  if err == sql.ErrNoRows {
+    return false, nil
+  } else if err != nil {
+    return false, err
+  }
+
+  now := time.Now().Unix()
+  if _, err := tx.ExecContext(ctx, archiveStmt, now, id); err != nil {
+    return false, err
+  }
+
+  teamId := strconv.FormatInt(id, 10)
+  // record team_archived event in the outbox
+  err = insertOutboxEvent(tx, TeamArchivedTopic, &v1.TeamArchived{
+    TeamId:     teamId,
+    Leader:     leader,
+    LastActive: lastActive,
+    OccurredAt: now,
+  })
+  if err != nil {
+    return false, err
+  }
+  err = insertAuditEvent(ctx, tx, teamId, AuditArchiveTeam, nil, auditState{
+    "last_active": lastActive,
+    "archived_at": now,
+  })
+  if err != nil {
+    return false, err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return false, err
+  }
+  return true, nil
+}
@@ -0,0 +1,82 @@
+package v1
+
+import (
+  "context"
+  "reflect"
+  "testing"
+  "time"
+
+  "github.com/DATA-DOG/go-sqlmock"
+  "github.com/golang/protobuf/proto"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+func TestRecordUserActivity(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  // teams active within the last hour are left alone
+  mock.ExpectExec(stmt(`UPDATE teams SET version = version + (inactive_at IS NOT NULL OR archived_at IS NOT NULL), last_active=?, inactive_at=NULL, archived_at=NULL`)).
+    WithArgs(int64(1600003600), int64(1600000000), "8").WillReturnResult(sqlmock.NewResult(0, 2))
+
+  if n, err := repo.RecordUserActivity(context.Background(), "8", 1600003600); err != nil || n != 2 {
+    t.Errorf("RecordUserActivity() = %d, %v", n, err)
+  }
+}
+
+func TestFlagInactiveTeams(t *testing.T) {
+  repo, mock := newMockRepository(t)
+  repo.WithInactivity(30*24*time.Hour, 7*24*time.Hour)
+  outbox := &fakeOutbox{}
+
+  mock.ExpectQuery(stmt(`SELECT id FROM teams WHERE deleted_at IS NULL AND inactive_at IS NULL AND archived_at IS NULL AND last_active < ?`)).
+    WithArgs(sqlmock.AnyArg(), sweepBatchSize).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(5))
+
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`SELECT leader, last_active FROM teams WHERE id=?`)).WithArgs(int64(3), sqlmock.AnyArg()).
+    WillReturnRows(sqlmock.NewRows([]string{"leader", "last_active"}).AddRow("1", 1600000000))
+  mock.ExpectExec(stmt(`UPDATE teams SET inactive_at=?, version = version + 1 WHERE id=?`)).WithArgs(sqlmock.AnyArg(), int64(3)).
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  outbox.expect(mock, TeamInactiveTopic)
+  expectAudit(mock, "3", AuditFlagInactive)
+  mock.ExpectCommit()
+
+  // team 5 was active meanwhile
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`SELECT leader, last_active FROM teams WHERE id=?`)).WithArgs(int64(5), sqlmock.AnyArg()).
+    WillReturnRows(sqlmock.NewRows([]string{"leader", "last_active"}))
+  mock.ExpectRollback()
+
+  flagged, err := repo.FlagInactiveTeams(context.Background())
+  if err != nil || !reflect.DeepEqual(flagged, []string{"3"}) {
+    t.Fatalf("FlagInactiveTeams() = %v, %v", flagged, err)
+  }
+
+  // the owner is told when the team will be archived
+  event := &v1.TeamInactive{}
+  if err := proto.Unmarshal(outbox.rows[0].payload.([]byte), event); err != nil {
+    t.Fatal(err)
+  }
+  if event.Leader != "1" || event.LastActive != 1600000000 || event.ArchiveAt-event.OccurredAt != int64((7 * 24 * time.Hour).Seconds()) {
+    t.Errorf("team_inactive = %v", event)
+  }
+}
+
+func TestArchiveInactiveTeams(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectQuery(stmt(`SELECT id FROM teams WHERE deleted_at IS NULL AND archived_at IS NULL AND inactive_at < ?`)).
+    WithArgs(sqlmock.AnyArg(), sweepBatchSize).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`SELECT leader, last_active FROM teams WHERE id=? AND deleted_at IS NULL AND archived_at IS NULL AND inactive_at < ? FOR UPDATE`)).
+    WithArgs(int64(3), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"leader", "last_active"}).AddRow("1", 1600000000))
+  mock.ExpectExec(stmt(`UPDATE teams SET archived_at=?, version = version + 1 WHERE id=?`)).WithArgs(sqlmock.AnyArg(), int64(3)).
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  expectOutbox(mock, TeamArchivedTopic)
+  expectAudit(mock, "3", AuditArchiveTeam)
+  mock.ExpectCommit()
+
+  if archived, err := repo.ArchiveInactiveTeams(context.Background()); err != nil || !reflect.DeepEqual(archived, []string{"3"}) {
+    t.Errorf("ArchiveInactiveTeams() = %v, %v", archived, err)
+  }
+}
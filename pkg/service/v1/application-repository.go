@@ -0,0 +1,401 @@
+package v1
+
+import (
+  "context"
+  "database/sql"
+  "strconv"
+  "time"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// statuses of an application, closed applications were pending when their
+// team filled up with auto_close_applications set
+const (
+  ApplicationPending   = "pending"
+  ApplicationApproved  = "approved"
+  ApplicationRejected  = "rejected"
+  ApplicationWithdrawn = "withdrawn"
+  ApplicationClosed    = "closed"
+)
+
+const applicationColumns = `id, team_id, user_id, email, member_role, message, status, created_at, decided_at, decided_by`
+
+// Creates a pending application to a team with open roles
+// input: context-the current handler context, app-the application, Id and Status are set by the repository
+// output ON SUCCESS: string - id of the application, error - nil
+// output ON FAILURE: string - "", error - TEAM_NOT_FOUND, TEAM_FULL, ALREADY_MEMBER, ALREADY_APPLIED or the error object from whatever created the error
+func (r *teamRepository) CreateApplication(ctx context.Context, app *v1.Application) (string, error) {
+  teamStmt := `SELECT open_roles FROM teams WHERE id=? AND deleted_at IS NULL FOR UPDATE`
+  memberStmt := `SELECT COUNT(*) FROM members WHERE team_id=? AND user_id=?`
+  pendingStmt := `SELECT COUNT(*) FROM applications WHERE team_id=? AND user_id=? AND status=?`
+  insertStmt := `INSERT INTO applications (team_id, user_id, email, member_role, message, status, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return "", err
+  }
+  defer tx.Rollback()
+
+  // lock the team so concurrent applications see each other
+  var openRoles int
+  err = tx.QueryRowContext(ctx, teamStmt, app.TeamId).Scan(&openRoles)
+  if err == sql.ErrNoRows {
+    return "", domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", app.TeamId).With("team_id", app.TeamId)
+  } else if err != nil {
+    return "", err
+  }
+  if openRoles < 1 {
+    return "", domainerr.FailedPrecondition(domainerr.ReasonTeamFull, "team '%s' has no open roles", app.TeamId).With("team_id", app.TeamId)
+  }
+
+  var count int
+  if err := tx.QueryRowContext(ctx, memberStmt, app.TeamId, app.UserId).Scan(&count); err != nil {
+    return "", err
+  }
+  if count > 0 {
+    return "", domainerr.AlreadyExists(domainerr.ReasonAlreadyMember, "user '%s' is already on team '%s'", app.UserId, app.TeamId).With("team_id", app.TeamId)
+  }
+  if err := tx.QueryRowContext(ctx, pendingStmt, app.TeamId, app.UserId, ApplicationPending).Scan(&count); err != nil {
+    return "", err
+  }
+  if count > 0 {
+    return "", domainerr.AlreadyExists(domainerr.ReasonAlreadyApplied, "user '%s' already has a pending application to team '%s'", app.UserId, app.TeamId).With("team_id", app.TeamId)
+  }
+
+  result, err := tx.ExecContext(ctx, insertStmt, app.TeamId, app.UserId, app.Email, app.Role, app.Message, ApplicationPending, app.CreatedAt)
+  if err != nil {
+    return "", err
+  }
+  id, err := result.LastInsertId()
+  if err != nil {
+    return "", err
+  }
+
+  // record application_submitted event in the outbox
+  err = insertOutboxEvent(tx, ApplicationSubmittedTopic, &v1.ApplicationSubmitted{
+    ApplicationId: strconv.FormatInt(id, 10),
+    TeamId:        app.TeamId,
+    UserId:        app.UserId,
+    Role:          app.Role,
+    OccurredAt:    app.CreatedAt,
+  })
+  if err != nil {
+    return "", err
+  }
+  after := applicationState(strconv.FormatInt(id, 10), app.UserId, ApplicationPending)
+  after["role"] = app.Role
+  if err := insertAuditEvent(ctx, tx, app.TeamId, AuditApplyToTeam, nil, after); err != nil {
+    return "", err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return "", err
+  }
+  return strconv.FormatInt(id, 10), nil
+}
+
+// Gets an application by id
+// output ON FAILURE: APPLICATION_NOT_FOUND or the error object from whatever created the error
+func (r *teamRepository) GetApplication(ctx context.Context, id string) (*v1.Application, error) {
+  row := r.db.QueryRowContext(ctx, `SELECT `+applicationColumns+` FROM applications WHERE id=?`, id)
+  app, err := scanApplication(row)
+  if err == sql.ErrNoRows {
+    return nil, applicationNotFound(id)
+  } else if err != nil {
+    return nil, err
+  }
+  return app, nil
+}
+
+// Lists the applications to team teamId, or of user userId when teamId is
+// empty, newest first. Only applications in one of statuses are listed,
+// pending ones when statuses is empty.
+func (r *teamRepository) ListApplications(ctx context.Context, teamId, userId string, statuses []string) ([]*v1.Application, error) {
+  stmt := `SELECT ` + applicationColumns + ` FROM applications WHERE `
+  args := []interface{}{}
+  if teamId != "" {
+    stmt += `team_id=?`
+    args = append(args, teamId)
+  } else {
+    stmt += `user_id=?`
+    args = append(args, userId)
+  }
+  if len(statuses) == 0 {
+    statuses = []string{ApplicationPending}
+  }
+  in, statusArgs := stringInList(statuses)
+  stmt += ` AND status IN ` + in + ` ORDER BY id DESC LIMIT ?`
+  args = append(args, statusArgs...)
+  args = append(args, maxApplications)
+
+  rows, err := r.db.QueryContext(ctx, stmt, args...)
+  if err != nil {
+    return nil, err
+  }
+  defer rows.Close()
+
+  applications := []*v1.Application{}
+  for rows.Next() {
+    app, err := scanApplication(rows)
+    if err != nil {
+      return nil, err
+    }
+    applications = append(applications, app)
+  }
+  return applications, rows.Err()
+}
+
+// Approves a pending application, consuming an open role of the team and
+// adding the applicant as a member in one transaction
+// output ON SUCCESS: string - id of the team, string - member number of the new member, error - nil
+// output ON FAILURE: APPLICATION_NOT_FOUND, APPLICATION_CLOSED, TEAM_NOT_FOUND, TEAM_FULL, ALREADY_MEMBER or the error object from whatever created the error
+func (r *teamRepository) ApproveApplication(ctx context.Context, id, deciderId string) (string, string, error) {
+  teamStmt := `SELECT open_roles FROM teams WHERE id=? AND deleted_at IS NULL FOR UPDATE`
+  existsStmt := `SELECT COUNT(*) FROM members WHERE team_id=? AND user_id=?`
+  memberStmt := `INSERT INTO members (user_id, team_id, member_email, member_role, access_role) VALUES (?, ?, ?, ?, ?)`
+  rolesStmt := `UPDATE teams SET open_roles = open_roles - 1, version = version + 1 WHERE id=?`
+  approveStmt := `UPDATE applications SET status=?, decided_at=?, decided_by=? WHERE id=?`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return "", "", err
+  }
+  defer tx.Rollback()
+
+  // lock the application so it is approved once
+  app, err := scanApplication(tx.QueryRowContext(ctx, `SELECT `+applicationColumns+` FROM applications WHERE id=? FOR UPDATE`, id))
+  if err == sql.ErrNoRows {
+    return "", "", applicationNotFound(id)
+  } else if err != nil {
+    return "", "", err
+  }
+  if app.Status != ApplicationPending {
+    return "", "", applicationClosed(app)
+  }
+
+  var openRoles int
+  err = tx.QueryRowContext(ctx, teamStmt, app.TeamId).Scan(&openRoles)
+  if err == sql.ErrNoRows {
+    return "", "", domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", app.TeamId).With("team_id", app.TeamId)
+  } else if err != nil {
+    return "", "", err
+  }
+  if openRoles < 1 {
+    return "", "", domainerr.FailedPrecondition(domainerr.ReasonTeamFull, "team '%s' has no open roles", app.TeamId).With("team_id", app.TeamId)
+  }
+
+  var count int
+  if err := tx.QueryRowContext(ctx, existsStmt, app.TeamId, app.UserId).Scan(&count); err != nil {
+    return "", "", err
+  }
+  if count > 0 {
+    return "", "", domainerr.AlreadyExists(domainerr.ReasonAlreadyMember, "user '%s' is already on team '%s'", app.UserId, app.TeamId).With("team_id", app.TeamId)
+  }
+
+  result, err := tx.ExecContext(ctx, memberStmt, app.UserId, app.TeamId, app.Email, app.Role, RoleMember)
+  if err != nil {
+    return "", "", err
+  }
+  memId, err := result.LastInsertId()
+  if err != nil {
+    return "", "", err
+  }
+  if _, err := tx.ExecContext(ctx, rolesStmt, app.TeamId); err != nil {
+    return "", "", err
+  }
+  if err := touchTeam(ctx, tx, app.TeamId); err != nil {
+    return "", "", err
+  }
+
+  now := time.Now().Unix()
+  if _, err := tx.ExecContext(ctx, approveStmt, ApplicationApproved, now, deciderId, id); err != nil {
+    return "", "", err
+  }
+
+  // record member_added and application_closed events in the outbox
+  err = insertOutboxEvent(tx, MemberAddedTopic, &v1.MemberAdded{
+    TeamId:       app.TeamId,
+    MemberNumber: strconv.FormatInt(memId, 10),
+    UserId:       app.UserId,
+    Email:        app.Email,
+    Role:         app.Role,
+    AccessRole:   RoleMember,
+    OccurredAt:   now,
+  })
+  if err != nil {
+    return "", "", err
+  }
+  err = insertOutboxEvent(tx, ApplicationClosedTopic, &v1.ApplicationClosed{
+    ApplicationId: id,
+    TeamId:        app.TeamId,
+    UserId:        app.UserId,
+    Status:        ApplicationApproved,
+    OccurredAt:    now,
+  })
+  if err != nil {
+    return "", "", err
+  }
+  after := memberState(strconv.FormatInt(memId, 10), app.UserId, app.Email, app.Role, RoleMember)
+  if err := insertAuditEvent(ctx, tx, app.TeamId, AuditAddMember, nil, after); err != nil {
+    return "", "", err
+  }
+  if err := closeApplicationAudit(ctx, tx, app.TeamId, id, app.UserId, ApplicationApproved); err != nil {
+    return "", "", err
+  }
+
+  // a team that just filled up may close its other pending applications
+  if err := closeFullTeamApplications(ctx, tx, app.TeamId); err != nil {
+    return "", "", err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return "", "", err
+  }
+  return app.TeamId, strconv.FormatInt(memId, 10), nil
+}
+
+// Closes a pending application as rejected or withdrawn
+// output ON FAILURE: APPLICATION_NOT_FOUND, APPLICATION_CLOSED or the error object from whatever created the error
+func (r *teamRepository) CloseApplication(ctx context.Context, id, status, deciderId string) error {
+  closeStmt := `UPDATE applications SET status=?, decided_at=?, decided_by=? WHERE id=?`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return err
+  }
+  defer tx.Rollback()
+
+  app, err := scanApplication(tx.QueryRowContext(ctx, `SELECT `+applicationColumns+` FROM applications WHERE id=? FOR UPDATE`, id))
+  if err == sql.ErrNoRows {
+    return applicationNotFound(id)
+  } else if err != nil {
+    return err
+  }
+  if app.Status != ApplicationPending {
+    return applicationClosed(app)
+  }
+
+  now := time.Now().Unix()
+  if _, err := tx.ExecContext(ctx, closeStmt, status, now, deciderId, id); err != nil {
+    return err
+  }
+
+  // record application_closed event in the outbox
+  err = insertOutboxEvent(tx, ApplicationClosedTopic, &v1.ApplicationClosed{
+    ApplicationId: id,
+    TeamId:        app.TeamId,
+    UserId:        app.UserId,
+    Status:        status,
+    OccurredAt:    now,
+  })
+  if err != nil {
+    return err
+  }
+  if err := closeApplicationAudit(ctx, tx, app.TeamId, id, app.UserId, status); err != nil {
+    return err
+  }
+  return tx.Commit()
+}
+
+// ---------------------------- HELPER FUNCTIONS -------------------------------
+
+// maxApplications caps the applications listed at once
+const maxApplications = 100
+
+// closeFullTeamApplications closes the pending applications of team teamId
+// if it has no open roles left and auto_close_applications is set. It runs
+// in the transaction that filled the team.
+func closeFullTeamApplications(ctx context.Context, tx *sql.Tx, teamId string) error {
+  selectStmt := `SELECT a.id, a.user_id FROM applications a JOIN teams t ON t.id = a.team_id
+    WHERE a.team_id=? AND a.status=? AND t.open_roles < 1 AND t.auto_close_applications = 1
+    FOR UPDATE`
+  closeStmt := `UPDATE applications SET status=?, decided_at=? WHERE id=?`
+
+  rows, err := tx.QueryContext(ctx, selectStmt, teamId, ApplicationPending)
+  if err != nil {
+    return err
+  }
+  type pending struct {
+    id     string
+    userId string
+  }
+  closing := []pending{}
+  for rows.Next() {
+    p := pending{}
+    if err := rows.Scan(&p.id, &p.userId); err != nil {
+      rows.Close()
+      return err
+    }
+    closing = append(closing, p)
+  }
+  rows.Close()
+  if err := rows.Err(); err != nil {
+    return err
+  }
+
+  now := time.Now().Unix()
+  for _, p := range closing {
+    if _, err := tx.ExecContext(ctx, closeStmt, ApplicationClosed, now, p.id); err != nil {
+      return err
+    }
+    err = insertOutboxEvent(tx, ApplicationClosedTopic, &v1.ApplicationClosed{
+      ApplicationId: p.id,
+      TeamId:        teamId,
+      UserId:        p.userId,
+      Status:        ApplicationClosed,
+      OccurredAt:    now,
+    })
+    if err != nil {
+      return err
+    }
+    if err := closeApplicationAudit(ctx, tx, teamId, p.id, p.userId, ApplicationClosed); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+// scanApplication scans a row of applicationColumns
+func scanApplication(row interface{ Scan(...interface{}) error }) (*v1.Application, error) {
+  app := &v1.Application{}
+  var id, teamId, userId int64
+  var decidedAt sql.NullInt64
+  var decidedBy sql.NullString
+  err := row.Scan(&id, &teamId, &userId, &app.Email, &app.Role, &app.Message, &app.Status, &app.CreatedAt, &decidedAt, &decidedBy)
+  if err != nil {
+    return nil, err
+  }
+  app.Id = strconv.FormatInt(id, 10)
+  app.TeamId = strconv.FormatInt(teamId, 10)
+  app.UserId = strconv.FormatInt(userId, 10)
+  app.DecidedAt = decidedAt.Int64
+  app.DecidedBy = decidedBy.String
+  return app, nil
+}
+
+// applicationState is the audited state of an application
+func applicationState(id, userId, status string) auditState {
+  return auditState{
+    "application_id": id,
+    "user_id":        userId,
+    "status":         status,
+  }
+}
+
+// closeApplicationAudit records the pending application id of userId to
+// team teamId closing as status
+func closeApplicationAudit(ctx context.Context, tx *sql.Tx, teamId, id, userId, status string) error {
+  before := applicationState(id, userId, ApplicationPending)
+  after := applicationState(id, userId, status)
+  return insertAuditEvent(ctx, tx, teamId, AuditCloseApplication, before, after)
+}
+
+func applicationNotFound(id string) error {
+  return domainerr.NotFound(domainerr.ReasonApplicationNotFound, "application '%s' not found", id).With("application_id", id)
+}
+
+func applicationClosed(app *v1.Application) error {
+  return domainerr.FailedPrecondition(domainerr.ReasonApplicationClosed, "application '%s' is already %s", app.Id, app.Status).With("application_id", app.Id)
+}
@@ -0,0 +1,172 @@
+package v1
+
+import (
+  "context"
+  "testing"
+
+  "github.com/DATA-DOG/go-sqlmock"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// applicationRow is application 6 of user 9 to team 3 with status
+func applicationRow(status string) *sqlmock.Rows {
+  return sqlmock.NewRows([]string{"id", "team_id", "user_id", "email", "member_role", "message", "status", "created_at", "decided_at", "decided_by"}).
+    AddRow(6, 3, 9, "m@example.com", "backend", "hi", status, 1600000000, nil, nil)
+}
+
+// expectLockApplication expects application 6 to be locked
+func expectLockApplication(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+  mock.ExpectQuery(stmt(`FROM applications WHERE id=? FOR UPDATE`)).WithArgs("6").WillReturnRows(rows)
+}
+
+func TestCreateApplicationChecks(t *testing.T) {
+  tests := []struct {
+    name   string
+    expect func(mock sqlmock.Sqlmock)
+    reason string
+  }{
+    {"full team", func(mock sqlmock.Sqlmock) {
+      expectOpenRoles(mock, "3", 0)
+    }, domainerr.ReasonTeamFull},
+    {"member", func(mock sqlmock.Sqlmock) {
+      expectOpenRoles(mock, "3", 1)
+      mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM members WHERE team_id=? AND user_id=?`)).WithArgs("3", "9").WillReturnRows(countRow(1))
+    }, domainerr.ReasonAlreadyMember},
+    {"pending application", func(mock sqlmock.Sqlmock) {
+      expectOpenRoles(mock, "3", 1)
+      mock.ExpectQuery(stmt(`FROM members`)).WillReturnRows(countRow(0))
+      mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM applications`)).WithArgs("3", "9", ApplicationPending).WillReturnRows(countRow(1))
+    }, domainerr.ReasonAlreadyApplied},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      repo, mock := newMockRepository(t)
+      mock.ExpectBegin()
+      tt.expect(mock)
+      mock.ExpectRollback()
+
+      _, err := repo.CreateApplication(context.Background(), &v1.Application{TeamId: "3", UserId: "9"})
+      if domainerr.ReasonOf(err) != tt.reason {
+        t.Errorf("CreateApplication() error = %v, want %s", err, tt.reason)
+      }
+    })
+  }
+}
+
+func TestApproveApplicationClosesTheOthersOfAFullTeam(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectLockApplication(mock, applicationRow(ApplicationPending))
+  expectOpenRoles(mock, "3", 1)
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM members WHERE team_id=? AND user_id=?`)).WithArgs("3", "9").WillReturnRows(countRow(0))
+  // applicants join as regular members
+  mock.ExpectExec(stmt(`INSERT INTO members`)).WithArgs("9", "3", "m@example.com", "backend", RoleMember).
+    WillReturnResult(sqlmock.NewResult(21, 1))
+  mock.ExpectExec(stmt(`UPDATE teams SET open_roles = open_roles - 1`)).WithArgs("3").WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`UPDATE teams SET last_active=?`)).WithArgs(sqlmock.AnyArg(), "3").WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`UPDATE applications SET status=?, decided_at=?, decided_by=? WHERE id=?`)).WithArgs(ApplicationApproved, sqlmock.AnyArg(), "2", "6").
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  expectOutbox(mock, MemberAddedTopic)
+  expectOutbox(mock, ApplicationClosedTopic)
+  expectAudit(mock, "3", AuditAddMember)
+  expectAudit(mock, "3", AuditCloseApplication)
+  // the team filled up and auto closes the other pending applications
+  mock.ExpectQuery(stmt(`FROM applications a JOIN teams t`)).WithArgs("3", ApplicationPending).
+    WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow("7", "10").AddRow("8", "11"))
+  for _, id := range []string{"7", "8"} {
+    mock.ExpectExec(stmt(`UPDATE applications SET status=?, decided_at=? WHERE id=?`)).WithArgs(ApplicationClosed, sqlmock.AnyArg(), id).
+      WillReturnResult(sqlmock.NewResult(0, 1))
+    expectOutbox(mock, ApplicationClosedTopic)
+    expectAudit(mock, "3", AuditCloseApplication)
+  }
+  mock.ExpectCommit()
+
+  teamId, memberNumber, err := repo.ApproveApplication(context.Background(), "6", "2")
+  if err != nil || teamId != "3" || memberNumber != "21" {
+    t.Errorf("ApproveApplication() = %s, %s, %v", teamId, memberNumber, err)
+  }
+}
+
+func TestApproveApplicationChecks(t *testing.T) {
+  tests := []struct {
+    name   string
+    expect func(mock sqlmock.Sqlmock)
+    reason string
+  }{
+    {"missing", func(mock sqlmock.Sqlmock) {
+      expectLockApplication(mock, sqlmock.NewRows(nil))
+    }, domainerr.ReasonApplicationNotFound},
+    {"approved", func(mock sqlmock.Sqlmock) {
+      expectLockApplication(mock, applicationRow(ApplicationApproved))
+    }, domainerr.ReasonApplicationClosed},
+    {"withdrawn", func(mock sqlmock.Sqlmock) {
+      expectLockApplication(mock, applicationRow(ApplicationWithdrawn))
+    }, domainerr.ReasonApplicationClosed},
+    {"full team", func(mock sqlmock.Sqlmock) {
+      expectLockApplication(mock, applicationRow(ApplicationPending))
+      expectOpenRoles(mock, "3", 0)
+    }, domainerr.ReasonTeamFull},
+    {"member", func(mock sqlmock.Sqlmock) {
+      expectLockApplication(mock, applicationRow(ApplicationPending))
+      expectOpenRoles(mock, "3", 1)
+      mock.ExpectQuery(stmt(`FROM members`)).WillReturnRows(countRow(1))
+    }, domainerr.ReasonAlreadyMember},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      repo, mock := newMockRepository(t)
+      mock.ExpectBegin()
+      tt.expect(mock)
+      mock.ExpectRollback()
+
+      if _, _, err := repo.ApproveApplication(context.Background(), "6", "2"); domainerr.ReasonOf(err) != tt.reason {
+        t.Errorf("ApproveApplication() error = %v, want %s", err, tt.reason)
+      }
+    })
+  }
+}
+
+func TestCloseApplication(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectLockApplication(mock, applicationRow(ApplicationPending))
+  mock.ExpectExec(stmt(`UPDATE applications SET status=?, decided_at=?, decided_by=? WHERE id=?`)).WithArgs(ApplicationWithdrawn, sqlmock.AnyArg(), "9", "6").
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  expectOutbox(mock, ApplicationClosedTopic)
+  expectAudit(mock, "3", AuditCloseApplication)
+  mock.ExpectCommit()
+
+  if err := repo.CloseApplication(context.Background(), "6", ApplicationWithdrawn, "9"); err != nil {
+    t.Fatal(err)
+  }
+
+  // a rejected application can't be withdrawn
+  mock.ExpectBegin()
+  expectLockApplication(mock, applicationRow(ApplicationRejected))
+  mock.ExpectRollback()
+  if err := repo.CloseApplication(context.Background(), "6", ApplicationWithdrawn, "9"); domainerr.ReasonOf(err) != domainerr.ReasonApplicationClosed {
+    t.Errorf("CloseApplication() error = %v, want %s", err, domainerr.ReasonApplicationClosed)
+  }
+}
+
+func TestListApplicationsFiltersStatuses(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  // pending applications are listed by default
+  mock.ExpectQuery(stmt(`FROM applications WHERE team_id=? AND status IN (?) ORDER BY id DESC LIMIT ?`)).
+    WithArgs("3", ApplicationPending, maxApplications).WillReturnRows(applicationRow(ApplicationPending))
+  apps, err := repo.ListApplications(context.Background(), "3", "", nil)
+  if err != nil || len(apps) != 1 || apps[0].UserId != "9" || apps[0].Status != ApplicationPending {
+    t.Errorf("ListApplications() = %v, %v", apps, err)
+  }
+
+  mock.ExpectQuery(stmt(`FROM applications WHERE user_id=? AND status IN (?, ?)`)).
+    WithArgs("9", ApplicationApproved, ApplicationRejected, maxApplications).WillReturnRows(applicationRow(ApplicationRejected))
+  if _, err := repo.ListApplications(context.Background(), "", "9", []string{ApplicationApproved, ApplicationRejected}); err != nil {
+    t.Error(err)
+  }
+}
@@ -0,0 +1,199 @@
+package v1
+
+import (
+  "context"
+  "fmt"
+  "os"
+  "time"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+func (s *handler) ApplyToTeam(ctx context.Context, req *v1.ApplyToTeamRequest) (*v1.ApplicationResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  identity, err := s.caller(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  application := &v1.Application{
+    TeamId:    req.TeamId,
+    UserId:    identity.UserId,
+    Email:     identity.Email,
+    Role:      req.Role,
+    Message:   req.Message,
+    CreatedAt: time.Now().Unix(),
+  }
+
+  // the repository checks the team has open roles and the caller isn't a member or already applied
+  id, err := s.repo.CreateApplication(ctx, application)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo CreateApplication: %v\n", req.TeamId)
+    return nil, err
+  }
+  application.Id = id
+  application.Status = ApplicationPending
+
+  // application_submitted Event is written to the outbox by the repository
+
+  return &v1.ApplicationResponse{
+    Api:         apiVersion,
+    Status:      "Applied",
+    Application: application,
+  }, nil
+}
+
+func (s *handler) ListApplications(ctx context.Context, req *v1.ListApplicationsRequest) (*v1.ListApplicationsResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  userId := ""
+  if req.TeamId != "" {
+    // a team's applications are listed to the members who review them
+    if _, _, err := s.authorize(ctx, req.TeamId, ActionReviewApplication); err != nil {
+      return nil, err
+    }
+  } else {
+    var err error
+    if userId, err = s.callerId(ctx); err != nil {
+      return nil, err
+    }
+  }
+
+  applications, err := s.repo.ListApplications(ctx, req.TeamId, userId, req.Statuses)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo ListApplications: %v\n", req.TeamId)
+    return nil, err
+  }
+
+  status := "applications"
+  if len(applications) == 0 {
+    status = "empty"
+  }
+  return &v1.ListApplicationsResponse{
+    Api:          apiVersion,
+    Status:       status,
+    Applications: applications,
+  }, nil
+}
+
+func (s *handler) ApproveApplication(ctx context.Context, req *v1.ApplicationRequest) (*v1.ApproveApplicationResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  application, err := s.repo.GetApplication(ctx, req.ApplicationId)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo GetApplication: %v\n", req.ApplicationId)
+    return nil, err
+  }
+
+  // Check if the caller may review applications to the application's team
+  userId, _, err := s.authorize(ctx, application.TeamId, ActionReviewApplication)
+  if err != nil {
+    return nil, err
+  }
+
+  // the same capacity and duplicate checks as AddMember, the repository
+  // repeats them while holding the team's lock
+  max, err := s.repo.CheckTeamSize(ctx, application.TeamId)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "Error ApproveApplication: Repo CheckTeamSize: %v\n", application.TeamId)
+    return nil, err
+  }
+  if max {
+    return nil, domainerr.FailedPrecondition(domainerr.ReasonTeamFull, "team '%s' has no open roles", application.TeamId).With("team_id", application.TeamId)
+  }
+  exists, err := s.repo.CheckMemberExists(ctx, application.UserId, application.TeamId)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "Error ApproveApplication: Repo CheckMemberExists: %v\n", application.TeamId)
+    return nil, err
+  }
+  if exists {
+    return nil, domainerr.AlreadyExists(domainerr.ReasonAlreadyMember, "user '%s' is already on team '%s'", application.UserId, application.TeamId).With("team_id", application.TeamId)
+  }
+
+  teamId, memberNumber, err := s.repo.ApproveApplication(ctx, req.ApplicationId, userId)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo ApproveApplication: %v\n", req.ApplicationId)
+    return nil, err
+  }
+
+  // member_added and application_closed Events are written to the outbox by the repository
+
+  return &v1.ApproveApplicationResponse{
+    Api:          apiVersion,
+    Status:       "Approved",
+    TeamId:       teamId,
+    MemberNumber: memberNumber,
+  }, nil
+}
+
+func (s *handler) RejectApplication(ctx context.Context, req *v1.ApplicationRequest) (*v1.ApplicationResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  application, err := s.repo.GetApplication(ctx, req.ApplicationId)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo GetApplication: %v\n", req.ApplicationId)
+    return nil, err
+  }
+
+  // Check if the caller may review applications to the application's team
+  userId, _, err := s.authorize(ctx, application.TeamId, ActionReviewApplication)
+  if err != nil {
+    return nil, err
+  }
+
+  if err := s.repo.CloseApplication(ctx, req.ApplicationId, ApplicationRejected, userId); err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo CloseApplication: %v\n", req.ApplicationId)
+    return nil, err
+  }
+
+  return &v1.ApplicationResponse{
+    Api:    apiVersion,
+    Status: "Rejected",
+  }, nil
+}
+
+func (s *handler) WithdrawApplication(ctx context.Context, req *v1.ApplicationRequest) (*v1.ApplicationResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  userId, err := s.callerId(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  // only applicants withdraw, others can't see the application exists
+  application, err := s.repo.GetApplication(ctx, req.ApplicationId)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo GetApplication: %v\n", req.ApplicationId)
+    return nil, err
+  }
+  if application.UserId != userId {
+    return nil, applicationNotFound(req.ApplicationId)
+  }
+
+  if err := s.repo.CloseApplication(ctx, req.ApplicationId, ApplicationWithdrawn, userId); err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo CloseApplication: %v\n", req.ApplicationId)
+    return nil, err
+  }
+
+  return &v1.ApplicationResponse{
+    Api:    apiVersion,
+    Status: "Withdrawn",
+  }, nil
+}
@@ -0,0 +1,164 @@
+package v1
+
+import (
+  "context"
+  "testing"
+
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/auth"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// applicationsRepository is team 3 of rolesRepository with application 6 of
+// user 9 pending, it records what is created, approved and closed
+type applicationsRepository struct {
+  *rolesRepository
+  full     bool
+  member   bool
+  created  []*v1.Application
+  approved []string
+  closed   map[string]string
+  listed   []string
+}
+
+func (r *applicationsRepository) CheckTeamSize(ctx context.Context, teamId string) (bool, error) {
+  return r.full, nil
+}
+
+func (r *applicationsRepository) CheckMemberExists(ctx context.Context, userId, teamId string) (bool, error) {
+  return r.member, nil
+}
+
+func (r *applicationsRepository) CreateApplication(ctx context.Context, app *v1.Application) (string, error) {
+  r.created = append(r.created, app)
+  return "6", nil
+}
+
+func (r *applicationsRepository) GetApplication(ctx context.Context, id string) (*v1.Application, error) {
+  if id != "6" {
+    return nil, applicationNotFound(id)
+  }
+  return &v1.Application{Id: "6", TeamId: "3", UserId: "9", Status: ApplicationPending}, nil
+}
+
+func (r *applicationsRepository) ListApplications(ctx context.Context, teamId, userId string, statuses []string) ([]*v1.Application, error) {
+  r.listed = append(r.listed, teamId+"/"+userId)
+  return []*v1.Application{}, nil
+}
+
+func (r *applicationsRepository) ApproveApplication(ctx context.Context, id, deciderId string) (string, string, error) {
+  r.approved = append(r.approved, deciderId)
+  return "3", "21", nil
+}
+
+func (r *applicationsRepository) CloseApplication(ctx context.Context, id, status, deciderId string) error {
+  r.closed[status] = deciderId
+  return nil
+}
+
+func newApplicationsHandler() (*handler, *applicationsRepository) {
+  _, roles := newRolesHandler()
+  repo := &applicationsRepository{rolesRepository: roles, closed: map[string]string{}}
+  return NewTeamServiceServer(repo, nil, ""), repo
+}
+
+func TestApplyToTeam(t *testing.T) {
+  s, repo := newApplicationsHandler()
+  ctx := auth.NewContext(context.Background(), &auth.Identity{UserId: "9", Email: "m@example.com"})
+
+  res, err := s.ApplyToTeam(ctx, &v1.ApplyToTeamRequest{TeamId: "3", Role: "backend", Message: "hi"})
+  if err != nil {
+    t.Fatal(err)
+  }
+  app := res.Application
+  if app.Id != "6" || app.UserId != "9" || app.Email != "m@example.com" || app.Status != ApplicationPending || app.CreatedAt == 0 {
+    t.Errorf("application = %v", app)
+  }
+
+  if _, err := s.ApplyToTeam(context.Background(), &v1.ApplyToTeamRequest{TeamId: "3", Role: "backend"}); status.Code(err) != codes.Unauthenticated {
+    t.Errorf("anonymous ApplyToTeam() error = %v, want Unauthenticated", err)
+  }
+  if len(repo.created) != 1 {
+    t.Errorf("created %d applications", len(repo.created))
+  }
+}
+
+func TestApproveApplication(t *testing.T) {
+  tests := []struct {
+    name   string
+    caller string
+    full   bool
+    member bool
+    reason string
+    code   codes.Code
+  }{
+    {"owner approves", "1", false, false, "", codes.OK},
+    {"admin approves", "2", false, false, "", codes.OK},
+    {"member approves", "3", false, false, domainerr.ReasonPermissionDenied, codes.PermissionDenied},
+    // the same checks as AddMember
+    {"full team", "2", true, false, domainerr.ReasonTeamFull, codes.FailedPrecondition},
+    {"applicant joined meanwhile", "2", false, true, domainerr.ReasonAlreadyMember, codes.AlreadyExists},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      s, repo := newApplicationsHandler()
+      repo.full, repo.member = tt.full, tt.member
+
+      res, err := s.ApproveApplication(as(tt.caller), &v1.ApplicationRequest{ApplicationId: "6"})
+      if status.Code(err) != tt.code {
+        t.Fatalf("ApproveApplication() error = %v, want %s", err, tt.code)
+      }
+      if tt.code != codes.OK {
+        if domainerr.ReasonOf(err) != tt.reason || len(repo.approved) != 0 {
+          t.Errorf("reason = %s, approved by %v", domainerr.ReasonOf(err), repo.approved)
+        }
+        return
+      }
+      // the decider is recorded
+      if res.MemberNumber != "21" || len(repo.approved) != 1 || repo.approved[0] != tt.caller {
+        t.Errorf("ApproveApplication() = %v, approved by %v", res, repo.approved)
+      }
+    })
+  }
+}
+
+func TestRejectAndWithdrawApplication(t *testing.T) {
+  s, repo := newApplicationsHandler()
+
+  if _, err := s.RejectApplication(as("3"), &v1.ApplicationRequest{ApplicationId: "6"}); status.Code(err) != codes.PermissionDenied {
+    t.Errorf("RejectApplication() by a member error = %v, want PermissionDenied", err)
+  }
+  if _, err := s.RejectApplication(as("2"), &v1.ApplicationRequest{ApplicationId: "6"}); err != nil || repo.closed[ApplicationRejected] != "2" {
+    t.Errorf("RejectApplication() = %v, closed %v", err, repo.closed)
+  }
+
+  // only the applicant withdraws, others can't tell the application exists
+  if _, err := s.WithdrawApplication(as("1"), &v1.ApplicationRequest{ApplicationId: "6"}); status.Code(err) != codes.NotFound {
+    t.Errorf("WithdrawApplication() by the owner error = %v, want NotFound", err)
+  }
+  if _, err := s.WithdrawApplication(as("9"), &v1.ApplicationRequest{ApplicationId: "6"}); err != nil || repo.closed[ApplicationWithdrawn] != "9" {
+    t.Errorf("WithdrawApplication() = %v, closed %v", err, repo.closed)
+  }
+}
+
+func TestListApplications(t *testing.T) {
+  s, repo := newApplicationsHandler()
+
+  // applicants list their own applications
+  if _, err := s.ListApplications(as("9"), &v1.ListApplicationsRequest{}); err != nil {
+    t.Fatal(err)
+  }
+  // reviewers list the team's
+  if _, err := s.ListApplications(as("2"), &v1.ListApplicationsRequest{TeamId: "3"}); err != nil {
+    t.Fatal(err)
+  }
+  if _, err := s.ListApplications(as("4"), &v1.ListApplicationsRequest{TeamId: "3"}); status.Code(err) != codes.PermissionDenied {
+    t.Errorf("ListApplications() by a viewer error = %v, want PermissionDenied", err)
+  }
+  if len(repo.listed) != 2 || repo.listed[0] != "/9" || repo.listed[1] != "3/" {
+    t.Errorf("listed %v", repo.listed)
+  }
+}
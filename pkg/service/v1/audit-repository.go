@@ -0,0 +1,232 @@
+package v1
+
+import (
+  "context"
+  "database/sql"
+  "encoding/json"
+  "strconv"
+  "strings"
+  "time"
+
+  "github.com/golang/protobuf/jsonpb"
+  structpb "github.com/golang/protobuf/ptypes/struct"
+  "google.golang.org/grpc/metadata"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/auth"
+)
+
+// actions recorded in the audit trail
+const (
+  AuditCreateTeam        = "create_team"
+  AuditUpdateTeam        = "update_team"
+  AuditDeleteTeam        = "delete_team"
+  AuditRestoreTeam       = "restore_team"
+  AuditPurgeTeam         = "purge_team"
+  AuditFlagInactive      = "flag_inactive"
+  AuditArchiveTeam       = "archive_team"
+  AuditAddMember         = "add_member"
+  AuditRemoveMember      = "remove_member"
+  AuditUpsertProject     = "upsert_project"
+  AuditCreateProject     = "create_project"
+  AuditUpdateProject     = "update_project"
+  AuditArchiveProject    = "archive_project"
+  AuditCreateMilestone   = "create_milestone"
+  AuditUpdateMilestone   = "update_milestone"
+  AuditDeleteMilestone   = "delete_milestone"
+  AuditCreateTask        = "create_task"
+  AuditUpdateTask        = "update_task"
+  AuditDeleteTask        = "delete_task"
+  AuditChangeLeader      = "change_leader"
+  AuditInviteMember      = "invite_member"
+  AuditCloseInvitation   = "close_invitation"
+  AuditApplyToTeam       = "apply_to_team"
+  AuditCloseApplication  = "close_application"
+  AuditUpdateMemberEmail = "update_member_email"
+)
+
+// auditSystemActor is the actor of changes made without a caller, e.g. by
+// the purger or the user event consumer
+const auditSystemActor = "system"
+
+// requestIdMetadata is the request metadata identifying the request
+const requestIdMetadata = "x-request-id"
+
+// defaultAuditPageSize is the ListAuditEvents page size when the request has no limit
+const defaultAuditPageSize = 50
+
+// auditState holds the fields of a team a change touched
+type auditState map[string]interface{}
+
+// insertAuditEvent appends the change action to team teamId to the audit
+// trail as part of tx so it is only recorded if the change commits. The
+// actor is the caller of ctx and before or after is nil when the change
+// created or removed the fields.
+func insertAuditEvent(ctx context.Context, tx *sql.Tx, teamId, action string, before, after auditState) error {
+  auditStmt := `INSERT INTO audit_events (team_id, actor, action, before_state, after_state, request_id, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+  actor := auditSystemActor
+  if identity, ok := auth.FromContext(ctx); ok {
+    actor = identity.UserId
+  }
+  requestId := ""
+  if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(requestIdMetadata)) > 0 {
+    requestId = md.Get(requestIdMetadata)[0]
+  }
+
+  beforeState, err := marshalAuditState(before)
+  if err != nil {
+    return err
+  }
+  afterState, err := marshalAuditState(after)
+  if err != nil {
+    return err
+  }
+
+  _, err = tx.ExecContext(ctx, auditStmt, teamId, actor, action, beforeState, afterState, requestId, time.Now().Unix())
+  return err
+}
+
+// Lists a page of the audit events matching every filter of req, most
+// recent first. Pages continue after the cursor of req.PageToken.
+// output: events of the page, token of the next page or "" on the last page, error
+func (r *teamRepository) ListAuditEvents(ctx context.Context, req *v1.ListAuditEventsRequest) ([]*v1.AuditEvent, string, error) {
+  token, err := decodeAuditPageToken(req)
+  if err != nil {
+    return nil, "", err
+  }
+
+  limit := req.Limit
+  if limit <= 0 {
+    limit = defaultAuditPageSize
+  }
+
+  where := []string{}
+  args := []interface{}{}
+  if req.TeamId != "" {
+    where = append(where, `team_id = ?`)
+    args = append(args, req.TeamId)
+  }
+  if req.Actor != "" {
+    where = append(where, `actor = ?`)
+    args = append(args, req.Actor)
+  }
+  if req.Action != "" {
+    where = append(where, `action = ?`)
+    args = append(args, req.Action)
+  }
+  if req.Since != 0 {
+    where = append(where, `created_at >= ?`)
+    args = append(args, req.Since)
+  }
+  if req.Until != 0 {
+    where = append(where, `created_at <= ?`)
+    args = append(args, req.Until)
+  }
+  if token != nil {
+    where = append(where, `id < ?`)
+    args = append(args, token.Id)
+  }
+
+  // one more row than the page tells whether there is a next page
+  selectStmt := `SELECT id, team_id, actor, action, before_state, after_state, request_id, created_at FROM audit_events`
+  if len(where) > 0 {
+    selectStmt += ` WHERE ` + strings.Join(where, ` AND `)
+  }
+  selectStmt += ` ORDER BY id DESC LIMIT ?`
+  args = append(args, limit+1)
+
+  rows, err := r.db.QueryContext(ctx, selectStmt, args...)
+  if err != nil {
+    return nil, "", err
+  }
+  defer rows.Close()
+
+  events := []*v1.AuditEvent{}
+  ids := []int64{}
+  for rows.Next() {
+    var id int64
+    var before, after sql.NullString
+    event := &v1.AuditEvent{}
+    if err := rows.Scan(&id, &event.TeamId, &event.Actor, &event.Action, &before, &after, &event.RequestId, &event.OccurredAt); err != nil {
+      return nil, "", err
+    }
+    event.Id = strconv.FormatInt(id, 10)
+    if event.Before, err = unmarshalAuditState(before); err != nil {
+      return nil, "", err
+    }
+    if event.After, err = unmarshalAuditState(after); err != nil {
+      return nil, "", err
+    }
+    events = append(events, event)
+    ids = append(ids, id)
+  }
+  if err := rows.Err(); err != nil {
+    return nil, "", err
+  }
+
+  nextToken := ""
+  if int64(len(events)) > limit {
+    events = events[:limit]
+    nextToken = encodeAuditPageToken(req, ids[limit-1])
+  }
+  return events, nextToken, nil
+}
+
+// ---------------------------- HELPER FUNCTIONS -------------------------------
+
+// marshalAuditState returns the JSON of state, NULL when it is nil
+func marshalAuditState(state auditState) (sql.NullString, error) {
+  if state == nil {
+    return sql.NullString{}, nil
+  }
+  b, err := json.Marshal(state)
+  if err != nil {
+    return sql.NullString{}, err
+  }
+  return sql.NullString{String: string(b), Valid: true}, nil
+}
+
+// unmarshalAuditState returns the struct of a stored state, nil when NULL
+func unmarshalAuditState(state sql.NullString) (*structpb.Struct, error) {
+  if !state.Valid {
+    return nil, nil
+  }
+  s := &structpb.Struct{}
+  if err := jsonpb.UnmarshalString(state.String, s); err != nil {
+    return nil, err
+  }
+  return s, nil
+}
+
+// memberState is the audited state of a membership
+func memberState(memberNumber, userId, email, role, accessRole string) auditState {
+  return auditState{
+    "member_number": memberNumber,
+    "user_id":       userId,
+    "email":         email,
+    "role":          role,
+    "access_role":   accessRole,
+  }
+}
+
+// projectState is the audited state of a project, nil for no project
+func projectState(project *v1.Project) auditState {
+  if project == nil {
+    return nil
+  }
+  languages := project.Languages
+  if languages == nil {
+    languages = []string{}
+  }
+  return auditState{
+    "project_id":  project.Id,
+    "name":        project.Name,
+    "description": project.Description,
+    "github_link": project.GithubLink,
+    "complexity":  project.Complexity,
+    "duration":    project.Duration,
+    "languages":   languages,
+    "status":      project.Status,
+  }
+}
@@ -0,0 +1,122 @@
+package v1
+
+import (
+  "context"
+  "testing"
+
+  "github.com/DATA-DOG/go-sqlmock"
+  "google.golang.org/grpc/metadata"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// auditRows returns the audit events ids of team 3, added by user 1
+func auditRows(ids ...int64) *sqlmock.Rows {
+  rows := sqlmock.NewRows([]string{"id", "team_id", "actor", "action", "before_state", "after_state", "request_id", "created_at"})
+  for _, id := range ids {
+    rows.AddRow(id, "3", "1", AuditAddMember, nil, `{"member_number":"21","role":"backend"}`, "req-1", 1600000000)
+  }
+  return rows
+}
+
+func TestInsertAuditEvent(t *testing.T) {
+  tests := []struct {
+    name      string
+    ctx       context.Context
+    actor     string
+    requestId string
+  }{
+    {"caller", metadata.NewIncomingContext(as("1"), metadata.Pairs(requestIdMetadata, "req-1")), "1", "req-1"},
+    // the purger and the user event consumer change teams without a caller
+    {"system", context.Background(), auditSystemActor, ""},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      repo, mock := newMockRepository(t)
+      mock.ExpectBegin()
+      mock.ExpectExec(stmt(`INSERT INTO audit_events (team_id, actor, action, before_state, after_state, request_id, created_at)`)).
+        WithArgs("3", tt.actor, AuditRemoveMember, `{"role":"backend"}`, nil, tt.requestId, sqlmock.AnyArg()).
+        WillReturnResult(sqlmock.NewResult(1, 1))
+      mock.ExpectCommit()
+
+      tx, _ := repo.db.Begin()
+      if err := insertAuditEvent(tt.ctx, tx, "3", AuditRemoveMember, auditState{"role": "backend"}, nil); err != nil {
+        t.Fatal(err)
+      }
+      tx.Commit()
+    })
+  }
+}
+
+func TestListAuditEventsFilters(t *testing.T) {
+  repo, mock := newMockRepository(t)
+  req := &v1.ListAuditEventsRequest{TeamId: "3", Actor: "1", Action: AuditAddMember, Since: 1500000000, Until: 1700000000}
+
+  mock.ExpectQuery(stmt(`FROM audit_events WHERE team_id = ? AND actor = ? AND action = ? AND created_at >= ? AND created_at <= ? ORDER BY id DESC LIMIT ?`)).
+    WithArgs("3", "1", AuditAddMember, int64(1500000000), int64(1700000000), int64(defaultAuditPageSize+1)).
+    WillReturnRows(auditRows(9))
+
+  events, next, err := repo.ListAuditEvents(context.Background(), req)
+  if err != nil || next != "" || len(events) != 1 {
+    t.Fatalf("ListAuditEvents() = %v, %q, %v", events, next, err)
+  }
+  event := events[0]
+  if event.Id != "9" || event.Actor != "1" || event.RequestId != "req-1" || event.Before != nil {
+    t.Errorf("event = %v", event)
+  }
+  if event.After.Fields["role"].GetStringValue() != "backend" {
+    t.Errorf("after = %v", event.After)
+  }
+}
+
+func TestListAuditEventsPages(t *testing.T) {
+  repo, mock := newMockRepository(t)
+  req := &v1.ListAuditEventsRequest{TeamId: "3", Limit: 2}
+
+  // the extra event tells there is a next page
+  mock.ExpectQuery(stmt(`FROM audit_events WHERE team_id = ? ORDER BY id DESC LIMIT ?`)).WithArgs("3", int64(3)).
+    WillReturnRows(auditRows(9, 8, 7))
+  events, next, err := repo.ListAuditEvents(context.Background(), req)
+  if err != nil || len(events) != 2 || next == "" {
+    t.Fatalf("ListAuditEvents() = %v, %q, %v", events, next, err)
+  }
+
+  // the next page starts below the last event
+  req.PageToken = next
+  mock.ExpectQuery(stmt(`FROM audit_events WHERE team_id = ? AND id < ? ORDER BY id DESC LIMIT ?`)).WithArgs("3", int64(8), int64(3)).
+    WillReturnRows(auditRows(7))
+  events, next, err = repo.ListAuditEvents(context.Background(), req)
+  if err != nil || len(events) != 1 || next != "" {
+    t.Fatalf("ListAuditEvents() = %v, %q, %v", events, next, err)
+  }
+
+  // a token doesn't carry over to other filters
+  req.Actor = "2"
+  if _, _, err := repo.ListAuditEvents(context.Background(), req); domainerr.ReasonOf(err) != domainerr.ReasonInvalidArgument {
+    t.Errorf("ListAuditEvents() error = %v, want %s", err, domainerr.ReasonInvalidArgument)
+  }
+}
+
+func TestAuditStateRoundTrip(t *testing.T) {
+  stored, err := marshalAuditState(nil)
+  if err != nil || stored.Valid {
+    t.Errorf("marshalAuditState(nil) = %v, %v, want NULL", stored, err)
+  }
+  if s, err := unmarshalAuditState(stored); err != nil || s != nil {
+    t.Errorf("unmarshalAuditState(NULL) = %v, %v", s, err)
+  }
+
+  stored, err = marshalAuditState(projectState(&v1.Project{Id: "2", Name: "api", Complexity: 3}))
+  if err != nil {
+    t.Fatal(err)
+  }
+  s, err := unmarshalAuditState(stored)
+  if err != nil {
+    t.Fatal(err)
+  }
+  // a project without languages has none rather than null
+  if s.Fields["name"].GetStringValue() != "api" || s.Fields["complexity"].GetNumberValue() != 3 || s.Fields["languages"].GetListValue() == nil {
+    t.Errorf("state = %v", s)
+  }
+}
@@ -0,0 +1,48 @@
+package v1
+
+import (
+  "context"
+  "fmt"
+  "os"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+func (s *handler) ListAuditEvents(ctx context.Context, req *v1.ListAuditEventsRequest) (*v1.ListAuditEventsResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  // support may search the whole trail, owners only the trail of their team
+  identity, err := s.caller(ctx)
+  if err != nil {
+    return nil, err
+  }
+  if !identity.HasRole(RoleSupport) {
+    if req.TeamId == "" {
+      return nil, domainerr.PermissionDenied("only support may list audit events across teams")
+    }
+    if _, _, err := s.authorize(ctx, req.TeamId, ActionListAuditEvents); err != nil {
+      return nil, err
+    }
+  }
+
+  events, nextToken, err := s.repo.ListAuditEvents(ctx, req)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo ListAuditEvents: %v\n", req.TeamId)
+    return nil, err
+  }
+
+  status := "events"
+  if len(events) == 0 {
+    status = "empty"
+  }
+  return &v1.ListAuditEventsResponse{
+    Api:           apiVersion,
+    Status:        status,
+    Events:        events,
+    NextPageToken: nextToken,
+  }, nil
+}
@@ -0,0 +1,56 @@
+package v1
+
+import (
+  "context"
+  "testing"
+
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/auth"
+)
+
+// auditRepository is team 3 of rolesRepository, it records the teams whose
+// trail is listed
+type auditRepository struct {
+  *rolesRepository
+  listed []string
+}
+
+func (r *auditRepository) ListAuditEvents(ctx context.Context, req *v1.ListAuditEventsRequest) ([]*v1.AuditEvent, string, error) {
+  r.listed = append(r.listed, req.TeamId)
+  return []*v1.AuditEvent{}, "", nil
+}
+
+func TestListAuditEvents(t *testing.T) {
+  support := auth.NewContext(context.Background(), &auth.Identity{UserId: "42", Roles: []string{RoleSupport}})
+  tests := []struct {
+    name   string
+    ctx    context.Context
+    teamId string
+    code   codes.Code
+  }{
+    {"owner", as("1"), "3", codes.OK},
+    {"admin", as("2"), "3", codes.PermissionDenied},
+    {"owner across teams", as("1"), "", codes.PermissionDenied},
+    {"support", support, "3", codes.OK},
+    {"support across teams", support, "", codes.OK},
+    {"anonymous", context.Background(), "3", codes.Unauthenticated},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      _, roles := newRolesHandler()
+      repo := &auditRepository{rolesRepository: roles}
+      s := NewTeamServiceServer(repo, nil, "")
+
+      _, err := s.ListAuditEvents(tt.ctx, &v1.ListAuditEventsRequest{Api: apiVersion, TeamId: tt.teamId})
+      if status.Code(err) != tt.code {
+        t.Fatalf("ListAuditEvents() error = %v, want %s", err, tt.code)
+      }
+      if listed := len(repo.listed) == 1; listed != (tt.code == codes.OK) {
+        t.Errorf("listed %v", repo.listed)
+      }
+    })
+  }
+}
@@ -5,37 +5,40 @@ import (
   "github.com/Shopify/sarama"
   "github.com/ThreeDotsLabs/watermill"
   "github.com/ThreeDotsLabs/watermill-kafka/v2/pkg/kafka"
+  "github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
   //"github.com/ThreeDotsLabs/watermill/message"
   //"log"
   //"time"
 )
 
-func InitSubscriber(config *sarama.Config) *kafka.Subscriber {
-  subscriber, err := kafka.NewSubscriber(
+func InitSubscriber(config *sarama.Config, brokers []string, consumerGroup string) (*kafka.Subscriber, error) {
+  return kafka.NewSubscriber(
     kafka.SubscriberConfig{
-      Brokers:               []string{"kafka:9092"},
+      Brokers:               brokers,
       Unmarshaler:           kafka.DefaultMarshaler{},
       OverwriteSaramaConfig: config,
-      ConsumerGroup:         "test_consumer_group",
+      ConsumerGroup:         consumerGroup,
     },
     watermill.NewStdLogger(false, false),
   )
-  if err != nil {
-    panic(err)
-  }
-  return subscriber
 }
 
-func InitPublisher() *kafka.Publisher {
-  publisher, err := kafka.NewPublisher(
+func InitPublisher(brokers []string) (*kafka.Publisher, error) {
+  return kafka.NewPublisher(
     kafka.PublisherConfig{
-      Brokers:   []string{"kafka:9092"},
+      Brokers:   brokers,
       Marshaler: kafka.DefaultMarshaler{},
     },
     watermill.NewStdLogger(false, false),
   )
-  if err != nil {
-    panic(err)
-  }
-  return publisher
+}
+
+// InitMemoryPubSub returns an in-process pub/sub usable as both publisher and
+// subscriber. It is meant for local development and tests where no Kafka
+// cluster is available; messages are lost when the process exits.
+func InitMemoryPubSub() *gochannel.GoChannel {
+  return gochannel.NewGoChannel(
+    gochannel.Config{},
+    watermill.NewStdLogger(false, false),
+  )
 }
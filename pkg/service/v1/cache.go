@@ -1,16 +1,350 @@
 package v1
 
 import (
-//"github.com/go-redis/cache/v7"
+  "context"
+  "errors"
+  "fmt"
+  "os"
+  "strings"
+  "time"
+
+  "github.com/go-redis/cache/v7"
+  "github.com/go-redis/redis/v7"
+  "github.com/golang/protobuf/proto"
+  "github.com/vmihailenco/msgpack/v4"
 )
 
+// errCacheMiss is returned by GetEntry when key is not cached
+var errCacheMiss = errors.New("cache: key is missing")
+
 type appCache interface {
-  AddEntry()
-  GetEntry()
+  // AddEntry caches v under key for ttl
+  AddEntry(ctx context.Context, key string, v interface{}, ttl time.Duration) error
+  // GetEntry decodes the entry cached under key into v or returns errCacheMiss
+  GetEntry(ctx context.Context, key string, v interface{}) error
+  // LoadEntry decodes the entry cached under key into v. On a miss it calls
+  // load, caches the result for ttl and decodes it into v. Concurrent misses
+  // for the same key share a single call to load, whose result isn't cached
+  // when key is deleted while it runs.
+  LoadEntry(ctx context.Context, key string, v interface{}, ttl time.Duration, load func() (interface{}, error)) error
+  // DeleteEntry removes keys from the cache, missing keys are ignored
+  DeleteEntry(ctx context.Context, keys ...string) error
+}
+
+// cache backends selectable with CacheConfig.Backend
+const (
+  CacheNone   = "none"
+  CacheMemory = "memory"
+  CacheRedis  = "redis"
+  // CacheTiered keeps a local LRU in front of redis, local entries are
+  // invalidated across instances through redis pub/sub
+  CacheTiered = "tiered"
+)
+
+// channel tiered caches publish invalidated keys on
+const cacheInvalidationChannel = "team:cache:invalidate"
+
+// CacheConfig selects and sizes the cache backend
+type CacheConfig struct {
+  // Backend is one of CacheNone, CacheMemory, CacheRedis or CacheTiered
+  Backend string
+  // Redis is required by the redis and tiered backends
+  Redis *redis.Ring
+  // Size is the maximum number of entries of a local LRU
+  Size int
+  // LocalTTL caps how long a tiered cache keeps entries locally
+  LocalTTL time.Duration
+}
+
+// NewAppCache returns the cache backend selected by cfg. A tiered cache
+// listens for invalidations until ctx is cancelled.
+func NewAppCache(ctx context.Context, cfg CacheConfig) (appCache, error) {
+  switch cfg.Backend {
+  case CacheNone, "":
+    return NewNoopCache(), nil
+  case CacheMemory:
+    return NewMemoryCache(cfg.Size), nil
+  case CacheRedis, CacheTiered:
+    if cfg.Redis == nil {
+      return nil, fmt.Errorf("cache backend '%s' requires a redis address", cfg.Backend)
+    }
+    remote := NewRedisCache(cfg.Redis)
+    if cfg.Backend == CacheRedis {
+      return remote, nil
+    }
+    tiered := &tieredCache{
+      local:    NewMemoryCache(cfg.Size),
+      remote:   remote,
+      redis:    cfg.Redis,
+      localTTL: cfg.LocalTTL,
+    }
+    go tiered.listen(ctx)
+    return tiered, nil
+  default:
+    return nil, fmt.Errorf("unknown cache backend: '%s'", cfg.Backend)
+  }
+}
+
+// marshalEntry encodes a cache entry, protobuf messages keep their own
+// encoding and anything else is msgpack
+func marshalEntry(v interface{}) ([]byte, error) {
+  if msg, ok := v.(proto.Message); ok {
+    return proto.Marshal(msg)
+  }
+  return msgpack.Marshal(v)
+}
+
+// unmarshalEntry decodes a cache entry encoded by marshalEntry into v
+func unmarshalEntry(b []byte, v interface{}) error {
+  if msg, ok := v.(proto.Message); ok {
+    return proto.Unmarshal(b, msg)
+  }
+  return msgpack.Unmarshal(b, v)
+}
+
+// cacheGenerationTTL is how long a redis cache remembers a key was deleted,
+// longer than any load takes
+const cacheGenerationTTL = 24 * time.Hour
+
+type redisCache struct {
+  codec *cache.Codec
+  ring  *redis.Ring
+  loads loadGroup
+}
+
+func NewRedisCache(ring *redis.Ring) *redisCache {
+  return &redisCache{
+    ring: ring,
+    codec: &cache.Codec{
+      Redis:     ring,
+      Marshal:   marshalEntry,
+      Unmarshal: unmarshalEntry,
+    },
+  }
+}
+
+func (c *redisCache) AddEntry(ctx context.Context, key string, v interface{}, ttl time.Duration) error {
+  return c.codec.Set(&cache.Item{
+    Ctx:        ctx,
+    Key:        key,
+    Object:     v,
+    Expiration: ttl,
+  })
+}
+
+func (c *redisCache) GetEntry(ctx context.Context, key string, v interface{}) error {
+  err := c.codec.GetContext(ctx, key, v)
+  if err == cache.ErrCacheMiss {
+    return errCacheMiss
+  }
+  return err
+}
+
+func (c *redisCache) LoadEntry(ctx context.Context, key string, v interface{}, ttl time.Duration, load func() (interface{}, error)) error {
+  if err := c.GetEntry(ctx, key, v); err == nil {
+    return nil
+  }
+
+  // only one load per key is in flight in this process
+  b, err := c.loads.do(key, func(call *loadCall) ([]byte, error) {
+    generation, err := c.generation(key)
+    if err != nil {
+      return nil, err
+    }
+    obj, err := load()
+    if err != nil {
+      return nil, err
+    }
+    b, err := marshalEntry(obj)
+    if err != nil {
+      return nil, err
+    }
+    // the loaded entry is good even when it can't be cached
+    if err := c.setLoaded(key, generation, b, ttl); err != nil {
+      fmt.Fprintf(os.Stderr, "caching %s: %v\n", key, err)
+    }
+    return b, nil
+  })
+  if err != nil {
+    return err
+  }
+  return unmarshalEntry(b, v)
+}
+
+func (c *redisCache) DeleteEntry(ctx context.Context, keys ...string) error {
+  var lastErr error
+  for _, key := range keys {
+    // loads in flight, on any instance, read the entry before it was deleted
+    if err := c.ring.Incr(generationKey(key)).Err(); err != nil {
+      lastErr = err
+      continue
+    }
+    c.ring.Expire(generationKey(key), cacheGenerationTTL)
+    c.loads.forget(key)
+
+    err := c.codec.DeleteContext(ctx, key)
+    if err != nil && err != cache.ErrCacheMiss {
+      lastErr = err
+    }
+  }
+  return lastErr
+}
+
+// generationKey is the key counting the deletes of key, its hash tag puts it
+// on the shard of key
+func generationKey(key string) string {
+  return "{" + key + "}:generation"
 }
 
-type redisCache struct{}
+// generation returns how many times key was deleted lately
+func (c *redisCache) generation(key string) (int64, error) {
+  generation, err := c.ring.Get(generationKey(key)).Int64()
+  if err == redis.Nil {
+    return 0, nil
+  }
+  return generation, err
+}
+
+// setLoaded stores entry b loaded at generation under key, unless key was
+// deleted since and b may predate the delete
+func (c *redisCache) setLoaded(key string, generation int64, b []byte, ttl time.Duration) error {
+  err := c.ring.Watch(func(tx *redis.Tx) error {
+    current, err := tx.Get(generationKey(key)).Int64()
+    if err == redis.Nil {
+      current = 0
+    } else if err != nil {
+      return err
+    }
+    if current != generation {
+      return nil
+    }
+    _, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+      pipe.Set(key, b, ttl)
+      return nil
+    })
+    return err
+  }, generationKey(key))
+  // a delete between the check and the set aborted it
+  if err == redis.TxFailedErr {
+    return nil
+  }
+  return err
+}
+
+// noopCache caches nothing, every read goes to the repository
+type noopCache struct{}
+
+func NewNoopCache() *noopCache {
+  return &noopCache{}
+}
+
+func (c *noopCache) AddEntry(ctx context.Context, key string, v interface{}, ttl time.Duration) error {
+  return nil
+}
+
+func (c *noopCache) GetEntry(ctx context.Context, key string, v interface{}) error {
+  return errCacheMiss
+}
+
+func (c *noopCache) LoadEntry(ctx context.Context, key string, v interface{}, ttl time.Duration, load func() (interface{}, error)) error {
+  obj, err := load()
+  if err != nil {
+    return err
+  }
+  return assign(v, obj)
+}
+
+func (c *noopCache) DeleteEntry(ctx context.Context, keys ...string) error {
+  return nil
+}
+
+// tieredCache serves entries from a local LRU and falls back to redis.
+// Writes and deletes go to redis and are broadcast so every instance drops
+// its local copy.
+type tieredCache struct {
+  local    *memoryCache
+  remote   *redisCache
+  redis    *redis.Ring
+  localTTL time.Duration
+}
+
+func (c *tieredCache) AddEntry(ctx context.Context, key string, v interface{}, ttl time.Duration) error {
+  if err := c.remote.AddEntry(ctx, key, v, ttl); err != nil {
+    return err
+  }
+  return c.invalidateLocal(ctx, key)
+}
+
+func (c *tieredCache) GetEntry(ctx context.Context, key string, v interface{}) error {
+  if err := c.local.GetEntry(ctx, key, v); err == nil {
+    return nil
+  }
+  if err := c.remote.GetEntry(ctx, key, v); err != nil {
+    return err
+  }
+  // the local copy expires with the remote entry at the latest
+  ttl, err := c.redis.PTTL(key).Result()
+  if err != nil {
+    return nil
+  }
+  if ttl < 0 {
+    // the remote entry doesn't expire or is already gone
+    ttl = c.localTTL
+  }
+  if ttl <= 0 {
+    return nil
+  }
+  return c.local.AddEntry(ctx, key, v, c.localTTLFor(ttl))
+}
+
+func (c *tieredCache) LoadEntry(ctx context.Context, key string, v interface{}, ttl time.Duration, load func() (interface{}, error)) error {
+  return c.local.LoadEntry(ctx, key, v, c.localTTLFor(ttl), func() (interface{}, error) {
+    obj := newEntryLike(v)
+    if err := c.remote.LoadEntry(ctx, key, obj, ttl, load); err != nil {
+      return nil, err
+    }
+    return obj, nil
+  })
+}
+
+func (c *tieredCache) DeleteEntry(ctx context.Context, keys ...string) error {
+  err := c.remote.DeleteEntry(ctx, keys...)
+  if localErr := c.invalidateLocal(ctx, keys...); localErr != nil {
+    return localErr
+  }
+  return err
+}
+
+// invalidateLocal drops keys locally and tells every other instance to do the same
+func (c *tieredCache) invalidateLocal(ctx context.Context, keys ...string) error {
+  c.local.DeleteEntry(ctx, keys...)
+  return c.redis.Publish(cacheInvalidationChannel, strings.Join(keys, "\n")).Err()
+}
+
+// listen drops local entries invalidated by other instances until ctx is cancelled
+func (c *tieredCache) listen(ctx context.Context) {
+  sub := c.redis.Subscribe(cacheInvalidationChannel)
+  defer sub.Close()
+
+  ch := sub.Channel()
+  for {
+    select {
+    case <-ctx.Done():
+      return
+    case msg, ok := <-ch:
+      if !ok {
+        fmt.Fprintf(os.Stderr, "cache invalidation subscription closed\n")
+        return
+      }
+      c.local.DeleteEntry(ctx, strings.Split(msg.Payload, "\n")...)
+    }
+  }
+}
 
-func NewRedisCache() *redisCache {
-  return &redisCache{}
+// localTTLFor caps ttl at the local ttl
+func (c *tieredCache) localTTLFor(ttl time.Duration) time.Duration {
+  if c.localTTL > 0 && c.localTTL < ttl {
+    return c.localTTL
+  }
+  return ttl
 }
@@ -0,0 +1,395 @@
+package v1
+
+import (
+  "context"
+  "reflect"
+  "strconv"
+  "strings"
+  "sync"
+  "testing"
+  "time"
+
+  "github.com/alicebob/miniredis/v2"
+  "github.com/go-redis/redis/v7"
+  "github.com/golang/protobuf/proto"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// newRedis returns a ring on an in-memory redis server
+func newRedis(t *testing.T) (*miniredis.Miniredis, *redis.Ring) {
+  mr, err := miniredis.Run()
+  if err != nil {
+    t.Fatal(err)
+  }
+  ring := redis.NewRing(&redis.RingOptions{Addrs: map[string]string{"server": mr.Addr()}})
+  t.Cleanup(func() {
+    ring.Close()
+    mr.Close()
+  })
+  return mr, ring
+}
+
+// teamsRepository serves teams from memory and counts the reads reaching it,
+// other methods aren't implemented
+type teamsRepository struct {
+  repository
+  mu    sync.Mutex
+  teams map[string]*v1.Team
+  reads int
+}
+
+func newTeamsRepository(teams ...*v1.Team) *teamsRepository {
+  r := &teamsRepository{teams: map[string]*v1.Team{}}
+  for _, team := range teams {
+    r.teams[team.Id] = team
+  }
+  return r
+}
+
+func (r *teamsRepository) read() {
+  r.mu.Lock()
+  r.reads++
+  r.mu.Unlock()
+}
+
+func (r *teamsRepository) readCount() int {
+  r.mu.Lock()
+  defer r.mu.Unlock()
+  return r.reads
+}
+
+func (r *teamsRepository) GetTeamByTeamId(ctx context.Context, id string) (*v1.Team, error) {
+  r.read()
+  team, ok := r.teams[id]
+  if !ok {
+    return nil, domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", id)
+  }
+  return proto.Clone(team).(*v1.Team), nil
+}
+
+func (r *teamsRepository) GetTeamByTeamName(ctx context.Context, name string) (*v1.Team, error) {
+  r.read()
+  for _, team := range r.teams {
+    if strings.EqualFold(team.Name, name) {
+      return proto.Clone(team).(*v1.Team), nil
+    }
+  }
+  return nil, domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", name)
+}
+
+func (r *teamsRepository) GetTeamsByUserId(ctx context.Context, id string) ([]*v1.Team, error) {
+  r.read()
+  teams := []*v1.Team{}
+  for _, team := range r.teams {
+    if team.Leader == id || hasMember(team, id) {
+      teams = append(teams, proto.Clone(team).(*v1.Team))
+    }
+  }
+  return teams, nil
+}
+
+func (r *teamsRepository) GetTeams(ctx context.Context, req *v1.GetTeamsRequest) ([]*v1.Team, string, error) {
+  r.read()
+  teams := []*v1.Team{}
+  for _, team := range r.teams {
+    teams = append(teams, proto.Clone(team).(*v1.Team))
+  }
+  return teams, "", nil
+}
+
+func hasMember(team *v1.Team, userId string) bool {
+  for _, member := range team.Members {
+    if strconv.Itoa(int(member.Id)) == userId {
+      return true
+    }
+  }
+  return false
+}
+
+func TestRedisCacheEntries(t *testing.T) {
+  _, ring := newRedis(t)
+  c := NewRedisCache(ring)
+  ctx := context.Background()
+
+  // protobuf messages and plain values both round trip
+  if err := c.AddEntry(ctx, "team", &v1.Team{Id: "3", Name: "gophers"}, time.Minute); err != nil {
+    t.Fatal(err)
+  }
+  if err := c.AddEntry(ctx, "gen", int64(42), time.Minute); err != nil {
+    t.Fatal(err)
+  }
+  team := &v1.Team{}
+  if err := c.GetEntry(ctx, "team", team); err != nil || team.Name != "gophers" {
+    t.Errorf("GetEntry(team) = %v, %v", team, err)
+  }
+  var gen int64
+  if err := c.GetEntry(ctx, "gen", &gen); err != nil || gen != 42 {
+    t.Errorf("GetEntry(gen) = %d, %v", gen, err)
+  }
+
+  // missing keys are ignored
+  if err := c.DeleteEntry(ctx, "team", "missing"); err != nil {
+    t.Fatal(err)
+  }
+  if err := c.GetEntry(ctx, "team", team); err != errCacheMiss {
+    t.Errorf("GetEntry() after delete error = %v, want errCacheMiss", err)
+  }
+}
+
+func TestRedisCacheLoadEntry(t *testing.T) {
+  mr, ring := newRedis(t)
+  c := NewRedisCache(ring)
+  ctx := context.Background()
+
+  loads := 0
+  load := func() (interface{}, error) {
+    loads++
+    return &v1.Team{Id: "3"}, nil
+  }
+  for i := 0; i < 3; i++ {
+    team := &v1.Team{}
+    if err := c.LoadEntry(ctx, "team", team, time.Minute, load); err != nil || team.Id != "3" {
+      t.Fatalf("LoadEntry() = %v, %v", team, err)
+    }
+  }
+  if loads != 1 {
+    t.Errorf("loaded %d times, want once", loads)
+  }
+  if ttl := mr.TTL("team"); ttl != time.Minute {
+    t.Errorf("ttl = %s, want 1m", ttl)
+  }
+
+  // expired entries are loaded again
+  mr.FastForward(time.Minute)
+  if err := c.LoadEntry(ctx, "team", &v1.Team{}, time.Minute, load); err != nil {
+    t.Fatal(err)
+  }
+  if loads != 2 {
+    t.Errorf("loaded %d times after expiry, want twice", loads)
+  }
+}
+
+func TestRedisCacheDropsLoadsDeletedInFlight(t *testing.T) {
+  mr, ring := newRedis(t)
+  // the delete comes from another instance
+  c, other := NewRedisCache(ring), NewRedisCache(ring)
+  ctx := context.Background()
+  release := make(chan struct{})
+  started := make(chan struct{})
+
+  done := make(chan error)
+  go func() {
+    done <- c.LoadEntry(ctx, "team", &v1.Team{}, time.Minute, func() (interface{}, error) {
+      close(started)
+      <-release
+      return &v1.Team{Id: "3", Name: "stale"}, nil
+    })
+  }()
+  <-started
+  if err := other.DeleteEntry(ctx, "team"); err != nil {
+    t.Fatal(err)
+  }
+  close(release)
+  if err := <-done; err != nil {
+    t.Fatal(err)
+  }
+
+  if mr.Exists("team") {
+    t.Error("the load in flight during the delete was cached")
+  }
+  // later loads are cached again
+  if err := c.LoadEntry(ctx, "team", &v1.Team{}, time.Minute, func() (interface{}, error) { return &v1.Team{Id: "3"}, nil }); err != nil {
+    t.Fatal(err)
+  }
+  if !mr.Exists("team") {
+    t.Error("the load after the delete wasn't cached")
+  }
+}
+
+func TestNoopCacheLoadsEveryTime(t *testing.T) {
+  c := NewNoopCache()
+  ctx := context.Background()
+
+  loads := 0
+  load := func() (interface{}, error) {
+    loads++
+    return &v1.Team{Id: "3"}, nil
+  }
+  for i := 0; i < 2; i++ {
+    team := &v1.Team{}
+    if err := c.LoadEntry(ctx, "team", team, time.Minute, load); err != nil || team.Id != "3" {
+      t.Fatalf("LoadEntry() = %v, %v", team, err)
+    }
+  }
+  if loads != 2 {
+    t.Errorf("loaded %d times, want twice", loads)
+  }
+  if err := c.GetEntry(ctx, "team", &v1.Team{}); err != errCacheMiss {
+    t.Errorf("GetEntry() error = %v, want errCacheMiss", err)
+  }
+}
+
+func TestNewAppCache(t *testing.T) {
+  mr, ring := newRedis(t)
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+
+  tests := []struct {
+    cfg  CacheConfig
+    want interface{}
+  }{
+    {cfg: CacheConfig{}, want: &noopCache{}},
+    {cfg: CacheConfig{Backend: CacheNone}, want: &noopCache{}},
+    {cfg: CacheConfig{Backend: CacheMemory, Size: 10}, want: &memoryCache{}},
+    {cfg: CacheConfig{Backend: CacheRedis, Redis: ring}, want: &redisCache{}},
+    {cfg: CacheConfig{Backend: CacheTiered, Redis: ring}, want: &tieredCache{}},
+  }
+  for _, tt := range tests {
+    c, err := NewAppCache(ctx, tt.cfg)
+    if err != nil {
+      t.Errorf("NewAppCache(%s) error = %v", tt.cfg.Backend, err)
+      continue
+    }
+    if reflect.TypeOf(c) != reflect.TypeOf(tt.want) {
+      t.Errorf("NewAppCache(%s) = %T, want %T", tt.cfg.Backend, c, tt.want)
+    }
+  }
+  // the tiered cache listens before redis is closed
+  waitForSubscribers(mr, 1)
+
+  if _, err := NewAppCache(ctx, CacheConfig{Backend: CacheRedis}); err == nil {
+    t.Error("the redis backend without redis must fail")
+  }
+  if _, err := NewAppCache(ctx, CacheConfig{Backend: "memcached"}); err == nil {
+    t.Error("an unknown backend must fail")
+  }
+}
+
+func TestTieredCacheInvalidatesOtherInstances(t *testing.T) {
+  mr, ring := newRedis(t)
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+
+  cfg := CacheConfig{Backend: CacheTiered, Redis: ring, Size: 10, LocalTTL: time.Minute}
+  a, err := NewAppCache(ctx, cfg)
+  if err != nil {
+    t.Fatal(err)
+  }
+  b, err := NewAppCache(ctx, cfg)
+  if err != nil {
+    t.Fatal(err)
+  }
+  waitForSubscribers(mr, 2)
+
+  load := func() (interface{}, error) { return &v1.Team{Id: "3", Name: "gophers"}, nil }
+  if err := a.LoadEntry(ctx, "team", &v1.Team{}, time.Hour, load); err != nil {
+    t.Fatal(err)
+  }
+  local := a.(*tieredCache).local
+  if err := local.GetEntry(ctx, "team", &v1.Team{}); err != nil {
+    t.Fatalf("entry isn't cached locally: %v", err)
+  }
+  // the local copy lives at most LocalTTL
+  if ttl := time.Until(local.entries["team"].Value.(*memoryEntry).expires); ttl > time.Minute {
+    t.Errorf("local ttl = %s, want at most 1m", ttl)
+  }
+
+  if err := b.DeleteEntry(ctx, "team"); err != nil {
+    t.Fatal(err)
+  }
+  deadline := time.Now().Add(5 * time.Second)
+  for local.GetEntry(ctx, "team", &v1.Team{}) != errCacheMiss {
+    if time.Now().After(deadline) {
+      t.Fatal("the other instance's local copy wasn't invalidated")
+    }
+    time.Sleep(time.Millisecond)
+  }
+  if err := a.GetEntry(ctx, "team", &v1.Team{}); err != errCacheMiss {
+    t.Errorf("GetEntry() error = %v, want errCacheMiss", err)
+  }
+}
+
+func TestTieredCacheKeepsTheRemoteTTL(t *testing.T) {
+  _, ring := newRedis(t)
+  ctx := context.Background()
+  c := &tieredCache{local: NewMemoryCache(10), remote: NewRedisCache(ring), redis: ring, localTTL: time.Hour}
+
+  // another instance cached the entry
+  if err := c.remote.AddEntry(ctx, "team", &v1.Team{Id: "3"}, time.Minute); err != nil {
+    t.Fatal(err)
+  }
+  if err := c.GetEntry(ctx, "team", &v1.Team{}); err != nil {
+    t.Fatal(err)
+  }
+  // the local copy doesn't outlive the remote entry
+  if ttl := time.Until(c.local.entries["team"].Value.(*memoryEntry).expires); ttl > time.Minute {
+    t.Errorf("local ttl = %s, want at most 1m", ttl)
+  }
+}
+
+func TestCachedRepositoryReadsThrough(t *testing.T) {
+  _, ring := newRedis(t)
+  repo := newTeamsRepository(&v1.Team{Id: "3", Name: "Gophers", Leader: "7"})
+  cached := NewCachedRepository(repo, NewRedisCache(ring), time.Minute)
+  ctx := context.Background()
+
+  for i := 0; i < 2; i++ {
+    if team, err := cached.GetTeamByTeamId(ctx, "3"); err != nil || team.Name != "Gophers" {
+      t.Fatalf("GetTeamByTeamId() = %v, %v", team, err)
+    }
+    // names are cached case insensitively
+    if team, err := cached.GetTeamByTeamName(ctx, []string{"gophers", "GOPHERS"}[i]); err != nil || team.Id != "3" {
+      t.Fatalf("GetTeamByTeamName() = %v, %v", team, err)
+    }
+    if teams, err := cached.GetTeamsByUserId(ctx, "7"); err != nil || len(teams) != 1 {
+      t.Fatalf("GetTeamsByUserId() = %v, %v", teams, err)
+    }
+    if teams, err := cached.GetTeamsByUserId(ctx, "8"); err != nil || teams == nil || len(teams) != 0 {
+      t.Fatalf("GetTeamsByUserId() without teams = %v, %v", teams, err)
+    }
+  }
+  if reads := repo.readCount(); reads != 4 {
+    t.Errorf("repository read %d times, want 4", reads)
+  }
+
+  // errors aren't cached
+  for i := 0; i < 2; i++ {
+    if _, err := cached.GetTeamByTeamId(ctx, "4"); domainerr.ReasonOf(err) != domainerr.ReasonTeamNotFound {
+      t.Errorf("GetTeamByTeamId() error = %v, want the repository error", err)
+    }
+  }
+  if reads := repo.readCount(); reads != 6 {
+    t.Errorf("repository read %d times, want 6", reads)
+  }
+}
+
+func TestRedisCacheCollapsesConcurrentLoads(t *testing.T) {
+  _, ring := newRedis(t)
+  repo := newTeamsRepository(&v1.Team{Id: "3", Name: "Gophers"})
+  cached := NewCachedRepository(repo, NewRedisCache(ring), time.Minute)
+
+  var wg sync.WaitGroup
+  for i := 0; i < 20; i++ {
+    wg.Add(1)
+    go func() {
+      defer wg.Done()
+      if _, err := cached.GetTeamByTeamId(context.Background(), "3"); err != nil {
+        t.Error(err)
+      }
+    }()
+  }
+  wg.Wait()
+  if reads := repo.readCount(); reads != 1 {
+    t.Errorf("repository read %d times, want once", reads)
+  }
+}
+
+
+// waitForSubscribers waits for count tiered caches to listen for invalidations
+func waitForSubscribers(mr *miniredis.Miniredis, count int) {
+  for mr.PubSubNumSub(cacheInvalidationChannel)[cacheInvalidationChannel] < count {
+    time.Sleep(time.Millisecond)
+  }
+}
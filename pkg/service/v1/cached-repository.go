@@ -0,0 +1,497 @@
+package v1
+
+import (
+  "context"
+  "fmt"
+  "os"
+  "reflect"
+  "strconv"
+  "strings"
+  "time"
+
+  "github.com/golang/protobuf/proto"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+// cache keys
+const (
+  teamIdPrefix    = "team:id:"
+  teamNamePrefix  = "team:name:"
+  userTeamsPrefix = "team:user:"
+  teamListPrefix  = "team:list:"
+  // teamListGenKey holds the generation of cached GetTeams pages, bumping it
+  // invalidates every cached page at once
+  teamListGenKey = "team:list:gen"
+)
+
+// cachedRepository is a read-through cache in front of a repository.
+// Single team and per user reads are cached by key and invalidated by the
+// mutating methods, GetTeams pages are cached per generation.
+type cachedRepository struct {
+  repository
+  cache appCache
+  ttl   time.Duration
+}
+
+func NewCachedRepository(repo repository, cache appCache, ttl time.Duration) *cachedRepository {
+  return &cachedRepository{
+    repository: repo,
+    cache:      cache,
+    ttl:        ttl,
+  }
+}
+
+func (r *cachedRepository) GetTeamByTeamId(ctx context.Context, id string) (*v1.Team, error) {
+  team := &v1.Team{}
+  err := r.cache.LoadEntry(ctx, teamIdPrefix+id, team, r.ttl, func() (interface{}, error) {
+    return r.repository.GetTeamByTeamId(ctx, id)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return team, nil
+}
+
+func (r *cachedRepository) GetTeamByTeamName(ctx context.Context, name string) (*v1.Team, error) {
+  team := &v1.Team{}
+  err := r.cache.LoadEntry(ctx, teamNamePrefix+strings.ToLower(name), team, r.ttl, func() (interface{}, error) {
+    return r.repository.GetTeamByTeamName(ctx, name)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return team, nil
+}
+
+func (r *cachedRepository) GetTeamsByUserId(ctx context.Context, id string) ([]*v1.Team, error) {
+  list := &v1.GetTeamsResponse{}
+  err := r.cache.LoadEntry(ctx, userTeamsPrefix+id, list, r.ttl, func() (interface{}, error) {
+    teams, err := r.repository.GetTeamsByUserId(ctx, id)
+    if err != nil {
+      return nil, err
+    }
+    return &v1.GetTeamsResponse{Teams: teams}, nil
+  })
+  if err != nil {
+    return nil, err
+  }
+  return nonNilTeams(list.Teams), nil
+}
+
+func (r *cachedRepository) GetTeams(ctx context.Context, req *v1.GetTeamsRequest) ([]*v1.Team, string, error) {
+  gen, err := r.listGeneration(ctx)
+  if err != nil {
+    // without a generation a cached page can't be trusted
+    return r.repository.GetTeams(ctx, req)
+  }
+
+  key := teamListPrefix + gen + ":" + proto.CompactTextString(req)
+  list := &v1.GetTeamsResponse{}
+  err = r.cache.LoadEntry(ctx, key, list, r.ttl, func() (interface{}, error) {
+    teams, next, err := r.repository.GetTeams(ctx, req)
+    if err != nil {
+      return nil, err
+    }
+    return &v1.GetTeamsResponse{Teams: teams, NextPageToken: next}, nil
+  })
+  if err != nil {
+    return nil, "", err
+  }
+  return nonNilTeams(list.Teams), list.NextPageToken, nil
+}
+
+func (r *cachedRepository) CreateTeam(ctx context.Context, team *v1.Team) (string, error) {
+  id, err := r.repository.CreateTeam(ctx, team)
+  if err != nil {
+    return id, err
+  }
+
+  keys := teamKeys(team)
+  keys = append(keys, teamIdPrefix+id)
+  r.invalidate(ctx, keys)
+
+  return id, nil
+}
+
+func (r *cachedRepository) UpdateTeam(ctx context.Context, id string, patch *v1.Team, paths []string, expected int64) (*v1.Team, error) {
+  // the previous name is among the keys loaded before the update
+  keys := r.loadTeamKeys(ctx, id)
+
+  team, err := r.repository.UpdateTeam(ctx, id, patch, paths, expected)
+  if err != nil {
+    return team, err
+  }
+
+  keys = append(keys, teamKeys(team)...)
+  r.invalidate(ctx, keys)
+  return team, nil
+}
+
+func (r *cachedRepository) DeleteTeam(ctx context.Context, id, userId string, expected int64) (int64, int64, int64, error) {
+  keys := r.loadTeamKeys(ctx, id)
+
+  teamRows, memRows, skillRows, err := r.repository.DeleteTeam(ctx, id, userId, expected)
+  if err != nil {
+    return teamRows, memRows, skillRows, err
+  }
+
+  r.invalidate(ctx, keys)
+  return teamRows, memRows, skillRows, nil
+}
+
+func (r *cachedRepository) RestoreTeam(ctx context.Context, teamId, userId string) (*v1.Team, error) {
+  team, err := r.repository.RestoreTeam(ctx, teamId, userId)
+  if err != nil {
+    return team, err
+  }
+
+  r.invalidate(ctx, teamKeys(team))
+  return team, nil
+}
+
+func (r *cachedRepository) AddMember(ctx context.Context, req *v1.MemberUpsertRequest) (string, int64, error) {
+  keys := r.loadTeamKeys(ctx, req.TeamId)
+
+  newId, version, err := r.repository.AddMember(ctx, req)
+  if err != nil {
+    return newId, version, err
+  }
+
+  keys = append(keys, userTeamsPrefix+req.MemberId)
+  r.invalidate(ctx, keys)
+  return newId, version, nil
+}
+
+func (r *cachedRepository) RemoveMember(ctx context.Context, teamId string, memberId string, expected int64) (int64, int64, error) {
+  // the removed member is among the members loaded before the removal
+  keys := r.loadTeamKeys(ctx, teamId)
+
+  count, version, err := r.repository.RemoveMember(ctx, teamId, memberId, expected)
+  if err != nil {
+    return count, version, err
+  }
+
+  r.invalidate(ctx, keys)
+  return count, version, nil
+}
+
+func (r *cachedRepository) UpsertProject(ctx context.Context, teamId string, project *v1.Project, expected int64) (int64, int64, error) {
+  keys := r.loadTeamKeys(ctx, teamId)
+
+  projectId, version, err := r.repository.UpsertProject(ctx, teamId, project, expected)
+  if err != nil {
+    return projectId, version, err
+  }
+
+  r.invalidate(ctx, keys)
+  return projectId, version, nil
+}
+
+func (r *cachedRepository) CreateProject(ctx context.Context, teamId string, project *v1.Project, expected int64) (*v1.Project, int64, error) {
+  keys := r.loadTeamKeys(ctx, teamId)
+
+  created, version, err := r.repository.CreateProject(ctx, teamId, project, expected)
+  if err != nil {
+    return created, version, err
+  }
+
+  r.invalidate(ctx, keys)
+  return created, version, nil
+}
+
+func (r *cachedRepository) UpdateProject(ctx context.Context, teamId, projectId string, patch *v1.Project, paths []string, expected int64) (*v1.Project, int64, error) {
+  keys := r.loadTeamKeys(ctx, teamId)
+
+  updated, version, err := r.repository.UpdateProject(ctx, teamId, projectId, patch, paths, expected)
+  if err != nil {
+    return updated, version, err
+  }
+
+  r.invalidate(ctx, keys)
+  return updated, version, nil
+}
+
+func (r *cachedRepository) ArchiveProject(ctx context.Context, teamId, projectId string, expected int64) (*v1.Project, int64, error) {
+  keys := r.loadTeamKeys(ctx, teamId)
+
+  archived, version, err := r.repository.ArchiveProject(ctx, teamId, projectId, expected)
+  if err != nil {
+    return archived, version, err
+  }
+
+  r.invalidate(ctx, keys)
+  return archived, version, nil
+}
+
+func (r *cachedRepository) CreateMilestone(ctx context.Context, teamId, projectId string, milestone *v1.Milestone, expected int64) (*v1.Milestone, int64, error) {
+  keys := r.loadTeamKeys(ctx, teamId)
+
+  created, version, err := r.repository.CreateMilestone(ctx, teamId, projectId, milestone, expected)
+  if err != nil {
+    return created, version, err
+  }
+
+  r.invalidate(ctx, keys)
+  return created, version, nil
+}
+
+func (r *cachedRepository) UpdateMilestone(ctx context.Context, teamId, projectId, milestoneId string, patch *v1.Milestone, paths []string, expected int64) (*v1.Milestone, int64, error) {
+  keys := r.loadTeamKeys(ctx, teamId)
+
+  updated, version, err := r.repository.UpdateMilestone(ctx, teamId, projectId, milestoneId, patch, paths, expected)
+  if err != nil {
+    return updated, version, err
+  }
+
+  r.invalidate(ctx, keys)
+  return updated, version, nil
+}
+
+func (r *cachedRepository) DeleteMilestone(ctx context.Context, teamId, projectId, milestoneId string, expected int64) (*v1.Milestone, int64, error) {
+  keys := r.loadTeamKeys(ctx, teamId)
+
+  deleted, version, err := r.repository.DeleteMilestone(ctx, teamId, projectId, milestoneId, expected)
+  if err != nil {
+    return deleted, version, err
+  }
+
+  r.invalidate(ctx, keys)
+  return deleted, version, nil
+}
+
+func (r *cachedRepository) CreateTask(ctx context.Context, teamId, projectId string, task *v1.Task, expected int64) (*v1.Task, int64, error) {
+  keys := r.loadTeamKeys(ctx, teamId)
+
+  created, version, err := r.repository.CreateTask(ctx, teamId, projectId, task, expected)
+  if err != nil {
+    return created, version, err
+  }
+
+  r.invalidate(ctx, keys)
+  return created, version, nil
+}
+
+func (r *cachedRepository) UpdateTask(ctx context.Context, teamId, projectId, taskId string, patch *v1.Task, paths []string, expected int64) (*v1.Task, int64, error) {
+  keys := r.loadTeamKeys(ctx, teamId)
+
+  updated, version, err := r.repository.UpdateTask(ctx, teamId, projectId, taskId, patch, paths, expected)
+  if err != nil {
+    return updated, version, err
+  }
+
+  r.invalidate(ctx, keys)
+  return updated, version, nil
+}
+
+func (r *cachedRepository) DeleteTask(ctx context.Context, teamId, projectId, taskId string, expected int64) (*v1.Task, int64, error) {
+  keys := r.loadTeamKeys(ctx, teamId)
+
+  deleted, version, err := r.repository.DeleteTask(ctx, teamId, projectId, taskId, expected)
+  if err != nil {
+    return deleted, version, err
+  }
+
+  r.invalidate(ctx, keys)
+  return deleted, version, nil
+}
+
+func (r *cachedRepository) RemoveUserFromTeams(ctx context.Context, userId string) (int64, error) {
+  keys := r.loadUserKeys(ctx, userId)
+
+  count, err := r.repository.RemoveUserFromTeams(ctx, userId)
+  if err != nil {
+    return count, err
+  }
+
+  r.invalidate(ctx, keys)
+  return count, nil
+}
+
+func (r *cachedRepository) UpdateMemberEmail(ctx context.Context, userId, email string) (int64, error) {
+  keys := r.loadUserKeys(ctx, userId)
+
+  count, err := r.repository.UpdateMemberEmail(ctx, userId, email)
+  if err != nil {
+    return count, err
+  }
+
+  r.invalidate(ctx, keys)
+  return count, nil
+}
+
+func (r *cachedRepository) AcceptInvitation(ctx context.Context, id, userId string) (string, string, error) {
+  teamId, memberNumber, err := r.repository.AcceptInvitation(ctx, id, userId)
+  if err != nil {
+    return teamId, memberNumber, err
+  }
+
+  keys := r.loadTeamKeys(ctx, teamId)
+  keys = append(keys, userTeamsPrefix+userId)
+  r.invalidate(ctx, keys)
+  return teamId, memberNumber, nil
+}
+
+func (r *cachedRepository) ApproveApplication(ctx context.Context, id, deciderId string) (string, string, error) {
+  teamId, memberNumber, err := r.repository.ApproveApplication(ctx, id, deciderId)
+  if err != nil {
+    return teamId, memberNumber, err
+  }
+
+  // the applicant's teams changed too, only the repository has them among the
+  // members yet
+  keys := []string{teamIdPrefix + teamId}
+  if team, err := r.repository.GetTeamByTeamId(ctx, teamId); err == nil {
+    keys = append(keys, teamKeys(team)...)
+  }
+  r.invalidate(ctx, keys)
+  return teamId, memberNumber, nil
+}
+
+func (r *cachedRepository) TransferOwnership(ctx context.Context, teamId, fromUserId, fromEmail, toUserId string, expected int64) (int64, error) {
+  version, err := r.repository.TransferOwnership(ctx, teamId, fromUserId, fromEmail, toUserId, expected)
+  if err != nil {
+    return version, err
+  }
+
+  r.invalidate(ctx, r.loadTeamKeys(ctx, teamId))
+  return version, nil
+}
+
+func (r *cachedRepository) RecordUserActivity(ctx context.Context, userId string, at int64) (int64, error) {
+  keys := r.loadUserKeys(ctx, userId)
+
+  count, err := r.repository.RecordUserActivity(ctx, userId, at)
+  if err != nil || count == 0 {
+    return count, err
+  }
+
+  r.invalidate(ctx, keys)
+  return count, nil
+}
+
+func (r *cachedRepository) FlagInactiveTeams(ctx context.Context) ([]string, error) {
+  ids, err := r.repository.FlagInactiveTeams(ctx)
+  r.invalidateTeams(ctx, ids)
+  return ids, err
+}
+
+func (r *cachedRepository) ArchiveInactiveTeams(ctx context.Context) ([]string, error) {
+  ids, err := r.repository.ArchiveInactiveTeams(ctx)
+  r.invalidateTeams(ctx, ids)
+  return ids, err
+}
+
+// ---------------------------- HELPER FUNCTIONS -------------------------------
+
+// listGeneration returns the current generation of cached GetTeams pages,
+// starting a new one if none is cached
+func (r *cachedRepository) listGeneration(ctx context.Context) (string, error) {
+  var gen int64
+  err := r.cache.GetEntry(ctx, teamListGenKey, &gen)
+  if err == errCacheMiss {
+    // a fresh generation never collides with pages cached under an evicted one
+    gen = time.Now().UnixNano()
+    err = r.cache.AddEntry(ctx, teamListGenKey, gen, 24*time.Hour)
+  }
+  if err != nil {
+    return "", err
+  }
+  return strconv.FormatInt(gen, 10), nil
+}
+
+// loadTeamKeys returns every key a change to team id invalidates. They are
+// derived from the cached team when there is one, every change to the team
+// drops it so it lists every member whose teams may be cached. The repository
+// is only read when the team isn't cached.
+func (r *cachedRepository) loadTeamKeys(ctx context.Context, id string) []string {
+  keys := []string{teamIdPrefix + id}
+
+  team := &v1.Team{}
+  if err := r.cache.GetEntry(ctx, teamIdPrefix+id, team); err != nil {
+    if team, err = r.repository.GetTeamByTeamId(ctx, id); err != nil {
+      return keys
+    }
+  }
+  return append(keys, teamKeys(team)...)
+}
+
+// loadUserKeys returns every key a change to the teams of userId invalidates,
+// derived from the cached teams of userId like loadTeamKeys
+func (r *cachedRepository) loadUserKeys(ctx context.Context, userId string) []string {
+  keys := []string{userTeamsPrefix + userId}
+
+  list := &v1.GetTeamsResponse{}
+  if err := r.cache.GetEntry(ctx, userTeamsPrefix+userId, list); err != nil {
+    teams, err := r.repository.GetTeamsByUserId(ctx, userId)
+    if err != nil {
+      return keys
+    }
+    list.Teams = teams
+  }
+  for _, team := range list.Teams {
+    keys = append(keys, teamKeys(team)...)
+  }
+  return keys
+}
+
+// invalidateTeams invalidates the teams with ids, which the sweeper changed
+// without changing their keys, teams changed before an error included
+func (r *cachedRepository) invalidateTeams(ctx context.Context, ids []string) {
+  if len(ids) == 0 {
+    return
+  }
+  keys := []string{}
+  for _, id := range ids {
+    keys = append(keys, r.loadTeamKeys(ctx, id)...)
+  }
+  r.invalidate(ctx, keys)
+}
+
+// invalidate deletes keys and starts a new generation of GetTeams pages.
+// Failures are logged, entries that could not be deleted expire with their ttl.
+func (r *cachedRepository) invalidate(ctx context.Context, keys []string) {
+  if err := r.cache.DeleteEntry(ctx, keys...); err != nil {
+    fmt.Fprintf(os.Stderr, "error invalidating cache keys %v: %v\n", keys, err)
+  }
+  if err := r.cache.DeleteEntry(ctx, teamListGenKey); err != nil {
+    fmt.Fprintf(os.Stderr, "error invalidating cached team pages: %v\n", err)
+  }
+}
+
+// teamKeys returns the keys team is cached under
+func teamKeys(team *v1.Team) []string {
+  keys := []string{}
+  if team.Id != "" {
+    keys = append(keys, teamIdPrefix+team.Id)
+  }
+  if team.Name != "" {
+    keys = append(keys, teamNamePrefix+strings.ToLower(team.Name))
+  }
+  if team.Leader != "" {
+    keys = append(keys, userTeamsPrefix+team.Leader)
+  }
+  for _, member := range team.Members {
+    keys = append(keys, userTeamsPrefix+strconv.Itoa(int(member.Id)))
+  }
+  return keys
+}
+
+// nonNilTeams keeps an empty list non-nil after a round trip through the cache
+func nonNilTeams(teams []*v1.Team) []*v1.Team {
+  if teams == nil {
+    return []*v1.Team{}
+  }
+  return teams
+}
+
+// assign copies the value obj points to into the value v points to
+func assign(v interface{}, obj interface{}) error {
+  dst := reflect.ValueOf(v)
+  src := reflect.ValueOf(obj)
+  if dst.Kind() != reflect.Ptr || src.Kind() != reflect.Ptr || dst.Type() != src.Type() {
+    return fmt.Errorf("cache: can't assign %T to %T", obj, v)
+  }
+  dst.Elem().Set(src.Elem())
+  return nil
+}
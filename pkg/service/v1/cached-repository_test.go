@@ -0,0 +1,386 @@
+package v1
+
+import (
+  "context"
+  "errors"
+  "reflect"
+  "sort"
+  "testing"
+  "time"
+
+  "github.com/golang/protobuf/proto"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+// mutatingRepository succeeds every mutation without changing its teams
+type mutatingRepository struct {
+  *teamsRepository
+  err error
+}
+
+func (r *mutatingRepository) CreateTeam(ctx context.Context, team *v1.Team) (string, error) {
+  return "10", r.err
+}
+
+func (r *mutatingRepository) UpdateTeam(ctx context.Context, id string, patch *v1.Team, paths []string, expected int64) (*v1.Team, error) {
+  team := proto.Clone(r.teams[id]).(*v1.Team)
+  team.Name = patch.Name
+  return team, r.err
+}
+
+func (r *mutatingRepository) DeleteTeam(ctx context.Context, id, userId string, expected int64) (int64, int64, int64, error) {
+  return 1, 1, 1, r.err
+}
+
+func (r *mutatingRepository) RestoreTeam(ctx context.Context, teamId, userId string) (*v1.Team, error) {
+  return proto.Clone(r.teams[teamId]).(*v1.Team), r.err
+}
+
+func (r *mutatingRepository) AddMember(ctx context.Context, req *v1.MemberUpsertRequest) (string, int64, error) {
+  return "5", 2, r.err
+}
+
+func (r *mutatingRepository) RemoveMember(ctx context.Context, teamId, memberId string, expected int64) (int64, int64, error) {
+  return 1, 2, r.err
+}
+
+func (r *mutatingRepository) UpsertProject(ctx context.Context, teamId string, project *v1.Project, expected int64) (int64, int64, error) {
+  return 1, 2, r.err
+}
+
+func (r *mutatingRepository) CreateProject(ctx context.Context, teamId string, project *v1.Project, expected int64) (*v1.Project, int64, error) {
+  return project, 2, r.err
+}
+
+func (r *mutatingRepository) UpdateProject(ctx context.Context, teamId, projectId string, patch *v1.Project, paths []string, expected int64) (*v1.Project, int64, error) {
+  return patch, 2, r.err
+}
+
+func (r *mutatingRepository) ArchiveProject(ctx context.Context, teamId, projectId string, expected int64) (*v1.Project, int64, error) {
+  return &v1.Project{}, 2, r.err
+}
+
+func (r *mutatingRepository) CreateMilestone(ctx context.Context, teamId, projectId string, milestone *v1.Milestone, expected int64) (*v1.Milestone, int64, error) {
+  return milestone, 2, r.err
+}
+
+func (r *mutatingRepository) UpdateMilestone(ctx context.Context, teamId, projectId, milestoneId string, patch *v1.Milestone, paths []string, expected int64) (*v1.Milestone, int64, error) {
+  return patch, 2, r.err
+}
+
+func (r *mutatingRepository) DeleteMilestone(ctx context.Context, teamId, projectId, milestoneId string, expected int64) (*v1.Milestone, int64, error) {
+  return &v1.Milestone{}, 2, r.err
+}
+
+func (r *mutatingRepository) CreateTask(ctx context.Context, teamId, projectId string, task *v1.Task, expected int64) (*v1.Task, int64, error) {
+  return task, 2, r.err
+}
+
+func (r *mutatingRepository) UpdateTask(ctx context.Context, teamId, projectId, taskId string, patch *v1.Task, paths []string, expected int64) (*v1.Task, int64, error) {
+  return patch, 2, r.err
+}
+
+func (r *mutatingRepository) DeleteTask(ctx context.Context, teamId, projectId, taskId string, expected int64) (*v1.Task, int64, error) {
+  return &v1.Task{}, 2, r.err
+}
+
+func (r *mutatingRepository) RemoveUserFromTeams(ctx context.Context, userId string) (int64, error) {
+  return 1, r.err
+}
+
+func (r *mutatingRepository) UpdateMemberEmail(ctx context.Context, userId, email string) (int64, error) {
+  return 1, r.err
+}
+
+func (r *mutatingRepository) AcceptInvitation(ctx context.Context, id, userId string) (string, string, error) {
+  return "3", "5", r.err
+}
+
+func (r *mutatingRepository) ApproveApplication(ctx context.Context, id, deciderId string) (string, string, error) {
+  return "3", "5", r.err
+}
+
+func (r *mutatingRepository) TransferOwnership(ctx context.Context, teamId, fromUserId, fromEmail, toUserId string, expected int64) (int64, error) {
+  return 2, r.err
+}
+
+func (r *mutatingRepository) RecordUserActivity(ctx context.Context, userId string, at int64) (int64, error) {
+  return 1, r.err
+}
+
+func (r *mutatingRepository) FlagInactiveTeams(ctx context.Context) ([]string, error) {
+  return []string{"3"}, r.err
+}
+
+func (r *mutatingRepository) ArchiveInactiveTeams(ctx context.Context) ([]string, error) {
+  return []string{"3"}, r.err
+}
+
+// deletionCache records the keys deleted from a memory cache
+type deletionCache struct {
+  *memoryCache
+  deleted map[string]bool
+}
+
+func (c *deletionCache) DeleteEntry(ctx context.Context, keys ...string) error {
+  for _, key := range keys {
+    c.deleted[key] = true
+  }
+  return c.memoryCache.DeleteEntry(ctx, keys...)
+}
+
+func (c *deletionCache) deletedKeys() []string {
+  keys := []string{}
+  for key := range c.deleted {
+    keys = append(keys, key)
+  }
+  sort.Strings(keys)
+  return keys
+}
+
+// newMutatingRepository returns a cached repository on team 3 led by 7 with
+// member 8
+func newMutatingRepository() (*cachedRepository, *mutatingRepository, *deletionCache) {
+  repo := &mutatingRepository{teamsRepository: newTeamsRepository(&v1.Team{
+    Id:      "3",
+    Name:    "Gophers",
+    Leader:  "7",
+    Members: []*v1.Member{{Id: 8}},
+  })}
+  cache := &deletionCache{memoryCache: NewMemoryCache(100), deleted: map[string]bool{}}
+  return NewCachedRepository(repo, cache, time.Minute), repo, cache
+}
+
+func TestCachedRepositoryInvalidation(t *testing.T) {
+  ctx := context.Background()
+  team := []string{teamIdPrefix + "3", teamNamePrefix + "gophers", userTeamsPrefix + "7", userTeamsPrefix + "8"}
+  with := func(keys ...string) []string {
+    return append(append([]string{}, team...), keys...)
+  }
+
+  tests := []struct {
+    name   string
+    mutate func(r *cachedRepository) error
+    keys   []string
+  }{
+    {"CreateTeam", func(r *cachedRepository) error {
+      _, err := r.CreateTeam(ctx, &v1.Team{Name: "Rustaceans", Leader: "9"})
+      return err
+    }, []string{teamIdPrefix + "10", teamNamePrefix + "rustaceans", userTeamsPrefix + "9"}},
+    // the previous and the new name are both dropped
+    {"UpdateTeam", func(r *cachedRepository) error {
+      _, err := r.UpdateTeam(ctx, "3", &v1.Team{Name: "Renamed"}, []string{"name"}, 0)
+      return err
+    }, with(teamNamePrefix + "renamed")},
+    {"DeleteTeam", func(r *cachedRepository) error {
+      _, _, _, err := r.DeleteTeam(ctx, "3", "7", 0)
+      return err
+    }, team},
+    {"RestoreTeam", func(r *cachedRepository) error {
+      _, err := r.RestoreTeam(ctx, "3", "7")
+      return err
+    }, team},
+    {"AddMember", func(r *cachedRepository) error {
+      _, _, err := r.AddMember(ctx, &v1.MemberUpsertRequest{TeamId: "3", MemberId: "9"})
+      return err
+    }, with(userTeamsPrefix + "9")},
+    {"RemoveMember", func(r *cachedRepository) error {
+      _, _, err := r.RemoveMember(ctx, "3", "5", 0)
+      return err
+    }, team},
+    {"UpsertProject", func(r *cachedRepository) error {
+      _, _, err := r.UpsertProject(ctx, "3", &v1.Project{}, 0)
+      return err
+    }, team},
+    {"CreateProject", func(r *cachedRepository) error {
+      _, _, err := r.CreateProject(ctx, "3", &v1.Project{}, 0)
+      return err
+    }, team},
+    {"UpdateProject", func(r *cachedRepository) error {
+      _, _, err := r.UpdateProject(ctx, "3", "1", &v1.Project{}, nil, 0)
+      return err
+    }, team},
+    {"ArchiveProject", func(r *cachedRepository) error {
+      _, _, err := r.ArchiveProject(ctx, "3", "1", 0)
+      return err
+    }, team},
+    {"CreateMilestone", func(r *cachedRepository) error {
+      _, _, err := r.CreateMilestone(ctx, "3", "1", &v1.Milestone{}, 0)
+      return err
+    }, team},
+    {"UpdateMilestone", func(r *cachedRepository) error {
+      _, _, err := r.UpdateMilestone(ctx, "3", "1", "1", &v1.Milestone{}, nil, 0)
+      return err
+    }, team},
+    {"DeleteMilestone", func(r *cachedRepository) error {
+      _, _, err := r.DeleteMilestone(ctx, "3", "1", "1", 0)
+      return err
+    }, team},
+    {"CreateTask", func(r *cachedRepository) error {
+      _, _, err := r.CreateTask(ctx, "3", "1", &v1.Task{}, 0)
+      return err
+    }, team},
+    {"UpdateTask", func(r *cachedRepository) error {
+      _, _, err := r.UpdateTask(ctx, "3", "1", "1", &v1.Task{}, nil, 0)
+      return err
+    }, team},
+    {"DeleteTask", func(r *cachedRepository) error {
+      _, _, err := r.DeleteTask(ctx, "3", "1", "1", 0)
+      return err
+    }, team},
+    {"RemoveUserFromTeams", func(r *cachedRepository) error {
+      _, err := r.RemoveUserFromTeams(ctx, "8")
+      return err
+    }, team},
+    {"UpdateMemberEmail", func(r *cachedRepository) error {
+      _, err := r.UpdateMemberEmail(ctx, "8", "new@example.com")
+      return err
+    }, team},
+    {"AcceptInvitation", func(r *cachedRepository) error {
+      _, _, err := r.AcceptInvitation(ctx, "1", "9")
+      return err
+    }, with(userTeamsPrefix + "9")},
+    {"ApproveApplication", func(r *cachedRepository) error {
+      _, _, err := r.ApproveApplication(ctx, "1", "7")
+      return err
+    }, team},
+    {"TransferOwnership", func(r *cachedRepository) error {
+      _, err := r.TransferOwnership(ctx, "3", "7", "", "8", 0)
+      return err
+    }, team},
+    {"RecordUserActivity", func(r *cachedRepository) error {
+      _, err := r.RecordUserActivity(ctx, "8", 1)
+      return err
+    }, team},
+    {"FlagInactiveTeams", func(r *cachedRepository) error {
+      _, err := r.FlagInactiveTeams(ctx)
+      return err
+    }, team},
+    {"ArchiveInactiveTeams", func(r *cachedRepository) error {
+      _, err := r.ArchiveInactiveTeams(ctx)
+      return err
+    }, team},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      cached, _, cache := newMutatingRepository()
+      if err := tt.mutate(cached); err != nil {
+        t.Fatal(err)
+      }
+      // every mutation starts a new generation of GetTeams pages
+      want := append(append([]string{}, tt.keys...), teamListGenKey)
+      sort.Strings(want)
+      if got := cache.deletedKeys(); !reflect.DeepEqual(got, want) {
+        t.Errorf("invalidated %v, want %v", got, want)
+      }
+    })
+  }
+}
+
+func TestCachedRepositoryWritesUseTheCachedTeam(t *testing.T) {
+  cached, repo, cache := newMutatingRepository()
+  ctx := context.Background()
+
+  if _, err := cached.GetTeamByTeamId(ctx, "3"); err != nil {
+    t.Fatal(err)
+  }
+  reads := repo.readCount()
+  // the keys of a cached team are known without reading it again
+  if _, _, err := cached.RemoveMember(ctx, "3", "5", 0); err != nil {
+    t.Fatal(err)
+  }
+  if got := repo.readCount(); got != reads {
+    t.Errorf("repository read %d more times", got-reads)
+  }
+  want := []string{teamIdPrefix + "3", teamListGenKey, teamNamePrefix + "gophers", userTeamsPrefix + "7", userTeamsPrefix + "8"}
+  if got := cache.deletedKeys(); !reflect.DeepEqual(got, want) {
+    t.Errorf("invalidated %v, want %v", got, want)
+  }
+}
+
+func TestCachedRepositoryKeepsEntriesOnFailure(t *testing.T) {
+  cached, repo, cache := newMutatingRepository()
+  repo.err = errors.New("deadlock")
+
+  if _, _, err := cached.RemoveMember(context.Background(), "3", "5", 0); err != repo.err {
+    t.Fatalf("RemoveMember() error = %v, want the repository error", err)
+  }
+  if keys := cache.deletedKeys(); len(keys) != 0 {
+    t.Errorf("invalidated %v after a failed mutation", keys)
+  }
+}
+
+func TestCachedRepositoryIgnoresIdleUsers(t *testing.T) {
+  repo := &idleRepository{}
+  cache := &deletionCache{memoryCache: NewMemoryCache(100), deleted: map[string]bool{}}
+  cached := NewCachedRepository(repo, cache, time.Minute)
+
+  // activity marking no team active changes nothing cached
+  if _, err := cached.RecordUserActivity(context.Background(), "8", 1); err != nil {
+    t.Fatal(err)
+  }
+  if keys := cache.deletedKeys(); len(keys) != 0 {
+    t.Errorf("invalidated %v", keys)
+  }
+}
+
+// idleRepository has no team to mark active
+type idleRepository struct {
+  teamsRepository
+}
+
+func (r *idleRepository) RecordUserActivity(ctx context.Context, userId string, at int64) (int64, error) {
+  return 0, nil
+}
+
+func TestCachedRepositoryCachesPagesPerGeneration(t *testing.T) {
+  cached, repo, _ := newMutatingRepository()
+  ctx := context.Background()
+  first := &v1.GetTeamsRequest{Limit: 10}
+  second := &v1.GetTeamsRequest{Limit: 20}
+
+  for i := 0; i < 2; i++ {
+    for _, req := range []*v1.GetTeamsRequest{first, second} {
+      if teams, _, err := cached.GetTeams(ctx, req); err != nil || len(teams) != 1 {
+        t.Fatalf("GetTeams() = %v, %v", teams, err)
+      }
+    }
+  }
+  if reads := repo.readCount(); reads != 2 {
+    t.Fatalf("repository read %d pages, want one per request", reads)
+  }
+
+  // a mutation drops every cached page at once
+  if _, _, err := cached.UpsertProject(ctx, "3", &v1.Project{}, 0); err != nil {
+    t.Fatal(err)
+  }
+  reads := repo.readCount()
+  for _, req := range []*v1.GetTeamsRequest{first, second, first} {
+    if _, _, err := cached.GetTeams(ctx, req); err != nil {
+      t.Fatal(err)
+    }
+  }
+  if got := repo.readCount() - reads; got != 2 {
+    t.Errorf("repository read %d pages after the mutation, want 2", got)
+  }
+}
+
+func TestCachedRepositoryDropsPagesOfAnEvictedGeneration(t *testing.T) {
+  cached, repo, cache := newMutatingRepository()
+  ctx := context.Background()
+  req := &v1.GetTeamsRequest{Limit: 10}
+
+  if _, _, err := cached.GetTeams(ctx, req); err != nil {
+    t.Fatal(err)
+  }
+  // the pages of an evicted generation are never served again
+  cache.memoryCache.DeleteEntry(ctx, teamListGenKey)
+  if _, _, err := cached.GetTeams(ctx, req); err != nil {
+    t.Fatal(err)
+  }
+  if reads := repo.readCount(); reads != 2 {
+    t.Errorf("repository read %d pages, want 2", reads)
+  }
+}
@@ -0,0 +1,54 @@
+package v1
+
+import (
+  "github.com/ThreeDotsLabs/watermill/message"
+)
+
+// topics the team service publishes its lifecycle events to
+const (
+  TeamCreatedTopic          = "team_created"
+  TeamUpdatedTopic          = "team_updated"
+  TeamDeletedTopic          = "team_deleted"
+  TeamRestoredTopic         = "team_restored"
+  TeamPurgedTopic           = "team_purged"
+  TeamInactiveTopic         = "team_inactive"
+  TeamArchivedTopic         = "team_archived"
+  MemberAddedTopic          = "member_added"
+  MemberRemovedTopic        = "member_removed"
+  ProjectUpsertedTopic      = "project_upserted"
+  ProjectCreatedTopic       = "project_created"
+  ProjectUpdatedTopic       = "project_updated"
+  ProjectArchivedTopic      = "project_archived"
+  MilestoneCreatedTopic     = "milestone_created"
+  MilestoneUpdatedTopic     = "milestone_updated"
+  MilestoneDeletedTopic     = "milestone_deleted"
+  TaskCreatedTopic          = "task_created"
+  TaskUpdatedTopic          = "task_updated"
+  TaskDeletedTopic          = "task_deleted"
+  LeaderChangedTopic        = "leader_changed"
+  MemberInvitedTopic        = "member_invited"
+  InvitationClosedTopic     = "invitation_closed"
+  ApplicationSubmittedTopic = "application_submitted"
+  ApplicationClosedTopic    = "application_closed"
+)
+
+// eventVersion is bumped whenever an event payload changes incompatibly so
+// consumers can tell old and new payloads apart.
+const eventVersion = "1"
+
+// metadata keys set on every published event
+const (
+  metadataEventName    = "event_name"
+  metadataEventVersion = "event_version"
+)
+
+// newEventMessage wraps an encoded protobuf event into a watermill message
+// input: uuid-unique id of the event used by consumers to dedupe, topic-the event name, payload-the encoded event
+// output: message ready to publish
+func newEventMessage(uuid, topic string, payload []byte) *message.Message {
+  msg := message.NewMessage(uuid, payload)
+  msg.Metadata.Set(metadataEventName, topic)
+  msg.Metadata.Set(metadataEventVersion, eventVersion)
+
+  return msg
+}
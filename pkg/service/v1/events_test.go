@@ -0,0 +1,317 @@
+package v1
+
+import (
+  "context"
+  "database/sql/driver"
+  "sync"
+  "testing"
+
+  "github.com/DATA-DOG/go-sqlmock"
+  "github.com/ThreeDotsLabs/watermill"
+  "github.com/ThreeDotsLabs/watermill/message"
+  "github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+  "github.com/golang/protobuf/proto"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+// capture is a sqlmock argument recording the value it is matched against
+type capture struct {
+  value *driver.Value
+}
+
+func (c capture) Match(v driver.Value) bool {
+  *c.value = v
+  return true
+}
+
+// outboxRow is an event a mutation wrote to the outbox
+type outboxRow struct {
+  eventId   driver.Value
+  topic     string
+  payload   driver.Value
+  published bool
+}
+
+// fakeOutbox keeps the events mutations write to the mock database so the
+// relay can read them back
+type fakeOutbox struct {
+  rows []*outboxRow
+}
+
+// expect expects an event on topic to be written to the outbox and records it
+func (o *fakeOutbox) expect(mock sqlmock.Sqlmock, topic string) {
+  row := &outboxRow{topic: topic}
+  o.rows = append(o.rows, row)
+  mock.ExpectExec(stmt(`INSERT INTO outbox`)).WithArgs(capture{&row.eventId}, topic, capture{&row.payload}, sqlmock.AnyArg(), sqlmock.AnyArg()).
+    WillReturnResult(sqlmock.NewResult(int64(len(o.rows)), 1))
+}
+
+// relay runs a batch of relay on the events of o that weren't published yet
+// and returns how many it attempted
+func (o *fakeOutbox) relay(t *testing.T, publisher message.Publisher) int {
+  db, mock, err := sqlmock.New()
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer db.Close()
+
+  rows := sqlmock.NewRows([]string{"id", "event_id", "topic", "payload", "attempts"})
+  pending := []int{}
+  for i, row := range o.rows {
+    if !row.published {
+      rows.AddRow(i+1, row.eventId, row.topic, row.payload, 0)
+      pending = append(pending, i)
+    }
+  }
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`SELECT id, event_id, topic, payload, attempts FROM outbox`)).WithArgs(sqlmock.AnyArg(), outboxBatchSize).WillReturnRows(rows)
+  for _, i := range pending {
+    mock.ExpectExec(stmt(`UPDATE outbox SET published_at=?`)).WithArgs(sqlmock.AnyArg(), i+1).WillReturnResult(sqlmock.NewResult(0, 1))
+  }
+  mock.ExpectCommit()
+
+  count, err := NewOutboxRelay(db, publisher).relayBatch(context.Background())
+  if err != nil {
+    t.Fatal(err)
+  }
+  if err := mock.ExpectationsWereMet(); err != nil {
+    t.Fatal(err)
+  }
+  for _, i := range pending {
+    o.rows[i].published = true
+  }
+  return count
+}
+
+// received collects the messages published on topics
+type received struct {
+  sync.Mutex
+  messages map[string][]*message.Message
+}
+
+// subscribe returns an in-memory pub/sub whose publishes return once every
+// message was received
+func subscribe(t *testing.T, topics ...string) (*gochannel.GoChannel, *received) {
+  pubsub := gochannel.NewGoChannel(gochannel.Config{BlockPublishUntilSubscriberAck: true}, watermill.NopLogger{})
+  t.Cleanup(func() { pubsub.Close() })
+
+  got := &received{messages: map[string][]*message.Message{}}
+  for _, topic := range topics {
+    messages, err := pubsub.Subscribe(context.Background(), topic)
+    if err != nil {
+      t.Fatal(err)
+    }
+    go func(topic string) {
+      for msg := range messages {
+        got.Lock()
+        got.messages[topic] = append(got.messages[topic], msg)
+        got.Unlock()
+        msg.Ack()
+      }
+    }(topic)
+  }
+  return pubsub, got
+}
+
+func (r *received) on(topic string) []*message.Message {
+  r.Lock()
+  defer r.Unlock()
+  return r.messages[topic]
+}
+
+// expectMemberRow expects member 4 of team 12 to be read for the audit trail
+func expectMemberRow(mock sqlmock.Sqlmock) {
+  mock.ExpectQuery(stmt(`SELECT user_id, member_email, member_role, access_role FROM members WHERE team_id=? AND id=?`)).WithArgs("12", "4").
+    WillReturnRows(sqlmock.NewRows([]string{"user_id", "member_email", "member_role", "access_role"}).AddRow("8", "m@example.com", "backend", RoleMember))
+}
+
+func TestMutationsPublishTheirEventOnce(t *testing.T) {
+  ctx := context.Background()
+  repo, mock := newMockRepository(t)
+  outbox := &fakeOutbox{}
+
+  // create team 12
+  mock.ExpectBegin()
+  mock.ExpectExec(stmt(`INSERT INTO teams`)).WillReturnResult(sqlmock.NewResult(12, 1))
+  mock.ExpectExec(stmt(`INSERT INTO skills`)).WillReturnResult(sqlmock.NewResult(1, 1))
+  outbox.expect(mock, TeamCreatedTopic)
+  expectAudit(mock, "12", AuditCreateTeam)
+  mock.ExpectCommit()
+  if _, err := repo.CreateTeam(ctx, &v1.Team{Leader: "7", Name: "gophers", OpenRoles: 2, Size: 3, Skills: []string{"go"}}); err != nil {
+    t.Fatal(err)
+  }
+
+  // add member 4
+  mock.ExpectBegin()
+  expectNextVersion(mock, "12", 1)
+  mock.ExpectExec(stmt(`INSERT INTO members`)).WillReturnResult(sqlmock.NewResult(4, 1))
+  mock.ExpectExec(stmt(`UPDATE teams SET open_roles = open_roles - 1 WHERE id=?`)).WithArgs("12").WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectQuery(stmt(`SELECT a.id, a.user_id FROM applications a`)).WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}))
+  outbox.expect(mock, MemberAddedTopic)
+  expectAudit(mock, "12", AuditAddMember)
+  mock.ExpectCommit()
+  if _, _, err := repo.AddMember(ctx, &v1.MemberUpsertRequest{TeamId: "12", MemberId: "8", MemberEmail: "m@example.com", Role: "backend"}); err != nil {
+    t.Fatal(err)
+  }
+
+  // upsert the first project of the team
+  mock.ExpectBegin()
+  expectNextVersion(mock, "12", 2)
+  mock.ExpectQuery(stmt(`SELECT id FROM projects WHERE team_id=? AND status=?`)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+  mock.ExpectExec(stmt(`INSERT INTO projects`)).WillReturnResult(sqlmock.NewResult(5, 1))
+  mock.ExpectExec(stmt(`INSERT INTO languages`)).WillReturnResult(sqlmock.NewResult(1, 1))
+  expectLoadProject(mock, "12", "5", []string{"go"})
+  outbox.expect(mock, ProjectUpsertedTopic)
+  expectAudit(mock, "12", AuditUpsertProject)
+  mock.ExpectCommit()
+  if _, _, err := repo.UpsertProject(ctx, "12", &v1.Project{Name: "tracker", Languages: []string{"go"}}, 0); err != nil {
+    t.Fatal(err)
+  }
+
+  // remove member 4
+  mock.ExpectBegin()
+  expectLockVersion(mock, "12", 3)
+  expectMemberRow(mock)
+  expectBumpVersion(mock, "12", 3)
+  mock.ExpectExec(stmt(`DELETE FROM members`)).WithArgs("12", "4").WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`UPDATE teams SET open_roles = open_roles + 1 WHERE id=?`)).WithArgs("12").WillReturnResult(sqlmock.NewResult(0, 1))
+  outbox.expect(mock, MemberRemovedTopic)
+  expectAudit(mock, "12", AuditRemoveMember)
+  mock.ExpectCommit()
+  if _, _, err := repo.RemoveMember(ctx, "12", "4", 0); err != nil {
+    t.Fatal(err)
+  }
+
+  // delete the team
+  mock.ExpectBegin()
+  expectNextVersion(mock, "12", 4)
+  mock.ExpectExec(stmt(`UPDATE teams SET deleted_at=?, deleted_by=? WHERE id=?`)).WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM members`)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM skills`)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+  outbox.expect(mock, TeamDeletedTopic)
+  expectAudit(mock, "12", AuditDeleteTeam)
+  mock.ExpectCommit()
+  if _, _, _, err := repo.DeleteTeam(ctx, "12", "7", 0); err != nil {
+    t.Fatal(err)
+  }
+
+  events := map[string]func() proto.Message{
+    TeamCreatedTopic:     func() proto.Message { return &v1.TeamCreated{} },
+    MemberAddedTopic:     func() proto.Message { return &v1.MemberAdded{} },
+    ProjectUpsertedTopic: func() proto.Message { return &v1.ProjectUpserted{} },
+    MemberRemovedTopic:   func() proto.Message { return &v1.MemberRemoved{} },
+    TeamDeletedTopic:     func() proto.Message { return &v1.TeamDeleted{} },
+  }
+  topics := []string{}
+  for topic := range events {
+    topics = append(topics, topic)
+  }
+  pubsub, got := subscribe(t, topics...)
+
+  if count := outbox.relay(t, pubsub); count != len(events) {
+    t.Fatalf("relayed %d events, want %d", count, len(events))
+  }
+  // published events aren't relayed again
+  if count := outbox.relay(t, pubsub); count != 0 {
+    t.Fatalf("relayed %d events again", count)
+  }
+
+  for i, row := range outbox.rows {
+    messages := got.on(row.topic)
+    if len(messages) != 1 {
+      t.Errorf("%s published %d times, want once", row.topic, len(messages))
+      continue
+    }
+    msg := messages[0]
+    if msg.UUID != row.eventId {
+      t.Errorf("%s uuid = %s, want the event id %v", row.topic, msg.UUID, row.eventId)
+    }
+    if name := msg.Metadata.Get(metadataEventName); name != row.topic {
+      t.Errorf("%s event_name = %s", row.topic, name)
+    }
+    if version := msg.Metadata.Get(metadataEventVersion); version != eventVersion {
+      t.Errorf("%s event_version = %s, want %s", row.topic, version, eventVersion)
+    }
+
+    event := events[row.topic]()
+    if err := proto.Unmarshal(msg.Payload, event); err != nil {
+      t.Errorf("%s payload: %v", row.topic, err)
+      continue
+    }
+    if teamId := event.(interface{ GetTeamId() string }).GetTeamId(); teamId != "12" {
+      t.Errorf("event %d %s is for team %s, want 12", i, row.topic, teamId)
+    }
+  }
+}
+
+func TestRemovingNoMemberWritesNoEvent(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectLockVersion(mock, "12", 3)
+  mock.ExpectQuery(stmt(`SELECT user_id, member_email`)).WillReturnRows(sqlmock.NewRows(nil))
+  mock.ExpectRollback()
+
+  // the team keeps its version
+  removed, version, err := repo.RemoveMember(context.Background(), "12", "4", 0)
+  if err != nil || removed != 0 || version != 3 {
+    t.Errorf("RemoveMember() = %d, %d, %v", removed, version, err)
+  }
+}
+
+func TestRolledBackMutationsPublishNothing(t *testing.T) {
+  repo, mock := newMockRepository(t)
+  outbox := &fakeOutbox{}
+
+  // the commit fails after the event was written, the event goes with the
+  // rolled back transaction
+  mock.ExpectBegin()
+  expectLockVersion(mock, "12", 3)
+  expectMemberRow(mock)
+  expectBumpVersion(mock, "12", 3)
+  mock.ExpectExec(stmt(`DELETE FROM members`)).WithArgs("12", "4").WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`UPDATE teams SET open_roles = open_roles + 1 WHERE id=?`)).WithArgs("12").WillReturnResult(sqlmock.NewResult(0, 1))
+  outbox.expect(mock, MemberRemovedTopic)
+  expectAudit(mock, "12", AuditRemoveMember)
+  mock.ExpectCommit().WillReturnError(driver.ErrBadConn)
+
+  if _, _, err := repo.RemoveMember(context.Background(), "12", "4", 0); err == nil {
+    t.Fatal("RemoveMember() error = nil, want the commit error")
+  }
+  // the row only existed in the transaction
+  outbox.rows = nil
+
+  pubsub, got := subscribe(t, MemberRemovedTopic)
+  if count := outbox.relay(t, pubsub); count != 0 {
+    t.Errorf("relayed %d events", count)
+  }
+  if messages := got.on(MemberRemovedTopic); len(messages) != 0 {
+    t.Errorf("published %d events", len(messages))
+  }
+}
+
+func TestNewEventMessage(t *testing.T) {
+  msg := newEventMessage("uuid-1", TeamCreatedTopic, []byte("payload"))
+  if msg.UUID != "uuid-1" || string(msg.Payload) != "payload" {
+    t.Errorf("message = %s %s", msg.UUID, msg.Payload)
+  }
+  if msg.Metadata.Get(metadataEventName) != TeamCreatedTopic || msg.Metadata.Get(metadataEventVersion) != eventVersion {
+    t.Errorf("metadata = %v", msg.Metadata)
+  }
+}
+
+// expectLoadProject expects loadProject to read project projectId of team
+// teamId with no tasks or milestones
+func expectLoadProject(mock sqlmock.Sqlmock, teamId, projectId string, languages []string) {
+  mock.ExpectQuery(stmt(`SELECT `+projectColumns+` FROM projects WHERE id=? AND team_id=?`)).WithArgs(projectId, teamId).
+    WillReturnRows(sqlmock.NewRows([]string{"id", "goal", "project_name", "github_link", "complexity", "duration", "status", "started_at", "completed_at", "archived_at"}).
+      AddRow(projectId, "", "tracker", "", 0, 0, ProjectActive, 1, nil, nil))
+  langs := sqlmock.NewRows([]string{"lang_name"})
+  for _, language := range languages {
+    langs.AddRow(language)
+  }
+  mock.ExpectQuery(stmt(`SELECT lang_name FROM languages WHERE project_id=?`)).WithArgs(projectId).WillReturnRows(langs)
+  mock.ExpectQuery(stmt(`FROM tasks WHERE project_id IN`)).WillReturnRows(sqlmock.NewRows([]string{"project_id", "total", "done"}))
+  mock.ExpectQuery(stmt(`FROM milestones WHERE project_id IN`)).WillReturnRows(sqlmock.NewRows([]string{"project_id", "total", "done"}))
+}
@@ -0,0 +1,344 @@
+package v1
+
+import (
+  "context"
+  "fmt"
+  "os"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/search"
+)
+
+// indexedRepository keeps a search index in sync with a repository. Every
+// mutating method reindexes the teams it changed once the change is
+// committed. Index failures are logged and never fail the mutation, the
+// index is repaired by the next change to the team or a reindex.
+type indexedRepository struct {
+  repository
+  index search.Index
+}
+
+func NewIndexedRepository(repo repository, index search.Index) *indexedRepository {
+  return &indexedRepository{
+    repository: repo,
+    index:      index,
+  }
+}
+
+func (r *indexedRepository) CreateTeam(ctx context.Context, team *v1.Team) (string, error) {
+  id, err := r.repository.CreateTeam(ctx, team)
+  if err != nil {
+    return id, err
+  }
+
+  r.reindex(ctx, id)
+  return id, nil
+}
+
+func (r *indexedRepository) UpdateTeam(ctx context.Context, id string, patch *v1.Team, paths []string, expected int64) (*v1.Team, error) {
+  team, err := r.repository.UpdateTeam(ctx, id, patch, paths, expected)
+  if err != nil {
+    return team, err
+  }
+
+  if err := r.index.Index(ctx, team); err != nil {
+    fmt.Fprintf(os.Stderr, "error indexing team %v: %v\n", id, err)
+  }
+  return team, nil
+}
+
+func (r *indexedRepository) DeleteTeam(ctx context.Context, id, userId string, expected int64) (int64, int64, int64, error) {
+  teamRows, memRows, skillRows, err := r.repository.DeleteTeam(ctx, id, userId, expected)
+  if err != nil {
+    return teamRows, memRows, skillRows, err
+  }
+
+  if err := r.index.Delete(ctx, id); err != nil {
+    fmt.Fprintf(os.Stderr, "error removing team %v from search index: %v\n", id, err)
+  }
+  return teamRows, memRows, skillRows, nil
+}
+
+func (r *indexedRepository) RestoreTeam(ctx context.Context, teamId, userId string) (*v1.Team, error) {
+  team, err := r.repository.RestoreTeam(ctx, teamId, userId)
+  if err != nil {
+    return team, err
+  }
+
+  if err := r.index.Index(ctx, team); err != nil {
+    fmt.Fprintf(os.Stderr, "error indexing team %v: %v\n", teamId, err)
+  }
+  return team, nil
+}
+
+func (r *indexedRepository) AddMember(ctx context.Context, req *v1.MemberUpsertRequest) (string, int64, error) {
+  newId, version, err := r.repository.AddMember(ctx, req)
+  if err != nil {
+    return newId, version, err
+  }
+
+  r.reindex(ctx, req.TeamId)
+  return newId, version, nil
+}
+
+func (r *indexedRepository) RemoveMember(ctx context.Context, teamId string, memberId string, expected int64) (int64, int64, error) {
+  count, version, err := r.repository.RemoveMember(ctx, teamId, memberId, expected)
+  if err != nil {
+    return count, version, err
+  }
+
+  r.reindex(ctx, teamId)
+  return count, version, nil
+}
+
+func (r *indexedRepository) UpsertProject(ctx context.Context, teamId string, project *v1.Project, expected int64) (int64, int64, error) {
+  projectId, version, err := r.repository.UpsertProject(ctx, teamId, project, expected)
+  if err != nil {
+    return projectId, version, err
+  }
+
+  r.reindex(ctx, teamId)
+  return projectId, version, nil
+}
+
+func (r *indexedRepository) CreateProject(ctx context.Context, teamId string, project *v1.Project, expected int64) (*v1.Project, int64, error) {
+  created, version, err := r.repository.CreateProject(ctx, teamId, project, expected)
+  if err != nil {
+    return created, version, err
+  }
+
+  r.reindex(ctx, teamId)
+  return created, version, nil
+}
+
+func (r *indexedRepository) UpdateProject(ctx context.Context, teamId, projectId string, patch *v1.Project, paths []string, expected int64) (*v1.Project, int64, error) {
+  updated, version, err := r.repository.UpdateProject(ctx, teamId, projectId, patch, paths, expected)
+  if err != nil {
+    return updated, version, err
+  }
+
+  r.reindex(ctx, teamId)
+  return updated, version, nil
+}
+
+func (r *indexedRepository) ArchiveProject(ctx context.Context, teamId, projectId string, expected int64) (*v1.Project, int64, error) {
+  archived, version, err := r.repository.ArchiveProject(ctx, teamId, projectId, expected)
+  if err != nil {
+    return archived, version, err
+  }
+
+  r.reindex(ctx, teamId)
+  return archived, version, nil
+}
+
+func (r *indexedRepository) RemoveUserFromTeams(ctx context.Context, userId string) (int64, error) {
+  // the user's teams must be read before they leave them
+  ids := r.userTeamIds(ctx, userId)
+
+  count, err := r.repository.RemoveUserFromTeams(ctx, userId)
+  if err != nil {
+    return count, err
+  }
+
+  r.reindex(ctx, ids...)
+  return count, nil
+}
+
+func (r *indexedRepository) UpdateMemberEmail(ctx context.Context, userId, email string) (int64, error) {
+  count, err := r.repository.UpdateMemberEmail(ctx, userId, email)
+  if err != nil {
+    return count, err
+  }
+
+  r.reindex(ctx, r.userTeamIds(ctx, userId)...)
+  return count, nil
+}
+
+func (r *indexedRepository) AcceptInvitation(ctx context.Context, id, userId string) (string, string, error) {
+  teamId, memberNumber, err := r.repository.AcceptInvitation(ctx, id, userId)
+  if err != nil {
+    return teamId, memberNumber, err
+  }
+
+  r.reindex(ctx, teamId)
+  return teamId, memberNumber, nil
+}
+
+func (r *indexedRepository) ApproveApplication(ctx context.Context, id, deciderId string) (string, string, error) {
+  teamId, memberNumber, err := r.repository.ApproveApplication(ctx, id, deciderId)
+  if err != nil {
+    return teamId, memberNumber, err
+  }
+
+  r.reindex(ctx, teamId)
+  return teamId, memberNumber, nil
+}
+
+func (r *indexedRepository) TransferOwnership(ctx context.Context, teamId, fromUserId, fromEmail, toUserId string, expected int64) (int64, error) {
+  version, err := r.repository.TransferOwnership(ctx, teamId, fromUserId, fromEmail, toUserId, expected)
+  if err != nil {
+    return version, err
+  }
+
+  r.reindex(ctx, teamId)
+  return version, nil
+}
+
+// milestones and tasks aren't indexed, but a change to them takes an
+// archived team back into the index
+func (r *indexedRepository) CreateMilestone(ctx context.Context, teamId, projectId string, milestone *v1.Milestone, expected int64) (*v1.Milestone, int64, error) {
+  created, version, err := r.repository.CreateMilestone(ctx, teamId, projectId, milestone, expected)
+  if err != nil {
+    return created, version, err
+  }
+
+  r.reindex(ctx, teamId)
+  return created, version, nil
+}
+
+func (r *indexedRepository) UpdateMilestone(ctx context.Context, teamId, projectId, milestoneId string, patch *v1.Milestone, paths []string, expected int64) (*v1.Milestone, int64, error) {
+  updated, version, err := r.repository.UpdateMilestone(ctx, teamId, projectId, milestoneId, patch, paths, expected)
+  if err != nil {
+    return updated, version, err
+  }
+
+  r.reindex(ctx, teamId)
+  return updated, version, nil
+}
+
+func (r *indexedRepository) DeleteMilestone(ctx context.Context, teamId, projectId, milestoneId string, expected int64) (*v1.Milestone, int64, error) {
+  deleted, version, err := r.repository.DeleteMilestone(ctx, teamId, projectId, milestoneId, expected)
+  if err != nil {
+    return deleted, version, err
+  }
+
+  r.reindex(ctx, teamId)
+  return deleted, version, nil
+}
+
+func (r *indexedRepository) CreateTask(ctx context.Context, teamId, projectId string, task *v1.Task, expected int64) (*v1.Task, int64, error) {
+  created, version, err := r.repository.CreateTask(ctx, teamId, projectId, task, expected)
+  if err != nil {
+    return created, version, err
+  }
+
+  r.reindex(ctx, teamId)
+  return created, version, nil
+}
+
+func (r *indexedRepository) UpdateTask(ctx context.Context, teamId, projectId, taskId string, patch *v1.Task, paths []string, expected int64) (*v1.Task, int64, error) {
+  updated, version, err := r.repository.UpdateTask(ctx, teamId, projectId, taskId, patch, paths, expected)
+  if err != nil {
+    return updated, version, err
+  }
+
+  r.reindex(ctx, teamId)
+  return updated, version, nil
+}
+
+func (r *indexedRepository) DeleteTask(ctx context.Context, teamId, projectId, taskId string, expected int64) (*v1.Task, int64, error) {
+  deleted, version, err := r.repository.DeleteTask(ctx, teamId, projectId, taskId, expected)
+  if err != nil {
+    return deleted, version, err
+  }
+
+  r.reindex(ctx, teamId)
+  return deleted, version, nil
+}
+
+func (r *indexedRepository) RecordUserActivity(ctx context.Context, userId string, at int64) (int64, error) {
+  count, err := r.repository.RecordUserActivity(ctx, userId, at)
+  if err != nil || count == 0 {
+    return count, err
+  }
+
+  r.reindex(ctx, r.userTeamIds(ctx, userId)...)
+  return count, nil
+}
+
+func (r *indexedRepository) ArchiveInactiveTeams(ctx context.Context) ([]string, error) {
+  // teams archived before an error are removed all the same
+  ids, err := r.repository.ArchiveInactiveTeams(ctx)
+  r.reindex(ctx, ids...)
+  return ids, err
+}
+
+// ---------------------------- HELPER FUNCTIONS -------------------------------
+
+// reindex loads the teams with ids from the repository and indexes them,
+// teams that no longer exist or are archived are removed from the index
+func (r *indexedRepository) reindex(ctx context.Context, ids ...string) {
+  if len(ids) == 0 {
+    return
+  }
+
+  teams, err := r.repository.GetTeamsByIds(ctx, ids)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error loading teams %v to index: %v\n", ids, err)
+    return
+  }
+
+  found := map[string]bool{}
+  for _, team := range teams {
+    if team.ArchivedAt != 0 {
+      continue
+    }
+    found[team.Id] = true
+    if err := r.index.Index(ctx, team); err != nil {
+      fmt.Fprintf(os.Stderr, "error indexing team %v: %v\n", team.Id, err)
+    }
+  }
+  for _, id := range ids {
+    if found[id] {
+      continue
+    }
+    if err := r.index.Delete(ctx, id); err != nil {
+      fmt.Fprintf(os.Stderr, "error removing team %v from search index: %v\n", id, err)
+    }
+  }
+}
+
+// userTeamIds returns the ids of the teams userId is on
+func (r *indexedRepository) userTeamIds(ctx context.Context, userId string) []string {
+  ids := []string{}
+  teams, err := r.repository.GetTeamsByUserId(ctx, userId)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error loading teams of user %v to index: %v\n", userId, err)
+    return ids
+  }
+  for _, team := range teams {
+    ids = append(ids, team.Id)
+  }
+  return ids
+}
+
+// EachTeam calls fn with every team of repo in id order, a page at a time,
+// teams archived for inactivity are skipped like GetTeams skips them
+func EachTeam(ctx context.Context, repo repository, fn func(*v1.Team) error) error {
+  req := &v1.GetTeamsRequest{Limit: 100}
+  for {
+    teams, next, err := repo.GetTeams(ctx, req)
+    if err != nil {
+      return err
+    }
+    for _, team := range teams {
+      if err := fn(team); err != nil {
+        return err
+      }
+    }
+    if next == "" {
+      return nil
+    }
+    req.PageToken = next
+  }
+}
+
+// IndexTeams adds every team of repo to index and returns how many were indexed
+func IndexTeams(ctx context.Context, repo repository, index search.Index) (int, error) {
+  count := 0
+  err := EachTeam(ctx, repo, func(team *v1.Team) error {
+    count++
+    return index.Index(ctx, team)
+  })
+  return count, err
+}
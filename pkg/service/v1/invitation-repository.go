@@ -0,0 +1,358 @@
+package v1
+
+import (
+  "context"
+  "database/sql"
+  "strconv"
+  "time"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// statuses of an invitation, expired is never stored, it is derived from
+// the expires_at of a pending invitation
+const (
+  InvitationPending  = "pending"
+  InvitationAccepted = "accepted"
+  InvitationDeclined = "declined"
+  InvitationRevoked  = "revoked"
+  InvitationExpired  = "expired"
+)
+
+const invitationColumns = `id, team_id, email, member_role, access_role, invited_by, status, created_at, expires_at`
+
+// Creates a pending invitation to a team with open roles
+// input: context-the current handler context, inv-the invitation, Id and Status are set by the repository
+// output ON SUCCESS: string - id of the invitation, error - nil
+// output ON FAILURE: string - "", error - TEAM_NOT_FOUND, TEAM_FULL, ALREADY_MEMBER, ALREADY_INVITED or the error object from whatever created the error
+func (r *teamRepository) CreateInvitation(ctx context.Context, inv *v1.Invitation) (string, error) {
+  teamStmt := `SELECT open_roles FROM teams WHERE id=? AND deleted_at IS NULL FOR UPDATE`
+  memberStmt := `SELECT COUNT(*) FROM members WHERE team_id=? AND member_email=?`
+  pendingStmt := `SELECT COUNT(*) FROM invitations WHERE team_id=? AND email=? AND status=? AND expires_at > ?`
+  insertStmt := `INSERT INTO invitations (team_id, email, member_role, access_role, invited_by, status, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return "", err
+  }
+  defer tx.Rollback()
+
+  // lock the team so concurrent invitations see each other
+  var openRoles int
+  err = tx.QueryRowContext(ctx, teamStmt, inv.TeamId).Scan(&openRoles)
+  if err == sql.ErrNoRows {
+    return "", domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", inv.TeamId).With("team_id", inv.TeamId)
+  } else if err != nil {
+    return "", err
+  }
+  if openRoles < 1 {
+    return "", domainerr.FailedPrecondition(domainerr.ReasonTeamFull, "team '%s' has no open roles", inv.TeamId).With("team_id", inv.TeamId)
+  }
+
+  var count int
+  if err := tx.QueryRowContext(ctx, memberStmt, inv.TeamId, inv.Email).Scan(&count); err != nil {
+    return "", err
+  }
+  if count > 0 {
+    return "", domainerr.AlreadyExists(domainerr.ReasonAlreadyMember, "'%s' is already on team '%s'", inv.Email, inv.TeamId).With("team_id", inv.TeamId)
+  }
+
+  now := time.Now().Unix()
+  if err := tx.QueryRowContext(ctx, pendingStmt, inv.TeamId, inv.Email, InvitationPending, now).Scan(&count); err != nil {
+    return "", err
+  }
+  if count > 0 {
+    return "", domainerr.AlreadyExists(domainerr.ReasonAlreadyInvited, "'%s' already has a pending invitation to team '%s'", inv.Email, inv.TeamId).With("team_id", inv.TeamId)
+  }
+
+  result, err := tx.ExecContext(ctx, insertStmt, inv.TeamId, inv.Email, inv.Role, storedAccessRole(inv.AccessRole), inv.InvitedBy, InvitationPending, inv.CreatedAt, inv.ExpiresAt)
+  if err != nil {
+    return "", err
+  }
+  id, err := result.LastInsertId()
+  if err != nil {
+    return "", err
+  }
+
+  // record member_invited event in the outbox
+  err = insertOutboxEvent(tx, MemberInvitedTopic, &v1.MemberInvited{
+    InvitationId: strconv.FormatInt(id, 10),
+    TeamId:       inv.TeamId,
+    Email:        inv.Email,
+    Role:         inv.Role,
+    AccessRole:   storedAccessRole(inv.AccessRole),
+    InvitedBy:    inv.InvitedBy,
+    ExpiresAt:    inv.ExpiresAt,
+    OccurredAt:   now,
+  })
+  if err != nil {
+    return "", err
+  }
+  inv.Id = strconv.FormatInt(id, 10)
+  inv.Status = InvitationPending
+  if err := insertAuditEvent(ctx, tx, inv.TeamId, AuditInviteMember, nil, invitationState(inv)); err != nil {
+    return "", err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return "", err
+  }
+  return strconv.FormatInt(id, 10), nil
+}
+
+// Gets an invitation by id
+// output ON FAILURE: INVITATION_NOT_FOUND or the error object from whatever created the error
+func (r *teamRepository) GetInvitation(ctx context.Context, id string) (*v1.Invitation, error) {
+  row := r.db.QueryRowContext(ctx, `SELECT `+invitationColumns+` FROM invitations WHERE id=?`, id)
+  inv, err := scanInvitation(row)
+  if err == sql.ErrNoRows {
+    return nil, invitationNotFound(id)
+  } else if err != nil {
+    return nil, err
+  }
+  return inv, nil
+}
+
+// Lists the invitations of team teamId, or sent to email when teamId is
+// empty, newest first. Only pending invitations are listed unless
+// includeClosed is set.
+func (r *teamRepository) ListInvitations(ctx context.Context, teamId, email string, includeClosed bool) ([]*v1.Invitation, error) {
+  stmt := `SELECT ` + invitationColumns + ` FROM invitations WHERE `
+  args := []interface{}{}
+  if teamId != "" {
+    stmt += `team_id=?`
+    args = append(args, teamId)
+  } else {
+    stmt += `email=?`
+    args = append(args, email)
+  }
+  if !includeClosed {
+    stmt += ` AND status=? AND expires_at > ?`
+    args = append(args, InvitationPending, time.Now().Unix())
+  }
+  stmt += ` ORDER BY id DESC LIMIT ?`
+  args = append(args, maxInvitations)
+
+  rows, err := r.db.QueryContext(ctx, stmt, args...)
+  if err != nil {
+    return nil, err
+  }
+  defer rows.Close()
+
+  invitations := []*v1.Invitation{}
+  for rows.Next() {
+    inv, err := scanInvitation(rows)
+    if err != nil {
+      return nil, err
+    }
+    invitations = append(invitations, inv)
+  }
+  return invitations, rows.Err()
+}
+
+// Accepts a pending invitation for user userId, consuming an open role of
+// the team and adding the user as a member in one transaction
+// output ON SUCCESS: string - id of the team, string - member number of the new member, error - nil
+// output ON FAILURE: INVITATION_NOT_FOUND, INVITATION_CLOSED, INVITATION_EXPIRED, TEAM_NOT_FOUND, TEAM_FULL, ALREADY_MEMBER or the error object from whatever created the error
+func (r *teamRepository) AcceptInvitation(ctx context.Context, id, userId string) (string, string, error) {
+  teamStmt := `SELECT open_roles FROM teams WHERE id=? AND deleted_at IS NULL FOR UPDATE`
+  existsStmt := `SELECT COUNT(*) FROM members WHERE team_id=? AND user_id=?`
+  memberStmt := `INSERT INTO members (user_id, team_id, member_email, member_role, access_role) VALUES (?, ?, ?, ?, ?)`
+  rolesStmt := `UPDATE teams SET open_roles = open_roles - 1, version = version + 1 WHERE id=?`
+  acceptStmt := `UPDATE invitations SET status=?, responded_at=? WHERE id=?`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return "", "", err
+  }
+  defer tx.Rollback()
+
+  // lock the invitation so it is used once
+  inv, err := scanInvitation(tx.QueryRowContext(ctx, `SELECT `+invitationColumns+` FROM invitations WHERE id=? FOR UPDATE`, id))
+  if err == sql.ErrNoRows {
+    return "", "", invitationNotFound(id)
+  } else if err != nil {
+    return "", "", err
+  }
+  if err := checkPending(inv); err != nil {
+    return "", "", err
+  }
+
+  var openRoles int
+  err = tx.QueryRowContext(ctx, teamStmt, inv.TeamId).Scan(&openRoles)
+  if err == sql.ErrNoRows {
+    return "", "", domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", inv.TeamId).With("team_id", inv.TeamId)
+  } else if err != nil {
+    return "", "", err
+  }
+  if openRoles < 1 {
+    return "", "", domainerr.FailedPrecondition(domainerr.ReasonTeamFull, "team '%s' has no open roles", inv.TeamId).With("team_id", inv.TeamId)
+  }
+
+  var count int
+  if err := tx.QueryRowContext(ctx, existsStmt, inv.TeamId, userId).Scan(&count); err != nil {
+    return "", "", err
+  }
+  if count > 0 {
+    return "", "", domainerr.AlreadyExists(domainerr.ReasonAlreadyMember, "user '%s' is already on team '%s'", userId, inv.TeamId).With("team_id", inv.TeamId)
+  }
+
+  result, err := tx.ExecContext(ctx, memberStmt, userId, inv.TeamId, inv.Email, inv.Role, storedAccessRole(inv.AccessRole))
+  if err != nil {
+    return "", "", err
+  }
+  memId, err := result.LastInsertId()
+  if err != nil {
+    return "", "", err
+  }
+  if _, err := tx.ExecContext(ctx, rolesStmt, inv.TeamId); err != nil {
+    return "", "", err
+  }
+  if err := touchTeam(ctx, tx, inv.TeamId); err != nil {
+    return "", "", err
+  }
+  if err := closeFullTeamApplications(ctx, tx, inv.TeamId); err != nil {
+    return "", "", err
+  }
+
+  now := time.Now().Unix()
+  if _, err := tx.ExecContext(ctx, acceptStmt, InvitationAccepted, now, id); err != nil {
+    return "", "", err
+  }
+
+  // record member_added and invitation_closed events in the outbox
+  err = insertOutboxEvent(tx, MemberAddedTopic, &v1.MemberAdded{
+    TeamId:       inv.TeamId,
+    MemberNumber: strconv.FormatInt(memId, 10),
+    UserId:       userId,
+    Email:        inv.Email,
+    Role:         inv.Role,
+    AccessRole:   storedAccessRole(inv.AccessRole),
+    OccurredAt:   now,
+  })
+  if err != nil {
+    return "", "", err
+  }
+  err = insertOutboxEvent(tx, InvitationClosedTopic, &v1.InvitationClosed{
+    InvitationId: id,
+    TeamId:       inv.TeamId,
+    Status:       InvitationAccepted,
+    OccurredAt:   now,
+  })
+  if err != nil {
+    return "", "", err
+  }
+  after := memberState(strconv.FormatInt(memId, 10), userId, inv.Email, inv.Role, storedAccessRole(inv.AccessRole))
+  if err := insertAuditEvent(ctx, tx, inv.TeamId, AuditAddMember, nil, after); err != nil {
+    return "", "", err
+  }
+  if err := closeInvitationAudit(ctx, tx, inv, InvitationAccepted); err != nil {
+    return "", "", err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return "", "", err
+  }
+  return inv.TeamId, strconv.FormatInt(memId, 10), nil
+}
+
+// Closes a pending invitation as declined or revoked
+// output ON FAILURE: INVITATION_NOT_FOUND, INVITATION_CLOSED, INVITATION_EXPIRED or the error object from whatever created the error
+func (r *teamRepository) CloseInvitation(ctx context.Context, id, status string) error {
+  closeStmt := `UPDATE invitations SET status=?, responded_at=? WHERE id=?`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return err
+  }
+  defer tx.Rollback()
+
+  inv, err := scanInvitation(tx.QueryRowContext(ctx, `SELECT `+invitationColumns+` FROM invitations WHERE id=? FOR UPDATE`, id))
+  if err == sql.ErrNoRows {
+    return invitationNotFound(id)
+  } else if err != nil {
+    return err
+  }
+  if err := checkPending(inv); err != nil {
+    return err
+  }
+
+  now := time.Now().Unix()
+  if _, err := tx.ExecContext(ctx, closeStmt, status, now, id); err != nil {
+    return err
+  }
+
+  // record invitation_closed event in the outbox
+  err = insertOutboxEvent(tx, InvitationClosedTopic, &v1.InvitationClosed{
+    InvitationId: id,
+    TeamId:       inv.TeamId,
+    Status:       status,
+    OccurredAt:   now,
+  })
+  if err != nil {
+    return err
+  }
+  if err := closeInvitationAudit(ctx, tx, inv, status); err != nil {
+    return err
+  }
+  return tx.Commit()
+}
+
+// ---------------------------- HELPER FUNCTIONS -------------------------------
+
+// maxInvitations caps the invitations listed at once
+const maxInvitations = 100
+
+// scanInvitation scans a row of invitationColumns, pending invitations past
+// their expiry are reported as expired
+func scanInvitation(row interface{ Scan(...interface{}) error }) (*v1.Invitation, error) {
+  inv := &v1.Invitation{}
+  var id, teamId int64
+  err := row.Scan(&id, &teamId, &inv.Email, &inv.Role, &inv.AccessRole, &inv.InvitedBy, &inv.Status, &inv.CreatedAt, &inv.ExpiresAt)
+  if err != nil {
+    return nil, err
+  }
+  inv.Id = strconv.FormatInt(id, 10)
+  inv.TeamId = strconv.FormatInt(teamId, 10)
+  if inv.Status == InvitationPending && inv.ExpiresAt <= time.Now().Unix() {
+    inv.Status = InvitationExpired
+  }
+  return inv, nil
+}
+
+// checkPending fails unless inv can still be answered
+func checkPending(inv *v1.Invitation) error {
+  switch inv.Status {
+  case InvitationPending:
+    return nil
+  case InvitationExpired:
+    return domainerr.FailedPrecondition(domainerr.ReasonInvitationExpired, "invitation '%s' expired", inv.Id).With("invitation_id", inv.Id)
+  default:
+    return domainerr.FailedPrecondition(domainerr.ReasonInvitationClosed, "invitation '%s' is already %s", inv.Id, inv.Status).With("invitation_id", inv.Id)
+  }
+}
+
+// invitationState is the audited state of an invitation
+func invitationState(inv *v1.Invitation) auditState {
+  return auditState{
+    "invitation_id": inv.Id,
+    "email":         inv.Email,
+    "role":          inv.Role,
+    "access_role":   storedAccessRole(inv.AccessRole),
+    "status":        inv.Status,
+    "expires_at":    inv.ExpiresAt,
+  }
+}
+
+// closeInvitationAudit records the pending invitation inv closing as status
+func closeInvitationAudit(ctx context.Context, tx *sql.Tx, inv *v1.Invitation, status string) error {
+  before := invitationState(inv)
+  closed := *inv
+  closed.Status = status
+  return insertAuditEvent(ctx, tx, inv.TeamId, AuditCloseInvitation, before, invitationState(&closed))
+}
+
+func invitationNotFound(id string) error {
+  return domainerr.NotFound(domainerr.ReasonInvitationNotFound, "invitation '%s' not found", id).With("invitation_id", id)
+}
@@ -0,0 +1,206 @@
+package v1
+
+import (
+  "context"
+  "testing"
+  "time"
+
+  "github.com/DATA-DOG/go-sqlmock"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// invitationRow is invitation 4 to team 3 for m@example.com with status,
+// expiring at expiresAt
+func invitationRow(status string, expiresAt int64) *sqlmock.Rows {
+  return sqlmock.NewRows([]string{"id", "team_id", "email", "member_role", "access_role", "invited_by", "status", "created_at", "expires_at"}).
+    AddRow(4, 3, "m@example.com", "backend", RoleAdmin, "1", status, expiresAt-int64(invitationTTL.Seconds()), expiresAt)
+}
+
+// expectLockInvitation expects invitation 4 to be locked
+func expectLockInvitation(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+  mock.ExpectQuery(stmt(`FROM invitations WHERE id=? FOR UPDATE`)).WithArgs("4").WillReturnRows(rows)
+}
+
+// expectOpenRoles expects team teamId to be locked with openRoles
+func expectOpenRoles(mock sqlmock.Sqlmock, teamId string, openRoles int) {
+  mock.ExpectQuery(stmt(`SELECT open_roles FROM teams WHERE id=? AND deleted_at IS NULL FOR UPDATE`)).WithArgs(teamId).
+    WillReturnRows(sqlmock.NewRows([]string{"open_roles"}).AddRow(openRoles))
+}
+
+// expectNoApplicationsToClose expects the pending applications of a full
+// team teamId to be looked up and none to be found
+func expectNoApplicationsToClose(mock sqlmock.Sqlmock, teamId string) {
+  mock.ExpectQuery(stmt(`FROM applications a JOIN teams t`)).WithArgs(teamId, ApplicationPending).
+    WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}))
+}
+
+func countRow(n int) *sqlmock.Rows {
+  return sqlmock.NewRows([]string{"count"}).AddRow(n)
+}
+
+func TestCreateInvitation(t *testing.T) {
+  repo, mock := newMockRepository(t)
+  inv := &v1.Invitation{TeamId: "3", Email: "m@example.com", Role: "backend", AccessRole: RoleMember, InvitedBy: "1", ExpiresAt: time.Now().Add(invitationTTL).Unix()}
+
+  mock.ExpectBegin()
+  expectOpenRoles(mock, "3", 1)
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM members WHERE team_id=? AND member_email=?`)).WithArgs("3", "m@example.com").WillReturnRows(countRow(0))
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM invitations`)).WithArgs("3", "m@example.com", InvitationPending, sqlmock.AnyArg()).WillReturnRows(countRow(0))
+  mock.ExpectExec(stmt(`INSERT INTO invitations`)).
+    WithArgs("3", "m@example.com", "backend", RoleMember, "1", InvitationPending, sqlmock.AnyArg(), inv.ExpiresAt).
+    WillReturnResult(sqlmock.NewResult(4, 1))
+  expectOutbox(mock, MemberInvitedTopic)
+  expectAudit(mock, "3", AuditInviteMember)
+  mock.ExpectCommit()
+
+  id, err := repo.CreateInvitation(context.Background(), inv)
+  if err != nil || id != "4" || inv.Status != InvitationPending {
+    t.Errorf("CreateInvitation() = %s, %v, invitation %v", id, err, inv)
+  }
+}
+
+func TestCreateInvitationChecks(t *testing.T) {
+  tests := []struct {
+    name   string
+    expect func(mock sqlmock.Sqlmock)
+    reason string
+  }{
+    {"no team", func(mock sqlmock.Sqlmock) {
+      mock.ExpectQuery(stmt(`SELECT open_roles FROM teams`)).WillReturnRows(sqlmock.NewRows([]string{"open_roles"}))
+    }, domainerr.ReasonTeamNotFound},
+    {"full team", func(mock sqlmock.Sqlmock) {
+      expectOpenRoles(mock, "3", 0)
+    }, domainerr.ReasonTeamFull},
+    {"member", func(mock sqlmock.Sqlmock) {
+      expectOpenRoles(mock, "3", 1)
+      mock.ExpectQuery(stmt(`FROM members`)).WillReturnRows(countRow(1))
+    }, domainerr.ReasonAlreadyMember},
+    {"pending invitation", func(mock sqlmock.Sqlmock) {
+      expectOpenRoles(mock, "3", 1)
+      mock.ExpectQuery(stmt(`FROM members`)).WillReturnRows(countRow(0))
+      mock.ExpectQuery(stmt(`FROM invitations`)).WillReturnRows(countRow(1))
+    }, domainerr.ReasonAlreadyInvited},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      repo, mock := newMockRepository(t)
+      mock.ExpectBegin()
+      tt.expect(mock)
+      mock.ExpectRollback()
+
+      _, err := repo.CreateInvitation(context.Background(), &v1.Invitation{TeamId: "3", Email: "m@example.com"})
+      if domainerr.ReasonOf(err) != tt.reason {
+        t.Errorf("CreateInvitation() error = %v, want %s", err, tt.reason)
+      }
+    })
+  }
+}
+
+func TestAcceptInvitation(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectLockInvitation(mock, invitationRow(InvitationPending, time.Now().Add(time.Hour).Unix()))
+  expectOpenRoles(mock, "3", 1)
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM members WHERE team_id=? AND user_id=?`)).WithArgs("3", "9").WillReturnRows(countRow(0))
+  // the invitee joins with the invited roles and consumes an open role
+  mock.ExpectExec(stmt(`INSERT INTO members`)).WithArgs("9", "3", "m@example.com", "backend", RoleAdmin).
+    WillReturnResult(sqlmock.NewResult(21, 1))
+  mock.ExpectExec(stmt(`UPDATE teams SET open_roles = open_roles - 1, version = version + 1 WHERE id=?`)).WithArgs("3").
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`UPDATE teams SET last_active=?`)).WithArgs(sqlmock.AnyArg(), "3").WillReturnResult(sqlmock.NewResult(0, 1))
+  expectNoApplicationsToClose(mock, "3")
+  mock.ExpectExec(stmt(`UPDATE invitations SET status=?, responded_at=? WHERE id=?`)).WithArgs(InvitationAccepted, sqlmock.AnyArg(), "4").
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  expectOutbox(mock, MemberAddedTopic)
+  expectOutbox(mock, InvitationClosedTopic)
+  expectAudit(mock, "3", AuditAddMember)
+  expectAudit(mock, "3", AuditCloseInvitation)
+  mock.ExpectCommit()
+
+  teamId, memberNumber, err := repo.AcceptInvitation(context.Background(), "4", "9")
+  if err != nil || teamId != "3" || memberNumber != "21" {
+    t.Errorf("AcceptInvitation() = %s, %s, %v", teamId, memberNumber, err)
+  }
+}
+
+func TestAcceptInvitationChecks(t *testing.T) {
+  later := time.Now().Add(time.Hour).Unix()
+  tests := []struct {
+    name   string
+    expect func(mock sqlmock.Sqlmock)
+    reason string
+  }{
+    {"missing", func(mock sqlmock.Sqlmock) {
+      expectLockInvitation(mock, sqlmock.NewRows(nil))
+    }, domainerr.ReasonInvitationNotFound},
+    // invitations are single use
+    {"accepted", func(mock sqlmock.Sqlmock) {
+      expectLockInvitation(mock, invitationRow(InvitationAccepted, later))
+    }, domainerr.ReasonInvitationClosed},
+    {"revoked", func(mock sqlmock.Sqlmock) {
+      expectLockInvitation(mock, invitationRow(InvitationRevoked, later))
+    }, domainerr.ReasonInvitationClosed},
+    {"expired", func(mock sqlmock.Sqlmock) {
+      expectLockInvitation(mock, invitationRow(InvitationPending, time.Now().Add(-time.Second).Unix()))
+    }, domainerr.ReasonInvitationExpired},
+    {"full team", func(mock sqlmock.Sqlmock) {
+      expectLockInvitation(mock, invitationRow(InvitationPending, later))
+      expectOpenRoles(mock, "3", 0)
+    }, domainerr.ReasonTeamFull},
+    {"member", func(mock sqlmock.Sqlmock) {
+      expectLockInvitation(mock, invitationRow(InvitationPending, later))
+      expectOpenRoles(mock, "3", 2)
+      mock.ExpectQuery(stmt(`FROM members`)).WillReturnRows(countRow(1))
+    }, domainerr.ReasonAlreadyMember},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      repo, mock := newMockRepository(t)
+      mock.ExpectBegin()
+      tt.expect(mock)
+      mock.ExpectRollback()
+
+      if _, _, err := repo.AcceptInvitation(context.Background(), "4", "9"); domainerr.ReasonOf(err) != tt.reason {
+        t.Errorf("AcceptInvitation() error = %v, want %s", err, tt.reason)
+      }
+    })
+  }
+}
+
+func TestCloseInvitation(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectLockInvitation(mock, invitationRow(InvitationPending, time.Now().Add(time.Hour).Unix()))
+  mock.ExpectExec(stmt(`UPDATE invitations SET status=?`)).WithArgs(InvitationDeclined, sqlmock.AnyArg(), "4").
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  expectOutbox(mock, InvitationClosedTopic)
+  expectAudit(mock, "3", AuditCloseInvitation)
+  mock.ExpectCommit()
+
+  if err := repo.CloseInvitation(context.Background(), "4", InvitationDeclined); err != nil {
+    t.Fatal(err)
+  }
+
+  // a declined invitation can't be revoked
+  mock.ExpectBegin()
+  expectLockInvitation(mock, invitationRow(InvitationDeclined, time.Now().Add(time.Hour).Unix()))
+  mock.ExpectRollback()
+  if err := repo.CloseInvitation(context.Background(), "4", InvitationRevoked); domainerr.ReasonOf(err) != domainerr.ReasonInvitationClosed {
+    t.Errorf("CloseInvitation() error = %v, want %s", err, domainerr.ReasonInvitationClosed)
+  }
+}
+
+func TestScanInvitationExpires(t *testing.T) {
+  repo, mock := newMockRepository(t)
+  mock.ExpectQuery(stmt(`FROM invitations WHERE id=?`)).WithArgs("4").
+    WillReturnRows(invitationRow(InvitationPending, time.Now().Unix()))
+
+  inv, err := repo.GetInvitation(context.Background(), "4")
+  if err != nil || inv.Status != InvitationExpired || inv.Id != "4" || inv.TeamId != "3" {
+    t.Errorf("GetInvitation() = %v, %v, want an expired invitation", inv, err)
+  }
+}
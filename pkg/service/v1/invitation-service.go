@@ -0,0 +1,220 @@
+package v1
+
+import (
+  "context"
+  "fmt"
+  "os"
+  "strings"
+  "time"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// invitationTTL is how long an invitation may be accepted
+const invitationTTL = 7 * 24 * time.Hour
+
+func (s *handler) InviteMember(ctx context.Context, req *v1.InviteMemberRequest) (*v1.InviteMemberResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  // Check if the caller may invite members to team correlating to req.TeamId
+  userId, role, err := s.authorize(ctx, req.TeamId, ActionInviteMember)
+  if err != nil {
+    return nil, err
+  }
+
+  // invitees join as regular members unless asked otherwise, only owners invite admins
+  if req.AccessRole == "" {
+    req.AccessRole = RoleMember
+  }
+  if !validAccessRole(req.AccessRole) {
+    return nil, domainerr.InvalidArgument(domainerr.FieldViolation{
+      Field:       "access_role",
+      Description: fmt.Sprintf("'%s' is not one of admin, member or viewer", req.AccessRole),
+    })
+  }
+  if !outranks(role, req.AccessRole) {
+    return nil, permissionDenied("invite "+req.AccessRole, req.TeamId)
+  }
+
+  now := time.Now()
+  invitation := &v1.Invitation{
+    TeamId:     req.TeamId,
+    Email:      strings.ToLower(req.Email),
+    Role:       req.Role,
+    AccessRole: req.AccessRole,
+    InvitedBy:  userId,
+    CreatedAt:  now.Unix(),
+    ExpiresAt:  now.Add(invitationTTL).Unix(),
+  }
+
+  // the repository checks the team has open roles and the invitee isn't a member or already invited
+  id, err := s.repo.CreateInvitation(ctx, invitation)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo CreateInvitation: %v\n", req.TeamId)
+    return nil, err
+  }
+  invitation.Id = id
+  invitation.Status = InvitationPending
+
+  // member_invited Event is written to the outbox by the repository
+
+  return &v1.InviteMemberResponse{
+    Api:        apiVersion,
+    Status:     "Invited",
+    Invitation: invitation,
+  }, nil
+}
+
+func (s *handler) ListInvitations(ctx context.Context, req *v1.ListInvitationsRequest) (*v1.ListInvitationsResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  email := ""
+  if req.TeamId != "" {
+    // a team's invitations are listed to the members who may send them
+    if _, _, err := s.authorize(ctx, req.TeamId, ActionInviteMember); err != nil {
+      return nil, err
+    }
+  } else {
+    var err error
+    if email, err = s.callerEmail(ctx); err != nil {
+      return nil, err
+    }
+  }
+
+  invitations, err := s.repo.ListInvitations(ctx, req.TeamId, email, req.IncludeClosed)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo ListInvitations: %v\n", req.TeamId)
+    return nil, err
+  }
+
+  status := "invitations"
+  if len(invitations) == 0 {
+    status = "empty"
+  }
+  return &v1.ListInvitationsResponse{
+    Api:         apiVersion,
+    Status:      status,
+    Invitations: invitations,
+  }, nil
+}
+
+func (s *handler) AcceptInvitation(ctx context.Context, req *v1.InvitationRequest) (*v1.AcceptInvitationResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  userId, err := s.callerId(ctx)
+  if err != nil {
+    return nil, err
+  }
+  if _, err := s.invitee(ctx, req.InvitationId); err != nil {
+    return nil, err
+  }
+
+  // the repository consumes an open role and adds the member in one transaction
+  teamId, memberNumber, err := s.repo.AcceptInvitation(ctx, req.InvitationId, userId)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo AcceptInvitation: %v\n", req.InvitationId)
+    return nil, err
+  }
+
+  // member_added and invitation_closed Events are written to the outbox by the repository
+
+  return &v1.AcceptInvitationResponse{
+    Api:          apiVersion,
+    Status:       "Accepted",
+    TeamId:       teamId,
+    MemberNumber: memberNumber,
+  }, nil
+}
+
+func (s *handler) DeclineInvitation(ctx context.Context, req *v1.InvitationRequest) (*v1.InvitationResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  if _, err := s.invitee(ctx, req.InvitationId); err != nil {
+    return nil, err
+  }
+
+  if err := s.repo.CloseInvitation(ctx, req.InvitationId, InvitationDeclined); err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo CloseInvitation: %v\n", req.InvitationId)
+    return nil, err
+  }
+
+  return &v1.InvitationResponse{
+    Api:    apiVersion,
+    Status: "Declined",
+  }, nil
+}
+
+func (s *handler) RevokeInvitation(ctx context.Context, req *v1.InvitationRequest) (*v1.InvitationResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  invitation, err := s.repo.GetInvitation(ctx, req.InvitationId)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo GetInvitation: %v\n", req.InvitationId)
+    return nil, err
+  }
+
+  // Check if the caller may withdraw invitations to the invitation's team
+  if _, _, err := s.authorize(ctx, invitation.TeamId, ActionInviteMember); err != nil {
+    return nil, err
+  }
+
+  if err := s.repo.CloseInvitation(ctx, req.InvitationId, InvitationRevoked); err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo CloseInvitation: %v\n", req.InvitationId)
+    return nil, err
+  }
+
+  return &v1.InvitationResponse{
+    Api:    apiVersion,
+    Status: "Revoked",
+  }, nil
+}
+
+// ---------------------------- HELPER FUNCTIONS -------------------------------
+
+// callerEmail returns the email of the caller's token, invitations are
+// matched against it
+func (s *handler) callerEmail(ctx context.Context) (string, error) {
+  identity, err := s.caller(ctx)
+  if err != nil {
+    return "", err
+  }
+  if identity.Email == "" {
+    return "", domainerr.PermissionDenied("the bearer token carries no email to match invitations against")
+  }
+  return strings.ToLower(identity.Email), nil
+}
+
+// invitee returns invitation id if it was sent to the caller's email,
+// others can't see it exists
+func (s *handler) invitee(ctx context.Context, id string) (*v1.Invitation, error) {
+  email, err := s.callerEmail(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  invitation, err := s.repo.GetInvitation(ctx, id)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo GetInvitation: %v\n", id)
+    return nil, err
+  }
+  if invitation.Email != email {
+    return nil, invitationNotFound(id)
+  }
+  return invitation, nil
+}
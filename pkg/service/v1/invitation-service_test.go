@@ -0,0 +1,167 @@
+package v1
+
+import (
+  "context"
+  "testing"
+
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/auth"
+)
+
+// invitationsRepository is team 3 of rolesRepository holding invitations
+// by id, it records what is created, accepted and closed
+type invitationsRepository struct {
+  *rolesRepository
+  invitations map[string]*v1.Invitation
+  created     []*v1.Invitation
+  accepted    []string
+  closed      map[string]string
+}
+
+func (r *invitationsRepository) CreateInvitation(ctx context.Context, inv *v1.Invitation) (string, error) {
+  r.created = append(r.created, inv)
+  return "4", nil
+}
+
+func (r *invitationsRepository) GetInvitation(ctx context.Context, id string) (*v1.Invitation, error) {
+  if inv, ok := r.invitations[id]; ok {
+    return inv, nil
+  }
+  return nil, invitationNotFound(id)
+}
+
+func (r *invitationsRepository) AcceptInvitation(ctx context.Context, id, userId string) (string, string, error) {
+  r.accepted = append(r.accepted, userId)
+  return "3", "21", nil
+}
+
+func (r *invitationsRepository) CloseInvitation(ctx context.Context, id, status string) error {
+  r.closed[id] = status
+  return nil
+}
+
+// newInvitationsHandler returns the handler of newRolesHandler with
+// invitation 4 to team 3 pending for m@example.com
+func newInvitationsHandler() (*handler, *invitationsRepository) {
+  _, roles := newRolesHandler()
+  repo := &invitationsRepository{
+    rolesRepository: roles,
+    invitations: map[string]*v1.Invitation{
+      "4": {Id: "4", TeamId: "3", Email: "m@example.com", Status: InvitationPending},
+    },
+    closed: map[string]string{},
+  }
+  return NewTeamServiceServer(repo, nil, ""), repo
+}
+
+// invitee is the context of user 9 with email
+func invitee(email string) context.Context {
+  return auth.NewContext(context.Background(), &auth.Identity{UserId: "9", Email: email})
+}
+
+func TestInviteMember(t *testing.T) {
+  tests := []struct {
+    name       string
+    caller     string
+    accessRole string
+    code       codes.Code
+  }{
+    {"owner invites an admin", "1", RoleAdmin, codes.OK},
+    {"admin invites a member", "2", "", codes.OK},
+    {"admin invites an admin", "2", RoleAdmin, codes.PermissionDenied},
+    {"member invites a viewer", "3", RoleViewer, codes.PermissionDenied},
+    {"owner invites an owner", "1", RoleOwner, codes.InvalidArgument},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      s, repo := newInvitationsHandler()
+      res, err := s.InviteMember(as(tt.caller), &v1.InviteMemberRequest{TeamId: "3", Email: "M@Example.com", Role: "backend", AccessRole: tt.accessRole})
+      if status.Code(err) != tt.code {
+        t.Fatalf("InviteMember() error = %v, want %s", err, tt.code)
+      }
+      if tt.code != codes.OK {
+        if len(repo.created) != 0 {
+          t.Errorf("created %v", repo.created)
+        }
+        return
+      }
+
+      inv := res.Invitation
+      if inv.Id != "4" || inv.Status != InvitationPending || inv.InvitedBy != tt.caller || inv.AccessRole == "" {
+        t.Errorf("invitation = %v", inv)
+      }
+      // emails are matched case insensitively
+      if inv.Email != "m@example.com" {
+        t.Errorf("email = %s, want it lowercased", inv.Email)
+      }
+      if ttl := inv.ExpiresAt - inv.CreatedAt; ttl != int64(invitationTTL.Seconds()) {
+        t.Errorf("invitation expires after %ds, want %v", ttl, invitationTTL)
+      }
+    })
+  }
+}
+
+func TestAnswerInvitation(t *testing.T) {
+  tests := []struct {
+    name string
+    ctx  context.Context
+    code codes.Code
+  }{
+    {"invitee", invitee("m@example.com"), codes.OK},
+    {"invitee with a capitalized email", invitee("M@example.com"), codes.OK},
+    // other users can't tell the invitation exists
+    {"other user", invitee("other@example.com"), codes.NotFound},
+    {"token without email", invitee(""), codes.PermissionDenied},
+    {"anonymous", context.Background(), codes.Unauthenticated},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      s, repo := newInvitationsHandler()
+      res, err := s.AcceptInvitation(tt.ctx, &v1.InvitationRequest{InvitationId: "4"})
+      if status.Code(err) != tt.code {
+        t.Fatalf("AcceptInvitation() error = %v, want %s", err, tt.code)
+      }
+      if tt.code == codes.OK && (res.TeamId != "3" || res.MemberNumber != "21" || len(repo.accepted) != 1 || repo.accepted[0] != "9") {
+        t.Errorf("AcceptInvitation() = %v, accepted by %v", res, repo.accepted)
+      }
+
+      _, err = s.DeclineInvitation(tt.ctx, &v1.InvitationRequest{InvitationId: "4"})
+      if status.Code(err) != tt.code {
+        t.Fatalf("DeclineInvitation() error = %v, want %s", err, tt.code)
+      }
+      if declined := repo.closed["4"] == InvitationDeclined; declined != (tt.code == codes.OK) {
+        t.Errorf("closed = %v", repo.closed)
+      }
+    })
+  }
+}
+
+func TestRevokeInvitation(t *testing.T) {
+  tests := []struct {
+    caller string
+    code   codes.Code
+  }{
+    {"1", codes.OK},
+    {"2", codes.OK},
+    {"3", codes.PermissionDenied},
+    {"42", codes.PermissionDenied},
+  }
+  for _, tt := range tests {
+    s, repo := newInvitationsHandler()
+    _, err := s.RevokeInvitation(as(tt.caller), &v1.InvitationRequest{InvitationId: "4"})
+    if status.Code(err) != tt.code {
+      t.Errorf("RevokeInvitation() by %s error = %v, want %s", tt.caller, err, tt.code)
+    }
+    if revoked := repo.closed["4"] == InvitationRevoked; revoked != (tt.code == codes.OK) {
+      t.Errorf("revoked by %s = %v", tt.caller, revoked)
+    }
+  }
+
+  s, _ := newInvitationsHandler()
+  if _, err := s.RevokeInvitation(as("1"), &v1.InvitationRequest{InvitationId: "5"}); status.Code(err) != codes.NotFound {
+    t.Errorf("RevokeInvitation() of a missing invitation error = %v, want NotFound", err)
+  }
+}
@@ -0,0 +1,227 @@
+package v1
+
+import (
+  "container/list"
+  "context"
+  "reflect"
+  "sync"
+  "time"
+)
+
+// memoryCache is an in-process LRU cache bounded by number of entries.
+// Entries are stored encoded so callers never share a cached object, and
+// expire after their ttl.
+type memoryCache struct {
+  mu      sync.Mutex
+  size    int
+  entries map[string]*list.Element
+  // most recently used entry at the front
+  order *list.List
+  loads loadGroup
+  // now is the clock used for expiry, replaceable for deterministic tests
+  now func() time.Time
+}
+
+type memoryEntry struct {
+  key     string
+  value   []byte
+  expires time.Time
+}
+
+func NewMemoryCache(size int) *memoryCache {
+  return &memoryCache{
+    size:    size,
+    entries: map[string]*list.Element{},
+    order:   list.New(),
+    now:     time.Now,
+  }
+}
+
+func (c *memoryCache) AddEntry(ctx context.Context, key string, v interface{}, ttl time.Duration) error {
+  b, err := marshalEntry(v)
+  if err != nil {
+    return err
+  }
+  c.set(key, b, ttl)
+  return nil
+}
+
+func (c *memoryCache) GetEntry(ctx context.Context, key string, v interface{}) error {
+  b, ok := c.get(key)
+  if !ok {
+    return errCacheMiss
+  }
+  return unmarshalEntry(b, v)
+}
+
+func (c *memoryCache) LoadEntry(ctx context.Context, key string, v interface{}, ttl time.Duration, load func() (interface{}, error)) error {
+  if b, ok := c.get(key); ok {
+    return unmarshalEntry(b, v)
+  }
+
+  b, err := c.loads.do(key, func(call *loadCall) ([]byte, error) {
+    obj, err := load()
+    if err != nil {
+      return nil, err
+    }
+    b, err := marshalEntry(obj)
+    if err != nil {
+      return nil, err
+    }
+    c.setLoaded(call, key, b, ttl)
+    return b, nil
+  })
+  if err != nil {
+    return err
+  }
+  return unmarshalEntry(b, v)
+}
+
+func (c *memoryCache) DeleteEntry(ctx context.Context, keys ...string) error {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  for _, key := range keys {
+    if el, ok := c.entries[key]; ok {
+      c.removeElement(el)
+    }
+  }
+  // loads in flight read the entries before they were deleted
+  c.loads.forget(keys...)
+  return nil
+}
+
+// get returns the live entry for key and marks it as recently used
+func (c *memoryCache) get(key string) ([]byte, bool) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  el, ok := c.entries[key]
+  if !ok {
+    return nil, false
+  }
+  entry := el.Value.(*memoryEntry)
+  if !c.now().Before(entry.expires) {
+    c.removeElement(el)
+    return nil, false
+  }
+  c.order.MoveToFront(el)
+  return entry.value, true
+}
+
+// set stores value under key, evicting the least recently used entries
+// when the cache is full
+func (c *memoryCache) set(key string, value []byte, ttl time.Duration) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+  c.setLocked(key, value, ttl)
+}
+
+// setLoaded stores value loaded by call under key, unless key was deleted
+// while call was in flight and value may predate the delete
+func (c *memoryCache) setLoaded(call *loadCall, key string, value []byte, ttl time.Duration) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+  if c.loads.forgotten(call) {
+    return
+  }
+  c.setLocked(key, value, ttl)
+}
+
+// setLocked is set with c.mu held
+func (c *memoryCache) setLocked(key string, value []byte, ttl time.Duration) {
+  expires := c.now().Add(ttl)
+  if el, ok := c.entries[key]; ok {
+    entry := el.Value.(*memoryEntry)
+    entry.value = value
+    entry.expires = expires
+    c.order.MoveToFront(el)
+    return
+  }
+
+  c.entries[key] = c.order.PushFront(&memoryEntry{
+    key:     key,
+    value:   value,
+    expires: expires,
+  })
+
+  for c.size > 0 && c.order.Len() > c.size {
+    c.removeElement(c.order.Back())
+  }
+}
+
+func (c *memoryCache) removeElement(el *list.Element) {
+  c.order.Remove(el)
+  delete(c.entries, el.Value.(*memoryEntry).key)
+}
+
+// loadGroup makes sure only one load per key is in flight at a time,
+// duplicate callers wait for and share the result of the first
+type loadGroup struct {
+  mu    sync.Mutex
+  calls map[string]*loadCall
+}
+
+type loadCall struct {
+  wg    sync.WaitGroup
+  value []byte
+  err   error
+  // forgotten is set under loadGroup.mu once the key was deleted
+  forgotten bool
+}
+
+// do calls fn once for concurrent callers with key, fn is given its call to
+// check whether it was forgotten
+func (g *loadGroup) do(key string, fn func(call *loadCall) ([]byte, error)) ([]byte, error) {
+  g.mu.Lock()
+  if g.calls == nil {
+    g.calls = map[string]*loadCall{}
+  }
+  if call, ok := g.calls[key]; ok {
+    g.mu.Unlock()
+    call.wg.Wait()
+    return call.value, call.err
+  }
+  call := &loadCall{}
+  call.wg.Add(1)
+  g.calls[key] = call
+  g.mu.Unlock()
+
+  call.value, call.err = fn(call)
+  call.wg.Done()
+
+  g.mu.Lock()
+  // a forgotten call may have been replaced by a new one
+  if g.calls[key] == call {
+    delete(g.calls, key)
+  }
+  g.mu.Unlock()
+
+  return call.value, call.err
+}
+
+// forget marks the calls in flight for keys as forgotten, callers arriving
+// later start a new call rather than share their result
+func (g *loadGroup) forget(keys ...string) {
+  g.mu.Lock()
+  defer g.mu.Unlock()
+
+  for _, key := range keys {
+    if call, ok := g.calls[key]; ok {
+      call.forgotten = true
+      delete(g.calls, key)
+    }
+  }
+}
+
+// forgotten reports whether call was forgotten
+func (g *loadGroup) forgotten(call *loadCall) bool {
+  g.mu.Lock()
+  defer g.mu.Unlock()
+  return call.forgotten
+}
+
+// newEntryLike returns a pointer to a new zero value of the type v points to
+func newEntryLike(v interface{}) interface{} {
+  return reflect.New(reflect.TypeOf(v).Elem()).Interface()
+}
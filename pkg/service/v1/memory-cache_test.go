@@ -0,0 +1,275 @@
+package v1
+
+import (
+  "context"
+  "errors"
+  "sync"
+  "testing"
+  "time"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+// newClockedCache returns a memory cache whose clock only moves when the
+// returned func is called
+func newClockedCache(size int) (*memoryCache, func(time.Duration)) {
+  c := NewMemoryCache(size)
+  now := time.Unix(1600000000, 0)
+  c.now = func() time.Time { return now }
+  return c, func(d time.Duration) { now = now.Add(d) }
+}
+
+func cached(c *memoryCache, key string) bool {
+  var v string
+  return c.GetEntry(context.Background(), key, &v) == nil
+}
+
+func TestMemoryCacheEvictsTheLeastRecentlyUsed(t *testing.T) {
+  c, _ := newClockedCache(2)
+  ctx := context.Background()
+
+  c.AddEntry(ctx, "a", "1", time.Minute)
+  c.AddEntry(ctx, "b", "2", time.Minute)
+  // reading a makes b the least recently used
+  if !cached(c, "a") {
+    t.Fatal("a isn't cached")
+  }
+  c.AddEntry(ctx, "c", "3", time.Minute)
+
+  if cached(c, "b") {
+    t.Error("b wasn't evicted")
+  }
+  if !cached(c, "a") || !cached(c, "c") {
+    t.Error("a and c must stay cached")
+  }
+  // overwriting a key doesn't evict anything
+  c.AddEntry(ctx, "a", "4", time.Minute)
+  if c.order.Len() != 2 || !cached(c, "c") {
+    t.Errorf("overwrite evicted an entry, %d entries left", c.order.Len())
+  }
+  var v string
+  if err := c.GetEntry(ctx, "a", &v); err != nil || v != "4" {
+    t.Errorf("GetEntry(a) = %s, %v, want the new value", v, err)
+  }
+}
+
+func TestMemoryCacheExpiresEntries(t *testing.T) {
+  c, advance := newClockedCache(10)
+  ctx := context.Background()
+
+  c.AddEntry(ctx, "team", "1", time.Minute)
+  advance(59 * time.Second)
+  if !cached(c, "team") {
+    t.Fatal("the entry expired early")
+  }
+  advance(time.Second)
+  if cached(c, "team") {
+    t.Error("the entry outlived its ttl")
+  }
+  if _, ok := c.entries["team"]; ok {
+    t.Error("an expired entry must be removed when read")
+  }
+
+  // setting an entry again restarts its ttl
+  c.AddEntry(ctx, "team", "1", time.Minute)
+  advance(30 * time.Second)
+  c.AddEntry(ctx, "team", "2", time.Minute)
+  advance(45 * time.Second)
+  if !cached(c, "team") {
+    t.Error("the ttl wasn't restarted")
+  }
+}
+
+func TestMemoryCacheCopiesEntries(t *testing.T) {
+  c := NewMemoryCache(10)
+  ctx := context.Background()
+
+  team := &v1.Team{Id: "3", Name: "gophers"}
+  c.AddEntry(ctx, "team", team, time.Minute)
+  team.Name = "changed"
+
+  got := &v1.Team{}
+  if err := c.GetEntry(ctx, "team", got); err != nil || got.Name != "gophers" {
+    t.Errorf("GetEntry() = %v, %v, want the value when it was added", got, err)
+  }
+  if err := c.GetEntry(ctx, "missing", got); err != errCacheMiss {
+    t.Errorf("GetEntry(missing) error = %v, want errCacheMiss", err)
+  }
+}
+
+func TestMemoryCacheLoadEntry(t *testing.T) {
+  c, advance := newClockedCache(10)
+  ctx := context.Background()
+
+  loads := 0
+  load := func() (interface{}, error) {
+    loads++
+    return &v1.Team{Id: "3"}, nil
+  }
+  for i := 0; i < 2; i++ {
+    team := &v1.Team{}
+    if err := c.LoadEntry(ctx, "team", team, time.Minute, load); err != nil || team.Id != "3" {
+      t.Fatalf("LoadEntry() = %v, %v", team, err)
+    }
+  }
+  advance(time.Minute)
+  if err := c.LoadEntry(ctx, "team", &v1.Team{}, time.Minute, load); err != nil {
+    t.Fatal(err)
+  }
+  if loads != 2 {
+    t.Errorf("loaded %d times, want once and once after expiry", loads)
+  }
+
+  // failed loads aren't cached
+  failed := errors.New("lost connection")
+  for i := 0; i < 2; i++ {
+    err := c.LoadEntry(ctx, "other", &v1.Team{}, time.Minute, func() (interface{}, error) { return nil, failed })
+    if err != failed {
+      t.Errorf("LoadEntry() error = %v, want the load error", err)
+    }
+  }
+}
+
+func TestMemoryCacheCollapsesConcurrentLoads(t *testing.T) {
+  c := NewMemoryCache(10)
+  release := make(chan struct{})
+  started := make(chan struct{})
+
+  var mu sync.Mutex
+  loads := 0
+  load := func() (interface{}, error) {
+    mu.Lock()
+    loads++
+    mu.Unlock()
+    close(started)
+    <-release
+    return &v1.Team{Id: "3"}, nil
+  }
+
+  var wg sync.WaitGroup
+  results := make([]*v1.Team, 20)
+  for i := range results {
+    results[i] = &v1.Team{}
+    wg.Add(1)
+    go func(team *v1.Team) {
+      defer wg.Done()
+      if err := c.LoadEntry(context.Background(), "team", team, time.Minute, load); err != nil {
+        t.Error(err)
+      }
+    }(results[i])
+  }
+  // callers arriving while the first load runs wait for it, later ones hit
+  <-started
+  time.Sleep(10 * time.Millisecond)
+  close(release)
+  wg.Wait()
+
+  if loads != 1 {
+    t.Errorf("loaded %d times, want once", loads)
+  }
+  for _, team := range results {
+    if team.Id != "3" {
+      t.Errorf("a caller got %v", team)
+    }
+  }
+}
+
+func TestMemoryCacheDropsLoadsDeletedInFlight(t *testing.T) {
+  c := NewMemoryCache(10)
+  ctx := context.Background()
+  release := make(chan struct{})
+  started := make(chan struct{})
+
+  done := make(chan error)
+  go func() {
+    done <- c.LoadEntry(ctx, "team", &v1.Team{}, time.Minute, func() (interface{}, error) {
+      close(started)
+      <-release
+      return &v1.Team{Id: "3", Name: "stale"}, nil
+    })
+  }()
+  <-started
+  if err := c.DeleteEntry(ctx, "team"); err != nil {
+    t.Fatal(err)
+  }
+
+  // callers arriving after the delete don't share the load in flight
+  team := &v1.Team{}
+  err := c.LoadEntry(ctx, "team", team, time.Minute, func() (interface{}, error) {
+    return &v1.Team{Id: "3", Name: "fresh"}, nil
+  })
+  if err != nil || team.Name != "fresh" {
+    t.Fatalf("LoadEntry() after the delete = %v, %v", team, err)
+  }
+  close(release)
+  if err := <-done; err != nil {
+    t.Fatal(err)
+  }
+
+  // and what the first load read isn't cached over it
+  team = &v1.Team{}
+  if err := c.GetEntry(ctx, "team", team); err != nil || team.Name != "fresh" {
+    t.Errorf("GetEntry() = %v, %v", team, err)
+  }
+}
+
+func TestLoadGroupSharesErrors(t *testing.T) {
+  var g loadGroup
+  release := make(chan struct{})
+  failed := errors.New("lost connection")
+
+  first := make(chan error)
+  go func() {
+    _, err := g.do("team", func(*loadCall) ([]byte, error) {
+      <-release
+      return nil, failed
+    })
+    first <- err
+  }()
+  // wait for the first call to be in flight
+  for {
+    g.mu.Lock()
+    _, ok := g.calls["team"]
+    g.mu.Unlock()
+    if ok {
+      break
+    }
+    time.Sleep(time.Millisecond)
+  }
+
+  second := make(chan error)
+  go func() {
+    _, err := g.do("team", func(*loadCall) ([]byte, error) {
+      t.Error("a duplicate call ran")
+      return nil, nil
+    })
+    second <- err
+  }()
+  time.Sleep(10 * time.Millisecond)
+  close(release)
+
+  if err := <-first; err != failed {
+    t.Errorf("first error = %v", err)
+  }
+  if err := <-second; err != failed {
+    t.Errorf("second error = %v, want the shared error", err)
+  }
+  // the call is forgotten once done
+  if b, err := g.do("team", func(*loadCall) ([]byte, error) { return []byte("ok"), nil }); err != nil || string(b) != "ok" {
+    t.Errorf("do() after the failure = %s, %v", b, err)
+  }
+}
+
+func TestMemoryCacheDeleteEntry(t *testing.T) {
+  c := NewMemoryCache(10)
+  ctx := context.Background()
+
+  c.AddEntry(ctx, "a", "1", time.Minute)
+  c.AddEntry(ctx, "b", "2", time.Minute)
+  if err := c.DeleteEntry(ctx, "a", "missing"); err != nil {
+    t.Fatal(err)
+  }
+  if cached(c, "a") || !cached(c, "b") {
+    t.Error("only a must be deleted")
+  }
+}
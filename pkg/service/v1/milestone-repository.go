@@ -0,0 +1,476 @@
+package v1
+
+import (
+  "context"
+  "database/sql"
+  "strconv"
+  "time"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// statuses of milestones and tasks
+const (
+  WorkTodo       = "todo"
+  WorkInProgress = "in_progress"
+  WorkDone       = "done"
+)
+
+const milestoneColumns = `id, project_id, title, assignee, due_at, status, position, created_at, completed_at`
+
+// Creates a milestone of project projectId of team teamId. It is placed at
+// milestone.Position, after the other milestones when 0.
+// output ON SUCCESS: *v1.Milestone - the created milestone, int64 - new version of the team, error - nil
+// output ON FAILURE: TEAM_NOT_FOUND, VERSION_MISMATCH, PROJECT_NOT_FOUND, PROJECT_ARCHIVED, MEMBER_NOT_FOUND or the error object from whatever created the error
+func (r *teamRepository) CreateMilestone(ctx context.Context, teamId, projectId string, milestone *v1.Milestone, expected int64) (*v1.Milestone, int64, error) {
+  insertStmt := `INSERT INTO milestones (team_id, project_id, title, assignee, due_at, status, position, created_at, completed_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return nil, -1, err
+  }
+  defer tx.Rollback()
+
+  // lock the team, positions are only changed under its lock
+  version, err := nextVersion(ctx, tx, teamId, expected)
+  if err != nil {
+    return nil, -1, err
+  }
+  if err := lockOpenProject(ctx, tx, teamId, projectId); err != nil {
+    return nil, -1, err
+  }
+  if err := checkAssignee(ctx, tx, teamId, milestone.Assignee); err != nil {
+    return nil, -1, err
+  }
+
+  now := time.Now().Unix()
+  status := milestone.Status
+  if status == "" {
+    status = WorkTodo
+  }
+  var completedAt int64
+  if status == WorkDone {
+    completedAt = now
+  }
+  position, err := insertPosition(ctx, tx, "milestones", projectId, milestone.Position)
+  if err != nil {
+    return nil, -1, err
+  }
+
+  result, err := tx.ExecContext(ctx, insertStmt, teamId, projectId, milestone.Title, nullId(milestone.Assignee), nullTime(milestone.DueAt), status, position, now, nullTime(completedAt))
+  if err != nil {
+    return nil, -1, err
+  }
+  id, err := result.LastInsertId()
+  if err != nil {
+    return nil, -1, err
+  }
+
+  created, err := readMilestone(ctx, tx, projectId, strconv.FormatInt(id, 10), ``)
+  if err != nil {
+    return nil, -1, err
+  }
+
+  // record milestone_created event in the outbox
+  err = insertOutboxEvent(tx, MilestoneCreatedTopic, &v1.MilestoneCreated{
+    TeamId:     teamId,
+    Milestone:  created,
+    OccurredAt: now,
+  })
+  if err != nil {
+    return nil, -1, err
+  }
+  if err := insertAuditEvent(ctx, tx, teamId, AuditCreateMilestone, nil, milestoneState(created)); err != nil {
+    return nil, -1, err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return nil, -1, err
+  }
+  return created, version, nil
+}
+
+// Updates the fields of milestone milestoneId listed in paths with the
+// values of patch. Moving it to another position shifts the milestones in
+// between.
+// output ON SUCCESS: *v1.Milestone - the updated milestone, int64 - new version of the team, error - nil
+// output ON FAILURE: TEAM_NOT_FOUND, VERSION_MISMATCH, PROJECT_NOT_FOUND, PROJECT_ARCHIVED, MILESTONE_NOT_FOUND, MEMBER_NOT_FOUND or the error object from whatever created the error
+func (r *teamRepository) UpdateMilestone(ctx context.Context, teamId, projectId, milestoneId string, patch *v1.Milestone, paths []string, expected int64) (*v1.Milestone, int64, error) {
+  updateStmt := `UPDATE milestones SET title=?, assignee=?, due_at=?, status=?, position=?, completed_at=? WHERE id=?`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return nil, -1, err
+  }
+  defer tx.Rollback()
+
+  version, err := nextVersion(ctx, tx, teamId, expected)
+  if err != nil {
+    return nil, -1, err
+  }
+  if err := lockOpenProject(ctx, tx, teamId, projectId); err != nil {
+    return nil, -1, err
+  }
+  milestone, err := readMilestone(ctx, tx, projectId, milestoneId, ` FOR UPDATE`)
+  if err != nil {
+    return nil, -1, err
+  }
+  before := milestoneState(milestone)
+
+  masked := map[string]bool{}
+  for _, path := range paths {
+    masked[path] = true
+  }
+  if masked["title"] {
+    milestone.Title = patch.Title
+  }
+  if masked["assignee"] {
+    if err := checkAssignee(ctx, tx, teamId, patch.Assignee); err != nil {
+      return nil, -1, err
+    }
+    milestone.Assignee = patch.Assignee
+  }
+  if masked["due_at"] {
+    milestone.DueAt = patch.DueAt
+  }
+  if masked["status"] {
+    milestone.CompletedAt = completedAt(milestone.Status, patch.Status, milestone.CompletedAt)
+    milestone.Status = patch.Status
+  }
+  if masked["position"] {
+    milestone.Position, err = movePosition(ctx, tx, "milestones", projectId, milestone.Position, patch.Position)
+    if err != nil {
+      return nil, -1, err
+    }
+  }
+
+  _, err = tx.ExecContext(ctx, updateStmt, milestone.Title, nullId(milestone.Assignee), nullTime(milestone.DueAt), milestone.Status, milestone.Position, nullTime(milestone.CompletedAt), milestoneId)
+  if err != nil {
+    return nil, -1, err
+  }
+
+  updated, err := readMilestone(ctx, tx, projectId, milestoneId, ``)
+  if err != nil {
+    return nil, -1, err
+  }
+
+  // record milestone_updated event in the outbox
+  err = insertOutboxEvent(tx, MilestoneUpdatedTopic, &v1.MilestoneUpdated{
+    TeamId:     teamId,
+    Paths:      paths,
+    Milestone:  updated,
+    OccurredAt: time.Now().Unix(),
+  })
+  if err != nil {
+    return nil, -1, err
+  }
+  if err := insertAuditEvent(ctx, tx, teamId, AuditUpdateMilestone, before, milestoneState(updated)); err != nil {
+    return nil, -1, err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return nil, -1, err
+  }
+  return updated, version, nil
+}
+
+// Deletes milestone milestoneId, its tasks stay on the project without a
+// milestone
+// output ON SUCCESS: *v1.Milestone - the deleted milestone, int64 - new version of the team, error - nil
+// output ON FAILURE: TEAM_NOT_FOUND, VERSION_MISMATCH, PROJECT_NOT_FOUND, PROJECT_ARCHIVED, MILESTONE_NOT_FOUND or the error object from whatever created the error
+func (r *teamRepository) DeleteMilestone(ctx context.Context, teamId, projectId, milestoneId string, expected int64) (*v1.Milestone, int64, error) {
+  tasksStmt := `UPDATE tasks SET milestone_id=NULL WHERE milestone_id=?`
+  deleteStmt := `DELETE FROM milestones WHERE id=?`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return nil, -1, err
+  }
+  defer tx.Rollback()
+
+  version, err := nextVersion(ctx, tx, teamId, expected)
+  if err != nil {
+    return nil, -1, err
+  }
+  if err := lockOpenProject(ctx, tx, teamId, projectId); err != nil {
+    return nil, -1, err
+  }
+  milestone, err := readMilestone(ctx, tx, projectId, milestoneId, ` FOR UPDATE`)
+  if err != nil {
+    return nil, -1, err
+  }
+
+  if _, err := tx.ExecContext(ctx, tasksStmt, milestoneId); err != nil {
+    return nil, -1, err
+  }
+  if _, err := tx.ExecContext(ctx, deleteStmt, milestoneId); err != nil {
+    return nil, -1, err
+  }
+  if err := closePosition(ctx, tx, "milestones", projectId, milestone.Position); err != nil {
+    return nil, -1, err
+  }
+
+  // record milestone_deleted event in the outbox
+  err = insertOutboxEvent(tx, MilestoneDeletedTopic, &v1.MilestoneDeleted{
+    TeamId:      teamId,
+    ProjectId:   projectId,
+    MilestoneId: milestoneId,
+    OccurredAt:  time.Now().Unix(),
+  })
+  if err != nil {
+    return nil, -1, err
+  }
+  if err := insertAuditEvent(ctx, tx, teamId, AuditDeleteMilestone, milestoneState(milestone), nil); err != nil {
+    return nil, -1, err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return nil, -1, err
+  }
+  return milestone, version, nil
+}
+
+// Lists the milestones of project projectId of team teamId in position order
+// output ON FAILURE: TEAM_NOT_FOUND, PROJECT_NOT_FOUND or the error object from whatever created the error
+func (r *teamRepository) ListMilestones(ctx context.Context, teamId, projectId string) ([]*v1.Milestone, error) {
+  selectStmt := `SELECT ` + milestoneColumns + ` FROM milestones WHERE project_id=? ORDER BY position, id`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+  if err != nil {
+    return nil, err
+  }
+  defer tx.Rollback()
+
+  if err := checkTeamExists(ctx, tx, teamId); err != nil {
+    return nil, err
+  }
+  if _, err := loadProject(ctx, tx, teamId, projectId); err != nil {
+    return nil, err
+  }
+
+  milestones := []*v1.Milestone{}
+  byId := map[int64]*v1.Milestone{}
+  err = queryRows(ctx, tx, selectStmt, []interface{}{projectId}, func(rows *sql.Rows) error {
+    milestone, err := scanMilestone(rows)
+    if err != nil {
+      return err
+    }
+    id, _ := strconv.ParseInt(milestone.Id, 10, 64)
+    milestones = append(milestones, milestone)
+    byId[id] = milestone
+    return nil
+  })
+  if err != nil {
+    return nil, err
+  }
+  if err := setMilestoneProgress(ctx, tx, byId); err != nil {
+    return nil, err
+  }
+  return milestones, nil
+}
+
+// ---------------------------- HELPER FUNCTIONS -------------------------------
+
+// scanMilestone scans a row of milestoneColumns
+func scanMilestone(row interface{ Scan(...interface{}) error }) (*v1.Milestone, error) {
+  milestone := &v1.Milestone{}
+  var id, projectId int64
+  var assignee sql.NullString
+  var dueAt, completedAt sql.NullInt64
+  err := row.Scan(&id, &projectId, &milestone.Title, &assignee, &dueAt, &milestone.Status, &milestone.Position, &milestone.CreatedAt, &completedAt)
+  if err != nil {
+    return nil, err
+  }
+  milestone.Id = strconv.FormatInt(id, 10)
+  milestone.ProjectId = strconv.FormatInt(projectId, 10)
+  milestone.Assignee = assignee.String
+  milestone.DueAt = dueAt.Int64
+  milestone.CompletedAt = completedAt.Int64
+  return milestone, nil
+}
+
+// readMilestone reads milestone milestoneId of project projectId within tx,
+// its progress included, lock is appended to the query
+func readMilestone(ctx context.Context, tx *sql.Tx, projectId, milestoneId, lock string) (*v1.Milestone, error) {
+  selectStmt := `SELECT ` + milestoneColumns + ` FROM milestones WHERE id=? AND project_id=?` + lock
+
+  milestone, err := scanMilestone(tx.QueryRowContext(ctx, selectStmt, milestoneId, projectId))
+  if err == sql.ErrNoRows {
+    return nil, milestoneNotFound(projectId, milestoneId)
+  } else if err != nil {
+    return nil, err
+  }
+  id, _ := strconv.ParseInt(milestoneId, 10, 64)
+  if err := setMilestoneProgress(ctx, tx, map[int64]*v1.Milestone{id: milestone}); err != nil {
+    return nil, err
+  }
+  return milestone, nil
+}
+
+// checkAssignee fails unless assignee is empty or the member number of a
+// member of team teamId
+func checkAssignee(ctx context.Context, tx *sql.Tx, teamId, assignee string) error {
+  memberStmt := `SELECT COUNT(*) FROM members WHERE team_id=? AND id=?`
+
+  if assignee == "" {
+    return nil
+  }
+  var count int
+  if err := tx.QueryRowContext(ctx, memberStmt, teamId, assignee).Scan(&count); err != nil {
+    return err
+  }
+  if count == 0 {
+    return domainerr.NotFound(domainerr.ReasonMemberNotFound, "member '%s' is not on team '%s'", assignee, teamId).
+      With("team_id", teamId).
+      With("member_number", assignee)
+  }
+  return nil
+}
+
+// completedAt returns the completion time of a milestone or task moving
+// from status from to status to that completed at completed: now when it
+// becomes done, 0 when it is reopened
+func completedAt(from, to string, completed int64) int64 {
+  if to != WorkDone {
+    return 0
+  }
+  if from != WorkDone {
+    return time.Now().Unix()
+  }
+  return completed
+}
+
+// insertPosition makes room at position among the rows of table belonging
+// to project projectId and returns it, positions past the end or 0 append.
+// table is a constant of the caller, never user input.
+func insertPosition(ctx context.Context, tx *sql.Tx, table, projectId string, position int32) (int32, error) {
+  var count int32
+  if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+table+` WHERE project_id=?`, projectId).Scan(&count); err != nil {
+    return 0, err
+  }
+  if position <= 0 || position > count {
+    return count + 1, nil
+  }
+  _, err := tx.ExecContext(ctx, `UPDATE `+table+` SET position = position + 1 WHERE project_id=? AND position >= ?`, projectId, position)
+  return position, err
+}
+
+// movePosition shifts the rows between positions from and to so a row can
+// move from one to the other and returns the position it moves to, the last
+// one when to is past the end or 0
+func movePosition(ctx context.Context, tx *sql.Tx, table, projectId string, from, to int32) (int32, error) {
+  var count int32
+  if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+table+` WHERE project_id=?`, projectId).Scan(&count); err != nil {
+    return 0, err
+  }
+  if to <= 0 || to > count {
+    to = count
+  }
+
+  var err error
+  if to < from {
+    _, err = tx.ExecContext(ctx, `UPDATE `+table+` SET position = position + 1 WHERE project_id=? AND position >= ? AND position < ?`, projectId, to, from)
+  } else if to > from {
+    _, err = tx.ExecContext(ctx, `UPDATE `+table+` SET position = position - 1 WHERE project_id=? AND position > ? AND position <= ?`, projectId, from, to)
+  }
+  return to, err
+}
+
+// closePosition closes the gap a row removed from position leaves
+func closePosition(ctx context.Context, tx *sql.Tx, table, projectId string, position int32) error {
+  _, err := tx.ExecContext(ctx, `UPDATE `+table+` SET position = position - 1 WHERE project_id=? AND position > ?`, projectId, position)
+  return err
+}
+
+// progressBy returns the percentage of done rows of table grouped by
+// column, for the groups in ids that have rows
+func progressBy(ctx context.Context, tx *sql.Tx, table, column string, ids []int64) (map[int64]int32, error) {
+  progress := map[int64]int32{}
+  if len(ids) == 0 {
+    return progress, nil
+  }
+
+  in, args := inList(ids)
+  selectStmt := `SELECT ` + column + `, COUNT(*), SUM(CASE WHEN status=? THEN 1 ELSE 0 END) FROM ` + table + ` WHERE ` + column + ` IN ` + in + ` GROUP BY ` + column
+  err := queryRows(ctx, tx, selectStmt, append([]interface{}{WorkDone}, args...), func(rows *sql.Rows) error {
+    var id, total, done int64
+    if err := rows.Scan(&id, &total, &done); err != nil {
+      return err
+    }
+    progress[id] = int32(done * 100 / total)
+    return nil
+  })
+  if err != nil {
+    return nil, err
+  }
+  return progress, nil
+}
+
+// setProjectProgress sets the progress of projects, keyed by id, from their
+// tasks or from their milestones when they have no tasks
+func setProjectProgress(ctx context.Context, tx *sql.Tx, projects map[int64]*v1.Project) error {
+  ids := make([]int64, 0, len(projects))
+  for id := range projects {
+    ids = append(ids, id)
+  }
+
+  tasks, err := progressBy(ctx, tx, "tasks", "project_id", ids)
+  if err != nil {
+    return err
+  }
+  milestones, err := progressBy(ctx, tx, "milestones", "project_id", ids)
+  if err != nil {
+    return err
+  }
+  for id, project := range projects {
+    if progress, ok := tasks[id]; ok {
+      project.Progress = progress
+    } else {
+      project.Progress = milestones[id]
+    }
+  }
+  return nil
+}
+
+// setMilestoneProgress sets the progress of milestones, keyed by id, from
+// their tasks
+func setMilestoneProgress(ctx context.Context, tx *sql.Tx, milestones map[int64]*v1.Milestone) error {
+  ids := make([]int64, 0, len(milestones))
+  for id := range milestones {
+    ids = append(ids, id)
+  }
+
+  progress, err := progressBy(ctx, tx, "tasks", "milestone_id", ids)
+  if err != nil {
+    return err
+  }
+  for id, milestone := range milestones {
+    milestone.Progress = progress[id]
+  }
+  return nil
+}
+
+// milestoneState is the audited state of a milestone
+func milestoneState(milestone *v1.Milestone) auditState {
+  return auditState{
+    "milestone_id": milestone.Id,
+    "project_id":   milestone.ProjectId,
+    "title":        milestone.Title,
+    "assignee":     milestone.Assignee,
+    "due_at":       milestone.DueAt,
+    "status":       milestone.Status,
+    "position":     milestone.Position,
+  }
+}
+
+// nullId stores the id s, NULL when it is empty
+func nullId(s string) sql.NullString {
+  return sql.NullString{String: s, Valid: s != ""}
+}
+
+func milestoneNotFound(projectId, milestoneId string) error {
+  return domainerr.NotFound(domainerr.ReasonMilestoneNotFound, "milestone '%s' not found on project '%s'", milestoneId, projectId).
+    With("project_id", projectId).
+    With("milestone_id", milestoneId)
+}
@@ -0,0 +1,199 @@
+package v1
+
+import (
+  "context"
+  "testing"
+  "time"
+
+  "github.com/DATA-DOG/go-sqlmock"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// progressRows returns the total and done rows of the groups, id followed
+// by total and done for each
+func progressRows(groups ...int64) *sqlmock.Rows {
+  rows := sqlmock.NewRows([]string{"id", "total", "done"})
+  for i := 0; i+2 < len(groups); i += 3 {
+    rows.AddRow(groups[i], groups[i+1], groups[i+2])
+  }
+  return rows
+}
+
+// milestoneRow is milestone 8 of project 5 at position 2 in status
+func milestoneRow(status string) *sqlmock.Rows {
+  return sqlmock.NewRows([]string{"id", "project_id", "title", "assignee", "due_at", "status", "position", "created_at", "completed_at"}).
+    AddRow(8, 5, "beta", nil, nil, status, 2, 1600000000, nil)
+}
+
+func TestSetProjectProgress(t *testing.T) {
+  repo, mock := newMockRepository(t)
+  projects := map[int64]*v1.Project{5: {}, 6: {}, 7: {}}
+
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`SELECT project_id, COUNT(*), SUM(CASE WHEN status=? THEN 1 ELSE 0 END) FROM tasks WHERE project_id IN (?, ?, ?) GROUP BY project_id`)).
+    WillReturnRows(progressRows(5, 4, 1))
+  mock.ExpectQuery(stmt(`FROM milestones WHERE project_id IN (?, ?, ?)`)).
+    WillReturnRows(progressRows(5, 2, 2, 6, 3, 2))
+  mock.ExpectCommit()
+
+  tx, _ := repo.db.Begin()
+  if err := setProjectProgress(context.Background(), tx, projects); err != nil {
+    t.Fatal(err)
+  }
+  tx.Commit()
+
+  // tasks win over milestones, a project without either has made none
+  if projects[5].Progress != 25 || projects[6].Progress != 66 || projects[7].Progress != 0 {
+    t.Errorf("progress = %d, %d, %d", projects[5].Progress, projects[6].Progress, projects[7].Progress)
+  }
+}
+
+func TestProgressByNothing(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  mock.ExpectCommit()
+  tx, _ := repo.db.Begin()
+  if progress, err := progressBy(context.Background(), tx, "tasks", "project_id", nil); err != nil || len(progress) != 0 {
+    t.Errorf("progressBy() = %v, %v", progress, err)
+  }
+  tx.Commit()
+}
+
+func TestCompletedAt(t *testing.T) {
+  if completedAt(WorkInProgress, WorkDone, 0) < time.Now().Unix()-1 {
+    t.Error("finishing doesn't complete now")
+  }
+  if got := completedAt(WorkDone, WorkDone, 1600000000); got != 1600000000 {
+    t.Errorf("staying done completed at %d", got)
+  }
+  if got := completedAt(WorkDone, WorkTodo, 1600000000); got != 0 {
+    t.Errorf("reopening completed at %d", got)
+  }
+}
+
+func TestPositions(t *testing.T) {
+  repo, mock := newMockRepository(t)
+  ctx := context.Background()
+  count := func(n int) {
+    mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM milestones WHERE project_id=?`)).WithArgs("5").WillReturnRows(countRow(n))
+  }
+
+  mock.ExpectBegin()
+  // 0 and past the end append
+  count(3)
+  count(3)
+  // inside makes room
+  count(3)
+  mock.ExpectExec(stmt(`UPDATE milestones SET position = position + 1 WHERE project_id=? AND position >= ?`)).WithArgs("5", int32(2)).
+    WillReturnResult(sqlmock.NewResult(0, 2))
+  // moving up shifts the rows in between down and the other way around
+  count(3)
+  mock.ExpectExec(stmt(`SET position = position + 1 WHERE project_id=? AND position >= ? AND position < ?`)).WithArgs("5", int32(1), int32(3)).
+    WillReturnResult(sqlmock.NewResult(0, 2))
+  count(3)
+  mock.ExpectExec(stmt(`SET position = position - 1 WHERE project_id=? AND position > ? AND position <= ?`)).WithArgs("5", int32(1), int32(3)).
+    WillReturnResult(sqlmock.NewResult(0, 2))
+  mock.ExpectExec(stmt(`SET position = position - 1 WHERE project_id=? AND position > ?`)).WithArgs("5", int32(2)).
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectCommit()
+
+  tx, _ := repo.db.Begin()
+  for _, position := range []int32{0, 9} {
+    if got, err := insertPosition(ctx, tx, "milestones", "5", position); err != nil || got != 4 {
+      t.Errorf("insertPosition(%d) = %d, %v, want 4", position, got, err)
+    }
+  }
+  if got, err := insertPosition(ctx, tx, "milestones", "5", 2); err != nil || got != 2 {
+    t.Errorf("insertPosition(2) = %d, %v", got, err)
+  }
+  if got, err := movePosition(ctx, tx, "milestones", "5", 3, 1); err != nil || got != 1 {
+    t.Errorf("movePosition(3, 1) = %d, %v", got, err)
+  }
+  if got, err := movePosition(ctx, tx, "milestones", "5", 1, 0); err != nil || got != 3 {
+    t.Errorf("movePosition(1, 0) = %d, %v, want the last position", got, err)
+  }
+  if err := closePosition(ctx, tx, "milestones", "5", 2); err != nil {
+    t.Error(err)
+  }
+  tx.Commit()
+}
+
+func TestCreateMilestone(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 1)
+  expectLockProject(mock, ProjectActive, 0)
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM members WHERE team_id=? AND id=?`)).WithArgs("3", "21").WillReturnRows(countRow(1))
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM milestones`)).WithArgs("5").WillReturnRows(countRow(1))
+  mock.ExpectExec(stmt(`INSERT INTO milestones`)).
+    WithArgs("3", "5", "beta", "21", nil, WorkTodo, int32(2), sqlmock.AnyArg(), nil).
+    WillReturnResult(sqlmock.NewResult(8, 1))
+  mock.ExpectQuery(stmt(`FROM milestones WHERE id=? AND project_id=?`)).WithArgs("8", "5").WillReturnRows(milestoneRow(WorkTodo))
+  mock.ExpectQuery(stmt(`FROM tasks WHERE milestone_id IN (?)`)).WillReturnRows(progressRows(8, 2, 1))
+  expectOutbox(mock, MilestoneCreatedTopic)
+  expectAudit(mock, "3", AuditCreateMilestone)
+  mock.ExpectCommit()
+
+  milestone, version, err := repo.CreateMilestone(context.Background(), "3", "5", &v1.Milestone{Title: "beta", Assignee: "21"}, 0)
+  if err != nil || version != 2 {
+    t.Fatalf("CreateMilestone() = %v, %d, %v", milestone, version, err)
+  }
+  if milestone.Id != "8" || milestone.Position != 2 || milestone.Progress != 50 {
+    t.Errorf("milestone = %v", milestone)
+  }
+}
+
+func TestCreateMilestoneChecks(t *testing.T) {
+  tests := []struct {
+    name   string
+    expect func(mock sqlmock.Sqlmock)
+    reason string
+  }{
+    {"archived project", func(mock sqlmock.Sqlmock) {
+      expectLockProject(mock, ProjectCompleted, 1600000000)
+    }, domainerr.ReasonProjectArchived},
+    // assignees are members of the team
+    {"assignee elsewhere", func(mock sqlmock.Sqlmock) {
+      expectLockProject(mock, ProjectActive, 0)
+      mock.ExpectQuery(stmt(`FROM members WHERE team_id=? AND id=?`)).WithArgs("3", "21").WillReturnRows(countRow(0))
+    }, domainerr.ReasonMemberNotFound},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      repo, mock := newMockRepository(t)
+      mock.ExpectBegin()
+      expectNextVersion(mock, "3", 1)
+      tt.expect(mock)
+      mock.ExpectRollback()
+
+      _, _, err := repo.CreateMilestone(context.Background(), "3", "5", &v1.Milestone{Title: "beta", Assignee: "21"}, 0)
+      if domainerr.ReasonOf(err) != tt.reason {
+        t.Errorf("CreateMilestone() error = %v, want %s", err, tt.reason)
+      }
+    })
+  }
+}
+
+func TestDeleteMilestoneKeepsItsTasks(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 1)
+  expectLockProject(mock, ProjectActive, 0)
+  mock.ExpectQuery(stmt(`FROM milestones WHERE id=? AND project_id=? FOR UPDATE`)).WithArgs("8", "5").WillReturnRows(milestoneRow(WorkInProgress))
+  mock.ExpectQuery(stmt(`FROM tasks WHERE milestone_id IN (?)`)).WillReturnRows(progressRows())
+  mock.ExpectExec(stmt(`UPDATE tasks SET milestone_id=NULL WHERE milestone_id=?`)).WithArgs("8").WillReturnResult(sqlmock.NewResult(0, 2))
+  mock.ExpectExec(stmt(`DELETE FROM milestones WHERE id=?`)).WithArgs("8").WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`UPDATE milestones SET position = position - 1`)).WithArgs("5", int32(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+  expectOutbox(mock, MilestoneDeletedTopic)
+  expectAudit(mock, "3", AuditDeleteMilestone)
+  mock.ExpectCommit()
+
+  if _, _, err := repo.DeleteMilestone(context.Background(), "3", "5", "8", 0); err != nil {
+    t.Fatal(err)
+  }
+}
@@ -0,0 +1,127 @@
+package v1
+
+import (
+  "context"
+  "fmt"
+  "os"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+func (s *handler) CreateMilestone(ctx context.Context, req *v1.CreateMilestoneRequest) (*v1.MilestoneResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  // milestones are managed by every member of the team
+  if _, _, err := s.authorize(ctx, req.TeamId, ActionManageTasks); err != nil {
+    return nil, err
+  }
+
+  expected, err := expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
+  }
+
+  milestone, version, err := s.repo.CreateMilestone(ctx, req.TeamId, req.ProjectId, req.Milestone, expected)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo CreateMilestone: %v\n", req.ProjectId)
+    return nil, err
+  }
+
+  // milestone_created Event is written to the outbox by the repository
+
+  return &v1.MilestoneResponse{
+    Api:       apiVersion,
+    Status:    "Created",
+    Milestone: milestone,
+    Version:   version,
+  }, nil
+}
+
+func (s *handler) UpdateMilestone(ctx context.Context, req *v1.UpdateMilestoneRequest) (*v1.MilestoneResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  if _, _, err := s.authorize(ctx, req.TeamId, ActionManageTasks); err != nil {
+    return nil, err
+  }
+
+  expected, err := expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
+  }
+
+  milestone, version, err := s.repo.UpdateMilestone(ctx, req.TeamId, req.ProjectId, req.MilestoneId, req.Milestone, req.GetUpdateMask().GetPaths(), expected)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo UpdateMilestone: %v\n", req.MilestoneId)
+    return nil, err
+  }
+
+  // milestone_updated Event is written to the outbox by the repository
+
+  return &v1.MilestoneResponse{
+    Api:       apiVersion,
+    Status:    "Updated",
+    Milestone: milestone,
+    Version:   version,
+  }, nil
+}
+
+func (s *handler) DeleteMilestone(ctx context.Context, req *v1.DeleteMilestoneRequest) (*v1.MilestoneResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  if _, _, err := s.authorize(ctx, req.TeamId, ActionManageTasks); err != nil {
+    return nil, err
+  }
+
+  expected, err := expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
+  }
+
+  milestone, version, err := s.repo.DeleteMilestone(ctx, req.TeamId, req.ProjectId, req.MilestoneId, expected)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo DeleteMilestone: %v\n", req.MilestoneId)
+    return nil, err
+  }
+
+  // milestone_deleted Event is written to the outbox by the repository
+
+  return &v1.MilestoneResponse{
+    Api:       apiVersion,
+    Status:    "Deleted",
+    Milestone: milestone,
+    Version:   version,
+  }, nil
+}
+
+func (s *handler) ListMilestones(ctx context.Context, req *v1.ListMilestonesRequest) (*v1.ListMilestonesResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  // milestones are public like the project they belong to
+  milestones, err := s.repo.ListMilestones(ctx, req.TeamId, req.ProjectId)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo ListMilestones: %v\n", req.ProjectId)
+    return nil, err
+  }
+
+  status := "milestones"
+  if len(milestones) == 0 {
+    status = "empty"
+  }
+  return &v1.ListMilestonesResponse{
+    Api:        apiVersion,
+    Status:     status,
+    Milestones: milestones,
+  }, nil
+}
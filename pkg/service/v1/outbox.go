@@ -0,0 +1,194 @@
+package v1
+
+import (
+  "context"
+  "database/sql"
+  "fmt"
+  "os"
+  "time"
+
+  "github.com/ThreeDotsLabs/watermill"
+  "github.com/ThreeDotsLabs/watermill/message"
+  "github.com/golang/protobuf/proto"
+)
+
+const (
+  // how often the relay polls the outbox table when it is drained
+  outboxPollInterval = time.Second
+  // how many events the relay publishes per transaction
+  outboxBatchSize = 100
+  // first retry delay, doubled on every failed attempt up to outboxMaxBackoff
+  outboxBaseBackoff = time.Second
+  outboxMaxBackoff  = 5 * time.Minute
+  // published events are kept this long before being purged
+  outboxRetention = 24 * time.Hour
+)
+
+// insertOutboxEvent stores event in the outbox table as part of tx so it is
+// only published if the surrounding domain change commits.
+// input: tx-the open transaction of the mutation, topic-the event name, event-the protobuf payload
+// output ON SUCCESS: error - nil
+// output ON FAILURE: error - the error from marshalling or inserting the event
+func insertOutboxEvent(tx *sql.Tx, topic string, event proto.Message) error {
+  outboxStmt := `INSERT INTO outbox (event_id, topic, payload, created_at, next_attempt_at) VALUES (?, ?, ?, ?, ?)`
+
+  payload, err := proto.Marshal(event)
+  if err != nil {
+    return err
+  }
+
+  created := time.Now().Unix()
+  _, err = tx.Exec(outboxStmt, watermill.NewUUID(), topic, payload, created, created)
+  return err
+}
+
+// OutboxRelay drains the outbox table to a watermill publisher.
+// Delivery is at-least-once: an event that was published but whose row could
+// not be marked as published is sent again, so consumers dedupe on the
+// message uuid which is the event_id of the row.
+type OutboxRelay struct {
+  db        *sql.DB
+  publisher message.Publisher
+}
+
+func NewOutboxRelay(db *sql.DB, publisher message.Publisher) *OutboxRelay {
+  return &OutboxRelay{
+    db:        db,
+    publisher: publisher,
+  }
+}
+
+// Run relays events until ctx is cancelled.
+func (o *OutboxRelay) Run(ctx context.Context) {
+  ticker := time.NewTicker(outboxPollInterval)
+  defer ticker.Stop()
+
+  for {
+    // keep relaying while full batches come back, the outbox has a backlog
+    for {
+      count, err := o.relayBatch(ctx)
+      if err != nil {
+        fmt.Fprintf(os.Stderr, "error relaying outbox: %v\n", err)
+        break
+      }
+      if count < outboxBatchSize {
+        break
+      }
+    }
+
+    if err := o.purgePublished(ctx); err != nil {
+      fmt.Fprintf(os.Stderr, "error purging outbox: %v\n", err)
+    }
+
+    select {
+    case <-ctx.Done():
+      return
+    case <-ticker.C:
+    }
+  }
+}
+
+// relayBatch publishes the next batch of due events.
+// Rows are locked with SKIP LOCKED so several replicas can run a relay without
+// publishing the same row concurrently.
+// output ON SUCCESS: int - number of events attempted, error - nil
+// output ON FAILURE: int - 0, error - the error object from whatever created the error
+func (o *OutboxRelay) relayBatch(ctx context.Context) (int, error) {
+  selectStmt := `SELECT id, event_id, topic, payload, attempts FROM outbox
+    WHERE published_at IS NULL AND next_attempt_at <= ?
+    ORDER BY id ASC LIMIT ? FOR UPDATE SKIP LOCKED`
+  publishedStmt := `UPDATE outbox SET published_at=?, attempts=attempts+1, last_error=NULL WHERE id=?`
+  failedStmt := `UPDATE outbox SET attempts=attempts+1, next_attempt_at=?, last_error=? WHERE id=?`
+
+  // start transaction
+  tx, err := o.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return 0, err
+  }
+
+  rows, err := tx.Query(selectStmt, time.Now().Unix(), outboxBatchSize)
+  if err != nil {
+    tx.Rollback()
+    return 0, err
+  }
+
+  type outboxRow struct {
+    id       int64
+    eventId  string
+    topic    string
+    payload  []byte
+    attempts int
+  }
+
+  // scan every due row before publishing, the connection is busy until rows is closed
+  pending := []outboxRow{}
+  for rows.Next() {
+    row := outboxRow{}
+    err = rows.Scan(&row.id, &row.eventId, &row.topic, &row.payload, &row.attempts)
+    if err != nil {
+      rows.Close()
+      tx.Rollback()
+      return 0, err
+    }
+    pending = append(pending, row)
+  }
+  rows.Close()
+
+  if err = rows.Err(); err != nil {
+    tx.Rollback()
+    return 0, err
+  }
+
+  for _, row := range pending {
+    msg := newEventMessage(row.eventId, row.topic, row.payload)
+    msg.SetContext(ctx)
+
+    if pubErr := o.publisher.Publish(row.topic, msg); pubErr != nil {
+      // schedule a retry with exponential backoff
+      retryAt := time.Now().Add(outboxBackoff(row.attempts + 1)).Unix()
+      _, err = tx.Exec(failedStmt, retryAt, truncate(pubErr.Error(), 255), row.id)
+    } else {
+      _, err = tx.Exec(publishedStmt, time.Now().Unix(), row.id)
+    }
+    if err != nil {
+      tx.Rollback()
+      return 0, err
+    }
+  }
+
+  // commit transaction
+  err = tx.Commit()
+  if err != nil {
+    return 0, err
+  }
+
+  return len(pending), nil
+}
+
+// purgePublished removes events that were published longer than outboxRetention ago
+func (o *OutboxRelay) purgePublished(ctx context.Context) error {
+  purgeStmt := `DELETE FROM outbox WHERE published_at IS NOT NULL AND published_at < ? LIMIT 1000`
+
+  _, err := o.db.ExecContext(ctx, purgeStmt, time.Now().Add(-outboxRetention).Unix())
+  return err
+}
+
+// outboxBackoff returns how long to wait before the given attempt
+func outboxBackoff(attempt int) time.Duration {
+  backoff := outboxBaseBackoff
+  for i := 1; i < attempt; i++ {
+    backoff *= 2
+    if backoff >= outboxMaxBackoff {
+      return outboxMaxBackoff
+    }
+  }
+  return backoff
+}
+
+// truncate shortens s to at most n bytes so it fits its column
+func truncate(s string, n int) string {
+  if len(s) > n {
+    return s[:n]
+  }
+  return s
+}
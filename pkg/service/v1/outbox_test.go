@@ -0,0 +1,169 @@
+package v1
+
+import (
+  "context"
+  "database/sql/driver"
+  "errors"
+  "strings"
+  "testing"
+  "time"
+
+  "github.com/DATA-DOG/go-sqlmock"
+  "github.com/ThreeDotsLabs/watermill/message"
+)
+
+// flakyPublisher fails to publish on the topics in failing
+type flakyPublisher struct {
+  failing   map[string]error
+  published []*message.Message
+}
+
+func (p *flakyPublisher) Publish(topic string, messages ...*message.Message) error {
+  if err := p.failing[topic]; err != nil {
+    return err
+  }
+  p.published = append(p.published, messages...)
+  return nil
+}
+
+func (p *flakyPublisher) Close() error {
+  return nil
+}
+
+// retryAt matches the unix time backoff from now
+type retryAt struct {
+  backoff time.Duration
+}
+
+func (r retryAt) Match(v driver.Value) bool {
+  at, ok := v.(int64)
+  if !ok {
+    return false
+  }
+  want := time.Now().Add(r.backoff).Unix()
+  return at >= want-2 && at <= want
+}
+
+func newMockRelay(t *testing.T, publisher message.Publisher) (*OutboxRelay, sqlmock.Sqlmock) {
+  db, mock, err := sqlmock.New()
+  if err != nil {
+    t.Fatal(err)
+  }
+  t.Cleanup(func() {
+    if err := mock.ExpectationsWereMet(); err != nil {
+      t.Error(err)
+    }
+    db.Close()
+  })
+  return NewOutboxRelay(db, publisher), mock
+}
+
+func outboxRows() *sqlmock.Rows {
+  return sqlmock.NewRows([]string{"id", "event_id", "topic", "payload", "attempts"})
+}
+
+func TestRelayBatchSchedulesFailedEvents(t *testing.T) {
+  publisher := &flakyPublisher{failing: map[string]error{MemberAddedTopic: errors.New(strings.Repeat("x", 300))}}
+  relay, mock := newMockRelay(t, publisher)
+
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`FROM outbox`)).WillReturnRows(outboxRows().
+    AddRow(1, "event-1", TeamCreatedTopic, []byte{}, 0).
+    AddRow(2, "event-2", MemberAddedTopic, []byte{}, 3).
+    AddRow(3, "event-3", TeamDeletedTopic, []byte{}, 0))
+  mock.ExpectExec(stmt(`UPDATE outbox SET published_at=?`)).WithArgs(sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+  // the fourth attempt waits 8s and the error is cut to fit last_error
+  mock.ExpectExec(stmt(`UPDATE outbox SET attempts=attempts+1, next_attempt_at=?, last_error=? WHERE id=?`)).
+    WithArgs(retryAt{8 * time.Second}, strings.Repeat("x", 255), 2).WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`UPDATE outbox SET published_at=?`)).WithArgs(sqlmock.AnyArg(), 3).WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectCommit()
+
+  count, err := relay.relayBatch(context.Background())
+  if err != nil {
+    t.Fatal(err)
+  }
+  if count != 3 {
+    t.Errorf("relayBatch() = %d, want 3", count)
+  }
+  // a failed event doesn't hold back the rest of the batch
+  if len(publisher.published) != 2 || publisher.published[0].UUID != "event-1" || publisher.published[1].UUID != "event-3" {
+    t.Errorf("published %d events", len(publisher.published))
+  }
+}
+
+func TestRelayBatchRetriesWithTheSameEventId(t *testing.T) {
+  publisher := &flakyPublisher{failing: map[string]error{TeamCreatedTopic: errors.New("broker down")}}
+  relay, mock := newMockRelay(t, publisher)
+
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`FROM outbox`)).WillReturnRows(outboxRows().AddRow(1, "event-1", TeamCreatedTopic, []byte{}, 0))
+  mock.ExpectExec(stmt(`next_attempt_at=?`)).WithArgs(retryAt{outboxBaseBackoff}, "broker down", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectCommit()
+  if _, err := relay.relayBatch(context.Background()); err != nil {
+    t.Fatal(err)
+  }
+
+  // once due the event is published again under its event id so consumers can dedupe
+  publisher.failing = nil
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`FROM outbox`)).WillReturnRows(outboxRows().AddRow(1, "event-1", TeamCreatedTopic, []byte{}, 1))
+  mock.ExpectExec(stmt(`UPDATE outbox SET published_at=?`)).WithArgs(sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectCommit()
+  if _, err := relay.relayBatch(context.Background()); err != nil {
+    t.Fatal(err)
+  }
+  if len(publisher.published) != 1 || publisher.published[0].UUID != "event-1" {
+    t.Errorf("published = %v", publisher.published)
+  }
+}
+
+func TestRelayBatchRollsBackWhenMarkingFails(t *testing.T) {
+  publisher := &flakyPublisher{}
+  relay, mock := newMockRelay(t, publisher)
+
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`FROM outbox`)).WillReturnRows(outboxRows().AddRow(1, "event-1", TeamCreatedTopic, []byte{}, 0))
+  mock.ExpectExec(stmt(`UPDATE outbox SET published_at=?`)).WillReturnError(errors.New("lost connection"))
+  mock.ExpectRollback()
+
+  // the row stays unpublished and is sent again, delivery is at-least-once
+  if count, err := relay.relayBatch(context.Background()); err == nil || count != 0 {
+    t.Errorf("relayBatch() = %d, %v, want an error", count, err)
+  }
+}
+
+func TestPurgePublished(t *testing.T) {
+  relay, mock := newMockRelay(t, &flakyPublisher{})
+
+  mock.ExpectExec(stmt(`DELETE FROM outbox WHERE published_at IS NOT NULL AND published_at < ?`)).
+    WithArgs(retryAt{-outboxRetention}).WillReturnResult(sqlmock.NewResult(0, 4))
+  if err := relay.purgePublished(context.Background()); err != nil {
+    t.Fatal(err)
+  }
+}
+
+func TestOutboxBackoff(t *testing.T) {
+  tests := map[int]time.Duration{
+    0:  time.Second,
+    1:  time.Second,
+    2:  2 * time.Second,
+    5:  16 * time.Second,
+    9:  256 * time.Second,
+    10: outboxMaxBackoff,
+    50: outboxMaxBackoff,
+  }
+  for attempt, want := range tests {
+    if got := outboxBackoff(attempt); got != want {
+      t.Errorf("outboxBackoff(%d) = %s, want %s", attempt, got, want)
+    }
+  }
+}
+
+func TestTruncate(t *testing.T) {
+  if got := truncate("broker down", 6); got != "broker" {
+    t.Errorf("truncate() = %q", got)
+  }
+  if got := truncate("down", 6); got != "down" {
+    t.Errorf("truncate() = %q", got)
+  }
+}
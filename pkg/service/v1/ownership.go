@@ -0,0 +1,181 @@
+package v1
+
+import (
+  "context"
+  "database/sql"
+  "fmt"
+  "strconv"
+  "time"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// SuccessionPolicy picks the new leader of a team whose leader's account was
+// removed
+type SuccessionPolicy string
+
+const (
+  // SuccessionAdmin hands the team to its longest-tenured admin
+  SuccessionAdmin SuccessionPolicy = "admin"
+  // SuccessionAdminThenMember falls back to the longest-tenured member when
+  // the team has no admin
+  SuccessionAdminThenMember SuccessionPolicy = "admin-then-member"
+  // SuccessionMember hands the team to its longest-tenured member
+  SuccessionMember SuccessionPolicy = "member"
+  // SuccessionNone leaves the team orphaned
+  SuccessionNone SuccessionPolicy = "none"
+)
+
+// successorRoles lists the access roles each policy picks from, in order,
+// "" matches any role
+var successorRoles = map[SuccessionPolicy][]string{
+  SuccessionAdmin:           {RoleAdmin},
+  SuccessionAdminThenMember: {RoleAdmin, ""},
+  SuccessionMember:          {""},
+  SuccessionNone:            {},
+}
+
+// ParseSuccessionPolicy returns the policy named s, the default when s is empty
+func ParseSuccessionPolicy(s string) (SuccessionPolicy, error) {
+  if s == "" {
+    return SuccessionAdminThenMember, nil
+  }
+  policy := SuccessionPolicy(s)
+  if _, ok := successorRoles[policy]; !ok {
+    return "", fmt.Errorf("unknown succession policy: '%s'", s)
+  }
+  return policy, nil
+}
+
+// reasons recorded on LeaderChanged events
+const (
+  leaderChangeTransfer   = "transfer"
+  leaderChangeSuccession = "succession"
+)
+
+// leaderMemberRole is the member role of a previous owner who wasn't a
+// member of their team
+const leaderMemberRole = "leader"
+
+// Hands team teamId from its owner fromUserId to the member toUserId. The
+// previous owner stays on the team as an admin, a membership with email
+// fromEmail is added when they didn't have one. The team must be at version
+// expected unless it is 0.
+// output ON SUCCESS: int64 - the new version of the team
+// output ON FAILURE: TEAM_NOT_FOUND, VERSION_MISMATCH, PERMISSION_DENIED if fromUserId no longer owns the team, MEMBER_NOT_FOUND, TEAM_LIMIT_REACHED or the error object from whatever created the error
+func (r *teamRepository) TransferOwnership(ctx context.Context, teamId, fromUserId, fromEmail, toUserId string, expected int64) (int64, error) {
+  teamStmt := `SELECT leader FROM teams WHERE id=? FOR UPDATE`
+  memberStmt := `SELECT COUNT(*) FROM members WHERE team_id=? AND user_id=?`
+  countStmt := `SELECT COUNT(*) FROM teams WHERE leader=? AND deleted_at IS NULL`
+  leaderStmt := `UPDATE teams SET leader=?, orphaned=0 WHERE id=?`
+  roleStmt := `UPDATE members SET access_role=? WHERE team_id=? AND user_id IN (?, ?)`
+  insertStmt := `INSERT INTO members (user_id, team_id, member_email, member_role, access_role) VALUES (?, ?, ?, ?, ?)`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return -1, err
+  }
+  defer tx.Rollback()
+
+  // lock the team so concurrent transfers are serialized
+  version, err := nextVersion(ctx, tx, teamId, expected)
+  if err != nil {
+    return -1, err
+  }
+  var leader string
+  err = tx.QueryRowContext(ctx, teamStmt, teamId).Scan(&leader)
+  if err == sql.ErrNoRows {
+    return -1, domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", teamId).With("team_id", teamId)
+  } else if err != nil {
+    return -1, err
+  }
+  if leader != fromUserId {
+    return -1, permissionDenied(ActionTransferOwnership, teamId)
+  }
+
+  var count int
+  if err := tx.QueryRowContext(ctx, memberStmt, teamId, toUserId).Scan(&count); err != nil {
+    return -1, err
+  }
+  if count == 0 {
+    return -1, domainerr.NotFound(domainerr.ReasonMemberNotFound, "user '%s' is not on team '%s'", toUserId, teamId).With("team_id", teamId).With("user_id", toUserId)
+  }
+
+  // the new owner must have room under the team cap
+  if err := tx.QueryRowContext(ctx, countStmt, toUserId).Scan(&count); err != nil {
+    return -1, err
+  }
+  if count >= maxTeamsPerUser {
+    return -1, domainerr.FailedPrecondition(domainerr.ReasonTeamLimitReached, "user '%s' already leads %d teams", toUserId, maxTeamsPerUser).With("user_id", toUserId)
+  }
+
+  if _, err := tx.ExecContext(ctx, leaderStmt, toUserId, teamId); err != nil {
+    return -1, err
+  }
+  // ownership comes from leading the team, both stored roles become admin so
+  // the previous owner keeps managing it
+  if _, err := tx.ExecContext(ctx, roleStmt, RoleAdmin, teamId, fromUserId, toUserId); err != nil {
+    return -1, err
+  }
+  // leaders aren't members unless they listed themselves when creating the team
+  if err := tx.QueryRowContext(ctx, memberStmt, teamId, fromUserId).Scan(&count); err != nil {
+    return -1, err
+  }
+  if count == 0 {
+    result, err := tx.ExecContext(ctx, insertStmt, fromUserId, teamId, fromEmail, leaderMemberRole, RoleAdmin)
+    if err != nil {
+      return -1, err
+    }
+    memId, err := result.LastInsertId()
+    if err != nil {
+      return -1, err
+    }
+    after := memberState(strconv.FormatInt(memId, 10), fromUserId, fromEmail, leaderMemberRole, RoleAdmin)
+    if err := insertAuditEvent(ctx, tx, teamId, AuditAddMember, nil, after); err != nil {
+      return -1, err
+    }
+  }
+
+  // record leader_changed event in the outbox
+  err = insertOutboxEvent(tx, LeaderChangedTopic, &v1.LeaderChanged{
+    TeamId:         teamId,
+    PreviousLeader: fromUserId,
+    Leader:         toUserId,
+    Reason:         leaderChangeTransfer,
+    OccurredAt:     time.Now().Unix(),
+  })
+  if err != nil {
+    return -1, err
+  }
+  err = insertAuditEvent(ctx, tx, teamId, AuditChangeLeader, auditState{"leader": fromUserId}, auditState{"leader": toUserId})
+  if err != nil {
+    return -1, err
+  }
+  if err := tx.Commit(); err != nil {
+    return -1, err
+  }
+  return version, nil
+}
+
+// successor returns the user id of the member succeeding the leader of team
+// teamId under the repository's policy, "" if nobody qualifies. Members
+// already leading as many teams as allowed are skipped.
+func (r *teamRepository) successor(ctx context.Context, tx *sql.Tx, teamId int64) (string, error) {
+  successorStmt := `SELECT m.user_id FROM members m
+    WHERE m.team_id=? AND (?='' OR m.access_role=?)
+    AND (SELECT COUNT(*) FROM teams t WHERE t.leader = CAST(m.user_id AS CHAR) AND t.deleted_at IS NULL) < ?
+    ORDER BY m.id ASC LIMIT 1`
+
+  for _, role := range successorRoles[r.succession] {
+    var userId string
+    err := tx.QueryRowContext(ctx, successorStmt, teamId, role, role, maxTeamsPerUser).Scan(&userId)
+    if err == sql.ErrNoRows {
+      continue
+    } else if err != nil {
+      return "", err
+    }
+    return userId, nil
+  }
+  return "", nil
+}
@@ -0,0 +1,220 @@
+package v1
+
+import (
+  "context"
+  "testing"
+
+  "github.com/DATA-DOG/go-sqlmock"
+  "github.com/golang/protobuf/proto"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// expectTransferChecks expects TransferOwnership to find team 3 led by 7
+// with 8 a member leading no team
+func expectTransferChecks(mock sqlmock.Sqlmock) {
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 1)
+  mock.ExpectQuery(stmt(`SELECT leader FROM teams WHERE id=? FOR UPDATE`)).WithArgs("3").
+    WillReturnRows(sqlmock.NewRows([]string{"leader"}).AddRow("7"))
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM members WHERE team_id=? AND user_id=?`)).WithArgs("3", "8").
+    WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM teams WHERE leader=?`)).WithArgs("8").
+    WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+  mock.ExpectExec(stmt(`UPDATE teams SET leader=?, orphaned=0 WHERE id=?`)).WithArgs("8", "3").
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`UPDATE members SET access_role=? WHERE team_id=? AND user_id IN (?, ?)`)).WithArgs(RoleAdmin, "3", "7", "8").
+    WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestTransferOwnershipAddsThePreviousOwner(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  expectTransferChecks(mock)
+  // the leader wasn't a member, they join as an admin
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM members WHERE team_id=? AND user_id=?`)).WithArgs("3", "7").
+    WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+  mock.ExpectExec(stmt(`INSERT INTO members`)).WithArgs("7", "3", "owner@example.com", leaderMemberRole, RoleAdmin).
+    WillReturnResult(sqlmock.NewResult(15, 1))
+  expectAudit(mock, "3", AuditAddMember)
+  expectOutbox(mock, LeaderChangedTopic)
+  expectAudit(mock, "3", AuditChangeLeader)
+  mock.ExpectCommit()
+
+  version, err := repo.TransferOwnership(context.Background(), "3", "7", "owner@example.com", "8", 1)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if version != 2 {
+    t.Errorf("TransferOwnership() = %d, want 2", version)
+  }
+}
+
+func TestTransferOwnershipKeepsAnExistingMembership(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  expectTransferChecks(mock)
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM members WHERE team_id=? AND user_id=?`)).WithArgs("3", "7").
+    WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+  expectOutbox(mock, LeaderChangedTopic)
+  expectAudit(mock, "3", AuditChangeLeader)
+  mock.ExpectCommit()
+
+  if _, err := repo.TransferOwnership(context.Background(), "3", "7", "owner@example.com", "8", 0); err != nil {
+    t.Fatal(err)
+  }
+}
+
+func TestTransferOwnershipChecks(t *testing.T) {
+  tests := []struct {
+    name   string
+    leader string
+    member int
+    leads  int
+    reason string
+  }{
+    {name: "caller no longer owns the team", leader: "9", reason: domainerr.ReasonPermissionDenied},
+    {name: "new owner isn't a member", leader: "7", member: 0, reason: domainerr.ReasonMemberNotFound},
+    {name: "new owner leads too many teams", leader: "7", member: 1, leads: maxTeamsPerUser, reason: domainerr.ReasonTeamLimitReached},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      repo, mock := newMockRepository(t)
+
+      mock.ExpectBegin()
+      expectNextVersion(mock, "3", 1)
+      mock.ExpectQuery(stmt(`SELECT leader FROM teams`)).WithArgs("3").
+        WillReturnRows(sqlmock.NewRows([]string{"leader"}).AddRow(tt.leader))
+      if tt.leader == "7" {
+        mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM members`)).WithArgs("3", "8").
+          WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.member))
+      }
+      if tt.member > 0 {
+        mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM teams WHERE leader=?`)).WithArgs("8").
+          WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.leads))
+      }
+      mock.ExpectRollback()
+
+      _, err := repo.TransferOwnership(context.Background(), "3", "7", "", "8", 0)
+      if reason := domainerr.ReasonOf(err); reason != tt.reason {
+        t.Errorf("TransferOwnership() error = %v, want %s", err, tt.reason)
+      }
+    })
+  }
+}
+
+func TestParseSuccessionPolicy(t *testing.T) {
+  policy, err := ParseSuccessionPolicy("")
+  if err != nil || policy != SuccessionAdminThenMember {
+    t.Errorf("ParseSuccessionPolicy(\"\") = %s, %v", policy, err)
+  }
+  for policy := range successorRoles {
+    if got, err := ParseSuccessionPolicy(string(policy)); err != nil || got != policy {
+      t.Errorf("ParseSuccessionPolicy(%s) = %s, %v", policy, got, err)
+    }
+  }
+  if _, err := ParseSuccessionPolicy("oldest"); err == nil {
+    t.Error("ParseSuccessionPolicy(oldest) error = nil, want an error")
+  }
+}
+
+// expectSuccessor expects the successor of team teamId to be looked up among
+// the members with access role, userId is found unless it is ""
+func expectSuccessor(mock sqlmock.Sqlmock, teamId int64, role, userId string) {
+  rows := sqlmock.NewRows([]string{"user_id"})
+  if userId != "" {
+    rows.AddRow(userId)
+  }
+  mock.ExpectQuery(stmt(`SELECT m.user_id FROM members m`)).WithArgs(teamId, role, role, maxTeamsPerUser).WillReturnRows(rows)
+}
+
+func TestSuccessorFollowsThePolicy(t *testing.T) {
+  tests := []struct {
+    policy SuccessionPolicy
+    expect func(mock sqlmock.Sqlmock)
+    want   string
+  }{
+    {SuccessionAdmin, func(mock sqlmock.Sqlmock) {
+      expectSuccessor(mock, 3, RoleAdmin, "8")
+    }, "8"},
+    {SuccessionAdmin, func(mock sqlmock.Sqlmock) {
+      expectSuccessor(mock, 3, RoleAdmin, "")
+    }, ""},
+    {SuccessionAdminThenMember, func(mock sqlmock.Sqlmock) {
+      expectSuccessor(mock, 3, RoleAdmin, "")
+      expectSuccessor(mock, 3, "", "9")
+    }, "9"},
+    {SuccessionMember, func(mock sqlmock.Sqlmock) {
+      expectSuccessor(mock, 3, "", "9")
+    }, "9"},
+    {SuccessionNone, func(mock sqlmock.Sqlmock) {}, ""},
+  }
+  for _, tt := range tests {
+    repo, mock := newMockRepository(t)
+    repo.WithSuccession(tt.policy)
+    mock.ExpectBegin()
+    tt.expect(mock)
+    mock.ExpectRollback()
+
+    tx, err := repo.db.Begin()
+    if err != nil {
+      t.Fatal(err)
+    }
+    successor, err := repo.successor(context.Background(), tx, 3)
+    tx.Rollback()
+    if err != nil || successor != tt.want {
+      t.Errorf("%s: successor() = %q, %v, want %q", tt.policy, successor, err, tt.want)
+    }
+  }
+}
+
+func TestRemoveUserFromTeamsHandsOverLedTeams(t *testing.T) {
+  repo, mock := newMockRepository(t)
+  repo.WithSuccession(SuccessionAdmin)
+  outbox := &fakeOutbox{}
+
+  // user 7 is a member of team 3 and leads teams 3 and 5
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`SELECT id, team_id, member_email, member_role, access_role FROM members WHERE user_id=? FOR UPDATE`)).WithArgs("7").
+    WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "member_email", "member_role", "access_role"}).AddRow(21, 3, "m@example.com", "backend", RoleAdmin))
+  mock.ExpectExec(stmt(`DELETE FROM members WHERE id=?`)).WithArgs(int64(21)).WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`UPDATE teams SET open_roles = open_roles + 1`)).WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+  outbox.expect(mock, MemberRemovedTopic)
+  expectAudit(mock, "3", AuditRemoveMember)
+  mock.ExpectQuery(stmt(`SELECT id FROM teams WHERE leader=? AND deleted_at IS NULL FOR UPDATE`)).WithArgs("7").
+    WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(5))
+  // team 3 goes to its admin 8, team 5 has none and is orphaned
+  expectSuccessor(mock, 3, RoleAdmin, "8")
+  mock.ExpectExec(stmt(`UPDATE teams SET leader=?, orphaned=0, version = version + 1 WHERE id=?`)).WithArgs("8", int64(3)).
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  outbox.expect(mock, LeaderChangedTopic)
+  expectAudit(mock, "3", AuditChangeLeader)
+  expectSuccessor(mock, 5, RoleAdmin, "")
+  mock.ExpectExec(stmt(`UPDATE teams SET orphaned=1, version = version + 1 WHERE id=?`)).WithArgs(int64(5)).
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  outbox.expect(mock, LeaderChangedTopic)
+  expectAudit(mock, "5", AuditChangeLeader)
+  mock.ExpectCommit()
+
+  removed, err := repo.RemoveUserFromTeams(context.Background(), "7")
+  if err != nil || removed != 1 {
+    t.Fatalf("RemoveUserFromTeams() = %d, %v", removed, err)
+  }
+
+  want := []*v1.LeaderChanged{
+    {TeamId: "3", PreviousLeader: "7", Leader: "8", Reason: leaderChangeSuccession},
+    {TeamId: "5", PreviousLeader: "7", Orphaned: true, Reason: leaderChangeSuccession},
+  }
+  for i, row := range outbox.rows[1:] {
+    event := &v1.LeaderChanged{}
+    if err := proto.Unmarshal(row.payload.([]byte), event); err != nil {
+      t.Fatal(err)
+    }
+    event.OccurredAt = 0
+    if !proto.Equal(event, want[i]) {
+      t.Errorf("leader_changed = %v, want %v", event, want[i])
+    }
+  }
+}
@@ -0,0 +1,199 @@
+package v1
+
+import (
+  "crypto/sha256"
+  "encoding/base64"
+  "encoding/hex"
+  "encoding/json"
+
+  "github.com/golang/protobuf/proto"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// pageTokenVersion is bumped whenever the token layout changes, older
+// tokens are then rejected instead of misread
+const pageTokenVersion = 2
+
+// pageToken is the cursor of a GetTeams page. It holds the sort key of the
+// last team of the previous page so the next page starts right after it
+// whatever was inserted or deleted in between. The key is a snapshot, see
+// teamSort for the sorts on keys that change.
+type pageToken struct {
+  Version int `json:"v"`
+  // Key is the sort key of the last team of the previous page
+  Key int64 `json:"k,omitempty"`
+  // Id is the id of the last team of the previous page, it breaks ties
+  Id int64 `json:"i"`
+  // Filter is the fingerprint of the filters the token was issued for
+  Filter string `json:"f"`
+}
+
+// encodePageToken returns the opaque token of the page following the team
+// lastId with sort key lastKey
+func encodePageToken(req *v1.GetTeamsRequest, lastKey, lastId int64) string {
+  b, _ := json.Marshal(pageToken{
+    Version: pageTokenVersion,
+    Key:     lastKey,
+    Id:      lastId,
+    Filter:  filterFingerprint(req),
+  })
+  return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodePageToken returns the cursor of req.PageToken, nil when it is empty
+func decodePageToken(req *v1.GetTeamsRequest) (*pageToken, error) {
+  if req.PageToken == "" {
+    return nil, nil
+  }
+
+  invalid := domainerr.InvalidArgument(domainerr.FieldViolation{
+    Field:       "page_token",
+    Description: "is not a token returned by GetTeams",
+  })
+  b, err := base64.RawURLEncoding.DecodeString(req.PageToken)
+  if err != nil {
+    return nil, invalid
+  }
+  token := &pageToken{}
+  if err := json.Unmarshal(b, token); err != nil || token.Version != pageTokenVersion {
+    return nil, invalid
+  }
+  if token.Filter != filterFingerprint(req) {
+    return nil, domainerr.InvalidArgument(domainerr.FieldViolation{
+      Field:       "page_token",
+      Description: "was issued for different filters, restart from the first page",
+    })
+  }
+  return token, nil
+}
+
+// filterFingerprint identifies the filters of req, every field but the
+// paging ones takes part so new filters are covered automatically
+func filterFingerprint(req *v1.GetTeamsRequest) string {
+  filters := proto.Clone(req).(*v1.GetTeamsRequest)
+  filters.Api = ""
+  filters.Page = 0
+  filters.Limit = 0
+  filters.PageToken = ""
+  return fingerprint(filters)
+}
+
+// searchPageToken is the cursor of a SearchTeams page. Search hits have no
+// stable sort key, pages are counted by offset into the ranked hits.
+type searchPageToken struct {
+  Version int `json:"v"`
+  // Offset is the rank of the first hit of the page
+  Offset int `json:"o"`
+  // Filter is the fingerprint of the query and filters the token was issued for
+  Filter string `json:"f"`
+}
+
+// encodeSearchPageToken returns the opaque token of the page of req
+// starting at offset
+func encodeSearchPageToken(req *v1.SearchTeamsRequest, offset int) string {
+  b, _ := json.Marshal(searchPageToken{
+    Version: pageTokenVersion,
+    Offset:  offset,
+    Filter:  searchFingerprint(req),
+  })
+  return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeSearchPageToken returns the offset of req.PageToken, 0 when it is empty
+func decodeSearchPageToken(req *v1.SearchTeamsRequest) (int, error) {
+  if req.PageToken == "" {
+    return 0, nil
+  }
+
+  token := &searchPageToken{}
+  b, err := base64.RawURLEncoding.DecodeString(req.PageToken)
+  if err == nil {
+    err = json.Unmarshal(b, token)
+  }
+  if err != nil || token.Version != pageTokenVersion || token.Offset < 0 {
+    return 0, domainerr.InvalidArgument(domainerr.FieldViolation{
+      Field:       "page_token",
+      Description: "is not a token returned by SearchTeams",
+    })
+  }
+  if token.Filter != searchFingerprint(req) {
+    return 0, domainerr.InvalidArgument(domainerr.FieldViolation{
+      Field:       "page_token",
+      Description: "was issued for a different query, restart from the first page",
+    })
+  }
+  return token.Offset, nil
+}
+
+// searchFingerprint identifies the query and filters of req
+func searchFingerprint(req *v1.SearchTeamsRequest) string {
+  filters := proto.Clone(req).(*v1.SearchTeamsRequest)
+  filters.Api = ""
+  filters.Limit = 0
+  filters.PageToken = ""
+  return fingerprint(filters)
+}
+
+// auditPageToken is the cursor of a ListAuditEvents page, events are listed
+// newest first so the next page starts below the id of the last event
+type auditPageToken struct {
+  Version int `json:"v"`
+  // Id is the id of the last event of the previous page
+  Id int64 `json:"i"`
+  // Filter is the fingerprint of the filters the token was issued for
+  Filter string `json:"f"`
+}
+
+// encodeAuditPageToken returns the opaque token of the page following the
+// event lastId
+func encodeAuditPageToken(req *v1.ListAuditEventsRequest, lastId int64) string {
+  b, _ := json.Marshal(auditPageToken{
+    Version: pageTokenVersion,
+    Id:      lastId,
+    Filter:  auditFingerprint(req),
+  })
+  return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeAuditPageToken returns the cursor of req.PageToken, nil when it is empty
+func decodeAuditPageToken(req *v1.ListAuditEventsRequest) (*auditPageToken, error) {
+  if req.PageToken == "" {
+    return nil, nil
+  }
+
+  token := &auditPageToken{}
+  b, err := base64.RawURLEncoding.DecodeString(req.PageToken)
+  if err == nil {
+    err = json.Unmarshal(b, token)
+  }
+  if err != nil || token.Version != pageTokenVersion {
+    return nil, domainerr.InvalidArgument(domainerr.FieldViolation{
+      Field:       "page_token",
+      Description: "is not a token returned by ListAuditEvents",
+    })
+  }
+  if token.Filter != auditFingerprint(req) {
+    return nil, domainerr.InvalidArgument(domainerr.FieldViolation{
+      Field:       "page_token",
+      Description: "was issued for different filters, restart from the first page",
+    })
+  }
+  return token, nil
+}
+
+// auditFingerprint identifies the filters of req
+func auditFingerprint(req *v1.ListAuditEventsRequest) string {
+  filters := proto.Clone(req).(*v1.ListAuditEventsRequest)
+  filters.Api = ""
+  filters.Limit = 0
+  filters.PageToken = ""
+  return fingerprint(filters)
+}
+
+// fingerprint is a short hash of msg
+func fingerprint(msg proto.Message) string {
+  sum := sha256.Sum256([]byte(proto.CompactTextString(msg)))
+  return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,132 @@
+package v1
+
+import (
+  "context"
+  "encoding/base64"
+  "testing"
+
+  "github.com/DATA-DOG/go-sqlmock"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+  req := &v1.GetTeamsRequest{Skills: []string{"go"}, Sort: v1.TeamSort_TEAM_SORT_SIZE, Limit: 10}
+  next := &v1.GetTeamsRequest{
+    Skills:    []string{"go"},
+    Sort:      v1.TeamSort_TEAM_SORT_SIZE,
+    PageToken: encodePageToken(req, 4, 7),
+    // paging fields may change between pages
+    Limit: 20,
+    Page:  3,
+    Api:   "v1",
+  }
+
+  token, err := decodePageToken(next)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if token.Key != 4 || token.Id != 7 {
+    t.Errorf("token = %+v, want key 4 and id 7", token)
+  }
+
+  if token, err := decodePageToken(&v1.GetTeamsRequest{}); token != nil || err != nil {
+    t.Errorf("decodePageToken() without a token = %v, %v", token, err)
+  }
+}
+
+func TestPageTokenRejectsOtherFilters(t *testing.T) {
+  token := encodePageToken(&v1.GetTeamsRequest{Skills: []string{"go"}}, 0, 7)
+
+  for _, req := range []*v1.GetTeamsRequest{
+    {Skills: []string{"sql"}, PageToken: token},
+    {Skills: []string{"go"}, Sort: v1.TeamSort_TEAM_SORT_NEWEST, PageToken: token},
+    {Skills: []string{"go"}, MinOpenRoles: 1, PageToken: token},
+  } {
+    if _, err := decodePageToken(req); domainerr.ReasonOf(err) != domainerr.ReasonInvalidArgument {
+      t.Errorf("decodePageToken(%v) error = %v, want an invalid argument", req, err)
+    }
+  }
+}
+
+func TestPageTokenRejectsForgedTokens(t *testing.T) {
+  old := base64.RawURLEncoding.EncodeToString([]byte(`{"v":1,"i":7,"f":"` + filterFingerprint(&v1.GetTeamsRequest{}) + `"}`))
+  for _, token := range []string{"not a token", base64.RawURLEncoding.EncodeToString([]byte("[]")), old} {
+    if _, err := decodePageToken(&v1.GetTeamsRequest{PageToken: token}); domainerr.ReasonOf(err) != domainerr.ReasonInvalidArgument {
+      t.Errorf("decodePageToken(%s) error = %v, want an invalid argument", token, err)
+    }
+  }
+}
+
+func TestSearchPageToken(t *testing.T) {
+  req := &v1.SearchTeamsRequest{Query: "gophers", Limit: 10}
+  offset, err := decodeSearchPageToken(&v1.SearchTeamsRequest{Query: "gophers", PageToken: encodeSearchPageToken(req, 20)})
+  if err != nil || offset != 20 {
+    t.Errorf("decodeSearchPageToken() = %d, %v, want 20", offset, err)
+  }
+
+  for _, next := range []*v1.SearchTeamsRequest{
+    {Query: "rustaceans", PageToken: encodeSearchPageToken(req, 20)},
+    {Query: "gophers", PageToken: encodeSearchPageToken(req, -1)},
+    {Query: "gophers", PageToken: "garbage"},
+  } {
+    if _, err := decodeSearchPageToken(next); domainerr.ReasonOf(err) != domainerr.ReasonInvalidArgument {
+      t.Errorf("decodeSearchPageToken(%v) error = %v, want an invalid argument", next, err)
+    }
+  }
+}
+
+// expectNoTeams expects loadTeams to find none of the teams it is given
+func expectNoTeams(mock sqlmock.Sqlmock) {
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`FROM teams WHERE id IN`)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+  mock.ExpectRollback()
+}
+
+func TestGetTeamsIssuesATokenAfterTheLastTeam(t *testing.T) {
+  repo, mock := newMockRepository(t)
+  req := &v1.GetTeamsRequest{Limit: 2}
+
+  // one row more than the page tells there is a next page
+  mock.ExpectQuery(stmt(`SELECT t.id, 0 FROM teams t`)).WithArgs(int64(3)).
+    WillReturnRows(sqlmock.NewRows([]string{"id", "key"}).AddRow(3, 0).AddRow(5, 0).AddRow(9, 0))
+  expectNoTeams(mock)
+
+  _, next, err := repo.GetTeams(context.Background(), req)
+  if err != nil {
+    t.Fatal(err)
+  }
+  token, err := decodePageToken(&v1.GetTeamsRequest{Limit: 2, PageToken: next})
+  if err != nil || token.Id != 5 {
+    t.Fatalf("next page token = %+v, %v, want the id of the second team", token, err)
+  }
+
+  // the next page starts after the token and is the last one
+  mock.ExpectQuery(stmt(`t.id > ?`)).WithArgs(int64(5), int64(3)).
+    WillReturnRows(sqlmock.NewRows([]string{"id", "key"}).AddRow(9, 0))
+  expectNoTeams(mock)
+
+  _, next, err = repo.GetTeams(context.Background(), &v1.GetTeamsRequest{Limit: 2, PageToken: next})
+  if err != nil || next != "" {
+    t.Errorf("GetTeams() = %q, %v, want no next page", next, err)
+  }
+}
+
+func TestGetTeamsKeepsPageNumbers(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectQuery(stmt(`ORDER BY t.id ASC LIMIT ? OFFSET ?`)).WithArgs(int64(11), int64(20)).
+    WillReturnRows(sqlmock.NewRows([]string{"id", "key"}))
+
+  teams, next, err := repo.GetTeams(context.Background(), &v1.GetTeamsRequest{Page: 3, Limit: 10})
+  if err != nil || len(teams) != 0 || next != "" {
+    t.Errorf("GetTeams() = %v, %q, %v", teams, next, err)
+  }
+
+  // a token for other filters fails before any query
+  token := encodePageToken(&v1.GetTeamsRequest{}, 0, 5)
+  if _, _, err := repo.GetTeams(context.Background(), &v1.GetTeamsRequest{MinSize: 2, PageToken: token}); domainerr.ReasonOf(err) != domainerr.ReasonInvalidArgument {
+    t.Errorf("GetTeams() error = %v, want an invalid argument", err)
+  }
+}
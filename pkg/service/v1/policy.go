@@ -0,0 +1,114 @@
+package v1
+
+import (
+  "context"
+  "fmt"
+  "os"
+
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// access roles of a team membership, the owner is the team's leader
+const (
+  RoleOwner  = "owner"
+  RoleAdmin  = "admin"
+  RoleMember = "member"
+  RoleViewer = "viewer"
+)
+
+// RoleSupport is the platform role of the support team, granted by the
+// token rather than a membership
+const RoleSupport = "support"
+
+// roleRank orders roles by how much they may do
+var roleRank = map[string]int{
+  RoleViewer: 1,
+  RoleMember: 2,
+  RoleAdmin:  3,
+  RoleOwner:  4,
+}
+
+// actions on a team guarded by the policy
+const (
+  ActionUpdateTeam        = "update_team"
+  ActionDeleteTeam        = "delete_team"
+  ActionRestoreTeam       = "restore_team"
+  ActionAddMember         = "add_member"
+  ActionRemoveMember      = "remove_member"
+  ActionUpsertProject     = "upsert_project"
+  ActionTransferOwnership = "transfer_ownership"
+  ActionInviteMember      = "invite_member"
+  ActionReviewApplication = "review_application"
+  ActionListAuditEvents   = "list_audit_events"
+  ActionManageTasks       = "manage_tasks"
+)
+
+// permissions lists the roles allowed to perform each action
+var permissions = map[string][]string{
+  ActionUpdateTeam:        {RoleOwner, RoleAdmin},
+  ActionDeleteTeam:        {RoleOwner},
+  ActionRestoreTeam:       {RoleOwner},
+  ActionAddMember:         {RoleOwner, RoleAdmin},
+  ActionRemoveMember:      {RoleOwner, RoleAdmin},
+  ActionUpsertProject:     {RoleOwner, RoleAdmin},
+  ActionTransferOwnership: {RoleOwner},
+  ActionInviteMember:      {RoleOwner, RoleAdmin},
+  ActionReviewApplication: {RoleOwner, RoleAdmin},
+  ActionListAuditEvents:   {RoleOwner},
+  ActionManageTasks:       {RoleOwner, RoleAdmin, RoleMember},
+}
+
+// can reports whether role may perform action
+func can(role, action string) bool {
+  for _, r := range permissions[action] {
+    if r == role {
+      return true
+    }
+  }
+  return false
+}
+
+// validAccessRole reports whether role may be granted to a member, owners
+// are only made by leading the team
+func validAccessRole(role string) bool {
+  return role == RoleAdmin || role == RoleMember || role == RoleViewer
+}
+
+// outranks reports whether role manages other, only owners manage admins
+func outranks(role, other string) bool {
+  return role == RoleOwner || roleRank[role] > roleRank[other]
+}
+
+// authorize returns the caller's id and role on team teamId if their role
+// allows action, PermissionDenied otherwise
+func (s *handler) authorize(ctx context.Context, teamId, action string) (string, string, error) {
+  return s.authorizeIn(ctx, teamId, action, false)
+}
+
+// authorizeIn is authorize on a team in the trash when deleted is true, on
+// the other teams otherwise
+func (s *handler) authorizeIn(ctx context.Context, teamId, action string, deleted bool) (string, string, error) {
+  userId, err := s.callerId(ctx)
+  if err != nil {
+    return "", "", err
+  }
+
+  getRole := s.repo.GetMemberRole
+  if deleted {
+    getRole = s.repo.GetDeletedMemberRole
+  }
+  role, err := getRole(ctx, userId, teamId)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo GetMemberRole: %v\n", teamId)
+    return "", "", err
+  }
+  if !can(role, action) {
+    fmt.Fprintf(os.Stderr, "user %v with role '%v' can't %v on team: %v\n", userId, role, action, teamId)
+    return "", "", permissionDenied(action, teamId)
+  }
+  return userId, role, nil
+}
+
+func permissionDenied(action, teamId string) error {
+  return domainerr.PermissionDenied("not allowed to %s on team '%s'", action, teamId).With("team_id", teamId).With("action", action)
+}
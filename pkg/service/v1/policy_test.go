@@ -0,0 +1,211 @@
+package v1
+
+import (
+  "context"
+  "testing"
+
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/auth"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// rolesRepository is team 3 with the access roles of roles by user id and
+// the members of members by member number, other methods aren't implemented
+type rolesRepository struct {
+  repository
+  roles   map[string]string
+  members map[string]*v1.Member
+  added   []*v1.MemberUpsertRequest
+  removed []string
+}
+
+func (r *rolesRepository) GetMemberRole(ctx context.Context, userId, teamId string) (string, error) {
+  return r.roles[userId], nil
+}
+
+func (r *rolesRepository) GetMember(ctx context.Context, teamId, memberNumber string) (*v1.Member, error) {
+  return r.members[memberNumber], nil
+}
+
+func (r *rolesRepository) CheckTeamSize(ctx context.Context, teamId string) (bool, error) {
+  return false, nil
+}
+
+func (r *rolesRepository) CheckMemberExists(ctx context.Context, userId, teamId string) (bool, error) {
+  return false, nil
+}
+
+func (r *rolesRepository) AddMember(ctx context.Context, req *v1.MemberUpsertRequest) (string, int64, error) {
+  r.added = append(r.added, req)
+  return "9", 2, nil
+}
+
+func (r *rolesRepository) RemoveMember(ctx context.Context, teamId, memberNumber string, expected int64) (int64, int64, error) {
+  r.removed = append(r.removed, memberNumber)
+  return 1, 2, nil
+}
+
+// newRolesHandler returns a handler on team 3 owned by 1 with admins 2 and
+// 5, member 3 and viewer 4, member numbers are user ids
+func newRolesHandler() (*handler, *rolesRepository) {
+  repo := &rolesRepository{
+    roles: map[string]string{"1": RoleOwner, "2": RoleAdmin, "3": RoleMember, "4": RoleViewer, "5": RoleAdmin},
+    members: map[string]*v1.Member{
+      "1": {Id: 1, AccessRole: RoleOwner},
+      "2": {Id: 2, AccessRole: RoleAdmin},
+      "3": {Id: 3, AccessRole: RoleMember},
+      "4": {Id: 4, AccessRole: RoleViewer},
+      "5": {Id: 5, AccessRole: RoleAdmin},
+    },
+  }
+  return NewTeamServiceServer(repo, nil, ""), repo
+}
+
+func as(userId string) context.Context {
+  return auth.NewContext(context.Background(), &auth.Identity{UserId: userId})
+}
+
+func TestCan(t *testing.T) {
+  allowed := map[string][]string{
+    RoleOwner:  {ActionUpdateTeam, ActionDeleteTeam, ActionRestoreTeam, ActionAddMember, ActionRemoveMember, ActionUpsertProject, ActionTransferOwnership, ActionInviteMember, ActionReviewApplication, ActionListAuditEvents, ActionManageTasks},
+    RoleAdmin:  {ActionUpdateTeam, ActionAddMember, ActionRemoveMember, ActionUpsertProject, ActionInviteMember, ActionReviewApplication, ActionManageTasks},
+    RoleMember: {ActionManageTasks},
+    RoleViewer: {},
+    "":         {},
+  }
+  for role, actions := range allowed {
+    may := map[string]bool{}
+    for _, action := range actions {
+      may[action] = true
+    }
+    for action := range permissions {
+      if got := can(role, action); got != may[action] {
+        t.Errorf("can(%q, %s) = %v, want %v", role, action, got, may[action])
+      }
+    }
+  }
+  if can(RoleOwner, "unknown_action") {
+    t.Error("unknown actions must be denied")
+  }
+}
+
+func TestOutranks(t *testing.T) {
+  tests := []struct {
+    role, other string
+    want        bool
+  }{
+    {RoleOwner, RoleAdmin, true},
+    {RoleOwner, RoleOwner, true},
+    {RoleAdmin, RoleMember, true},
+    {RoleAdmin, RoleViewer, true},
+    {RoleAdmin, RoleAdmin, false},
+    {RoleAdmin, RoleOwner, false},
+    {RoleMember, RoleViewer, true},
+    {RoleMember, RoleMember, false},
+    {RoleViewer, RoleViewer, false},
+    {"", RoleViewer, false},
+  }
+  for _, tt := range tests {
+    if got := outranks(tt.role, tt.other); got != tt.want {
+      t.Errorf("outranks(%q, %q) = %v, want %v", tt.role, tt.other, got, tt.want)
+    }
+  }
+}
+
+func TestValidAccessRole(t *testing.T) {
+  for _, role := range []string{RoleAdmin, RoleMember, RoleViewer} {
+    if !validAccessRole(role) {
+      t.Errorf("validAccessRole(%s) = false", role)
+    }
+  }
+  for _, role := range []string{RoleOwner, RoleSupport, "", "Admin"} {
+    if validAccessRole(role) {
+      t.Errorf("validAccessRole(%q) = true", role)
+    }
+  }
+}
+
+func TestAuthorize(t *testing.T) {
+  s, _ := newRolesHandler()
+
+  if _, _, err := s.authorize(context.Background(), "3", ActionUpdateTeam); status.Code(err) != codes.Unauthenticated {
+    t.Errorf("anonymous authorize() error = %v, want Unauthenticated", err)
+  }
+  if _, _, err := s.authorize(as("3"), "3", ActionUpdateTeam); status.Code(err) != codes.PermissionDenied {
+    t.Errorf("member authorize() error = %v, want PermissionDenied", err)
+  }
+  // outsiders have no role on the team
+  if _, _, err := s.authorize(as("42"), "3", ActionManageTasks); status.Code(err) != codes.PermissionDenied {
+    t.Errorf("outsider authorize() error = %v, want PermissionDenied", err)
+  }
+
+  userId, role, err := s.authorize(as("2"), "3", ActionUpdateTeam)
+  if err != nil || userId != "2" || role != RoleAdmin {
+    t.Errorf("admin authorize() = %s, %s, %v", userId, role, err)
+  }
+}
+
+func TestAddMemberAccessRoles(t *testing.T) {
+  tests := []struct {
+    name   string
+    caller string
+    role   string
+    code   codes.Code
+  }{
+    {"owner adds an admin", "1", RoleAdmin, codes.OK},
+    {"admin adds a member", "2", "", codes.OK},
+    {"admin adds a viewer", "2", RoleViewer, codes.OK},
+    {"admin adds an admin", "2", RoleAdmin, codes.PermissionDenied},
+    {"member adds a viewer", "3", RoleViewer, codes.PermissionDenied},
+    {"owner adds an owner", "1", RoleOwner, codes.InvalidArgument},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      s, repo := newRolesHandler()
+      _, err := s.AddMember(as(tt.caller), &v1.MemberUpsertRequest{TeamId: "3", MemberId: "9", AccessRole: tt.role})
+      if status.Code(err) != tt.code {
+        t.Fatalf("AddMember() error = %v, want %s", err, tt.code)
+      }
+      if tt.code == codes.OK && (len(repo.added) != 1 || repo.added[0].AccessRole == "") {
+        t.Errorf("added %v", repo.added)
+      }
+    })
+  }
+}
+
+func TestRemoveMemberPolicy(t *testing.T) {
+  tests := []struct {
+    name   string
+    caller string
+    member string
+    code   codes.Code
+  }{
+    {"owner removes an admin", "1", "2", codes.OK},
+    {"admin removes a member", "2", "3", codes.OK},
+    {"admin removes an admin", "2", "5", codes.PermissionDenied},
+    {"admin removes the owner", "2", "1", codes.PermissionDenied},
+    {"member removes a viewer", "3", "4", codes.PermissionDenied},
+    {"member leaves", "3", "3", codes.OK},
+    {"admin leaves", "2", "2", codes.OK},
+    {"owner leaves", "1", "1", codes.FailedPrecondition},
+    {"missing member", "1", "9", codes.NotFound},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      s, repo := newRolesHandler()
+      _, err := s.RemoveMember(as(tt.caller), &v1.MemberDeleteRequest{TeamId: "3", MemberNumber: tt.member})
+      if status.Code(err) != tt.code {
+        t.Fatalf("RemoveMember() error = %v, want %s", err, tt.code)
+      }
+      if removed := len(repo.removed) == 1; removed != (tt.code == codes.OK) {
+        t.Errorf("removed %v", repo.removed)
+      }
+      if tt.code == codes.FailedPrecondition && domainerr.ReasonOf(err) != domainerr.ReasonOwnerMustTransfer {
+        t.Errorf("reason = %s, want %s", domainerr.ReasonOf(err), domainerr.ReasonOwnerMustTransfer)
+      }
+    })
+  }
+}
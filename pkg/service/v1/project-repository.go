@@ -0,0 +1,475 @@
+package v1
+
+import (
+  "context"
+  "database/sql"
+  "fmt"
+  "strconv"
+  "strings"
+  "time"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// statuses of a project, a team has at most one active project
+const (
+  ProjectPlanning  = "planning"
+  ProjectActive    = "active"
+  ProjectCompleted = "completed"
+  ProjectAbandoned = "abandoned"
+)
+
+// projectTransitions lists the statuses a project in each status may move to
+var projectTransitions = map[string][]string{
+  ProjectPlanning:  {ProjectActive, ProjectAbandoned},
+  ProjectActive:    {ProjectCompleted, ProjectAbandoned},
+  ProjectCompleted: {},
+  ProjectAbandoned: {},
+}
+
+const projectColumns = `id, goal, project_name, github_link, complexity, duration, status, started_at, completed_at, archived_at`
+
+// Creates a project of team teamId, planning unless project.Status is active
+// output ON SUCCESS: *v1.Project - the created project, int64 - new version of the team, error - nil
+// output ON FAILURE: TEAM_NOT_FOUND, VERSION_MISMATCH, ACTIVE_PROJECT_EXISTS or the error object from whatever created the error
+func (r *teamRepository) CreateProject(ctx context.Context, teamId string, project *v1.Project, expected int64) (*v1.Project, int64, error) {
+  insertStmt := `INSERT INTO projects (goal, project_name, github_link, team_id, complexity, duration, status, started_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return nil, -1, err
+  }
+  defer tx.Rollback()
+
+  // lock the team, its projects are checked for an active one
+  version, err := nextVersion(ctx, tx, teamId, expected)
+  if err != nil {
+    return nil, -1, err
+  }
+
+  status := project.Status
+  if status == "" {
+    status = ProjectPlanning
+  }
+  var startedAt sql.NullInt64
+  now := time.Now().Unix()
+  if status == ProjectActive {
+    if err := checkNoActiveProject(ctx, tx, teamId, ""); err != nil {
+      return nil, -1, err
+    }
+    startedAt = sql.NullInt64{Int64: now, Valid: true}
+  }
+
+  result, err := tx.ExecContext(ctx, insertStmt, project.Description, project.Name, project.GithubLink, teamId, project.Complexity, project.Duration, status, startedAt)
+  if err != nil {
+    return nil, -1, err
+  }
+  id, err := result.LastInsertId()
+  if err != nil {
+    return nil, -1, err
+  }
+  projectId := strconv.FormatInt(id, 10)
+  if err := insertLanguages(ctx, tx, teamId, projectId, project.Languages); err != nil {
+    return nil, -1, err
+  }
+
+  created, err := loadProject(ctx, tx, teamId, projectId)
+  if err != nil {
+    return nil, -1, err
+  }
+
+  // record project_created event in the outbox
+  err = insertOutboxEvent(tx, ProjectCreatedTopic, &v1.ProjectCreated{
+    TeamId:     teamId,
+    Project:    created,
+    OccurredAt: now,
+  })
+  if err != nil {
+    return nil, -1, err
+  }
+  if err := insertAuditEvent(ctx, tx, teamId, AuditCreateProject, nil, projectState(created)); err != nil {
+    return nil, -1, err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return nil, -1, err
+  }
+  return created, version, nil
+}
+
+// Updates the fields of project projectId of team teamId listed in paths
+// with the values of patch. A status change must follow the project
+// lifecycle and stamps started_at or completed_at.
+// output ON SUCCESS: *v1.Project - the updated project, int64 - new version of the team, error - nil
+// output ON FAILURE: TEAM_NOT_FOUND, VERSION_MISMATCH, PROJECT_NOT_FOUND, PROJECT_ARCHIVED, INVALID_PROJECT_TRANSITION, ACTIVE_PROJECT_EXISTS or the error object from whatever created the error
+func (r *teamRepository) UpdateProject(ctx context.Context, teamId, projectId string, patch *v1.Project, paths []string, expected int64) (*v1.Project, int64, error) {
+  updateStmt := `UPDATE projects SET goal=?, project_name=?, github_link=?, complexity=?, duration=?, status=?, started_at=?, completed_at=? WHERE id=?`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return nil, -1, err
+  }
+  defer tx.Rollback()
+
+  version, err := nextVersion(ctx, tx, teamId, expected)
+  if err != nil {
+    return nil, -1, err
+  }
+  project, err := lockProject(ctx, tx, teamId, projectId)
+  if err != nil {
+    return nil, -1, err
+  }
+  if project.ArchivedAt != 0 {
+    return nil, -1, projectArchived(projectId)
+  }
+  before := projectState(project)
+
+  masked := map[string]bool{}
+  for _, path := range paths {
+    masked[path] = true
+  }
+  now := time.Now().Unix()
+  if masked["status"] && patch.Status != project.Status {
+    if err := checkProjectTransition(project, patch.Status); err != nil {
+      return nil, -1, err
+    }
+    if patch.Status == ProjectActive {
+      if err := checkNoActiveProject(ctx, tx, teamId, projectId); err != nil {
+        return nil, -1, err
+      }
+      project.StartedAt = now
+    }
+    if patch.Status == ProjectCompleted {
+      project.CompletedAt = now
+    }
+    project.Status = patch.Status
+  }
+  if masked["name"] {
+    project.Name = patch.Name
+  }
+  if masked["description"] {
+    project.Description = patch.Description
+  }
+  if masked["github_link"] {
+    project.GithubLink = patch.GithubLink
+  }
+  if masked["complexity"] {
+    project.Complexity = patch.Complexity
+  }
+  if masked["duration"] {
+    project.Duration = patch.Duration
+  }
+
+  _, err = tx.ExecContext(ctx, updateStmt, project.Description, project.Name, project.GithubLink, project.Complexity, project.Duration,
+    project.Status, nullTime(project.StartedAt), nullTime(project.CompletedAt), projectId)
+  if err != nil {
+    return nil, -1, err
+  }
+  if masked["languages"] {
+    if _, err := tx.ExecContext(ctx, `DELETE FROM languages WHERE project_id=?`, projectId); err != nil {
+      return nil, -1, err
+    }
+    if err := insertLanguages(ctx, tx, teamId, projectId, patch.Languages); err != nil {
+      return nil, -1, err
+    }
+  }
+
+  updated, err := loadProject(ctx, tx, teamId, projectId)
+  if err != nil {
+    return nil, -1, err
+  }
+
+  // record project_updated event in the outbox
+  err = insertOutboxEvent(tx, ProjectUpdatedTopic, &v1.ProjectUpdated{
+    TeamId:     teamId,
+    Paths:      paths,
+    Project:    updated,
+    OccurredAt: now,
+  })
+  if err != nil {
+    return nil, -1, err
+  }
+  if err := insertAuditEvent(ctx, tx, teamId, AuditUpdateProject, before, projectState(updated)); err != nil {
+    return nil, -1, err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return nil, -1, err
+  }
+  return updated, version, nil
+}
+
+// Archives project projectId of team teamId, it is kept but no longer shown
+// on the team. Active projects must be completed or abandoned first.
+// output ON SUCCESS: *v1.Project - the archived project, int64 - new version of the team, error - nil
+// output ON FAILURE: TEAM_NOT_FOUND, VERSION_MISMATCH, PROJECT_NOT_FOUND, PROJECT_ACTIVE, PROJECT_ARCHIVED or the error object from whatever created the error
+func (r *teamRepository) ArchiveProject(ctx context.Context, teamId, projectId string, expected int64) (*v1.Project, int64, error) {
+  archiveStmt := `UPDATE projects SET archived_at=? WHERE id=?`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return nil, -1, err
+  }
+  defer tx.Rollback()
+
+  version, err := nextVersion(ctx, tx, teamId, expected)
+  if err != nil {
+    return nil, -1, err
+  }
+  project, err := lockProject(ctx, tx, teamId, projectId)
+  if err != nil {
+    return nil, -1, err
+  }
+  if project.ArchivedAt != 0 {
+    return nil, -1, projectArchived(projectId)
+  }
+  if project.Status == ProjectActive {
+    return nil, -1, domainerr.FailedPrecondition(domainerr.ReasonProjectActive, "project '%s' is active, complete or abandon it first", projectId).With("project_id", projectId)
+  }
+
+  now := time.Now().Unix()
+  if _, err := tx.ExecContext(ctx, archiveStmt, now, projectId); err != nil {
+    return nil, -1, err
+  }
+  project.ArchivedAt = now
+
+  // record project_archived event in the outbox
+  err = insertOutboxEvent(tx, ProjectArchivedTopic, &v1.ProjectArchived{
+    TeamId:     teamId,
+    ProjectId:  projectId,
+    OccurredAt: now,
+  })
+  if err != nil {
+    return nil, -1, err
+  }
+  err = insertAuditEvent(ctx, tx, teamId, AuditArchiveProject, auditState{"project_id": projectId, "archived_at": 0}, auditState{"project_id": projectId, "archived_at": now})
+  if err != nil {
+    return nil, -1, err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return nil, -1, err
+  }
+  return project, version, nil
+}
+
+// Lists the projects of team teamId in any of statuses, every status when
+// statuses is empty, oldest first. Archived projects are only listed when
+// includeArchived is set.
+// output ON FAILURE: TEAM_NOT_FOUND or the error object from whatever created the error
+func (r *teamRepository) ListProjects(ctx context.Context, teamId string, statuses []string, includeArchived bool) ([]*v1.Project, error) {
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+  if err != nil {
+    return nil, err
+  }
+  defer tx.Rollback()
+
+  if err := checkTeamExists(ctx, tx, teamId); err != nil {
+    return nil, err
+  }
+
+  stmt := `SELECT ` + projectColumns + ` FROM projects WHERE team_id=?`
+  args := []interface{}{teamId}
+  if len(statuses) > 0 {
+    in, statusArgs := stringInList(statuses)
+    stmt += ` AND status IN ` + in
+    args = append(args, statusArgs...)
+  }
+  if !includeArchived {
+    stmt += ` AND archived_at IS NULL`
+  }
+  stmt += ` ORDER BY id`
+
+  projects := []*v1.Project{}
+  byId := map[string]*v1.Project{}
+  progress := map[int64]*v1.Project{}
+  err = queryRows(ctx, tx, stmt, args, func(rows *sql.Rows) error {
+    project, err := scanProject(rows)
+    if err != nil {
+      return err
+    }
+    projects = append(projects, project)
+    byId[project.Id] = project
+    id, _ := strconv.ParseInt(project.Id, 10, 64)
+    progress[id] = project
+    return nil
+  })
+  if err != nil {
+    return nil, err
+  }
+
+  err = queryRows(ctx, tx, `SELECT project_id, lang_name FROM languages WHERE team_id=? ORDER BY id`, []interface{}{teamId}, func(rows *sql.Rows) error {
+    var projectId sql.NullString
+    var language string
+    if err := rows.Scan(&projectId, &language); err != nil {
+      return err
+    }
+    if project, ok := byId[projectId.String]; ok {
+      project.Languages = append(project.Languages, language)
+    }
+    return nil
+  })
+  if err != nil {
+    return nil, err
+  }
+  if err := setProjectProgress(ctx, tx, progress); err != nil {
+    return nil, err
+  }
+  return projects, nil
+}
+
+// ---------------------------- HELPER FUNCTIONS -------------------------------
+
+// scanProject scans a row of the columns before, if any, followed by projectColumns
+func scanProject(row interface{ Scan(...interface{}) error }, before ...interface{}) (*v1.Project, error) {
+  project := &v1.Project{}
+  var id int64
+  var startedAt, completedAt, archivedAt sql.NullInt64
+  dest := append(before, &id, &project.Description, &project.Name, &project.GithubLink, &project.Complexity, &project.Duration,
+    &project.Status, &startedAt, &completedAt, &archivedAt)
+  if err := row.Scan(dest...); err != nil {
+    return nil, err
+  }
+  project.Id = strconv.FormatInt(id, 10)
+  project.StartedAt = startedAt.Int64
+  project.CompletedAt = completedAt.Int64
+  project.ArchivedAt = archivedAt.Int64
+  return project, nil
+}
+
+// loadProject reads project projectId of team teamId within tx, languages
+// included
+func loadProject(ctx context.Context, tx *sql.Tx, teamId, projectId string) (*v1.Project, error) {
+  return readProject(ctx, tx, teamId, projectId, ``)
+}
+
+// lockProject is loadProject locking the project row
+func lockProject(ctx context.Context, tx *sql.Tx, teamId, projectId string) (*v1.Project, error) {
+  return readProject(ctx, tx, teamId, projectId, ` FOR UPDATE`)
+}
+
+func readProject(ctx context.Context, tx *sql.Tx, teamId, projectId, lock string) (*v1.Project, error) {
+  projectStmt := `SELECT ` + projectColumns + ` FROM projects WHERE id=? AND team_id=?` + lock
+  langStmt := `SELECT lang_name FROM languages WHERE project_id=? ORDER BY id`
+
+  project, err := scanProject(tx.QueryRowContext(ctx, projectStmt, projectId, teamId))
+  if err == sql.ErrNoRows {
+    return nil, domainerr.NotFound(domainerr.ReasonProjectNotFound, "project '%s' not found on team '%s'", projectId, teamId).
+      With("team_id", teamId).
+      With("project_id", projectId)
+  } else if err != nil {
+    return nil, err
+  }
+
+  err = queryRows(ctx, tx, langStmt, []interface{}{projectId}, func(rows *sql.Rows) error {
+    var language string
+    if err := rows.Scan(&language); err != nil {
+      return err
+    }
+    project.Languages = append(project.Languages, language)
+    return nil
+  })
+  if err != nil {
+    return nil, err
+  }
+
+  id, _ := strconv.ParseInt(projectId, 10, 64)
+  if err := setProjectProgress(ctx, tx, map[int64]*v1.Project{id: project}); err != nil {
+    return nil, err
+  }
+  return project, nil
+}
+
+// activeProjectId returns the id of the active project of team teamId, ""
+// if it has none
+func activeProjectId(ctx context.Context, tx *sql.Tx, teamId string) (string, error) {
+  selectStmt := `SELECT id FROM projects WHERE team_id=? AND status=? AND archived_at IS NULL FOR UPDATE`
+
+  var id string
+  err := tx.QueryRowContext(ctx, selectStmt, teamId, ProjectActive).Scan(&id)
+  if err == sql.ErrNoRows {
+    return "", nil
+  }
+  return id, err
+}
+
+// checkNoActiveProject fails unless team teamId has no active project other
+// than projectId
+func checkNoActiveProject(ctx context.Context, tx *sql.Tx, teamId, projectId string) error {
+  active, err := activeProjectId(ctx, tx, teamId)
+  if err != nil {
+    return err
+  }
+  if active != "" && active != projectId {
+    return domainerr.FailedPrecondition(domainerr.ReasonActiveProjectExists, "team '%s' already has the active project '%s'", teamId, active).
+      With("team_id", teamId).
+      With("project_id", active)
+  }
+  return nil
+}
+
+// checkProjectTransition fails unless project may move to status
+func checkProjectTransition(project *v1.Project, status string) error {
+  for _, next := range projectTransitions[project.Status] {
+    if next == status {
+      return nil
+    }
+  }
+  return domainerr.FailedPrecondition(domainerr.ReasonInvalidProjectTransition, "project '%s' can't go from %s to %s", project.Id, project.Status, status).
+    With("project_id", project.Id).
+    With("status", project.Status)
+}
+
+// insertLanguages adds languages to project projectId of team teamId
+func insertLanguages(ctx context.Context, tx *sql.Tx, teamId, projectId string, languages []string) error {
+  if len(languages) == 0 {
+    return nil
+  }
+  langStmt := `INSERT INTO languages (lang_name, team_id, project_id) VALUES %s`
+
+  langStrings := []string{}
+  langArgs := []interface{}{}
+  for _, w := range languages {
+    langStrings = append(langStrings, "(?, ?, ?)")
+    langArgs = append(langArgs, w, teamId, projectId)
+  }
+  _, err := tx.ExecContext(ctx, fmt.Sprintf(langStmt, strings.Join(langStrings, ",")), langArgs...)
+  return err
+}
+
+// lockOpenProject locks project projectId of team teamId, archived projects
+// and what they hold can't be changed
+func lockOpenProject(ctx context.Context, tx *sql.Tx, teamId, projectId string) error {
+  project, err := lockProject(ctx, tx, teamId, projectId)
+  if err != nil {
+    return err
+  }
+  if project.ArchivedAt != 0 {
+    return projectArchived(projectId)
+  }
+  return nil
+}
+
+// checkTeamExists fails unless team teamId exists and isn't in the trash
+func checkTeamExists(ctx context.Context, tx *sql.Tx, teamId string) error {
+  teamStmt := `SELECT COUNT(*) FROM teams WHERE id=? AND deleted_at IS NULL`
+
+  var count int
+  if err := tx.QueryRowContext(ctx, teamStmt, teamId).Scan(&count); err != nil {
+    return err
+  }
+  if count == 0 {
+    return domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", teamId).With("team_id", teamId)
+  }
+  return nil
+}
+
+func projectArchived(projectId string) error {
+  return domainerr.FailedPrecondition(domainerr.ReasonProjectArchived, "project '%s' is archived", projectId).With("project_id", projectId)
+}
+
+// nullTime stores the unix time t, NULL when it is 0
+func nullTime(t int64) sql.NullInt64 {
+  return sql.NullInt64{Int64: t, Valid: t != 0}
+}
@@ -0,0 +1,217 @@
+package v1
+
+import (
+  "context"
+  "testing"
+
+  "github.com/DATA-DOG/go-sqlmock"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// expectLockProject expects project 5 of team 3 to be locked in status,
+// archived at archivedAt unless it is 0
+func expectLockProject(mock sqlmock.Sqlmock, status string, archivedAt int64) {
+  var archived interface{}
+  if archivedAt != 0 {
+    archived = archivedAt
+  }
+  mock.ExpectQuery(stmt(`FROM projects WHERE id=? AND team_id=? FOR UPDATE`)).WithArgs("5", "3").
+    WillReturnRows(sqlmock.NewRows([]string{"id", "goal", "project_name", "github_link", "complexity", "duration", "status", "started_at", "completed_at", "archived_at"}).
+      AddRow(5, "", "tracker", "", 0, 0, status, nil, nil, archived))
+  mock.ExpectQuery(stmt(`SELECT lang_name FROM languages WHERE project_id=?`)).WithArgs("5").WillReturnRows(sqlmock.NewRows([]string{"lang_name"}))
+  mock.ExpectQuery(stmt(`FROM tasks WHERE project_id IN`)).WillReturnRows(sqlmock.NewRows([]string{"project_id", "total", "done"}))
+  mock.ExpectQuery(stmt(`FROM milestones WHERE project_id IN`)).WillReturnRows(sqlmock.NewRows([]string{"project_id", "total", "done"}))
+}
+
+// expectActiveProject expects the active project of team 3 to be looked up
+// and found to be id, none when id is ""
+func expectActiveProject(mock sqlmock.Sqlmock, id string) {
+  rows := sqlmock.NewRows([]string{"id"})
+  if id != "" {
+    rows.AddRow(id)
+  }
+  mock.ExpectQuery(stmt(`SELECT id FROM projects WHERE team_id=? AND status=? AND archived_at IS NULL FOR UPDATE`)).WithArgs("3", ProjectActive).
+    WillReturnRows(rows)
+}
+
+func TestProjectTransitions(t *testing.T) {
+  statuses := []string{ProjectPlanning, ProjectActive, ProjectCompleted, ProjectAbandoned}
+  allowed := map[string]map[string]bool{
+    ProjectPlanning: {ProjectActive: true, ProjectAbandoned: true},
+    ProjectActive:   {ProjectCompleted: true, ProjectAbandoned: true},
+  }
+  for _, from := range statuses {
+    for _, to := range statuses {
+      err := checkProjectTransition(&v1.Project{Id: "5", Status: from}, to)
+      if (err == nil) != allowed[from][to] {
+        t.Errorf("%s -> %s error = %v", from, to, err)
+      }
+      if err != nil && domainerr.ReasonOf(err) != domainerr.ReasonInvalidProjectTransition {
+        t.Errorf("%s -> %s error = %v, want %s", from, to, err, domainerr.ReasonInvalidProjectTransition)
+      }
+    }
+  }
+}
+
+func TestCreateProjectPlansByDefault(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 1)
+  // planned projects aren't started and don't need the team to be free
+  mock.ExpectExec(stmt(`INSERT INTO projects`)).WithArgs("", "tracker", "", "3", int32(0), int32(0), ProjectPlanning, nil).
+    WillReturnResult(sqlmock.NewResult(5, 1))
+  mock.ExpectExec(stmt(`INSERT INTO languages (lang_name, team_id, project_id) VALUES (?, ?, ?)`)).WithArgs("go", "3", "5").
+    WillReturnResult(sqlmock.NewResult(1, 1))
+  expectLoadProject(mock, "3", "5", []string{"go"})
+  expectOutbox(mock, ProjectCreatedTopic)
+  expectAudit(mock, "3", AuditCreateProject)
+  mock.ExpectCommit()
+
+  project, version, err := repo.CreateProject(context.Background(), "3", &v1.Project{Name: "tracker", Languages: []string{"go"}}, 0)
+  if err != nil || project.Id != "5" || version != 2 {
+    t.Errorf("CreateProject() = %v, %d, %v", project, version, err)
+  }
+}
+
+func TestCreateActiveProjectNeedsAFreeTeam(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 1)
+  expectActiveProject(mock, "4")
+  mock.ExpectRollback()
+
+  _, _, err := repo.CreateProject(context.Background(), "3", &v1.Project{Name: "tracker", Status: ProjectActive}, 0)
+  if domainerr.ReasonOf(err) != domainerr.ReasonActiveProjectExists {
+    t.Errorf("CreateProject() error = %v, want %s", err, domainerr.ReasonActiveProjectExists)
+  }
+}
+
+func TestUpdateProjectStartsIt(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 1)
+  expectLockProject(mock, ProjectPlanning, 0)
+  expectActiveProject(mock, "")
+  // only the status is masked, it stamps started_at
+  mock.ExpectExec(stmt(`UPDATE projects SET goal=?, project_name=?, github_link=?, complexity=?, duration=?, status=?, started_at=?, completed_at=? WHERE id=?`)).
+    WithArgs("", "tracker", "", int32(0), int32(0), ProjectActive, sqlmock.AnyArg(), nil, "5").
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  expectLoadProject(mock, "3", "5", nil)
+  expectOutbox(mock, ProjectUpdatedTopic)
+  expectAudit(mock, "3", AuditUpdateProject)
+  mock.ExpectCommit()
+
+  patch := &v1.Project{Name: "renamed", Status: ProjectActive}
+  project, _, err := repo.UpdateProject(context.Background(), "3", "5", patch, []string{"status"}, 0)
+  if err != nil || project.Status != ProjectActive {
+    t.Errorf("UpdateProject() = %v, %v", project, err)
+  }
+}
+
+func TestUpdateProjectChecks(t *testing.T) {
+  tests := []struct {
+    name   string
+    status string
+    expect func(mock sqlmock.Sqlmock)
+    reason string
+  }{
+    {"missing", ProjectActive, func(mock sqlmock.Sqlmock) {
+      mock.ExpectQuery(stmt(`FROM projects WHERE id=?`)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+    }, domainerr.ReasonProjectNotFound},
+    {"archived", ProjectActive, func(mock sqlmock.Sqlmock) {
+      expectLockProject(mock, ProjectPlanning, 1600000000)
+    }, domainerr.ReasonProjectArchived},
+    // completed projects stay completed
+    {"restarted", ProjectActive, func(mock sqlmock.Sqlmock) {
+      expectLockProject(mock, ProjectCompleted, 0)
+    }, domainerr.ReasonInvalidProjectTransition},
+    {"second active project", ProjectActive, func(mock sqlmock.Sqlmock) {
+      expectLockProject(mock, ProjectPlanning, 0)
+      expectActiveProject(mock, "4")
+    }, domainerr.ReasonActiveProjectExists},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      repo, mock := newMockRepository(t)
+      mock.ExpectBegin()
+      expectNextVersion(mock, "3", 1)
+      tt.expect(mock)
+      mock.ExpectRollback()
+
+      _, _, err := repo.UpdateProject(context.Background(), "3", "5", &v1.Project{Status: tt.status}, []string{"status"}, 0)
+      if domainerr.ReasonOf(err) != tt.reason {
+        t.Errorf("UpdateProject() error = %v, want %s", err, tt.reason)
+      }
+    })
+  }
+}
+
+func TestArchiveProject(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 1)
+  expectLockProject(mock, ProjectCompleted, 0)
+  mock.ExpectExec(stmt(`UPDATE projects SET archived_at=? WHERE id=?`)).WithArgs(sqlmock.AnyArg(), "5").WillReturnResult(sqlmock.NewResult(0, 1))
+  expectOutbox(mock, ProjectArchivedTopic)
+  expectAudit(mock, "3", AuditArchiveProject)
+  mock.ExpectCommit()
+
+  project, _, err := repo.ArchiveProject(context.Background(), "3", "5", 0)
+  if err != nil || project.ArchivedAt == 0 {
+    t.Errorf("ArchiveProject() = %v, %v", project, err)
+  }
+
+  // active projects are completed or abandoned first
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 2)
+  expectLockProject(mock, ProjectActive, 0)
+  mock.ExpectRollback()
+  if _, _, err := repo.ArchiveProject(context.Background(), "3", "5", 0); domainerr.ReasonOf(err) != domainerr.ReasonProjectActive {
+    t.Errorf("ArchiveProject() error = %v, want %s", err, domainerr.ReasonProjectActive)
+  }
+}
+
+func TestListProjectsFilters(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM teams`)).WillReturnRows(countRow(1))
+  mock.ExpectQuery(stmt(`FROM projects WHERE team_id=? AND status IN (?, ?) AND archived_at IS NULL ORDER BY id`)).
+    WithArgs("3", ProjectCompleted, ProjectAbandoned).WillReturnRows(sqlmock.NewRows(nil))
+  mock.ExpectQuery(stmt(`SELECT project_id, lang_name FROM languages WHERE team_id=?`)).WithArgs("3").WillReturnRows(sqlmock.NewRows(nil))
+  mock.ExpectRollback()
+
+  projects, err := repo.ListProjects(context.Background(), "3", []string{ProjectCompleted, ProjectAbandoned}, false)
+  if err != nil || len(projects) != 0 {
+    t.Errorf("ListProjects() = %v, %v", projects, err)
+  }
+}
+
+func TestUpsertProjectReplacesTheActiveProjectOnly(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 1)
+  expectActiveProject(mock, "5")
+  expectLoadProject(mock, "3", "5", []string{"go"})
+  // the other projects of the team are kept
+  mock.ExpectExec(stmt(`UPDATE projects SET goal=?, project_name=?, github_link=?, complexity=?, duration=? WHERE id=?`)).
+    WithArgs("", "tracker v2", "", int32(0), int32(0), "5").WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`DELETE FROM languages WHERE project_id=?`)).WithArgs("5").WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`INSERT INTO languages`)).WithArgs("rust", "3", "5").WillReturnResult(sqlmock.NewResult(2, 1))
+  expectLoadProject(mock, "3", "5", []string{"rust"})
+  expectOutbox(mock, ProjectUpsertedTopic)
+  expectAudit(mock, "3", AuditUpsertProject)
+  mock.ExpectCommit()
+
+  id, version, err := repo.UpsertProject(context.Background(), "3", &v1.Project{Name: "tracker v2", Languages: []string{"rust"}}, 0)
+  if err != nil || id != 5 || version != 2 {
+    t.Errorf("UpsertProject() = %d, %d, %v", id, version, err)
+  }
+}
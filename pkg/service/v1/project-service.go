@@ -0,0 +1,128 @@
+package v1
+
+import (
+  "context"
+  "fmt"
+  "os"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+func (s *handler) CreateProject(ctx context.Context, req *v1.CreateProjectRequest) (*v1.ProjectResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  // projects are managed by whoever may set the team's project
+  if _, _, err := s.authorize(ctx, req.TeamId, ActionUpsertProject); err != nil {
+    return nil, err
+  }
+
+  expected, err := expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
+  }
+
+  project, version, err := s.repo.CreateProject(ctx, req.TeamId, req.Project, expected)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo CreateProject: %v\n", req.TeamId)
+    return nil, err
+  }
+
+  // project_created Event is written to the outbox by the repository
+
+  return &v1.ProjectResponse{
+    Api:     apiVersion,
+    Status:  "Created",
+    Project: project,
+    Version: version,
+  }, nil
+}
+
+func (s *handler) UpdateProject(ctx context.Context, req *v1.UpdateProjectRequest) (*v1.ProjectResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  if _, _, err := s.authorize(ctx, req.TeamId, ActionUpsertProject); err != nil {
+    return nil, err
+  }
+
+  expected, err := expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
+  }
+
+  // the repository checks the status change against the project lifecycle
+  project, version, err := s.repo.UpdateProject(ctx, req.TeamId, req.ProjectId, req.Project, req.GetUpdateMask().GetPaths(), expected)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo UpdateProject: %v\n", req.ProjectId)
+    return nil, err
+  }
+
+  // project_updated Event is written to the outbox by the repository
+
+  return &v1.ProjectResponse{
+    Api:     apiVersion,
+    Status:  "Updated",
+    Project: project,
+    Version: version,
+  }, nil
+}
+
+func (s *handler) ArchiveProject(ctx context.Context, req *v1.ArchiveProjectRequest) (*v1.ProjectResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  if _, _, err := s.authorize(ctx, req.TeamId, ActionUpsertProject); err != nil {
+    return nil, err
+  }
+
+  expected, err := expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
+  }
+
+  project, version, err := s.repo.ArchiveProject(ctx, req.TeamId, req.ProjectId, expected)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo ArchiveProject: %v\n", req.ProjectId)
+    return nil, err
+  }
+
+  // project_archived Event is written to the outbox by the repository
+
+  return &v1.ProjectResponse{
+    Api:     apiVersion,
+    Status:  "Archived",
+    Project: project,
+    Version: version,
+  }, nil
+}
+
+func (s *handler) ListProjects(ctx context.Context, req *v1.ListProjectsRequest) (*v1.ListProjectsResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  // projects are public like the team they belong to
+  projects, err := s.repo.ListProjects(ctx, req.TeamId, req.Statuses, req.IncludeArchived)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo ListProjects: %v\n", req.TeamId)
+    return nil, err
+  }
+
+  status := "projects"
+  if len(projects) == 0 {
+    status = "empty"
+  }
+  return &v1.ListProjectsResponse{
+    Api:      apiVersion,
+    Status:   status,
+    Projects: projects,
+  }, nil
+}
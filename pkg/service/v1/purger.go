@@ -0,0 +1,50 @@
+package v1
+
+import (
+  "context"
+  "fmt"
+  "os"
+  "time"
+)
+
+// how often the purger looks for teams whose retention period is over
+const purgeInterval = time.Hour
+
+// TeamPurger deletes teams for good once they have been in the trash for the
+// repository's retention period. Several replicas may run one, a team is
+// purged by whichever locks it first.
+type TeamPurger struct {
+  repo repository
+}
+
+func NewTeamPurger(repo repository) *TeamPurger {
+  return &TeamPurger{
+    repo: repo,
+  }
+}
+
+// Run purges the trash until ctx is cancelled.
+func (p *TeamPurger) Run(ctx context.Context) {
+  ticker := time.NewTicker(purgeInterval)
+  defer ticker.Stop()
+
+  for {
+    // keep purging while full batches come back, the trash has a backlog
+    for {
+      count, err := p.repo.PurgeDeletedTeams(ctx)
+      if err != nil {
+        fmt.Fprintf(os.Stderr, "error purging deleted teams: %v\n", err)
+        break
+      }
+      if count < purgeBatchSize {
+        break
+      }
+    }
+
+    select {
+    case <-ctx.Done():
+      return
+    case <-ticker.C:
+    }
+  }
+}
@@ -0,0 +1,31 @@
+package v1
+
+import (
+  "context"
+  "testing"
+)
+
+func TestTeamPurgerDrainsTheBacklog(t *testing.T) {
+  _, repo := newTrashHandler()
+  // full batches are followed up at once, the last one isn't full
+  repo.batches = []int{purgeBatchSize, purgeBatchSize, 3}
+
+  ctx, cancel := context.WithCancel(context.Background())
+  cancel()
+  NewTeamPurger(repo).Run(ctx)
+  if repo.purges != 3 {
+    t.Errorf("purged %d times, want 3", repo.purges)
+  }
+}
+
+func TestTeamPurgerWaitsAfterAnError(t *testing.T) {
+  _, repo := newTrashHandler()
+  repo.batches = []int{purgeBatchSize}
+
+  ctx, cancel := context.WithCancel(context.Background())
+  cancel()
+  NewTeamPurger(repo).Run(ctx)
+  if repo.purges != 2 {
+    t.Errorf("purged %d times, want 2", repo.purges)
+  }
+}
@@ -3,42 +3,110 @@ package v1
 import (
   "context"
   "database/sql"
-  "errors"
   "fmt"
   "os"
   "strconv"
   "strings"
-  // "time"
+  "time"
 
   v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
 )
 
 type repository interface {
   CreateTeam(context.Context, *v1.Team) (string, error)
-  DeleteTeam(context.Context, string) (int64, int64, int64, error)
+  UpdateTeam(context.Context, string, *v1.Team, []string, int64) (*v1.Team, error) // in: teamId, new values, field mask paths, expected version || out: updated team
+  DeleteTeam(context.Context, string, string, int64) (int64, int64, int64, error) // in: teamId, userId, expected version
+  RestoreTeam(context.Context, string, string) (*v1.Team, error) // in: teamId, userId || out: restored team
+  ListDeletedTeams(context.Context, string) ([]*v1.DeletedTeam, error) // in: userId || out: teams the user leads in the trash
+  PurgeDeletedTeams(context.Context) (int, error) // out: number of teams purged
   GetTeamByTeamId(context.Context, string) (*v1.Team, error)
   GetTeamByTeamName(context.Context, string) (*v1.Team, error)
+  CheckTeamNameTaken(context.Context, string) (bool, error) // in: team name || out: whether a team, deleted ones included, has the name
   GetTeamsByUserId(context.Context, string) ([]*v1.Team, error)
-  AddMember(context.Context, *v1.MemberUpsertRequest) (string, error)
-  RemoveMember(context.Context, string, string) (int64, error)
-  UpsertProject(context.Context, string, *v1.Project) (int64, error)
-  GetTeams(context.Context, *v1.GetTeamsRequest) ([]*v1.Team, error)
+  GetTeamsByIds(context.Context, []string) ([]*v1.Team, error) // out: teams in the order of the ids, missing ones skipped
+  AddMember(context.Context, *v1.MemberUpsertRequest) (string, int64, error) // out: member number, new version
+  RemoveMember(context.Context, string, string, int64) (int64, int64, error) // in: teamId, member number, expected version || out: members removed, new version
+  UpsertProject(context.Context, string, *v1.Project, int64) (int64, int64, error) // in: teamId, project, expected version || out: id of the active project, new version
+  CreateProject(context.Context, string, *v1.Project, int64) (*v1.Project, int64, error) // in: teamId, project, expected version || out: created project, new version
+  UpdateProject(context.Context, string, string, *v1.Project, []string, int64) (*v1.Project, int64, error) // in: teamId, projectId, new values, field mask paths, expected version || out: updated project, new version
+  ArchiveProject(context.Context, string, string, int64) (*v1.Project, int64, error) // in: teamId, projectId, expected version || out: archived project, new version
+  ListProjects(context.Context, string, []string, bool) ([]*v1.Project, error) // in: teamId, statuses, includeArchived
+  CreateMilestone(context.Context, string, string, *v1.Milestone, int64) (*v1.Milestone, int64, error) // in: teamId, projectId, milestone, expected version || out: created milestone, new version
+  UpdateMilestone(context.Context, string, string, string, *v1.Milestone, []string, int64) (*v1.Milestone, int64, error) // in: teamId, projectId, milestoneId, new values, field mask paths, expected version || out: updated milestone, new version
+  DeleteMilestone(context.Context, string, string, string, int64) (*v1.Milestone, int64, error) // in: teamId, projectId, milestoneId, expected version || out: deleted milestone, new version
+  ListMilestones(context.Context, string, string) ([]*v1.Milestone, error) // in: teamId, projectId
+  CreateTask(context.Context, string, string, *v1.Task, int64) (*v1.Task, int64, error) // in: teamId, projectId, task, expected version || out: created task, new version
+  UpdateTask(context.Context, string, string, string, *v1.Task, []string, int64) (*v1.Task, int64, error) // in: teamId, projectId, taskId, new values, field mask paths, expected version || out: updated task, new version
+  DeleteTask(context.Context, string, string, string, int64) (*v1.Task, int64, error) // in: teamId, projectId, taskId, expected version || out: deleted task, new version
+  ListTasks(context.Context, *v1.ListTasksRequest) ([]*v1.Task, error)
+  GetTeams(context.Context, *v1.GetTeamsRequest) ([]*v1.Team, string, error) // out: page of teams, next page token
   CountUserTeams(context.Context, string) (int, error) // in: userId as string || out: Number of teams user owns as int, error
   CheckUserOwnsTeam(context.Context, string, string) (bool, error)
+  GetMemberRole(context.Context, string, string) (string, error) // in: userId, teamId || out: access role of user on team, "" if not a member
+  GetDeletedMemberRole(context.Context, string, string) (string, error) // in: userId, teamId || out: access role of user on the team in the trash, "" if not a member
+  GetMember(context.Context, string, string) (*v1.Member, error) // in: teamId, member number || out: member, nil if not found
   CheckMemberExists(context.Context, string, string) (bool, error)
   CheckTeamSize(context.Context, string) (bool, error)
+  RemoveUserFromTeams(context.Context, string) (int64, error)
+  UpdateMemberEmail(context.Context, string, string) (int64, error)
+  CreateInvitation(context.Context, *v1.Invitation) (string, error)
+  GetInvitation(context.Context, string) (*v1.Invitation, error)
+  ListInvitations(context.Context, string, string, bool) ([]*v1.Invitation, error) // in: teamId, or email when teamId is "", includeClosed
+  AcceptInvitation(context.Context, string, string) (string, string, error) // in: invitation id, userId || out: teamId, member number
+  CloseInvitation(context.Context, string, string) error // in: invitation id, declined or revoked
+  CreateApplication(context.Context, *v1.Application) (string, error)
+  GetApplication(context.Context, string) (*v1.Application, error)
+  ListApplications(context.Context, string, string, []string) ([]*v1.Application, error) // in: teamId, or userId when teamId is "", statuses
+  ApproveApplication(context.Context, string, string) (string, string, error) // in: application id, id of the approving user || out: teamId, member number
+  CloseApplication(context.Context, string, string, string) error // in: application id, rejected or withdrawn, id of the deciding user
+  TransferOwnership(context.Context, string, string, string, string, int64) (int64, error) // in: teamId, current owner's userId and email, new owner's userId, expected version || out: new version
+  ListAuditEvents(context.Context, *v1.ListAuditEventsRequest) ([]*v1.AuditEvent, string, error) // out: page of events, next page token
+  RecordUserActivity(context.Context, string, int64) (int64, error) // in: userId, unix time of the activity || out: number of teams marked active
+  FlagInactiveTeams(context.Context) ([]string, error) // out: ids of the teams flagged inactive
+  ArchiveInactiveTeams(context.Context) ([]string, error) // out: ids of the teams archived
 }
 
 type teamRepository struct {
-  db *sql.DB
+  db            *sql.DB
+  succession    SuccessionPolicy
+  retention     time.Duration
+  inactiveAfter time.Duration
+  inactiveGrace time.Duration
 }
 
 func NewTeamRepository(db *sql.DB) *teamRepository {
   return &teamRepository{
-    db: db,
+    db:            db,
+    succession:    SuccessionAdminThenMember,
+    retention:     DefaultTrashRetention,
+    inactiveAfter: DefaultInactiveAfter,
+    inactiveGrace: DefaultInactiveGrace,
   }
 }
 
+// WithSuccession sets the policy picking the new leader of a team whose
+// leader's account was removed
+func (r *teamRepository) WithSuccession(policy SuccessionPolicy) *teamRepository {
+  r.succession = policy
+  return r
+}
+
+// WithRetention sets how long deleted teams stay in the trash before they
+// are purged
+func (r *teamRepository) WithRetention(retention time.Duration) *teamRepository {
+  r.retention = retention
+  return r
+}
+
+// WithInactivity sets how long a team may be inactive before it is flagged
+// and how long after that it is archived unless it is active again
+func (r *teamRepository) WithInactivity(after, grace time.Duration) *teamRepository {
+  r.inactiveAfter = after
+  r.inactiveGrace = grace
+  return r
+}
+
 func (r *teamRepository) connect(ctx context.Context) (*sql.Conn, error) {
   c, err := r.db.Conn(ctx)
   if err != nil {
@@ -53,8 +121,8 @@ func (r *teamRepository) connect(ctx context.Context) (*sql.Conn, error) {
 // output ON FAILURE: string - nil, error - the error object from whatever created the error
 func (r *teamRepository) CreateTeam(ctx context.Context, team *v1.Team) (string, error) {
   // prepare sql statements for teams, skills, members
-  teamStmt := `INSERT INTO teams (leader, team_name, open_roles, size, last_active) VALUES(?, ?, ?, ?, ?)`
-  memberStmt := `INSERT INTO members (user_id, member_email, team_id, member_role) VALUES %s`
+  teamStmt := `INSERT INTO teams (leader, team_name, open_roles, size, last_active, auto_close_applications) VALUES(?, ?, ?, ?, ?, ?)`
+  memberStmt := `INSERT INTO members (user_id, member_email, team_id, member_role, access_role) VALUES %s`
   skillStmt := `INSERT INTO skills (skill_name, team_id) VALUES %s`
 
   //
@@ -65,8 +133,8 @@ func (r *teamRepository) CreateTeam(ctx context.Context, team *v1.Team) (string,
     return "transaction begin", err
   }
 
-  // insert team into teams table capturing the id
-  result, err := tx.Exec(teamStmt, team.Leader, team.Name, team.OpenRoles, team.Size, team.LastActive)
+  // insert team into teams table capturing the id, a new team is active
+  result, err := tx.Exec(teamStmt, team.Leader, team.Name, team.OpenRoles, team.Size, time.Now().Unix(), team.AutoCloseApplications)
   if err != nil {
     tx.Rollback()
     return "Exec team stmt", err
@@ -85,12 +153,13 @@ func (r *teamRepository) CreateTeam(ctx context.Context, team *v1.Team) (string,
     memberArgs := []interface{}{}
     // iterate over each member and construct sql arguments
     for _, w := range team.Members {
-      memberStrings = append(memberStrings, "(?, ?, ?, ?)")
+      memberStrings = append(memberStrings, "(?, ?, ?, ?, ?)")
 
       memberArgs = append(memberArgs, w.Id)
       memberArgs = append(memberArgs, w.Email)
       memberArgs = append(memberArgs, teamId)
       memberArgs = append(memberArgs, w.Role)
+      memberArgs = append(memberArgs, storedAccessRole(w.AccessRole))
     }
 
     // create member sql statement
@@ -126,6 +195,40 @@ func (r *teamRepository) CreateTeam(ctx context.Context, team *v1.Team) (string,
     return "Exec skill stmt", err
   }
 
+  // record team_created event in the outbox
+  err = insertOutboxEvent(tx, TeamCreatedTopic, &v1.TeamCreated{
+    TeamId:     strconv.FormatInt(teamId, 10),
+    Leader:     team.Leader,
+    Name:       team.Name,
+    OpenRoles:  team.OpenRoles,
+    Size:       team.Size,
+    Skills:     team.Skills,
+    Members:    team.Members,
+    OccurredAt: time.Now().Unix(),
+  })
+  if err != nil {
+    tx.Rollback()
+    return "Exec outbox stmt", err
+  }
+
+  // record the creation in the audit trail
+  skills := team.Skills
+  if skills == nil {
+    skills = []string{}
+  }
+  err = insertAuditEvent(ctx, tx, strconv.FormatInt(teamId, 10), AuditCreateTeam, nil, auditState{
+    "name":                    team.Name,
+    "leader":                  team.Leader,
+    "open_roles":              team.OpenRoles,
+    "size":                    team.Size,
+    "skills":                  skills,
+    "auto_close_applications": team.AutoCloseApplications,
+  })
+  if err != nil {
+    tx.Rollback()
+    return "Exec audit stmt", err
+  }
+
   // commit transaction
   err = tx.Commit()
   if err != nil {
@@ -136,685 +239,518 @@ func (r *teamRepository) CreateTeam(ctx context.Context, team *v1.Team) (string,
   return strconv.FormatInt(teamId, 10), nil
 }
 
-// Deletes a Team
-// input: context-the current handler context, team-team object from gRPC endpoint handler
-// output ON SUCCESS: string - id of newly inserted team, error - nil
-// output ON FAILURE: string - nil, error - the error object from whatever created the error
-func (r *teamRepository) DeleteTeam(ctx context.Context, id string) (int64, int64, int64, error) {
-  // prepare sql statements for teams, skills, members
-  teamStmt := `DELETE FROM teams WHERE id=?`
-  memberStmt := `DELETE FROM members WHERE team_id=?`
-  skillStmt := `DELETE FROM skills WHERE team_id=?`
-  projStmt := `DELETE FROM projects WHERE team_id=?`
-  langStmt := `DELETE FROM languages WHERE team_id=?`
-
-  idAsInt, _ := strconv.Atoi(id)
+// Moves a Team to the trash, its rows are kept until the purger deletes
+// them once the retention period is over and its name stays reserved
+// input: context-the current handler context, id-id of the team, userId-id of the deleting user, expected-version the team must be at, 0 for any
+// output ON SUCCESS: int64s - number of teams deleted and of members and skills they hold, error - nil
+// output ON FAILURE: int64s - -1, error - TEAM_NOT_FOUND, VERSION_MISMATCH or the error object from whatever created the error
+func (r *teamRepository) DeleteTeam(ctx context.Context, id, userId string, expected int64) (int64, int64, int64, error) {
+  teamStmt := `UPDATE teams SET deleted_at=?, deleted_by=? WHERE id=?`
+  memberStmt := `SELECT COUNT(*) FROM members WHERE team_id=?`
+  skillStmt := `SELECT COUNT(*) FROM skills WHERE team_id=?`
 
   // start transaction
   tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
   if err != nil {
     return -1, -1, -1, err
   }
+  defer tx.Rollback()
 
-  // delete all languages of a specific team
-  langResult, err := tx.Exec(langStmt, idAsInt)
-  if err != nil {
-    tx.Rollback()
-    return -1, -1, -1, err
-  }
-  // gather the number of rows deleted
-  _, err = langResult.RowsAffected()
-  if err != nil {
-    tx.Rollback()
+  // lock the team, a concurrent change fails the delete
+  if _, err := nextVersion(ctx, tx, id, expected); err != nil {
     return -1, -1, -1, err
   }
 
-  // delete all projects of a specific team
-  projResult, err := tx.Exec(projStmt, idAsInt)
-  if err != nil {
-    tx.Rollback()
-    return -1, -1, -1, err
-  }
-  // gather the number of rows deleted
-  _, err = projResult.RowsAffected()
-  if err != nil {
-    tx.Rollback()
+  now := time.Now()
+  if _, err := tx.ExecContext(ctx, teamStmt, now.Unix(), userId, id); err != nil {
     return -1, -1, -1, err
   }
 
-  // delete all members of a specific team
-  memResult, err := tx.Exec(memberStmt, idAsInt)
-  if err != nil {
-    tx.Rollback()
+  var memRows, skillRows int64
+  if err := tx.QueryRowContext(ctx, memberStmt, id).Scan(&memRows); err != nil {
     return -1, -1, -1, err
   }
-  // gather the number of rows deleted
-  memRows, err := memResult.RowsAffected()
-  if err != nil {
-    tx.Rollback()
+  if err := tx.QueryRowContext(ctx, skillStmt, id).Scan(&skillRows); err != nil {
     return -1, -1, -1, err
   }
 
-  // delete skills of a specific team
-  skillResult, err := tx.Exec(skillStmt, idAsInt)
-  if err != nil {
-    tx.Rollback()
-    return -1, -1, -1, err
-  }
-  // gather the number of rows deleted
-  skillRows, err := skillResult.RowsAffected()
+  // record team_deleted event in the outbox
+  err = insertOutboxEvent(tx, TeamDeletedTopic, &v1.TeamDeleted{
+    TeamId:     id,
+    DeletedBy:  userId,
+    PurgeAt:    now.Add(r.retention).Unix(),
+    OccurredAt: now.Unix(),
+  })
   if err != nil {
-    tx.Rollback()
     return -1, -1, -1, err
   }
 
-  // delete team
-  result, err := tx.Exec(teamStmt, idAsInt)
+  err = insertAuditEvent(ctx, tx, id, AuditDeleteTeam, nil, auditState{
+    "deleted_at": now.Unix(),
+    "purge_at":   now.Add(r.retention).Unix(),
+  })
   if err != nil {
-    tx.Rollback()
-    return -1, -1, -1, err
-  }
-  // gather the num of rows deleted
-  teamRows, err := result.RowsAffected()
-  if err != nil {
-    tx.Rollback()
     return -1, -1, -1, err
   }
 
   // commit transaction
-  err = tx.Commit()
-  if err != nil {
+  if err := tx.Commit(); err != nil {
     return -1, -1, -1, err
   }
-
-  // return number of rows deleted per object
-  return teamRows, memRows, skillRows, nil
+  return 1, memRows, skillRows, nil
 }
 
 // Adds a member to a team
 // input: context-the current handler context, id of team to be inserted to, user id of new member
-// output ON SUCCESS: string - member number of new member within team, error - nil
-// output ON FAILURE: string - nil, error - the error object from whatever created the error
-func (r *teamRepository) AddMember(ctx context.Context, req *v1.MemberUpsertRequest) (string, error) {
+// output ON SUCCESS: string - member number of new member within team, int64 - new version of the team, error - nil
+// output ON FAILURE: string - nil, error - TEAM_NOT_FOUND, VERSION_MISMATCH or the error object from whatever created the error
+func (r *teamRepository) AddMember(ctx context.Context, req *v1.MemberUpsertRequest) (string, int64, error) {
   // prepare sql statements for teams, skills, members
   // need to change this to update
 
-  memberStmt := `INSERT INTO members (user_id, team_id, member_email, member_role) VALUES (?, ?, ?, ?)`
+  memberStmt := `INSERT INTO members (user_id, team_id, member_email, member_role, access_role) VALUES (?, ?, ?, ?, ?)`
   teamStmt := `UPDATE teams SET open_roles = open_roles - 1 WHERE id=?`
 
   // start transaction
   tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
   if err != nil {
-    return "", err
+    return "", -1, err
+  }
+
+  // lock the team, checking it is at the expected version
+  version, err := nextVersion(ctx, tx, req.TeamId, req.ExpectedVersion)
+  if err != nil {
+    tx.Rollback()
+    return "", -1, err
   }
 
   convert, _ := strconv.ParseInt(req.MemberId, 10, 64)
 
   // insert team into teams table capturing the id
-  memResult, err := tx.Exec(memberStmt, convert, req.TeamId, req.MemberEmail, req.Role)
+  memResult, err := tx.Exec(memberStmt, convert, req.TeamId, req.MemberEmail, req.Role, storedAccessRole(req.AccessRole))
   if err != nil {
     tx.Rollback()
-    return "", err
+    return "", -1, err
   }
   // gather the id of the inserted team
   memId, err := memResult.LastInsertId()
   if err != nil {
     tx.Rollback()
-    return "", err
+    return "", -1, err
   }
 
   // decrement number of open roles on specific team
   _, err = tx.Exec(teamStmt, req.TeamId)
   if err != nil {
     tx.Rollback()
-    return "", err
+    return "", -1, err
+  }
+
+  // a team that just filled up may close its pending applications
+  err = closeFullTeamApplications(ctx, tx, req.TeamId)
+  if err != nil {
+    tx.Rollback()
+    return "", -1, err
+  }
+
+  // record member_added event in the outbox
+  err = insertOutboxEvent(tx, MemberAddedTopic, &v1.MemberAdded{
+    TeamId:       req.TeamId,
+    MemberNumber: strconv.FormatInt(memId, 10),
+    UserId:       req.MemberId,
+    Email:        req.MemberEmail,
+    Role:         req.Role,
+    AccessRole:   storedAccessRole(req.AccessRole),
+    OccurredAt:   time.Now().Unix(),
+  })
+  if err != nil {
+    tx.Rollback()
+    return "", -1, err
+  }
+
+  after := memberState(strconv.FormatInt(memId, 10), req.MemberId, req.MemberEmail, req.Role, storedAccessRole(req.AccessRole))
+  err = insertAuditEvent(ctx, tx, req.TeamId, AuditAddMember, nil, after)
+  if err != nil {
+    tx.Rollback()
+    return "", -1, err
   }
 
   // commit transaction
   err = tx.Commit()
   if err != nil {
-    return "", err
+    return "", -1, err
   }
-  return strconv.FormatInt(memId, 10), nil
+  return strconv.FormatInt(memId, 10), version, nil
 }
 
 // Removes a member from a team
-// input: context-the current handler context, id of team to be inserted to, user id of new member
-// output ON SUCCESS: string - member number of new member within team, error - nil
-// output ON FAILURE: string - nil, error - the error object from whatever created the error
-func (r *teamRepository) RemoveMember(ctx context.Context, teamId string, memberId string) (int64, error) {
+// input: context-the current handler context, id of team, member number of the member, expected-version the team must be at, 0 for any
+// output ON SUCCESS: int64 - number of members removed, int64 - new version of the team, error - nil
+// output ON FAILURE: int64 - -1, error - TEAM_NOT_FOUND, VERSION_MISMATCH or the error object from whatever created the error
+func (r *teamRepository) RemoveMember(ctx context.Context, teamId string, memberId string, expected int64) (int64, int64, error) {
   // prepare sql statements for teams, skills, members
   // need to change this to update
 
+  selectStmt := `SELECT user_id, member_email, member_role, access_role FROM members WHERE team_id=? AND id=?`
   memberStmt := `DELETE FROM members WHERE team_id=? AND id=?`
+  teamStmt := `UPDATE teams SET open_roles = open_roles + 1 WHERE id=?`
   // start transaction
   tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
   if err != nil {
-    return -1, err
+    return -1, -1, err
   }
 
-  // delete member from specified team
-  memResult, err := tx.Exec(memberStmt, teamId, memberId)
+  // lock the team, checking it is at the expected version
+  current, err := lockVersion(ctx, tx, teamId, expected)
   if err != nil {
     tx.Rollback()
-    return -1, err
+    return -1, -1, err
   }
-  // gather the number of rows deleted
-  numRows, err := memResult.RowsAffected()
-  if err != nil {
+
+  // keep the member for the audit trail, without one nothing is removed and
+  // the team keeps its version
+  var userId, email, role, accessRole string
+  err = tx.QueryRowContext(ctx, selectStmt, teamId, memberId).Scan(&userId, &email, &role, &accessRole)
+  if err == sql.ErrNoRows {
     tx.Rollback()
-    return -1, err
+    return 0, current, nil
+  } else if err != nil {
+    tx.Rollback()
+    return -1, -1, err
   }
 
-  // commit transaction
-  err = tx.Commit()
+  version, err := bumpVersion(ctx, tx, teamId, current)
   if err != nil {
-    return -1, err
+    tx.Rollback()
+    return -1, -1, err
   }
-  return numRows, nil
-}
-
-func (r *teamRepository) UpsertProject(ctx context.Context, teamId string, project *v1.Project) (int64, error) {
-  // prepare sql statements
-  projStmt := `INSERT INTO projects (goal, project_name, github_link, team_id, complexity, duration) VALUES(?, ?, ?, ?, ?, ?)`
-  langStmt := `INSERT INTO languages (lang_name, team_id) VALUES %s`
-
-  projDel := `DELETE FROM projects WHERE team_id=? `
-  langDel := `DELETE FROM languages WHERE team_id=? `
 
-  // start transaction
-  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  // delete member from specified team
+  memResult, err := tx.Exec(memberStmt, teamId, memberId)
   if err != nil {
-    fmt.Fprintf(os.Stderr, "error in BeginTx")
-    return -1, err
+    tx.Rollback()
+    return -1, -1, err
   }
-
-  // if team has project already delete it and its languages
-  // delete languages from specified team
-  _, err = tx.Exec(langDel, teamId)
+  // gather the number of rows deleted
+  numRows, err := memResult.RowsAffected()
   if err != nil {
     tx.Rollback()
-    return -1, err
+    return -1, -1, err
   }
 
-  // delete projects from specified team
-  _, err = tx.Exec(projDel, teamId)
-  if err != nil {
+  // nothing was removed, the team keeps its version
+  if numRows == 0 {
     tx.Rollback()
-    return -1, err
+    return 0, current, nil
   }
 
-  // insert project into projects table capturing the id
-  result, err := tx.Exec(projStmt, project.Description, project.Name, project.GithubLink, teamId, project.Complexity, project.Duration)
+  // the member's role is open again
+  _, err = tx.Exec(teamStmt, teamId)
   if err != nil {
-    fmt.Fprintf(os.Stderr, "error in Exec(Project)")
     tx.Rollback()
-    return -1, err
+    return -1, -1, err
   }
-  // gather the id of the inserted project
-  projectId, err := result.LastInsertId()
+
+  // record member_removed event in the outbox
+  err = insertOutboxEvent(tx, MemberRemovedTopic, &v1.MemberRemoved{
+    TeamId:       teamId,
+    MemberNumber: memberId,
+    OccurredAt:   time.Now().Unix(),
+  })
   if err != nil {
-    fmt.Fprintf(os.Stderr, "error in LastInsertId")
     tx.Rollback()
-    return -1, err
+    return -1, -1, err
   }
 
-  // create bulk array insert values.
-  langStrings := []string{}
-  langArgs := []interface{}{}
-  for _, w := range project.Languages {
-    langStrings = append(langStrings, "(?, ?)")
-
-    langArgs = append(langArgs, w)
-    langArgs = append(langArgs, teamId)
-  }
-
-  // create lang sql statement
-  langStmt = fmt.Sprintf(langStmt, strings.Join(langStrings, ","))
-
-  // insert langs into langs table including team_id field
-  _, err = tx.Exec(langStmt, langArgs...)
+  err = insertAuditEvent(ctx, tx, teamId, AuditRemoveMember, memberState(memberId, userId, email, role, accessRole), nil)
   if err != nil {
-    fmt.Fprintf(os.Stderr, "error in Exec(Language)")
     tx.Rollback()
-    return -1, err
+    return -1, -1, err
   }
 
   // commit transaction
   err = tx.Commit()
   if err != nil {
-    fmt.Fprintf(os.Stderr, "error in Commit()")
-    return -1, err
+    return -1, -1, err
   }
-
-  // return id of newly inserted team and no error
-  return projectId, nil
+  return numRows, version, nil
 }
 
-func (r *teamRepository) GetTeamByTeamName(ctx context.Context, name string) (*v1.Team, error) {
-  // prepare sql statements for team, member, skills, project, languages
-  teamStmt := `SELECT leader, team_name, open_roles, size, last_active, id FROM teams WHERE team_name=?`
-  memberStmt := `SELECT user_id, member_email, member_role FROM members WHERE team_id=?`
-  skillStmt := `SELECT skill_name FROM skills WHERE team_id=?`
-  projStmt := `SELECT goal, project_name, github_link, complexity, duration FROM projects WHERE team_id=?`
-  langStmt := `SELECT lang_name FROM languages WHERE team_id=?`
-
-  team := &v1.Team{}
-  members := []*v1.Member{}
-  skills := []string{}
-  project := &v1.Project{}
-  languages := []string{}
+// Sets the active project of a team, the active project is updated in place
+// or a new active project is created when the team has none. The team's
+// other projects are kept.
+// input: context-the current handler context, teamId-id of the team, project-the new project, expected-version the team must be at, 0 for any
+// output ON SUCCESS: int64 - id of the active project, int64 - new version of the team, error - nil
+// output ON FAILURE: int64 - -1, error - TEAM_NOT_FOUND, VERSION_MISMATCH or the error object from whatever created the error
+func (r *teamRepository) UpsertProject(ctx context.Context, teamId string, project *v1.Project, expected int64) (int64, int64, error) {
+  // prepare sql statements
+  projStmt := `INSERT INTO projects (goal, project_name, github_link, team_id, complexity, duration, status, started_at) VALUES(?, ?, ?, ?, ?, ?, ?, ?)`
+  projUpdate := `UPDATE projects SET goal=?, project_name=?, github_link=?, complexity=?, duration=? WHERE id=?`
+  langDel := `DELETE FROM languages WHERE project_id=? `
 
   // start transaction
   tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
   if err != nil {
     fmt.Fprintf(os.Stderr, "error in BeginTx")
-    return nil, err
+    return -1, -1, err
   }
 
-  name = strings.ToLower(name)
-
-  // execute team sql statement
-  row := tx.QueryRow(teamStmt, name)
-
-  // scan fields into team
-  err = row.Scan(&team.Leader, &team.Name, &team.OpenRoles, &team.Size, &team.LastActive, &team.Id)
-  if err == sql.ErrNoRows {
-    return nil, errors.New("team Query: no matching record found")
-  } else if err != nil {
-    return nil, err
+  // lock the team so concurrent upserts don't overwrite each other unnoticed
+  version, err := nextVersion(ctx, tx, teamId, expected)
+  if err != nil {
+    tx.Rollback()
+    return -1, -1, err
   }
 
-  // execute member statement
-  memberRows, err := tx.Query(memberStmt, team.Id)
+  // find the active project the upsert replaces
+  activeId, err := activeProjectId(ctx, tx, teamId)
   if err != nil {
-    fmt.Fprintf(os.Stderr, "error in members Query")
-    return nil, err
+    tx.Rollback()
+    return -1, -1, err
   }
 
-  defer memberRows.Close()
-
-  // scan each member row into members variable
-  for memberRows.Next() {
-    s := &v1.Member{}
-
-    err = memberRows.Scan(&s.Id, &s.Email, &s.Role)
+  var previous *v1.Project
+  if activeId != "" {
+    // keep the replaced project for the audit trail
+    previous, err = loadProject(ctx, tx, teamId, activeId)
     if err != nil {
-      return nil, err
+      tx.Rollback()
+      return -1, -1, err
     }
-    members = append(members, s)
-  }
-
-  if err = memberRows.Err(); err != nil {
-    return nil, err
-  }
-
-  // add retrieved members to team
-  team.Members = members
 
-  // execute skills statement query
-  skillRows, err := tx.Query(skillStmt, team.Id)
-  if err != nil {
-    fmt.Fprintf(os.Stderr, "error in skill Query")
-    return nil, err
-  }
-
-  //scan skills
-  defer skillRows.Close()
-
-  // scan each member row into skills variable
-  for skillRows.Next() {
-    s := ""
-
-    err = skillRows.Scan(&s)
+    // update the active project and drop its languages
+    _, err = tx.Exec(projUpdate, project.Description, project.Name, project.GithubLink, project.Complexity, project.Duration, activeId)
     if err != nil {
-      return nil, err
+      fmt.Fprintf(os.Stderr, "error in Exec(Project)")
+      tx.Rollback()
+      return -1, -1, err
     }
-    skills = append(skills, s)
-  }
-
-  if err = skillRows.Err(); err != nil {
-    return nil, err
+    _, err = tx.Exec(langDel, activeId)
+    if err != nil {
+      tx.Rollback()
+      return -1, -1, err
+    }
+  } else {
+    // insert an active project capturing the id
+    result, err := tx.Exec(projStmt, project.Description, project.Name, project.GithubLink, teamId, project.Complexity, project.Duration, ProjectActive, time.Now().Unix())
+    if err != nil {
+      fmt.Fprintf(os.Stderr, "error in Exec(Project)")
+      tx.Rollback()
+      return -1, -1, err
+    }
+    // gather the id of the inserted project
+    id, err := result.LastInsertId()
+    if err != nil {
+      fmt.Fprintf(os.Stderr, "error in LastInsertId")
+      tx.Rollback()
+      return -1, -1, err
+    }
+    activeId = strconv.FormatInt(id, 10)
   }
+  projectId, _ := strconv.ParseInt(activeId, 10, 64)
 
-  // add retrieved skills to team
-  team.Skills = skills
-
-  // execute project statement query
-  projectRow := tx.QueryRow(projStmt, team.Id)
-
-  // scan project
-  err = projectRow.Scan(&project.Description, &project.Name, &project.GithubLink, &project.Complexity, &project.Duration)
-  if err == sql.ErrNoRows {
-    // for development this is fine
-    // fmt.Fprintf(os.Stderr, "team has no project")
-  } else if err != nil {
-    return nil, err
+  // insert langs into langs table including team_id and project_id fields
+  err = insertLanguages(ctx, tx, teamId, activeId, project.Languages)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error in Exec(Language)")
+    tx.Rollback()
+    return -1, -1, err
   }
 
-  // execute languages statement query
-  languagesRows, err := tx.Query(langStmt, team.Id)
+  upserted, err := loadProject(ctx, tx, teamId, activeId)
   if err != nil {
-    fmt.Fprintf(os.Stderr, "error in languages Query")
-    return nil, err
+    tx.Rollback()
+    return -1, -1, err
   }
-  // scan languages
-  defer languagesRows.Close()
 
-  // scan each languages row into languages variable
-  for languagesRows.Next() {
-    s := ""
-
-    err = languagesRows.Scan(&s)
-    if err != nil {
-      return nil, err
-    }
-    languages = append(languages, s)
+  // record project_upserted event in the outbox
+  err = insertOutboxEvent(tx, ProjectUpsertedTopic, &v1.ProjectUpserted{
+    TeamId:     teamId,
+    ProjectId:  projectId,
+    Project:    upserted,
+    OccurredAt: time.Now().Unix(),
+  })
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error in Exec(Outbox)")
+    tx.Rollback()
+    return -1, -1, err
   }
 
-  if err = languagesRows.Err(); err != nil {
-    return nil, err
+  err = insertAuditEvent(ctx, tx, teamId, AuditUpsertProject, projectState(previous), projectState(upserted))
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error in Exec(Audit)")
+    tx.Rollback()
+    return -1, -1, err
   }
 
-  // add retrieved languagess to team
-  project.Languages = languages
-
   // commit transaction
   err = tx.Commit()
   if err != nil {
     fmt.Fprintf(os.Stderr, "error in Commit()")
-    return nil, err
+    return -1, -1, err
   }
 
-  team.Project = project
-
-  // return id of newly inserted team and no error
-  return team, nil
+  // return id of the active project, the team's new version and no error
+  return projectId, version, nil
 }
 
-func (r *teamRepository) GetTeamByTeamId(ctx context.Context, id string) (*v1.Team, error) {
-  // prepare sql statements for team, member, skills, project, languages
-  teamStmt := `SELECT leader, team_name, open_roles, size, last_active, id FROM teams WHERE id=?`
-  memberStmt := `SELECT user_id, member_email, member_role FROM members WHERE team_id=?`
-  skillStmt := `SELECT skill_name FROM skills WHERE team_id=?`
-  projStmt := `SELECT goal, project_name, github_link, complexity, duration FROM projects WHERE team_id=?`
-  langStmt := `SELECT lang_name FROM languages WHERE team_id=?`
-
-  team := &v1.Team{}
-  members := []*v1.Member{}
-  skills := []string{}
-  project := &v1.Project{}
-  languages := []string{}
-
-  // start transaction
-  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
-  if err != nil {
-    fmt.Fprintf(os.Stderr, "error in BeginTx")
-    return nil, err
-  }
+func (r *teamRepository) GetTeamByTeamName(ctx context.Context, name string) (*v1.Team, error) {
+  teamStmt := `SELECT id FROM teams WHERE team_name=? AND deleted_at IS NULL`
 
-  // execute team sql statement
-  row := tx.QueryRow(teamStmt, id)
+  name = strings.ToLower(name)
 
-  // scan fields into team
-  err = row.Scan(&team.Leader, &team.Name, &team.OpenRoles, &team.Size, &team.LastActive, &team.Id)
+  var id int64
+  err := r.db.QueryRowContext(ctx, teamStmt, name).Scan(&id)
   if err == sql.ErrNoRows {
-    return nil, errors.New("team Query: no matching record found")
+    return nil, domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", name).With("team_name", name)
   } else if err != nil {
     return nil, err
   }
 
-  // execute member statement
-  memberRows, err := tx.Query(memberStmt, id)
+  team, err := r.loadTeam(ctx, id)
   if err != nil {
-    fmt.Fprintf(os.Stderr, "error in members Query")
     return nil, err
   }
-
-  defer memberRows.Close()
-
-  // scan each member row into members variable
-  for memberRows.Next() {
-    s := &v1.Member{}
-
-    err = memberRows.Scan(&s.Id, &s.Email, &s.Role)
-    if err != nil {
-      return nil, err
-    }
-    members = append(members, s)
+  if team == nil {
+    // deleted between the two queries
+    return nil, domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", name).With("team_name", name)
   }
+  return team, nil
+}
 
-  if err = memberRows.Err(); err != nil {
-    return nil, err
-  }
-
-  // add retrieved members to team
-  team.Members = members
-
-  // execute skills statement query
-  skillRows, err := tx.Query(skillStmt, id)
-  if err != nil {
-    fmt.Fprintf(os.Stderr, "error in skill Query")
-    return nil, err
-  }
-
-  //scan skills
-  defer skillRows.Close()
-
-  // scan each member row into skills variable
-  for skillRows.Next() {
-    s := ""
-
-    err = skillRows.Scan(&s)
-    if err != nil {
-      return nil, err
-    }
-    skills = append(skills, s)
-  }
-
-  if err = skillRows.Err(); err != nil {
-    return nil, err
-  }
-
-  // add retrieved skills to team
-  team.Skills = skills
-
-  // execute project statement query
-  projectRow := tx.QueryRow(projStmt, id)
+// takes a team name and reports whether a team has it, teams in the trash
+// keep their name reserved until they are purged
+func (r *teamRepository) CheckTeamNameTaken(ctx context.Context, name string) (bool, error) {
+  countStmt := `SELECT COUNT(*) FROM teams WHERE team_name=?`
 
-  // scan project
-  err = projectRow.Scan(&project.Description, &project.Name, &project.GithubLink, &project.Complexity, &project.Duration)
-  if err == sql.ErrNoRows {
-    // for development this is fine
-    // fmt.Fprintf(os.Stderr, "team has no project")
-  } else if err != nil {
-    return nil, err
+  var count int
+  if err := r.db.QueryRowContext(ctx, countStmt, name).Scan(&count); err != nil {
+    return false, err
   }
+  return count > 0, nil
+}
 
-  // execute languages statement query
-  languagesRows, err := tx.Query(langStmt, id)
+func (r *teamRepository) GetTeamByTeamId(ctx context.Context, id string) (*v1.Team, error) {
+  teamId, err := strconv.ParseInt(id, 10, 64)
   if err != nil {
-    fmt.Fprintf(os.Stderr, "error in languages Query")
-    return nil, err
-  }
-  // scan languages
-  defer languagesRows.Close()
-
-  // scan each languages row into languages variable
-  for languagesRows.Next() {
-    s := ""
-
-    err = languagesRows.Scan(&s)
-    if err != nil {
-      return nil, err
-    }
-    languages = append(languages, s)
-  }
-
-  if err = languagesRows.Err(); err != nil {
-    return nil, err
+    return nil, domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", id).With("team_id", id)
   }
 
-  // add retrieved languagess to team
-  project.Languages = languages
-
-  // commit transaction
-  err = tx.Commit()
+  team, err := r.loadTeam(ctx, teamId)
   if err != nil {
-    fmt.Fprintf(os.Stderr, "error in Commit()")
     return nil, err
   }
-
-  team.Project = project
-
-  // return id of newly inserted team and no error
+  if team == nil {
+    return nil, domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", id).With("team_id", id)
+  }
   return team, nil
 }
 
 func (r *teamRepository) GetTeamsByUserId(ctx context.Context, id string) ([]*v1.Team, error) {
-  // prepare sql statements for team, member, skills, project, languages
-  memberStmt := `SELECT team_id FROM members WHERE user_id=?`
-
-  // prepare necessary variables
-  teams := []*v1.Team{}
-  members := []int{}
-
-  fmt.Fprintf(os.Stderr, "id: %v\n")
-
-  // select all member rows where user_id = id
-  // for each row, call GetTeamByTeamId append response to teams var
-  // return teams
+  memberStmt := `SELECT DISTINCT team_id FROM members WHERE user_id=? ORDER BY team_id`
 
-  // execute member statement
-  memberRows, err := r.db.Query(memberStmt, id)
+  // select the teams the user is a member of
+  memberRows, err := r.db.QueryContext(ctx, memberStmt, id)
   if err != nil {
-    fmt.Fprintf(os.Stderr, "error in members Query")
+    fmt.Fprintf(os.Stderr, "error in members Query\n")
     return nil, err
   }
-
   defer memberRows.Close()
 
-  // scan each member row into members variable
+  ids := []int64{}
   for memberRows.Next() {
-    var s int
-
-    // scan members.team_id into member's Id field
-    err = memberRows.Scan(&s)
+    var teamId int64
+    err = memberRows.Scan(&teamId)
     if err != nil {
       return nil, err
     }
-    members = append(members, s)
+    ids = append(ids, teamId)
   }
-
-  fmt.Fprintf(os.Stderr, "members: %v\n", members)
-
   if err = memberRows.Err(); err != nil {
     return nil, err
   }
 
-  // iterate over each member, calling GetTeamByTeamId() with team_id of each team user is from
-  for _, mem := range members {
-    team := &v1.Team{}
+  // return list of teams that user is in
+  return r.loadTeams(ctx, ids)
+}
 
-    team, err = r.GetTeamByTeamId(ctx, strconv.Itoa(int(mem)))
-    fmt.Fprintf(os.Stderr, "team: %v\n", team)
+// Gets the teams with ids in the order given, ids of missing teams are skipped
+func (r *teamRepository) GetTeamsByIds(ctx context.Context, ids []string) ([]*v1.Team, error) {
+  teamIds := []int64{}
+  for _, id := range ids {
+    teamId, err := strconv.ParseInt(id, 10, 64)
     if err != nil {
-      return teams, err
+      continue
     }
-
-    teams = append(teams, team)
+    teamIds = append(teamIds, teamId)
   }
-
-  // return list of teams that user is in
-  return teams, nil
+  return r.loadTeams(ctx, teamIds)
 }
 
-func (r *teamRepository) GetTeams(ctx context.Context, req *v1.GetTeamsRequest) ([]*v1.Team, error) {
-  // calculate page id
-  // range over id's from page_id to page_id + limit calling GetTeamByTeamId and appending to teams var
-  // return teams
-  var item_id int64
-  item_id = 0
-  if req.Page > 1 {
-    item_id = req.Limit * (req.Page - 1)
-  }
+// defaultPageSize is the GetTeams page size when the request has no limit
+const defaultPageSize = 20
 
-  // time_diff := time.Now().Unix() - 2592000
-
-  // query for future in production
-  // `SELECT id FROM teams WHERE id > ? AND last_active > ? ORDER BY id ASC LIMIT ?`
-
-  // technology query param is frozen for now
-
-  teamStmt := ""
-  var teamRows *sql.Rows
-  var err error
-
-  if req.Role != "" {
-    teamStmt = `SELECT team_id FROM skills WHERE skill_name=? ORDER BY id ASC LIMIT ?`
-    teamRows, err = r.db.Query(teamStmt, req.Role, req.Limit*10)
-  } else if req.Level != 0 {
-    teamStmt = `SELECT team_id FROM projects WHERE complexity=? ORDER BY id ASC LIMIT ?`
-    teamRows, err = r.db.Query(teamStmt, req.Level, req.Limit)
-  } else {
-    teamStmt = `SELECT id FROM teams WHERE id > ? ORDER BY id ASC LIMIT ?`
-    teamRows, err = r.db.Query(teamStmt, item_id, req.Limit)
+// Gets a page of teams in the order of req.Sort matching every filter of req. Pages
+// continue after the cursor of req.PageToken, or are counted with
+// req.Page for clients that don't send tokens yet.
+// output: teams of the page, token of the next page or "" on the last page, error
+func (r *teamRepository) GetTeams(ctx context.Context, req *v1.GetTeamsRequest) ([]*v1.Team, string, error) {
+  token, err := decodePageToken(req)
+  if err != nil {
+    return nil, "", err
   }
 
-  teams := []*v1.Team{}
-  teamsOut := []*v1.Team{}
+  limit := req.Limit
+  if limit <= 0 {
+    limit = defaultPageSize
+  }
 
-  fmt.Fprintf(os.Stderr, "request in repo: %v\n", req)
-  fmt.Fprintf(os.Stderr, "teamStmt: %v\n", teamStmt)
+  // one extra row tells whether there is a next page
+  teamStmt, args := buildTeamQuery(req, token, limit+1)
+  if token == nil && req.Page > 1 {
+    teamStmt += ` OFFSET ?`
+    args = append(args, limit*(req.Page-1))
+  }
 
+  teamRows, err := r.db.QueryContext(ctx, teamStmt, args...)
   if err != nil {
-    fmt.Fprintf(os.Stderr, "error in GetTeams Query")
-    return nil, err
+    fmt.Fprintf(os.Stderr, "error in GetTeams Query\n")
+    return nil, "", err
   }
-
   defer teamRows.Close()
 
-  // scan each team row into teams variable
+  ids := []int64{}
+  keys := []int64{}
   for teamRows.Next() {
-    s := &v1.Team{}
-
-    // scan teams.team_id into team's Id field
-    err = teamRows.Scan(&s.Id)
+    var id, key int64
+    err = teamRows.Scan(&id, &key)
     if err != nil {
-      return nil, err
+      return nil, "", err
     }
-    teams = append(teams, s)
+    ids = append(ids, id)
+    keys = append(keys, key)
   }
-
-  fmt.Fprintf(os.Stderr, "teams after 1st scan: %v\n", teams)
-
   if err = teamRows.Err(); err != nil {
-    return nil, err
+    return nil, "", err
   }
 
-  // range over list of ids calling GetTeamByTeamId
-  for _, mem := range teams {
-    team := &v1.Team{}
-
-    team, err = r.GetTeamByTeamId(ctx, mem.Id)
-    if err != nil {
-      return teamsOut, err
-    }
-
-    teamsOut = append(teamsOut, team)
+  nextToken := ""
+  if int64(len(ids)) > limit {
+    ids = ids[:limit]
+    nextToken = encodePageToken(req, keys[limit-1], ids[limit-1])
   }
 
-  fmt.Fprintf(os.Stderr, "teamsOut before return: %v\n", teamsOut)
-
-  // return list of teams that user is in
-  return teamsOut, nil
-  //return teams, nil
+  teams, err := r.loadTeams(ctx, ids)
+  if err != nil {
+    return nil, "", err
+  }
+  return teams, nextToken, nil
 }
 
 // takes a userId and searches db for how many teams this user owns
 // returns number of teams owned and an error
 func (r *teamRepository) CountUserTeams(ctx context.Context, userId string) (int, error) {
-  countStmt := `SELECT COUNT(*) FROM teams WHERE leader=?`
+  countStmt := `SELECT COUNT(*) FROM teams WHERE leader=? AND deleted_at IS NULL`
 
   fmt.Fprintf(os.Stderr, "request userID: %v\n", userId)
   rows, err := r.db.Query(countStmt, userId)
@@ -837,14 +773,14 @@ func (r *teamRepository) CountUserTeams(ctx context.Context, userId string) (int
 // returns true if user owns team false if not
 func (r *teamRepository) CheckUserOwnsTeam(ctx context.Context, userId, teamId string) (bool, error) {
   // select leader FROM teams WHERE leader=userId AND id=teamId
-  checkStmt := `SELECT leader FROM teams WHERE leader=? AND id=?`
+  checkStmt := `SELECT leader FROM teams WHERE leader=? AND id=? AND deleted_at IS NULL`
   // get row
   row := r.db.QueryRow(checkStmt, userId, teamId)
   var leader string
   // scan fields into team
   err := row.Scan(&leader)
   if err == sql.ErrNoRows {
-    return false, errors.New("CheckUserOwnsTeam Query: no matching record found")
+    return false, nil
   } else if err != nil {
     return false, err
   }
@@ -853,6 +789,70 @@ func (r *teamRepository) CheckUserOwnsTeam(ctx context.Context, userId, teamId s
   return true, nil
 }
 
+// takes a userId and teamId and returns the user's access role on the team,
+// the leader is the owner and "" is returned if the user isn't a member.
+// Teams in the trash aren't found.
+func (r *teamRepository) GetMemberRole(ctx context.Context, userId, teamId string) (string, error) {
+  return r.memberRoleIn(ctx, userId, teamId, false)
+}
+
+// GetDeletedMemberRole is GetMemberRole over the teams in the trash, it
+// authorizes restoring them
+func (r *teamRepository) GetDeletedMemberRole(ctx context.Context, userId, teamId string) (string, error) {
+  return r.memberRoleIn(ctx, userId, teamId, true)
+}
+
+// memberRoleIn is GetMemberRole over the teams in the trash when deleted is
+// true, over the other teams otherwise
+func (r *teamRepository) memberRoleIn(ctx context.Context, userId, teamId string, deleted bool) (string, error) {
+  trash := ` AND t.deleted_at IS NULL`
+  if deleted {
+    trash = ` AND t.deleted_at IS NOT NULL`
+  }
+  roleStmt := `SELECT t.leader, m.access_role FROM teams t
+    LEFT JOIN members m ON m.team_id = t.id AND m.user_id = ?
+    WHERE t.id = ?` + trash + `
+    ORDER BY m.id LIMIT 1`
+
+  row := r.db.QueryRowContext(ctx, roleStmt, userId, teamId)
+  var leader string
+  var role sql.NullString
+  err := row.Scan(&leader, &role)
+  if err == sql.ErrNoRows {
+    return "", domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", teamId).With("team_id", teamId)
+  } else if err != nil {
+    return "", err
+  }
+
+  if leader == userId {
+    return RoleOwner, nil
+  }
+  return role.String, nil
+}
+
+// takes a teamId and member number and returns the membership, nil if the
+// team has no such member
+func (r *teamRepository) GetMember(ctx context.Context, teamId, memberNumber string) (*v1.Member, error) {
+  memberStmt := `SELECT m.id, m.user_id, m.member_email, m.member_role, m.access_role, t.leader
+    FROM members m JOIN teams t ON t.id = m.team_id
+    WHERE m.team_id = ? AND m.id = ?`
+
+  row := r.db.QueryRowContext(ctx, memberStmt, teamId, memberNumber)
+  member := &v1.Member{}
+  var leader string
+  err := row.Scan(&member.MemberNumber, &member.Id, &member.Email, &member.Role, &member.AccessRole, &leader)
+  if err == sql.ErrNoRows {
+    return nil, nil
+  } else if err != nil {
+    return nil, err
+  }
+
+  if strconv.Itoa(int(member.Id)) == leader {
+    member.AccessRole = RoleOwner
+  }
+  return member, nil
+}
+
 // takes a userId and teamId and searches db if user is already on team
 // returns true if user on team false if not
 func (r *teamRepository) CheckMemberExists(ctx context.Context, userId, teamId string) (bool, error) {
@@ -898,8 +898,237 @@ func (r *teamRepository) CheckTeamSize(ctx context.Context, teamId string) (bool
   return false, nil
 }
 
+// Removes a deleted user from every team they are on
+// Each membership frees a role on its team and every team the user led is
+// handed to the successor picked by the repository's SuccessionPolicy, or
+// flagged as orphaned if nobody qualifies.
+// input: context-the current handler context, userId-id of the deleted user
+// output ON SUCCESS: int64 - number of memberships removed, error - nil
+// output ON FAILURE: int64 - -1, error - the error object from whatever created the error
+func (r *teamRepository) RemoveUserFromTeams(ctx context.Context, userId string) (int64, error) {
+  selectStmt := `SELECT id, team_id, member_email, member_role, access_role FROM members WHERE user_id=? FOR UPDATE`
+  memberStmt := `DELETE FROM members WHERE id=?`
+  teamStmt := `UPDATE teams SET open_roles = open_roles + 1, version = version + 1 WHERE id=?`
+  ledStmt := `SELECT id FROM teams WHERE leader=? AND deleted_at IS NULL FOR UPDATE`
+  leaderStmt := `UPDATE teams SET leader=?, orphaned=0, version = version + 1 WHERE id=?`
+  orphanStmt := `UPDATE teams SET orphaned=1, version = version + 1 WHERE id=?`
+
+  // start transaction
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return -1, err
+  }
+
+  // gather every membership of the user, locking the rows
+  rows, err := tx.Query(selectStmt, userId)
+  if err != nil {
+    tx.Rollback()
+    return -1, err
+  }
+
+  type membership struct {
+    id         int64
+    teamId     int64
+    email      string
+    role       string
+    accessRole string
+  }
+
+  memberships := []membership{}
+  for rows.Next() {
+    m := membership{}
+    err = rows.Scan(&m.id, &m.teamId, &m.email, &m.role, &m.accessRole)
+    if err != nil {
+      rows.Close()
+      tx.Rollback()
+      return -1, err
+    }
+    memberships = append(memberships, m)
+  }
+  rows.Close()
+
+  if err = rows.Err(); err != nil {
+    tx.Rollback()
+    return -1, err
+  }
+
+  // delete each membership and give the role back to its team
+  for _, m := range memberships {
+    _, err = tx.Exec(memberStmt, m.id)
+    if err != nil {
+      tx.Rollback()
+      return -1, err
+    }
+
+    _, err = tx.Exec(teamStmt, m.teamId)
+    if err != nil {
+      tx.Rollback()
+      return -1, err
+    }
+
+    err = insertOutboxEvent(tx, MemberRemovedTopic, &v1.MemberRemoved{
+      TeamId:       strconv.FormatInt(m.teamId, 10),
+      MemberNumber: strconv.FormatInt(m.id, 10),
+      OccurredAt:   time.Now().Unix(),
+    })
+    if err != nil {
+      tx.Rollback()
+      return -1, err
+    }
+
+    before := memberState(strconv.FormatInt(m.id, 10), userId, m.email, m.role, m.accessRole)
+    err = insertAuditEvent(ctx, tx, strconv.FormatInt(m.teamId, 10), AuditRemoveMember, before, nil)
+    if err != nil {
+      tx.Rollback()
+      return -1, err
+    }
+  }
+
+  // gather every team the user led
+  ledRows, err := tx.Query(ledStmt, userId)
+  if err != nil {
+    tx.Rollback()
+    return -1, err
+  }
+
+  led := []int64{}
+  for ledRows.Next() {
+    var teamId int64
+    err = ledRows.Scan(&teamId)
+    if err != nil {
+      ledRows.Close()
+      tx.Rollback()
+      return -1, err
+    }
+    led = append(led, teamId)
+  }
+  ledRows.Close()
+
+  if err = ledRows.Err(); err != nil {
+    tx.Rollback()
+    return -1, err
+  }
+
+  // hand each team to the successor its SuccessionPolicy picks or flag it as
+  // orphaned when nobody qualifies
+  for _, teamId := range led {
+    event := &v1.LeaderChanged{
+      TeamId:         strconv.FormatInt(teamId, 10),
+      PreviousLeader: userId,
+      Reason:         leaderChangeSuccession,
+      OccurredAt:     time.Now().Unix(),
+    }
+
+    successor, err := r.successor(ctx, tx, teamId)
+    if err == nil && successor == "" {
+      _, err = tx.Exec(orphanStmt, teamId)
+      event.Orphaned = true
+    } else if err == nil {
+      _, err = tx.Exec(leaderStmt, successor, teamId)
+      event.Leader = successor
+    }
+    if err != nil {
+      tx.Rollback()
+      return -1, err
+    }
+
+    err = insertOutboxEvent(tx, LeaderChangedTopic, event)
+    if err != nil {
+      tx.Rollback()
+      return -1, err
+    }
+
+    after := auditState{"leader": event.Leader, "orphaned": event.Orphaned}
+    err = insertAuditEvent(ctx, tx, event.TeamId, AuditChangeLeader, auditState{"leader": userId}, after)
+    if err != nil {
+      tx.Rollback()
+      return -1, err
+    }
+  }
+
+  // commit transaction
+  err = tx.Commit()
+  if err != nil {
+    return -1, err
+  }
+
+  return int64(len(memberships)), nil
+}
+
+// Updates the email stored on every membership of a user
+// input: context-the current handler context, userId-id of the user, email-the new email
+// output ON SUCCESS: int64 - number of memberships updated, error - nil
+// output ON FAILURE: int64 - -1, error - the error object from whatever created the error
+func (r *teamRepository) UpdateMemberEmail(ctx context.Context, userId, email string) (int64, error) {
+  selectStmt := `SELECT id, team_id, member_email FROM members WHERE user_id=? FOR UPDATE`
+  updateStmt := `UPDATE members SET member_email=? WHERE user_id=?`
+  versionStmt := `UPDATE teams SET version = version + 1 WHERE id IN (SELECT team_id FROM members WHERE user_id=?)`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return -1, err
+  }
+  defer tx.Rollback()
+
+  // every membership changing email is audited on its team
+  type membership struct {
+    id     string
+    teamId string
+    email  string
+  }
+  memberships := []membership{}
+  err = queryRows(ctx, tx, selectStmt, []interface{}{userId}, func(rows *sql.Rows) error {
+    m := membership{}
+    if err := rows.Scan(&m.id, &m.teamId, &m.email); err != nil {
+      return err
+    }
+    memberships = append(memberships, m)
+    return nil
+  })
+  if err != nil {
+    return -1, err
+  }
+
+  result, err := tx.ExecContext(ctx, updateStmt, email, userId)
+  if err != nil {
+    return -1, err
+  }
+  count, err := result.RowsAffected()
+  if err != nil {
+    return -1, err
+  }
+
+  // the email is part of every team the user is on
+  if _, err := tx.ExecContext(ctx, versionStmt, userId); err != nil {
+    return -1, err
+  }
+
+  for _, m := range memberships {
+    before := auditState{"member_number": m.id, "email": m.email}
+    after := auditState{"member_number": m.id, "email": email}
+    if err := insertAuditEvent(ctx, tx, m.teamId, AuditUpdateMemberEmail, before, after); err != nil {
+      return -1, err
+    }
+  }
+
+  if err := tx.Commit(); err != nil {
+    return -1, err
+  }
+  return count, nil
+}
+
 // ---------------------------- HELPER FUNCTIONS -------------------------------
 
+// storedAccessRole returns the access role a membership is stored with,
+// ownership comes from leading the team so it is never stored and anything
+// that isn't a grantable role is stored as member
+func storedAccessRole(role string) string {
+  if !validAccessRole(role) {
+    return RoleMember
+  }
+  return role
+}
+
 func (r *teamRepository) checkCount(rows *sql.Rows) (int, error) {
   var count int
   for rows.Next() {
@@ -0,0 +1,181 @@
+package v1
+
+import (
+  "context"
+  "regexp"
+  "testing"
+
+  "github.com/DATA-DOG/go-sqlmock"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// newMockRepository returns a repository on a mock database, statements are
+// matched on the fragments passed to the expect helpers
+func newMockRepository(t *testing.T) (*teamRepository, sqlmock.Sqlmock) {
+  db, mock, err := sqlmock.New()
+  if err != nil {
+    t.Fatal(err)
+  }
+  t.Cleanup(func() {
+    if err := mock.ExpectationsWereMet(); err != nil {
+      t.Error(err)
+    }
+    db.Close()
+  })
+  return NewTeamRepository(db), mock
+}
+
+// stmt matches statements containing fragment
+func stmt(fragment string) string {
+  return regexp.QuoteMeta(fragment)
+}
+
+// expectNextVersion expects nextVersion to lock team teamId at version
+func expectNextVersion(mock sqlmock.Sqlmock, teamId string, version int64) {
+  expectLockVersion(mock, teamId, version)
+  expectBumpVersion(mock, teamId, version)
+}
+
+// expectLockVersion expects lockVersion to lock team teamId at version
+func expectLockVersion(mock sqlmock.Sqlmock, teamId string, version int64) {
+  mock.ExpectQuery(stmt(`SELECT version FROM teams WHERE id=? AND deleted_at IS NULL FOR UPDATE`)).WithArgs(teamId).
+    WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(version))
+}
+
+// expectBumpVersion expects bumpVersion to move team teamId on from version
+func expectBumpVersion(mock sqlmock.Sqlmock, teamId string, version int64) {
+  mock.ExpectExec(stmt(`UPDATE teams SET version=? WHERE id=?`)).WithArgs(version+1, teamId).
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`UPDATE teams SET last_active=?, inactive_at=NULL, archived_at=NULL WHERE id=?`)).WithArgs(sqlmock.AnyArg(), teamId).
+    WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+// expectAudit expects action on team teamId to be written to the audit trail
+func expectAudit(mock sqlmock.Sqlmock, teamId, action string) {
+  mock.ExpectExec(stmt(`INSERT INTO audit_events`)).WithArgs(teamId, sqlmock.AnyArg(), action, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+    WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+// expectOutbox expects an event on topic to be written to the outbox
+func expectOutbox(mock sqlmock.Sqlmock, topic string) {
+  mock.ExpectExec(stmt(`INSERT INTO outbox`)).WithArgs(sqlmock.AnyArg(), topic, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+    WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func TestCreateTeam(t *testing.T) {
+  repo, mock := newMockRepository(t)
+  team := &v1.Team{
+    Leader:    "7",
+    Name:      "gophers",
+    OpenRoles: 2,
+    Size:      3,
+    Skills:    []string{"go"},
+    Members:   []*v1.Member{{Id: 7, Email: "leader@example.com", Role: "backend", AccessRole: "owner"}},
+  }
+
+  mock.ExpectBegin()
+  mock.ExpectExec(stmt(`INSERT INTO teams`)).WithArgs("7", "gophers", int32(2), int32(3), sqlmock.AnyArg(), false).
+    WillReturnResult(sqlmock.NewResult(12, 1))
+  // ownership is never stored, the leader's row is a member's
+  mock.ExpectExec(stmt(`INSERT INTO members`)).WithArgs(int64(7), "leader@example.com", int64(12), "backend", RoleMember).
+    WillReturnResult(sqlmock.NewResult(1, 1))
+  mock.ExpectExec(stmt(`INSERT INTO skills`)).WithArgs("go", int64(12)).WillReturnResult(sqlmock.NewResult(1, 1))
+  expectOutbox(mock, TeamCreatedTopic)
+  expectAudit(mock, "12", AuditCreateTeam)
+  mock.ExpectCommit()
+
+  id, err := repo.CreateTeam(context.Background(), team)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if id != "12" {
+    t.Errorf("CreateTeam() = %s, want 12", id)
+  }
+}
+
+func TestGetMemberRole(t *testing.T) {
+  tests := []struct {
+    name   string
+    userId string
+    role   interface{}
+    want   string
+  }{
+    {"the leader owns the team", "1", RoleMember, RoleOwner},
+    {"admin", "2", RoleAdmin, RoleAdmin},
+    {"viewer", "4", RoleViewer, RoleViewer},
+    {"outsider", "42", nil, ""},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      repo, mock := newMockRepository(t)
+      mock.ExpectQuery(stmt(`SELECT t.leader, m.access_role FROM teams t`)).WithArgs(tt.userId, "3").
+        WillReturnRows(sqlmock.NewRows([]string{"leader", "access_role"}).AddRow("1", tt.role))
+
+      role, err := repo.GetMemberRole(context.Background(), tt.userId, "3")
+      if err != nil || role != tt.want {
+        t.Errorf("GetMemberRole() = %q, %v, want %q", role, err, tt.want)
+      }
+    })
+  }
+
+  repo, mock := newMockRepository(t)
+  mock.ExpectQuery(stmt(`SELECT t.leader, m.access_role FROM teams t`)).WithArgs("1", "9").
+    WillReturnRows(sqlmock.NewRows([]string{"leader", "access_role"}))
+  if _, err := repo.GetMemberRole(context.Background(), "1", "9"); !domainerr.Is(err, domainerr.ReasonTeamNotFound) {
+    t.Errorf("GetMemberRole() of a missing team error = %v, want %s", err, domainerr.ReasonTeamNotFound)
+  }
+
+  // roles on teams in the trash only authorize restoring them
+  repo, mock = newMockRepository(t)
+  mock.ExpectQuery(stmt(`WHERE t.id = ? AND t.deleted_at IS NULL`)).WithArgs("1", "3").
+    WillReturnRows(sqlmock.NewRows([]string{"leader", "access_role"}))
+  mock.ExpectQuery(stmt(`WHERE t.id = ? AND t.deleted_at IS NOT NULL`)).WithArgs("1", "3").
+    WillReturnRows(sqlmock.NewRows([]string{"leader", "access_role"}).AddRow("1", nil))
+  if _, err := repo.GetMemberRole(context.Background(), "1", "3"); !domainerr.Is(err, domainerr.ReasonTeamNotFound) {
+    t.Errorf("GetMemberRole() of a deleted team error = %v, want %s", err, domainerr.ReasonTeamNotFound)
+  }
+  if role, err := repo.GetDeletedMemberRole(context.Background(), "1", "3"); err != nil || role != RoleOwner {
+    t.Errorf("GetDeletedMemberRole() = %q, %v, want %q", role, err, RoleOwner)
+  }
+}
+
+func TestRemoveMemberGivesBackTheRole(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectLockVersion(mock, "3", 4)
+  mock.ExpectQuery(stmt(`SELECT user_id, member_email, member_role, access_role FROM members WHERE team_id=? AND id=?`)).WithArgs("3", "9").
+    WillReturnRows(sqlmock.NewRows([]string{"user_id", "member_email", "member_role", "access_role"}).AddRow("8", "m@example.com", "backend", RoleMember))
+  expectBumpVersion(mock, "3", 4)
+  mock.ExpectExec(stmt(`DELETE FROM members WHERE team_id=? AND id=?`)).WithArgs("3", "9").WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`UPDATE teams SET open_roles = open_roles + 1 WHERE id=?`)).WithArgs("3").WillReturnResult(sqlmock.NewResult(0, 1))
+  expectOutbox(mock, MemberRemovedTopic)
+  expectAudit(mock, "3", AuditRemoveMember)
+  mock.ExpectCommit()
+
+  removed, version, err := repo.RemoveMember(context.Background(), "3", "9", 4)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if removed != 1 || version != 5 {
+    t.Errorf("RemoveMember() = %d, %d, want 1, 5", removed, version)
+  }
+}
+
+func TestStoredAccessRole(t *testing.T) {
+  tests := map[string]string{
+    "":          RoleMember,
+    RoleOwner:   RoleMember,
+    "superuser": RoleMember,
+    RoleAdmin:   RoleAdmin,
+    RoleMember:  RoleMember,
+    RoleViewer:  RoleViewer,
+  }
+  for role, want := range tests {
+    if got := storedAccessRole(role); got != want {
+      t.Errorf("storedAccessRole(%q) = %s, want %s", role, got, want)
+    }
+  }
+}
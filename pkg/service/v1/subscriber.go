@@ -0,0 +1,131 @@
+package v1
+
+import (
+  "fmt"
+  "os"
+  "time"
+
+  "github.com/ThreeDotsLabs/watermill"
+  "github.com/ThreeDotsLabs/watermill/message"
+  "github.com/ThreeDotsLabs/watermill/message/router/middleware"
+  "github.com/golang/protobuf/proto"
+
+  user "github.com/ckbball/dev-user/pkg/api/v1"
+)
+
+// topics consumed from the user service, the payload of each is a user.User
+const (
+  UserDeletedTopic      = "user_deleted"
+  UserEmailChangedTopic = "user_email_changed"
+  // UserActiveTopic carries the user's last_active, it marks their teams active
+  UserActiveTopic = "user_active"
+)
+
+type userEventHandler struct {
+  repo repository
+}
+
+// NewUserEventRouter returns a watermill router that keeps member data in
+// sync with the user service. Handlers are idempotent so redelivered events
+// are harmless; failures are retried before the message is nacked.
+func NewUserEventRouter(repo repository, subscriber message.Subscriber) (*message.Router, error) {
+  logger := watermill.NewStdLogger(false, false)
+
+  router, err := message.NewRouter(message.RouterConfig{}, logger)
+  if err != nil {
+    return nil, err
+  }
+
+  router.AddMiddleware(
+    middleware.Retry{
+      MaxRetries:      5,
+      InitialInterval: 100 * time.Millisecond,
+      MaxInterval:     5 * time.Second,
+      Multiplier:      2,
+      Logger:          logger,
+    }.Middleware,
+    middleware.Recoverer,
+  )
+
+  h := &userEventHandler{repo: repo}
+  router.AddNoPublisherHandler("team_user_deleted", UserDeletedTopic, subscriber, h.userDeleted)
+  router.AddNoPublisherHandler("team_user_email_changed", UserEmailChangedTopic, subscriber, h.userEmailChanged)
+  router.AddNoPublisherHandler("team_user_active", UserActiveTopic, subscriber, h.userActive)
+
+  return router, nil
+}
+
+// userDeleted removes the user from every team and reassigns the teams they led
+func (h *userEventHandler) userDeleted(msg *message.Message) error {
+  u, ok := decodeUser(msg)
+  if !ok {
+    return nil
+  }
+
+  count, err := h.repo.RemoveUserFromTeams(msg.Context(), u.Id)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo RemoveUserFromTeams: %v\n", u.Id)
+    return err
+  }
+
+  fmt.Fprintf(os.Stderr, "user %v deleted, removed from %v teams\n", u.Id, count)
+  return nil
+}
+
+// userEmailChanged updates the email stored on the user's memberships
+func (h *userEventHandler) userEmailChanged(msg *message.Message) error {
+  u, ok := decodeUser(msg)
+  if !ok {
+    return nil
+  }
+
+  if u.Email == "" {
+    fmt.Fprintf(os.Stderr, "user_email_changed event %v has no email\n", msg.UUID)
+    return nil
+  }
+
+  _, err := h.repo.UpdateMemberEmail(msg.Context(), u.Id, u.Email)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo UpdateMemberEmail: %v\n", u.Id)
+    return err
+  }
+
+  return nil
+}
+
+// userActive marks the user's teams active when the user was, now if the
+// event doesn't say or says a time in the future
+func (h *userEventHandler) userActive(msg *message.Message) error {
+  u, ok := decodeUser(msg)
+  if !ok {
+    return nil
+  }
+
+  at := int64(u.LastActive)
+  if now := time.Now().Unix(); at <= 0 || at > now {
+    at = now
+  }
+
+  _, err := h.repo.RecordUserActivity(msg.Context(), u.Id, at)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo RecordUserActivity: %v\n", u.Id)
+    return err
+  }
+
+  return nil
+}
+
+// decodeUser unmarshals the user carried by msg. A payload that can never be
+// processed is logged and acked so it does not block the topic.
+func decodeUser(msg *message.Message) (*user.User, bool) {
+  u := &user.User{}
+  if err := proto.Unmarshal(msg.Payload, u); err != nil {
+    fmt.Fprintf(os.Stderr, "error decoding user event %v: %v\n", msg.UUID, err)
+    return nil, false
+  }
+  if u.Id == "" {
+    fmt.Fprintf(os.Stderr, "user event %v has no user id\n", msg.UUID)
+    return nil, false
+  }
+  return u, true
+}
@@ -0,0 +1,165 @@
+package v1
+
+import (
+  "context"
+  "errors"
+  "sync"
+  "testing"
+  "time"
+
+  "github.com/DATA-DOG/go-sqlmock"
+  "github.com/ThreeDotsLabs/watermill"
+  "github.com/ThreeDotsLabs/watermill/message"
+  "github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+  "github.com/golang/protobuf/proto"
+
+  user "github.com/ckbball/dev-user/pkg/api/v1"
+)
+
+// userRepository records the user events reaching the repository, other
+// methods aren't implemented
+type userRepository struct {
+  repository
+  sync.Mutex
+  err     error
+  deleted []string
+  emails  map[string]string
+}
+
+func newUserRepository() *userRepository {
+  return &userRepository{emails: map[string]string{}}
+}
+
+func (r *userRepository) RemoveUserFromTeams(ctx context.Context, userId string) (int64, error) {
+  r.Lock()
+  defer r.Unlock()
+  r.deleted = append(r.deleted, userId)
+  return 1, r.err
+}
+
+func (r *userRepository) UpdateMemberEmail(ctx context.Context, userId, email string) (int64, error) {
+  r.Lock()
+  defer r.Unlock()
+  r.emails[userId] = email
+  return 1, r.err
+}
+
+func userMessage(t *testing.T, u *user.User) *message.Message {
+  payload, err := proto.Marshal(u)
+  if err != nil {
+    t.Fatal(err)
+  }
+  return message.NewMessage(watermill.NewUUID(), payload)
+}
+
+func TestUserDeleted(t *testing.T) {
+  repo := newUserRepository()
+  h := &userEventHandler{repo: repo}
+
+  if err := h.userDeleted(userMessage(t, &user.User{Id: "8"})); err != nil {
+    t.Fatal(err)
+  }
+  if len(repo.deleted) != 1 || repo.deleted[0] != "8" {
+    t.Errorf("deleted = %v", repo.deleted)
+  }
+
+  // failures are returned so the message is retried
+  repo.err = errors.New("deadlock")
+  if err := h.userDeleted(userMessage(t, &user.User{Id: "8"})); err != repo.err {
+    t.Errorf("userDeleted() error = %v, want the repository error", err)
+  }
+}
+
+func TestUserEmailChanged(t *testing.T) {
+  repo := newUserRepository()
+  h := &userEventHandler{repo: repo}
+
+  if err := h.userEmailChanged(userMessage(t, &user.User{Id: "8", Email: "new@example.com"})); err != nil {
+    t.Fatal(err)
+  }
+  // an event without an email is acked, there is nothing to store
+  if err := h.userEmailChanged(userMessage(t, &user.User{Id: "9"})); err != nil {
+    t.Fatal(err)
+  }
+  if len(repo.emails) != 1 || repo.emails["8"] != "new@example.com" {
+    t.Errorf("emails = %v", repo.emails)
+  }
+}
+
+func TestUndecodableUserEventsAreAcked(t *testing.T) {
+  repo := newUserRepository()
+  h := &userEventHandler{repo: repo}
+
+  messages := []*message.Message{
+    message.NewMessage(watermill.NewUUID(), []byte{0xff, 0xff}),
+    userMessage(t, &user.User{Email: "no-id@example.com"}),
+  }
+  for _, msg := range messages {
+    for _, handle := range []message.NoPublishHandlerFunc{h.userDeleted, h.userEmailChanged} {
+      if err := handle(msg); err != nil {
+        t.Errorf("handler error = %v, want the message acked", err)
+      }
+    }
+  }
+  if len(repo.deleted) != 0 || len(repo.emails) != 0 {
+    t.Errorf("repository called for an undecodable event")
+  }
+}
+
+func TestUserEventRouter(t *testing.T) {
+  repo := newUserRepository()
+  pubsub := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+  defer pubsub.Close()
+
+  router, err := NewUserEventRouter(repo, pubsub)
+  if err != nil {
+    t.Fatal(err)
+  }
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  go router.Run(ctx)
+  <-router.Running()
+  defer router.Close()
+
+  if err := pubsub.Publish(UserDeletedTopic, userMessage(t, &user.User{Id: "8"})); err != nil {
+    t.Fatal(err)
+  }
+  if err := pubsub.Publish(UserEmailChangedTopic, userMessage(t, &user.User{Id: "9", Email: "new@example.com"})); err != nil {
+    t.Fatal(err)
+  }
+
+  deadline := time.Now().Add(5 * time.Second)
+  for {
+    repo.Lock()
+    done := len(repo.deleted) == 1 && repo.emails["9"] == "new@example.com"
+    repo.Unlock()
+    if done {
+      break
+    }
+    if time.Now().After(deadline) {
+      t.Fatal("the router didn't handle the user events")
+    }
+    time.Sleep(10 * time.Millisecond)
+  }
+}
+
+func TestUpdateMemberEmail(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`SELECT id, team_id, member_email FROM members WHERE user_id=? FOR UPDATE`)).WithArgs("8").
+    WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "member_email"}).AddRow("4", "3", "old@example.com").AddRow("5", "6", "old@example.com"))
+  mock.ExpectExec(stmt(`UPDATE members SET member_email=? WHERE user_id=?`)).WithArgs("new@example.com", "8").WillReturnResult(sqlmock.NewResult(0, 2))
+  mock.ExpectExec(stmt(`UPDATE teams SET version = version + 1`)).WithArgs("8").WillReturnResult(sqlmock.NewResult(0, 2))
+  expectAudit(mock, "3", AuditUpdateMemberEmail)
+  expectAudit(mock, "6", AuditUpdateMemberEmail)
+  mock.ExpectCommit()
+
+  count, err := repo.UpdateMemberEmail(context.Background(), "8", "new@example.com")
+  if err != nil {
+    t.Fatal(err)
+  }
+  if count != 2 {
+    t.Errorf("UpdateMemberEmail() = %d, want 2", count)
+  }
+}
@@ -0,0 +1,61 @@
+package v1
+
+import (
+  "context"
+  "fmt"
+  "os"
+  "time"
+)
+
+// how often the sweeper looks for inactive teams
+const sweepInterval = time.Hour
+
+// InactivitySweeper flags the teams that haven't been active for the
+// repository's inactivity period, which tells their owners, and archives
+// them once the grace period is over. Several replicas may run one, a team
+// is handled by whichever locks it first.
+type InactivitySweeper struct {
+  repo repository
+}
+
+func NewInactivitySweeper(repo repository) *InactivitySweeper {
+  return &InactivitySweeper{
+    repo: repo,
+  }
+}
+
+// Run sweeps inactive teams until ctx is cancelled.
+func (s *InactivitySweeper) Run(ctx context.Context) {
+  ticker := time.NewTicker(sweepInterval)
+  defer ticker.Stop()
+
+  for {
+    // keep sweeping while full batches come back, there is a backlog
+    for {
+      ids, err := s.repo.FlagInactiveTeams(ctx)
+      if err != nil {
+        fmt.Fprintf(os.Stderr, "error flagging inactive teams: %v\n", err)
+        break
+      }
+      if len(ids) < sweepBatchSize {
+        break
+      }
+    }
+    for {
+      ids, err := s.repo.ArchiveInactiveTeams(ctx)
+      if err != nil {
+        fmt.Fprintf(os.Stderr, "error archiving inactive teams: %v\n", err)
+        break
+      }
+      if len(ids) < sweepBatchSize {
+        break
+      }
+    }
+
+    select {
+    case <-ctx.Done():
+      return
+    case <-ticker.C:
+    }
+  }
+}
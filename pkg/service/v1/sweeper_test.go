@@ -0,0 +1,52 @@
+package v1
+
+import (
+  "context"
+  "errors"
+  "strconv"
+  "testing"
+)
+
+// sweptRepository flags and archives the next of its batches of teams, then
+// fails
+type sweptRepository struct {
+  repository
+  flag     []int
+  archive  []int
+  flags    int
+  archives int
+}
+
+func (r *sweptRepository) FlagInactiveTeams(ctx context.Context) ([]string, error) {
+  r.flags++
+  return nextSweep(&r.flag)
+}
+
+func (r *sweptRepository) ArchiveInactiveTeams(ctx context.Context) ([]string, error) {
+  r.archives++
+  return nextSweep(&r.archive)
+}
+
+func nextSweep(batches *[]int) ([]string, error) {
+  if len(*batches) == 0 {
+    return nil, errors.New("database is down")
+  }
+  ids := make([]string, (*batches)[0])
+  for i := range ids {
+    ids[i] = strconv.Itoa(i + 1)
+  }
+  *batches = (*batches)[1:]
+  return ids, nil
+}
+
+func TestInactivitySweeper(t *testing.T) {
+  // full batches are followed up at once, archiving runs after flagging failed
+  repo := &sweptRepository{flag: []int{sweepBatchSize}, archive: []int{sweepBatchSize, sweepBatchSize, 1}}
+
+  ctx, cancel := context.WithCancel(context.Background())
+  cancel()
+  NewInactivitySweeper(repo).Run(ctx)
+  if repo.flags != 2 || repo.archives != 3 {
+    t.Errorf("flagged %d times, archived %d times, want 2 and 3", repo.flags, repo.archives)
+  }
+}
@@ -0,0 +1,345 @@
+package v1
+
+import (
+  "context"
+  "database/sql"
+  "strconv"
+  "time"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+const taskColumns = `id, project_id, milestone_id, title, assignee, due_at, status, position, created_at, completed_at`
+
+// Creates a task of project projectId of team teamId. It is placed at
+// task.Position, after the other tasks when 0.
+// output ON SUCCESS: *v1.Task - the created task, int64 - new version of the team, error - nil
+// output ON FAILURE: TEAM_NOT_FOUND, VERSION_MISMATCH, PROJECT_NOT_FOUND, PROJECT_ARCHIVED, MILESTONE_NOT_FOUND, MEMBER_NOT_FOUND or the error object from whatever created the error
+func (r *teamRepository) CreateTask(ctx context.Context, teamId, projectId string, task *v1.Task, expected int64) (*v1.Task, int64, error) {
+  insertStmt := `INSERT INTO tasks (team_id, project_id, milestone_id, title, assignee, due_at, status, position, created_at, completed_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return nil, -1, err
+  }
+  defer tx.Rollback()
+
+  // lock the team, positions are only changed under its lock
+  version, err := nextVersion(ctx, tx, teamId, expected)
+  if err != nil {
+    return nil, -1, err
+  }
+  if err := lockOpenProject(ctx, tx, teamId, projectId); err != nil {
+    return nil, -1, err
+  }
+  if err := checkMilestone(ctx, tx, projectId, task.MilestoneId); err != nil {
+    return nil, -1, err
+  }
+  if err := checkAssignee(ctx, tx, teamId, task.Assignee); err != nil {
+    return nil, -1, err
+  }
+
+  now := time.Now().Unix()
+  status := task.Status
+  if status == "" {
+    status = WorkTodo
+  }
+  var completedAt int64
+  if status == WorkDone {
+    completedAt = now
+  }
+  position, err := insertPosition(ctx, tx, "tasks", projectId, task.Position)
+  if err != nil {
+    return nil, -1, err
+  }
+
+  result, err := tx.ExecContext(ctx, insertStmt, teamId, projectId, nullId(task.MilestoneId), task.Title, nullId(task.Assignee), nullTime(task.DueAt), status, position, now, nullTime(completedAt))
+  if err != nil {
+    return nil, -1, err
+  }
+  id, err := result.LastInsertId()
+  if err != nil {
+    return nil, -1, err
+  }
+
+  created, err := readTask(ctx, tx, projectId, strconv.FormatInt(id, 10), ``)
+  if err != nil {
+    return nil, -1, err
+  }
+
+  // record task_created event in the outbox
+  err = insertOutboxEvent(tx, TaskCreatedTopic, &v1.TaskCreated{
+    TeamId:     teamId,
+    Task:       created,
+    OccurredAt: now,
+  })
+  if err != nil {
+    return nil, -1, err
+  }
+  if err := insertAuditEvent(ctx, tx, teamId, AuditCreateTask, nil, taskState(created)); err != nil {
+    return nil, -1, err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return nil, -1, err
+  }
+  return created, version, nil
+}
+
+// Updates the fields of task taskId listed in paths with the values of
+// patch. Moving it to another position shifts the tasks in between.
+// output ON SUCCESS: *v1.Task - the updated task, int64 - new version of the team, error - nil
+// output ON FAILURE: TEAM_NOT_FOUND, VERSION_MISMATCH, PROJECT_NOT_FOUND, PROJECT_ARCHIVED, TASK_NOT_FOUND, MILESTONE_NOT_FOUND, MEMBER_NOT_FOUND or the error object from whatever created the error
+func (r *teamRepository) UpdateTask(ctx context.Context, teamId, projectId, taskId string, patch *v1.Task, paths []string, expected int64) (*v1.Task, int64, error) {
+  updateStmt := `UPDATE tasks SET milestone_id=?, title=?, assignee=?, due_at=?, status=?, position=?, completed_at=? WHERE id=?`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return nil, -1, err
+  }
+  defer tx.Rollback()
+
+  version, err := nextVersion(ctx, tx, teamId, expected)
+  if err != nil {
+    return nil, -1, err
+  }
+  if err := lockOpenProject(ctx, tx, teamId, projectId); err != nil {
+    return nil, -1, err
+  }
+  task, err := readTask(ctx, tx, projectId, taskId, ` FOR UPDATE`)
+  if err != nil {
+    return nil, -1, err
+  }
+  before := taskState(task)
+
+  masked := map[string]bool{}
+  for _, path := range paths {
+    masked[path] = true
+  }
+  if masked["title"] {
+    task.Title = patch.Title
+  }
+  if masked["milestone_id"] {
+    if err := checkMilestone(ctx, tx, projectId, patch.MilestoneId); err != nil {
+      return nil, -1, err
+    }
+    task.MilestoneId = patch.MilestoneId
+  }
+  if masked["assignee"] {
+    if err := checkAssignee(ctx, tx, teamId, patch.Assignee); err != nil {
+      return nil, -1, err
+    }
+    task.Assignee = patch.Assignee
+  }
+  if masked["due_at"] {
+    task.DueAt = patch.DueAt
+  }
+  if masked["status"] {
+    task.CompletedAt = completedAt(task.Status, patch.Status, task.CompletedAt)
+    task.Status = patch.Status
+  }
+  if masked["position"] {
+    task.Position, err = movePosition(ctx, tx, "tasks", projectId, task.Position, patch.Position)
+    if err != nil {
+      return nil, -1, err
+    }
+  }
+
+  _, err = tx.ExecContext(ctx, updateStmt, nullId(task.MilestoneId), task.Title, nullId(task.Assignee), nullTime(task.DueAt), task.Status, task.Position, nullTime(task.CompletedAt), taskId)
+  if err != nil {
+    return nil, -1, err
+  }
+
+  updated, err := readTask(ctx, tx, projectId, taskId, ``)
+  if err != nil {
+    return nil, -1, err
+  }
+
+  // record task_updated event in the outbox
+  err = insertOutboxEvent(tx, TaskUpdatedTopic, &v1.TaskUpdated{
+    TeamId:     teamId,
+    Paths:      paths,
+    Task:       updated,
+    OccurredAt: time.Now().Unix(),
+  })
+  if err != nil {
+    return nil, -1, err
+  }
+  if err := insertAuditEvent(ctx, tx, teamId, AuditUpdateTask, before, taskState(updated)); err != nil {
+    return nil, -1, err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return nil, -1, err
+  }
+  return updated, version, nil
+}
+
+// Deletes task taskId of project projectId
+// output ON SUCCESS: *v1.Task - the deleted task, int64 - new version of the team, error - nil
+// output ON FAILURE: TEAM_NOT_FOUND, VERSION_MISMATCH, PROJECT_NOT_FOUND, PROJECT_ARCHIVED, TASK_NOT_FOUND or the error object from whatever created the error
+func (r *teamRepository) DeleteTask(ctx context.Context, teamId, projectId, taskId string, expected int64) (*v1.Task, int64, error) {
+  deleteStmt := `DELETE FROM tasks WHERE id=?`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return nil, -1, err
+  }
+  defer tx.Rollback()
+
+  version, err := nextVersion(ctx, tx, teamId, expected)
+  if err != nil {
+    return nil, -1, err
+  }
+  if err := lockOpenProject(ctx, tx, teamId, projectId); err != nil {
+    return nil, -1, err
+  }
+  task, err := readTask(ctx, tx, projectId, taskId, ` FOR UPDATE`)
+  if err != nil {
+    return nil, -1, err
+  }
+
+  if _, err := tx.ExecContext(ctx, deleteStmt, taskId); err != nil {
+    return nil, -1, err
+  }
+  if err := closePosition(ctx, tx, "tasks", projectId, task.Position); err != nil {
+    return nil, -1, err
+  }
+
+  // record task_deleted event in the outbox
+  err = insertOutboxEvent(tx, TaskDeletedTopic, &v1.TaskDeleted{
+    TeamId:     teamId,
+    ProjectId:  projectId,
+    TaskId:     taskId,
+    OccurredAt: time.Now().Unix(),
+  })
+  if err != nil {
+    return nil, -1, err
+  }
+  if err := insertAuditEvent(ctx, tx, teamId, AuditDeleteTask, taskState(task), nil); err != nil {
+    return nil, -1, err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return nil, -1, err
+  }
+  return task, version, nil
+}
+
+// Lists the tasks of a project matching every filter of req in position order
+// output ON FAILURE: TEAM_NOT_FOUND, PROJECT_NOT_FOUND or the error object from whatever created the error
+func (r *teamRepository) ListTasks(ctx context.Context, req *v1.ListTasksRequest) ([]*v1.Task, error) {
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+  if err != nil {
+    return nil, err
+  }
+  defer tx.Rollback()
+
+  if err := checkTeamExists(ctx, tx, req.TeamId); err != nil {
+    return nil, err
+  }
+  if _, err := loadProject(ctx, tx, req.TeamId, req.ProjectId); err != nil {
+    return nil, err
+  }
+
+  stmt := `SELECT ` + taskColumns + ` FROM tasks WHERE project_id=?`
+  args := []interface{}{req.ProjectId}
+  if req.MilestoneId != "" {
+    stmt += ` AND milestone_id=?`
+    args = append(args, req.MilestoneId)
+  }
+  if req.Assignee != "" {
+    stmt += ` AND assignee=?`
+    args = append(args, req.Assignee)
+  }
+  if len(req.Statuses) > 0 {
+    in, statusArgs := stringInList(req.Statuses)
+    stmt += ` AND status IN ` + in
+    args = append(args, statusArgs...)
+  }
+  stmt += ` ORDER BY position, id`
+
+  tasks := []*v1.Task{}
+  err = queryRows(ctx, tx, stmt, args, func(rows *sql.Rows) error {
+    task, err := scanTask(rows)
+    if err != nil {
+      return err
+    }
+    tasks = append(tasks, task)
+    return nil
+  })
+  if err != nil {
+    return nil, err
+  }
+  return tasks, nil
+}
+
+// ---------------------------- HELPER FUNCTIONS -------------------------------
+
+// scanTask scans a row of taskColumns
+func scanTask(row interface{ Scan(...interface{}) error }) (*v1.Task, error) {
+  task := &v1.Task{}
+  var id, projectId int64
+  var milestoneId, assignee sql.NullString
+  var dueAt, completedAt sql.NullInt64
+  err := row.Scan(&id, &projectId, &milestoneId, &task.Title, &assignee, &dueAt, &task.Status, &task.Position, &task.CreatedAt, &completedAt)
+  if err != nil {
+    return nil, err
+  }
+  task.Id = strconv.FormatInt(id, 10)
+  task.ProjectId = strconv.FormatInt(projectId, 10)
+  task.MilestoneId = milestoneId.String
+  task.Assignee = assignee.String
+  task.DueAt = dueAt.Int64
+  task.CompletedAt = completedAt.Int64
+  return task, nil
+}
+
+// readTask reads task taskId of project projectId within tx, lock is
+// appended to the query
+func readTask(ctx context.Context, tx *sql.Tx, projectId, taskId, lock string) (*v1.Task, error) {
+  selectStmt := `SELECT ` + taskColumns + ` FROM tasks WHERE id=? AND project_id=?` + lock
+
+  task, err := scanTask(tx.QueryRowContext(ctx, selectStmt, taskId, projectId))
+  if err == sql.ErrNoRows {
+    return nil, domainerr.NotFound(domainerr.ReasonTaskNotFound, "task '%s' not found on project '%s'", taskId, projectId).
+      With("project_id", projectId).
+      With("task_id", taskId)
+  } else if err != nil {
+    return nil, err
+  }
+  return task, nil
+}
+
+// checkMilestone fails unless milestoneId is empty or a milestone of project
+// projectId
+func checkMilestone(ctx context.Context, tx *sql.Tx, projectId, milestoneId string) error {
+  milestoneStmt := `SELECT COUNT(*) FROM milestones WHERE id=? AND project_id=?`
+
+  if milestoneId == "" {
+    return nil
+  }
+  var count int
+  if err := tx.QueryRowContext(ctx, milestoneStmt, milestoneId, projectId).Scan(&count); err != nil {
+    return err
+  }
+  if count == 0 {
+    return milestoneNotFound(projectId, milestoneId)
+  }
+  return nil
+}
+
+// taskState is the audited state of a task
+func taskState(task *v1.Task) auditState {
+  return auditState{
+    "task_id":      task.Id,
+    "project_id":   task.ProjectId,
+    "milestone_id": task.MilestoneId,
+    "title":        task.Title,
+    "assignee":     task.Assignee,
+    "due_at":       task.DueAt,
+    "status":       task.Status,
+    "position":     task.Position,
+  }
+}
@@ -0,0 +1,118 @@
+package v1
+
+import (
+  "context"
+  "testing"
+
+  "github.com/DATA-DOG/go-sqlmock"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// taskRow is task 11 of milestone 8 of project 5 at position 1 in status,
+// completed at completedAt unless it is 0
+func taskRow(status string, completedAt int64) *sqlmock.Rows {
+  var completed interface{}
+  if completedAt != 0 {
+    completed = completedAt
+  }
+  return sqlmock.NewRows([]string{"id", "project_id", "milestone_id", "title", "assignee", "due_at", "status", "position", "created_at", "completed_at"}).
+    AddRow(11, 5, "8", "ship it", nil, nil, status, 1, 1600000000, completed)
+}
+
+func TestCreateTaskChecks(t *testing.T) {
+  tests := []struct {
+    name   string
+    expect func(mock sqlmock.Sqlmock)
+    reason string
+  }{
+    {"missing project", func(mock sqlmock.Sqlmock) {
+      mock.ExpectQuery(stmt(`FROM projects WHERE id=?`)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+    }, domainerr.ReasonProjectNotFound},
+    // milestones of other projects aren't found
+    {"milestone elsewhere", func(mock sqlmock.Sqlmock) {
+      expectLockProject(mock, ProjectActive, 0)
+      mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM milestones WHERE id=? AND project_id=?`)).WithArgs("8", "5").WillReturnRows(countRow(0))
+    }, domainerr.ReasonMilestoneNotFound},
+    {"assignee elsewhere", func(mock sqlmock.Sqlmock) {
+      expectLockProject(mock, ProjectActive, 0)
+      mock.ExpectQuery(stmt(`FROM milestones`)).WillReturnRows(countRow(1))
+      mock.ExpectQuery(stmt(`FROM members WHERE team_id=? AND id=?`)).WithArgs("3", "21").WillReturnRows(countRow(0))
+    }, domainerr.ReasonMemberNotFound},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      repo, mock := newMockRepository(t)
+      mock.ExpectBegin()
+      expectNextVersion(mock, "3", 1)
+      tt.expect(mock)
+      mock.ExpectRollback()
+
+      _, _, err := repo.CreateTask(context.Background(), "3", "5", &v1.Task{Title: "ship it", MilestoneId: "8", Assignee: "21"}, 0)
+      if domainerr.ReasonOf(err) != tt.reason {
+        t.Errorf("CreateTask() error = %v, want %s", err, tt.reason)
+      }
+    })
+  }
+}
+
+func TestCreateDoneTaskIsCompleted(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 1)
+  expectLockProject(mock, ProjectActive, 0)
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM tasks`)).WithArgs("5").WillReturnRows(countRow(0))
+  mock.ExpectExec(stmt(`INSERT INTO tasks`)).
+    WithArgs("3", "5", nil, "ship it", nil, nil, WorkDone, int32(1), sqlmock.AnyArg(), sqlmock.AnyArg()).
+    WillReturnResult(sqlmock.NewResult(11, 1))
+  mock.ExpectQuery(stmt(`FROM tasks WHERE id=? AND project_id=?`)).WithArgs("11", "5").WillReturnRows(taskRow(WorkDone, 1600000000))
+  expectOutbox(mock, TaskCreatedTopic)
+  expectAudit(mock, "3", AuditCreateTask)
+  mock.ExpectCommit()
+
+  task, _, err := repo.CreateTask(context.Background(), "3", "5", &v1.Task{Title: "ship it", Status: WorkDone}, 0)
+  if err != nil || task.Id != "11" || task.CompletedAt == 0 {
+    t.Errorf("CreateTask() = %v, %v", task, err)
+  }
+}
+
+func TestUpdateTaskReopensIt(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 1)
+  expectLockProject(mock, ProjectActive, 0)
+  mock.ExpectQuery(stmt(`FROM tasks WHERE id=? AND project_id=? FOR UPDATE`)).WithArgs("11", "5").WillReturnRows(taskRow(WorkDone, 1600000000))
+  // the milestone is kept, the completion time is cleared
+  mock.ExpectExec(stmt(`UPDATE tasks SET milestone_id=?, title=?, assignee=?, due_at=?, status=?, position=?, completed_at=? WHERE id=?`)).
+    WithArgs("8", "ship it", nil, nil, WorkInProgress, int32(1), nil, "11").
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectQuery(stmt(`FROM tasks WHERE id=? AND project_id=?`)).WithArgs("11", "5").WillReturnRows(taskRow(WorkInProgress, 0))
+  expectOutbox(mock, TaskUpdatedTopic)
+  expectAudit(mock, "3", AuditUpdateTask)
+  mock.ExpectCommit()
+
+  task, _, err := repo.UpdateTask(context.Background(), "3", "5", "11", &v1.Task{Status: WorkInProgress}, []string{"status"}, 0)
+  if err != nil || task.Status != WorkInProgress || task.CompletedAt != 0 || task.MilestoneId != "8" {
+    t.Errorf("UpdateTask() = %v, %v", task, err)
+  }
+}
+
+func TestListTasksFilters(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM teams`)).WillReturnRows(countRow(1))
+  expectLoadProject(mock, "3", "5", nil)
+  mock.ExpectQuery(stmt(`FROM tasks WHERE project_id=? AND milestone_id=? AND assignee=? AND status IN (?) ORDER BY position, id`)).
+    WithArgs("5", "8", "21", WorkTodo).WillReturnRows(taskRow(WorkTodo, 0))
+  mock.ExpectRollback()
+
+  req := &v1.ListTasksRequest{TeamId: "3", ProjectId: "5", MilestoneId: "8", Assignee: "21", Statuses: []string{WorkTodo}}
+  tasks, err := repo.ListTasks(context.Background(), req)
+  if err != nil || len(tasks) != 1 || tasks[0].Id != "11" {
+    t.Errorf("ListTasks() = %v, %v", tasks, err)
+  }
+}
@@ -0,0 +1,127 @@
+package v1
+
+import (
+  "context"
+  "fmt"
+  "os"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+func (s *handler) CreateTask(ctx context.Context, req *v1.CreateTaskRequest) (*v1.TaskResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  // tasks are managed by every member of the team
+  if _, _, err := s.authorize(ctx, req.TeamId, ActionManageTasks); err != nil {
+    return nil, err
+  }
+
+  expected, err := expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
+  }
+
+  task, version, err := s.repo.CreateTask(ctx, req.TeamId, req.ProjectId, req.Task, expected)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo CreateTask: %v\n", req.ProjectId)
+    return nil, err
+  }
+
+  // task_created Event is written to the outbox by the repository
+
+  return &v1.TaskResponse{
+    Api:     apiVersion,
+    Status:  "Created",
+    Task:    task,
+    Version: version,
+  }, nil
+}
+
+func (s *handler) UpdateTask(ctx context.Context, req *v1.UpdateTaskRequest) (*v1.TaskResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  if _, _, err := s.authorize(ctx, req.TeamId, ActionManageTasks); err != nil {
+    return nil, err
+  }
+
+  expected, err := expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
+  }
+
+  task, version, err := s.repo.UpdateTask(ctx, req.TeamId, req.ProjectId, req.TaskId, req.Task, req.GetUpdateMask().GetPaths(), expected)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo UpdateTask: %v\n", req.TaskId)
+    return nil, err
+  }
+
+  // task_updated Event is written to the outbox by the repository
+
+  return &v1.TaskResponse{
+    Api:     apiVersion,
+    Status:  "Updated",
+    Task:    task,
+    Version: version,
+  }, nil
+}
+
+func (s *handler) DeleteTask(ctx context.Context, req *v1.DeleteTaskRequest) (*v1.TaskResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  if _, _, err := s.authorize(ctx, req.TeamId, ActionManageTasks); err != nil {
+    return nil, err
+  }
+
+  expected, err := expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
+  }
+
+  task, version, err := s.repo.DeleteTask(ctx, req.TeamId, req.ProjectId, req.TaskId, expected)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo DeleteTask: %v\n", req.TaskId)
+    return nil, err
+  }
+
+  // task_deleted Event is written to the outbox by the repository
+
+  return &v1.TaskResponse{
+    Api:     apiVersion,
+    Status:  "Deleted",
+    Task:    task,
+    Version: version,
+  }, nil
+}
+
+func (s *handler) ListTasks(ctx context.Context, req *v1.ListTasksRequest) (*v1.ListTasksResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  // tasks are public like the project they belong to
+  tasks, err := s.repo.ListTasks(ctx, req)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo ListTasks: %v\n", req.ProjectId)
+    return nil, err
+  }
+
+  status := "tasks"
+  if len(tasks) == 0 {
+    status = "empty"
+  }
+  return &v1.ListTasksResponse{
+    Api:    apiVersion,
+    Status: status,
+    Tasks:  tasks,
+  }, nil
+}
@@ -0,0 +1,184 @@
+package v1
+
+import (
+  "context"
+  "database/sql"
+  "strconv"
+  "strings"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+// loadTeams hydrates the teams with ids, members, skills, projects and
+// languages included, in a constant number of queries however many ids are
+// given. Teams are returned in the order of ids, missing ids and teams in the
+// trash are skipped. Every list endpoint loads its page through it.
+func (r *teamRepository) loadTeams(ctx context.Context, ids []int64) ([]*v1.Team, error) {
+  return r.loadTeamsIn(ctx, ids, false)
+}
+
+// loadTeamsIn is loadTeams over the teams in the trash when deleted is true,
+// over the other teams otherwise
+func (r *teamRepository) loadTeamsIn(ctx context.Context, ids []int64, deleted bool) ([]*v1.Team, error) {
+  teams := []*v1.Team{}
+  if len(ids) == 0 {
+    return teams, nil
+  }
+
+  // read every table from the same snapshot
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+  if err != nil {
+    return nil, err
+  }
+  defer tx.Rollback()
+
+  in, args := inList(ids)
+  byId := map[int64]*v1.Team{}
+
+  // teams
+  trash := ` AND deleted_at IS NULL`
+  if deleted {
+    trash = ` AND deleted_at IS NOT NULL`
+  }
+  err = queryRows(ctx, tx, `SELECT id, leader, team_name, open_roles, size, last_active, auto_close_applications, version, inactive_at, archived_at FROM teams WHERE id IN `+in+trash, args, func(rows *sql.Rows) error {
+    var id int64
+    team := &v1.Team{Members: []*v1.Member{}, Skills: []string{}, Project: &v1.Project{}, Projects: []*v1.Project{}}
+    var lastActive, inactiveAt, archivedAt sql.NullInt64
+    if err := rows.Scan(&id, &team.Leader, &team.Name, &team.OpenRoles, &team.Size, &lastActive, &team.AutoCloseApplications, &team.Version, &inactiveAt, &archivedAt); err != nil {
+      return err
+    }
+    team.Id = strconv.FormatInt(id, 10)
+    team.LastActive = int32(lastActive.Int64)
+    team.InactiveAt = inactiveAt.Int64
+    team.ArchivedAt = archivedAt.Int64
+    byId[id] = team
+    return nil
+  })
+  if err != nil {
+    return nil, err
+  }
+  if len(byId) == 0 {
+    return teams, nil
+  }
+
+  // members, the leader owns the team whatever role their membership was created with
+  err = queryRows(ctx, tx, `SELECT team_id, id, user_id, member_email, member_role, access_role FROM members WHERE team_id IN `+in+` ORDER BY id`, args, func(rows *sql.Rows) error {
+    var teamId int64
+    member := &v1.Member{}
+    if err := rows.Scan(&teamId, &member.MemberNumber, &member.Id, &member.Email, &member.Role, &member.AccessRole); err != nil {
+      return err
+    }
+    if team, ok := byId[teamId]; ok {
+      if strconv.Itoa(int(member.Id)) == team.Leader {
+        member.AccessRole = RoleOwner
+      }
+      team.Members = append(team.Members, member)
+    }
+    return nil
+  })
+  if err != nil {
+    return nil, err
+  }
+
+  // skills
+  err = queryRows(ctx, tx, `SELECT team_id, skill_name FROM skills WHERE team_id IN `+in+` ORDER BY id`, args, func(rows *sql.Rows) error {
+    var teamId int64
+    var skill string
+    if err := rows.Scan(&teamId, &skill); err != nil {
+      return err
+    }
+    if team, ok := byId[teamId]; ok {
+      team.Skills = append(team.Skills, skill)
+    }
+    return nil
+  })
+  if err != nil {
+    return nil, err
+  }
+
+  // projects that aren't archived, Team.Project is the active one
+  byProject := map[int64]*v1.Project{}
+  err = queryRows(ctx, tx, `SELECT team_id, `+projectColumns+` FROM projects WHERE team_id IN `+in+` AND archived_at IS NULL ORDER BY id`, args, func(rows *sql.Rows) error {
+    var teamId int64
+    project, err := scanProject(rows, &teamId)
+    if err != nil {
+      return err
+    }
+    if team, ok := byId[teamId]; ok {
+      team.Projects = append(team.Projects, project)
+      if project.Status == ProjectActive {
+        team.Project = project
+      }
+      id, _ := strconv.ParseInt(project.Id, 10, 64)
+      byProject[id] = project
+    }
+    return nil
+  })
+  if err != nil {
+    return nil, err
+  }
+
+  // languages
+  err = queryRows(ctx, tx, `SELECT project_id, lang_name FROM languages WHERE team_id IN `+in+` ORDER BY id`, args, func(rows *sql.Rows) error {
+    var projectId sql.NullInt64
+    var language string
+    if err := rows.Scan(&projectId, &language); err != nil {
+      return err
+    }
+    if project, ok := byProject[projectId.Int64]; ok {
+      project.Languages = append(project.Languages, language)
+    }
+    return nil
+  })
+  if err != nil {
+    return nil, err
+  }
+
+  // progress from tasks and milestones
+  if err := setProjectProgress(ctx, tx, byProject); err != nil {
+    return nil, err
+  }
+
+  for _, id := range ids {
+    if team, ok := byId[id]; ok {
+      teams = append(teams, team)
+    }
+  }
+  return teams, nil
+}
+
+// loadTeam hydrates a single team, nil if it doesn't exist
+func (r *teamRepository) loadTeam(ctx context.Context, id int64) (*v1.Team, error) {
+  teams, err := r.loadTeams(ctx, []int64{id})
+  if err != nil || len(teams) == 0 {
+    return nil, err
+  }
+  return teams[0], nil
+}
+
+// queryRows runs query and calls scan for every row
+func queryRows(ctx context.Context, tx *sql.Tx, query string, args []interface{}, scan func(*sql.Rows) error) error {
+  rows, err := tx.QueryContext(ctx, query, args...)
+  if err != nil {
+    return err
+  }
+  defer rows.Close()
+
+  for rows.Next() {
+    if err := scan(rows); err != nil {
+      return err
+    }
+  }
+  return rows.Err()
+}
+
+// inList returns the placeholder list "(?, ?, ...)" and arguments of ids
+func inList(ids []int64) (string, []interface{}) {
+  placeholders := make([]string, len(ids))
+  args := make([]interface{}, len(ids))
+  for i, id := range ids {
+    placeholders[i] = "?"
+    args[i] = id
+  }
+  return "(" + strings.Join(placeholders, ", ") + ")", args
+}
@@ -0,0 +1,221 @@
+package v1
+
+import (
+  "context"
+  "database/sql"
+  "database/sql/driver"
+  "errors"
+  "fmt"
+  "io"
+  "strconv"
+  "strings"
+  "sync/atomic"
+  "testing"
+)
+
+// countingConnector connects to a database/sql driver counting the queries
+// it runs. Every team asked for exists with one member, skill, active
+// project and language, and progress on tasks and milestones.
+type countingConnector struct {
+  queries int64
+}
+
+func (c *countingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+  return &countingConn{c}, nil
+}
+
+func (c *countingConnector) Driver() driver.Driver {
+  return countingDriver{c}
+}
+
+func (c *countingConnector) count() int64 {
+  return atomic.LoadInt64(&c.queries)
+}
+
+type countingDriver struct {
+  c *countingConnector
+}
+
+func (d countingDriver) Open(name string) (driver.Conn, error) {
+  return d.c.Connect(context.Background())
+}
+
+type countingConn struct {
+  c *countingConnector
+}
+
+func (conn *countingConn) Prepare(query string) (driver.Stmt, error) {
+  return nil, errors.New("statements aren't supported")
+}
+
+func (conn *countingConn) Close() error {
+  return nil
+}
+
+func (conn *countingConn) Begin() (driver.Tx, error) {
+  return conn, nil
+}
+
+func (conn *countingConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+  return conn, nil
+}
+
+func (conn *countingConn) Commit() error {
+  return nil
+}
+
+func (conn *countingConn) Rollback() error {
+  return nil
+}
+
+func (conn *countingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+  atomic.AddInt64(&conn.c.queries, 1)
+
+  ids := []int64{}
+  for _, arg := range args {
+    if id, ok := arg.Value.(int64); ok {
+      ids = append(ids, id)
+    }
+  }
+  rows := &countingRows{}
+  for _, id := range ids {
+    name := strconv.FormatInt(id, 10)
+    switch {
+    case strings.Contains(query, "FROM teams"):
+      rows.add(id, "7", "team "+name, int64(1), int64(3), int64(1600000000), false, int64(1), nil, nil)
+    case strings.Contains(query, "FROM members"):
+      rows.add(id, id, int64(7), "m@example.com", "backend", RoleMember)
+    case strings.Contains(query, "FROM skills"):
+      rows.add(id, "go")
+    case strings.Contains(query, "FROM projects"):
+      // the project of a team has the team's id
+      rows.add(id, id, "", "project "+name, "", int64(2), int64(3), ProjectActive, int64(1600000000), nil, nil)
+    case strings.Contains(query, "FROM languages"):
+      rows.add(id, "go")
+    case strings.Contains(query, "FROM tasks"), strings.Contains(query, "FROM milestones"):
+      rows.add(id, int64(4), int64(2))
+    default:
+      return nil, fmt.Errorf("unexpected query %s", query)
+    }
+  }
+  return rows, nil
+}
+
+type countingRows struct {
+  values [][]driver.Value
+}
+
+func (r *countingRows) add(values ...driver.Value) {
+  r.values = append(r.values, values)
+}
+
+// Columns names as many columns as the rows have, scans only need the count
+func (r *countingRows) Columns() []string {
+  if len(r.values) == 0 {
+    return nil
+  }
+  return make([]string, len(r.values[0]))
+}
+
+func (r *countingRows) Close() error {
+  return nil
+}
+
+func (r *countingRows) Next(dest []driver.Value) error {
+  if len(r.values) == 0 {
+    return io.EOF
+  }
+  copy(dest, r.values[0])
+  r.values = r.values[1:]
+  return nil
+}
+
+// newCountingRepository returns a repository on a counting database
+func newCountingRepository() (*teamRepository, *countingConnector) {
+  c := &countingConnector{}
+  return NewTeamRepository(sql.OpenDB(c)), c
+}
+
+func teamIds(n int) []int64 {
+  ids := make([]int64, n)
+  for i := range ids {
+    ids[i] = int64(i + 1)
+  }
+  return ids
+}
+
+func TestLoadTeams(t *testing.T) {
+  repo, _ := newCountingRepository()
+  teams, err := repo.loadTeams(context.Background(), []int64{3, 1, 2})
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  // teams come in the order of ids, fully hydrated
+  for i, id := range []string{"3", "1", "2"} {
+    team := teams[i]
+    if team.Id != id || len(team.Members) != 1 || len(team.Skills) != 1 || len(team.Projects) != 1 {
+      t.Fatalf("team %d = %v", i, team)
+    }
+    // the leader owns the team
+    if team.Members[0].AccessRole != RoleOwner {
+      t.Errorf("leader access role = %s", team.Members[0].AccessRole)
+    }
+    if team.Project.Id != id || len(team.Project.Languages) != 1 || team.Project.Progress != 50 {
+      t.Errorf("project = %v", team.Project)
+    }
+  }
+}
+
+func TestLoadTeamsQueryCount(t *testing.T) {
+  repo, c := newCountingRepository()
+
+  // teams, members, skills, projects, languages and task and milestone progress
+  const queries = 7
+  for _, n := range []int{1, 10, 100} {
+    before := c.count()
+    teams, err := repo.loadTeams(context.Background(), teamIds(n))
+    if err != nil {
+      t.Fatal(err)
+    }
+    if len(teams) != n {
+      t.Fatalf("loaded %d teams, want %d", len(teams), n)
+    }
+    if ran := c.count() - before; ran != queries {
+      t.Errorf("loading %d teams ran %d queries, want %d", n, ran, queries)
+    }
+  }
+
+  before := c.count()
+  if _, err := repo.loadTeams(context.Background(), nil); err != nil || c.count() != before {
+    t.Errorf("loading no teams ran %d queries, error %v", c.count()-before, err)
+  }
+}
+
+func BenchmarkLoadTeams(b *testing.B) {
+  var perTeams []int64
+  for _, n := range []int{1, 10, 100, 1000} {
+    b.Run(fmt.Sprintf("%d teams", n), func(b *testing.B) {
+      repo, c := newCountingRepository()
+      ids := teamIds(n)
+      b.ResetTimer()
+      for i := 0; i < b.N; i++ {
+        if _, err := repo.loadTeams(context.Background(), ids); err != nil {
+          b.Fatal(err)
+        }
+      }
+      b.StopTimer()
+
+      queries := c.count() / int64(b.N)
+      b.ReportMetric(float64(queries), "queries/op")
+      perTeams = append(perTeams, queries)
+    })
+  }
+
+  // the query count doesn't grow with the number of teams
+  for i, queries := range perTeams {
+    if queries != perTeams[0] {
+      b.Errorf("run %d ran %d queries per load, the first %d", i, queries, perTeams[0])
+    }
+  }
+}
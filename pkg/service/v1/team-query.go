@@ -0,0 +1,167 @@
+package v1
+
+import (
+  "strings"
+  "time"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+// teamSort is how a TeamSort orders teams, ties are broken by id in the
+// same direction so the order is total and pages can resume after any team.
+//
+// Pages resume after the key the last team had when its page was read, the
+// token keeps that snapshot. Ids never change so the id sorts never skip or
+// repeat a team. last_active, open_roles and size change as teams do, a team
+// whose key moves past the cursor between two pages is skipped or listed
+// twice; clients needing an exact listing page by id.
+type teamSort struct {
+  // key is the sql expression teams are ordered by, "" orders by id only
+  key  string
+  desc bool
+}
+
+var teamSorts = map[v1.TeamSort]teamSort{
+  v1.TeamSort_TEAM_SORT_DEFAULT:     {},
+  v1.TeamSort_TEAM_SORT_NEWEST:      {desc: true},
+  v1.TeamSort_TEAM_SORT_LAST_ACTIVE: {key: "COALESCE(t.last_active, 0)", desc: true},
+  v1.TeamSort_TEAM_SORT_OPEN_ROLES:  {key: "t.open_roles", desc: true},
+  v1.TeamSort_TEAM_SORT_SIZE:        {key: "t.size", desc: true},
+}
+
+// teamQuery builds the GetTeams query, every filter is ANDed
+type teamQuery struct {
+  where []string
+  args  []interface{}
+}
+
+func (q *teamQuery) add(cond string, args ...interface{}) {
+  q.where = append(q.where, cond)
+  q.args = append(q.args, args...)
+}
+
+// buildTeamQuery returns the query selecting the id and sort key of the
+// teams matching req after token, at most limit rows
+func buildTeamQuery(req *v1.GetTeamsRequest, token *pageToken, limit int64) (string, []interface{}) {
+  q := &teamQuery{}
+
+  // teams in the trash and teams archived for inactivity are hidden
+  q.add(`t.deleted_at IS NULL`)
+  q.add(`t.archived_at IS NULL`)
+
+  // role is the single skill filter of older clients
+  if req.Role != "" {
+    q.add(`EXISTS (SELECT 1 FROM skills s WHERE s.team_id = t.id AND s.skill_name = ?)`, req.Role)
+  }
+  if skills := req.Skills; len(skills) > 0 {
+    in, args := stringInList(skills)
+    if req.SkillsMatch == v1.SkillMatch_SKILL_MATCH_ALL {
+      args = append(args, len(distinct(skills)))
+      q.add(`(SELECT COUNT(DISTINCT s.skill_name) FROM skills s WHERE s.team_id = t.id AND s.skill_name IN `+in+`) = ?`, args...)
+    } else {
+      q.add(`EXISTS (SELECT 1 FROM skills s WHERE s.team_id = t.id AND s.skill_name IN `+in+`)`, args...)
+    }
+  }
+
+  if req.Level != 0 {
+    q.add(`EXISTS (SELECT 1 FROM projects p WHERE p.team_id = t.id AND p.archived_at IS NULL AND p.complexity = ?)`, req.Level)
+  }
+  if req.MinComplexity != 0 || req.MaxComplexity != 0 {
+    cond := `EXISTS (SELECT 1 FROM projects p WHERE p.team_id = t.id AND p.archived_at IS NULL`
+    args := []interface{}{}
+    if req.MinComplexity != 0 {
+      cond += ` AND p.complexity >= ?`
+      args = append(args, req.MinComplexity)
+    }
+    if req.MaxComplexity != 0 {
+      cond += ` AND p.complexity <= ?`
+      args = append(args, req.MaxComplexity)
+    }
+    q.add(cond+`)`, args...)
+  }
+
+  if req.Technology != "" {
+    q.add(`EXISTS (SELECT 1 FROM languages l JOIN projects p ON p.id = l.project_id WHERE l.team_id = t.id AND p.archived_at IS NULL AND l.lang_name = ?)`, req.Technology)
+  }
+  if len(req.Languages) > 0 {
+    in, args := stringInList(req.Languages)
+    q.add(`EXISTS (SELECT 1 FROM languages l JOIN projects p ON p.id = l.project_id WHERE l.team_id = t.id AND p.archived_at IS NULL AND l.lang_name IN `+in+`)`, args...)
+  }
+
+  if req.MinOpenRoles != 0 {
+    q.add(`t.open_roles >= ?`, req.MinOpenRoles)
+  }
+  if req.MinSize != 0 {
+    q.add(`t.size >= ?`, req.MinSize)
+  }
+  if req.MaxSize != 0 {
+    q.add(`t.size <= ?`, req.MaxSize)
+  }
+  if req.ActiveSince != 0 {
+    q.add(`t.last_active >= ?`, req.ActiveSince)
+  }
+  if req.ActiveWithinDays != 0 {
+    q.add(`t.last_active >= ?`, time.Now().AddDate(0, 0, -int(req.ActiveWithinDays)).Unix())
+  }
+  if req.NamePrefix != "" {
+    q.add(`t.team_name LIKE ?`, escapeLike(req.NamePrefix)+"%")
+  }
+
+  // resume after the last team of the previous page
+  sort := teamSorts[req.Sort]
+  cmp := ">"
+  dir := "ASC"
+  if sort.desc {
+    cmp = "<"
+    dir = "DESC"
+  }
+  if token != nil {
+    if sort.key == "" {
+      q.add(`t.id `+cmp+` ?`, token.Id)
+    } else {
+      q.add(`(`+sort.key+` `+cmp+` ? OR (`+sort.key+` = ? AND t.id `+cmp+` ?))`, token.Key, token.Key, token.Id)
+    }
+  }
+
+  key := sort.key
+  if key == "" {
+    key = "0"
+  }
+  stmt := `SELECT t.id, ` + key + ` FROM teams t WHERE ` + strings.Join(q.where, ` AND `)
+  order := `t.id ` + dir
+  if sort.key != "" {
+    order = sort.key + ` ` + dir + `, ` + order
+  }
+  stmt += ` ORDER BY ` + order + ` LIMIT ?`
+  q.args = append(q.args, limit)
+  return stmt, q.args
+}
+
+// stringInList returns the placeholder list and arguments of values
+func stringInList(values []string) (string, []interface{}) {
+  placeholders := make([]string, len(values))
+  args := make([]interface{}, len(values))
+  for i, v := range values {
+    placeholders[i] = "?"
+    args[i] = v
+  }
+  return "(" + strings.Join(placeholders, ", ") + ")", args
+}
+
+// distinct drops duplicate values, case insensitive like the column collation
+func distinct(values []string) []string {
+  seen := map[string]bool{}
+  out := []string{}
+  for _, v := range values {
+    if !seen[strings.ToLower(v)] {
+      seen[strings.ToLower(v)] = true
+      out = append(out, v)
+    }
+  }
+  return out
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern
+func escapeLike(s string) string {
+  return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,121 @@
+package v1
+
+import (
+  "reflect"
+  "strings"
+  "testing"
+  "time"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+func TestBuildTeamQueryDefaults(t *testing.T) {
+  stmt, args := buildTeamQuery(&v1.GetTeamsRequest{}, nil, 11)
+
+  want := `SELECT t.id, 0 FROM teams t WHERE t.deleted_at IS NULL AND t.archived_at IS NULL ORDER BY t.id ASC LIMIT ?`
+  if stmt != want {
+    t.Errorf("stmt = %s, want %s", stmt, want)
+  }
+  if !reflect.DeepEqual(args, []interface{}{int64(11)}) {
+    t.Errorf("args = %v", args)
+  }
+}
+
+func TestBuildTeamQueryFilters(t *testing.T) {
+  req := &v1.GetTeamsRequest{
+    Skills:       []string{"go", "Go", "sql"},
+    SkillsMatch:  v1.SkillMatch_SKILL_MATCH_ALL,
+    MinOpenRoles: 1,
+    MaxSize:      5,
+    NamePrefix:   "a_b%",
+  }
+  stmt, args := buildTeamQuery(req, nil, 10)
+
+  for _, cond := range []string{
+    `(SELECT COUNT(DISTINCT s.skill_name) FROM skills s WHERE s.team_id = t.id AND s.skill_name IN (?, ?, ?)) = ?`,
+    `t.open_roles >= ?`,
+    `t.size <= ?`,
+    `t.team_name LIKE ?`,
+  } {
+    if !strings.Contains(stmt, cond) {
+      t.Errorf("stmt = %s, want it to contain %s", stmt, cond)
+    }
+  }
+  // duplicate skills only count once, wildcards in the prefix are literal
+  want := []interface{}{"go", "Go", "sql", 2, int32(1), int32(5), `a\_b\%%`, int64(10)}
+  if !reflect.DeepEqual(args, want) {
+    t.Errorf("args = %#v, want %#v", args, want)
+  }
+}
+
+func TestBuildTeamQueryResumesAfterToken(t *testing.T) {
+  tests := []struct {
+    name   string
+    sort   v1.TeamSort
+    cursor string
+    order  string
+    args   []interface{}
+  }{
+    {
+      name:   "id",
+      sort:   v1.TeamSort_TEAM_SORT_DEFAULT,
+      cursor: `t.id > ?`,
+      order:  `ORDER BY t.id ASC`,
+      args:   []interface{}{int64(7), int64(10)},
+    },
+    {
+      name:   "newest",
+      sort:   v1.TeamSort_TEAM_SORT_NEWEST,
+      cursor: `t.id < ?`,
+      order:  `ORDER BY t.id DESC`,
+      args:   []interface{}{int64(7), int64(10)},
+    },
+    {
+      name:   "size",
+      sort:   v1.TeamSort_TEAM_SORT_SIZE,
+      cursor: `(t.size < ? OR (t.size = ? AND t.id < ?))`,
+      order:  `ORDER BY t.size DESC, t.id DESC`,
+      args:   []interface{}{int64(4), int64(4), int64(7), int64(10)},
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      stmt, args := buildTeamQuery(&v1.GetTeamsRequest{Sort: tt.sort}, &pageToken{Key: 4, Id: 7}, 10)
+      if !strings.Contains(stmt, tt.cursor) || !strings.Contains(stmt, tt.order) {
+        t.Errorf("stmt = %s, want %s and %s", stmt, tt.cursor, tt.order)
+      }
+      if !reflect.DeepEqual(args, tt.args) {
+        t.Errorf("args = %v, want %v", args, tt.args)
+      }
+    })
+  }
+}
+
+func TestBuildTeamQueryActiveWithin(t *testing.T) {
+  stmt, args := buildTeamQuery(&v1.GetTeamsRequest{ActiveWithinDays: 30}, nil, 10)
+
+  // archived teams stay hidden whatever the filters
+  if !strings.Contains(stmt, `t.archived_at IS NULL AND t.last_active >= ?`) {
+    t.Errorf("stmt = %s", stmt)
+  }
+  since := time.Now().AddDate(0, 0, -30).Unix()
+  if got := args[0].(int64); got < since-1 || got > since {
+    t.Errorf("active since %d, want %d", got, since)
+  }
+}
+
+func TestEveryTeamSortIsKnown(t *testing.T) {
+  for value, name := range v1.TeamSort_name {
+    if _, ok := teamSorts[v1.TeamSort(value)]; !ok {
+      t.Errorf("%s has no teamSort", name)
+    }
+  }
+}
+
+func TestDistinct(t *testing.T) {
+  got := distinct([]string{"Go", "go", "SQL", "Go"})
+  if !reflect.DeepEqual(got, []string{"Go", "SQL"}) {
+    t.Errorf("distinct() = %v", got)
+  }
+}
@@ -2,42 +2,44 @@ package v1
 
 import (
   "context"
-  "errors"
   "fmt"
   // "log"
-  // "strconv"
   // "time"
   "os"
+  "strconv"
 
   //"github.com/golang/protobuf/ptypes"
   // "encoding/json"
-  // "github.com/ThreeDotsLabs/watermill"
   //"github.com/ThreeDotsLabs/watermill/message"
   // "github.com/go-redis/cache/v7"
   "google.golang.org/grpc/codes"
   "google.golang.org/grpc/status"
 
   v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/auth"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+  "github.com/ckbball/dev-team/pkg/search"
 )
 
 const (
   apiVersion = "v1"
-  eventName  = "team_created"
+  // maxTeamsPerUser is how many teams a user may lead
+  maxTeamsPerUser = 5
+  // facetSize is how many skill and language facets a search returns
+  facetSize = 10
 )
 
 type handler struct {
   repo        repository
+  index       search.Index
   userSvcAddr string
-  //subscriber message.Subscriber
-  //publisher  message.Publisher
 }
 
-func NewTeamServiceServer(repo repository, user string) *handler {
+func NewTeamServiceServer(repo repository, index search.Index, user string) *handler {
   return &handler{
     repo:        repo,
+    index:       index,
     userSvcAddr: user,
-    //subscriber: subscriber,
-    //publisher:  publisher,
   }
 }
 
@@ -51,6 +53,25 @@ func (s *handler) checkAPI(api string) error {
   return nil
 }
 
+// callerId returns the user id of the authenticated caller. Request fields
+// naming a user are never trusted, the caller must send a valid token.
+func (s *handler) callerId(ctx context.Context) (string, error) {
+  identity, err := s.caller(ctx)
+  if err != nil {
+    return "", err
+  }
+  return identity.UserId, nil
+}
+
+// caller returns the identity of the authenticated caller
+func (s *handler) caller(ctx context.Context) (*auth.Identity, error) {
+  identity, ok := auth.FromContext(ctx)
+  if !ok {
+    return nil, domainerr.Unauthenticated("a valid bearer token is required")
+  }
+  return identity, nil
+}
+
 /* Team handles api calls to grpc method Team and REST endpoint: /v1/Team
 any error generated or nil if no errors.
 */
@@ -59,80 +80,131 @@ func (s *handler) CreateTeam(ctx context.Context, req *v1.TeamUpsertRequest) (*v
   if err := s.checkAPI(req.Api); err != nil {
     return nil, err
   }
-  // need to make sure team_name is unique
-  teamTemp, err := s.repo.GetTeamByTeamName(ctx, req.Team.Name)
-  fmt.Fprintf(os.Stderr, "Error: from Repo GetTeamByTeamName: %v\n", err)
-  fmt.Fprintf(os.Stderr, "Team: from Repo GetTeamByTeamName: %v\n", teamTemp)
-  if err != nil && err.Error() != "team Query: no matching record found" {
+  // the caller leads the team they create
+  userId, err := s.callerId(ctx)
+  if err != nil {
     return nil, err
-  } else if teamTemp != nil {
-    return &v1.TeamUpsertResponse{
-      Api:    "v1",
-      Status: "error:duplicatename",
-    }, nil
+  }
+  req.Team.Leader = userId
+
+  // the leader is the only initial member, everyone else joins through an
+  // invitation or an application. Ownership comes from leading the team.
+  for i, member := range req.Team.Members {
+    field := fmt.Sprintf("team.members[%d]", i)
+    if strconv.Itoa(int(member.Id)) != userId {
+      return nil, domainerr.InvalidArgument(domainerr.FieldViolation{
+        Field:       field + ".id",
+        Description: "only the leader may be an initial member, others must be invited",
+      })
+    }
+    if member.AccessRole != "" && member.AccessRole != RoleOwner {
+      return nil, domainerr.InvalidArgument(domainerr.FieldViolation{
+        Field:       field + ".access_role",
+        Description: fmt.Sprintf("'%s' is not owner, the leader owns the team", member.AccessRole),
+      })
+    }
+  }
+
+  // need to make sure team_name is unique, teams in the trash keep theirs
+  taken, err := s.repo.CheckTeamNameTaken(ctx, req.Team.Name)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "Error: from Repo CheckTeamNameTaken: %v\n", err)
+    return nil, err
+  } else if taken {
+    return nil, domainerr.AlreadyExists(domainerr.ReasonTeamNameTaken, "team name '%s' is taken", req.Team.Name).With("team_name", req.Team.Name)
   }
   // team name is now unique
 
   // need to make sure auth token user has less than 5 teams
   // get number of teams user owns
-  count, err := s.repo.CountUserTeams(ctx, req.UserId)
+  count, err := s.repo.CountUserTeams(ctx, userId)
   if err != nil || count == -1 {
     return nil, err
   }
   // if >= 5 deny request
-  if count >= 5 {
-    return &v1.TeamUpsertResponse{
-      Api:    "v1",
-      Status: "error:maxteamcount",
-    }, nil
+  if count >= maxTeamsPerUser {
+    return nil, domainerr.FailedPrecondition(domainerr.ReasonTeamLimitReached, "users may lead at most %d teams", maxTeamsPerUser).With("limit", strconv.Itoa(maxTeamsPerUser))
   }
 
   // else continue
 
   // call repo func to create a new team
-  fmt.Fprintf(os.Stderr, "State Team: about to call CreateTeam: %v\n", teamTemp)
+  fmt.Fprintf(os.Stderr, "State Team: about to call CreateTeam: %v\n", req.Team)
   newId, err := s.repo.CreateTeam(ctx, req.Team)
   if err != nil {
     fmt.Fprintf(os.Stderr, "error from Repo CreateTeam: %v\n", newId)
     return nil, err
   }
 
-  // publish team_created Event here
+  // team_created Event is written to the outbox by the repository
 
   // return successful response
   return &v1.TeamUpsertResponse{
-    Api:    "v1",
-    Status: "Upserted",
-    Id:     newId,
+    Api:     "v1",
+    Status:  "Upserted",
+    Id:      newId,
+    Version: initialVersion,
   }, nil
 
 }
 
+func (s *handler) UpdateTeam(ctx context.Context, req *v1.UpdateTeamRequest) (*v1.UpdateTeamResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  // Check if the caller may edit team correlating to req.Id
+  if _, _, err := s.authorize(ctx, req.Id, ActionUpdateTeam); err != nil {
+    return nil, err
+  }
+
+  expected, err := expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
+  }
+
+  // the repository re-checks name uniqueness and the sizes against the stored team
+  team, err := s.repo.UpdateTeam(ctx, req.Id, req.Team, req.GetUpdateMask().GetPaths(), expected)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo UpdateTeam: %v\n", req.Id)
+    return nil, err
+  }
+
+  // team_updated Event is written to the outbox by the repository
+
+  return &v1.UpdateTeamResponse{
+    Api:    apiVersion,
+    Status: "Updated",
+    Team:   team,
+  }, nil
+}
+
 func (s *handler) DeleteTeam(ctx context.Context, req *v1.TeamDeleteRequest) (*v1.TeamDeleteResponse, error) {
   // check api version
   if err := s.checkAPI(req.Api); err != nil {
     return nil, err
   }
 
-  // Check if user owns team correlating to req.TeamId, using req.UserId
-  owns, err := s.repo.CheckUserOwnsTeam(ctx, req.UserId, req.TeamId)
+  // Check if the caller may delete team correlating to req.TeamId
+  userId, _, err := s.authorize(ctx, req.TeamId, ActionDeleteTeam)
   if err != nil {
-    fmt.Fprintf(os.Stderr, "error from Repo CheckIfUserOwnsTeam: %v\n", req.TeamId)
     return nil, err
   }
-  if !owns {
-    fmt.Fprintf(os.Stderr, "user %v doesn't own team: %v\n", req.UserId, req.TeamId)
-    return nil, errors.New("invalid")
+
+  expected, err := expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
   }
 
-  // delete the team corresponding to TeamId
-  teamRows, memRows, skillRows, err := s.repo.DeleteTeam(ctx, req.TeamId)
+  // move the team corresponding to TeamId to the trash
+  teamRows, memRows, skillRows, err := s.repo.DeleteTeam(ctx, req.TeamId, userId, expected)
   if err != nil {
     fmt.Fprintf(os.Stderr, "error from Repo DeleteTeam: %v\n", req.TeamId)
     return nil, err
   }
 
-  // publish team_deleted Event here
+  // team_deleted Event is written to the outbox by the repository
 
   return &v1.TeamDeleteResponse{
     Api:     "v1",
@@ -149,29 +221,35 @@ func (s *handler) AddMember(ctx context.Context, req *v1.MemberUpsertRequest) (*
     return nil, err
   }
 
-  // Check if team is at max size
-  max, err := s.repo.CheckTeamSize(ctx, req.TeamId)
+  // Check if the caller may add members to team correlating to req.TeamId
+  _, role, err := s.authorize(ctx, req.TeamId, ActionAddMember)
   if err != nil {
-    fmt.Fprintf(os.Stderr, "Error AddMember: Repo CheckIfUserOwnsTeam: %v\n", req.TeamId)
     return nil, err
   }
-  if max {
-    fmt.Fprintf(os.Stderr, "max team size: %v\n", req.UserId, req.TeamId)
-    return &v1.MemberUpsertResponse{
-      Api:    "v1",
-      Status: "error:maxmembercount",
-    }, nil
+
+  // new members are regular members unless asked otherwise, only owners add admins
+  if req.AccessRole == "" {
+    req.AccessRole = RoleMember
+  }
+  if !validAccessRole(req.AccessRole) {
+    return nil, domainerr.InvalidArgument(domainerr.FieldViolation{
+      Field:       "access_role",
+      Description: fmt.Sprintf("'%s' is not one of admin, member or viewer", req.AccessRole),
+    })
+  }
+  if !outranks(role, req.AccessRole) {
+    return nil, permissionDenied("add "+req.AccessRole, req.TeamId)
   }
 
-  // Check if user owns team correlating to req.TeamId, using req.UserId
-  owns, err := s.repo.CheckUserOwnsTeam(ctx, req.UserId, req.TeamId)
+  // Check if team is at max size
+  max, err := s.repo.CheckTeamSize(ctx, req.TeamId)
   if err != nil {
     fmt.Fprintf(os.Stderr, "Error AddMember: Repo CheckIfUserOwnsTeam: %v\n", req.TeamId)
     return nil, err
   }
-  if !owns {
-    fmt.Fprintf(os.Stderr, "user %v doesn't own team: %v\n", req.UserId, req.TeamId)
-    return nil, errors.New("invalid")
+  if max {
+    fmt.Fprintf(os.Stderr, "max team size: %v\n", req.TeamId)
+    return nil, domainerr.FailedPrecondition(domainerr.ReasonTeamFull, "team '%s' has no open roles", req.TeamId).With("team_id", req.TeamId)
   }
 
   // need to check if trying to add duplicate user
@@ -182,83 +260,184 @@ func (s *handler) AddMember(ctx context.Context, req *v1.MemberUpsertRequest) (*
     return nil, err
   }
   if exists {
-    fmt.Fprintf(os.Stderr, "user %v exists on team: %v\n", req.UserId, req.TeamId)
-    return &v1.MemberUpsertResponse{
-      Api:    "v1",
-      Status: "error:exists",
-    }, nil
+    fmt.Fprintf(os.Stderr, "user %v exists on team: %v\n", req.MemberId, req.TeamId)
+    return nil, domainerr.AlreadyExists(domainerr.ReasonAlreadyMember, "user '%s' is already on team '%s'", req.MemberId, req.TeamId).With("team_id", req.TeamId)
   }
 
-  // need to grab user's id by email because team owner wont know user's id fullfil this in edge?
+  // owners who only know the user's email invite them instead, see InviteMember
 
-  newId, err := s.repo.AddMember(ctx, req)
+  req.ExpectedVersion, err = expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
+  }
+
+  newId, version, err := s.repo.AddMember(ctx, req)
   if err != nil {
     fmt.Fprintf(os.Stderr, "error from Repo AddMember: %v\n", req.TeamId)
     return nil, err
   }
 
-  // publish team_created Event here
+  // member_added Event is written to the outbox by the repository
 
   return &v1.MemberUpsertResponse{
     Api:          "v1",
     Status:       "Upserted",
     MemberNumber: newId,
+    Version:      version,
   }, nil
 }
 
-// untested
 func (s *handler) RemoveMember(ctx context.Context, req *v1.MemberDeleteRequest) (*v1.MemberDeleteResponse, error) {
   // check api version
   if err := s.checkAPI(req.Api); err != nil {
     return nil, err
   }
 
-  // check req.UserId owns team=req.TeamId
+  userId, err := s.callerId(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  member, err := s.repo.GetMember(ctx, req.TeamId, req.MemberNumber)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo GetMember: %v\n", req.TeamId)
+    return nil, err
+  }
+  if member == nil {
+    return nil, domainerr.NotFound(domainerr.ReasonMemberNotFound, "team '%s' has no member '%s'", req.TeamId, req.MemberNumber).With("member_number", req.MemberNumber)
+  }
+
+  if strconv.Itoa(int(member.Id)) == userId {
+    // members may leave a team, the owner has to hand it over first
+    if member.AccessRole == RoleOwner {
+      return nil, domainerr.FailedPrecondition(domainerr.ReasonOwnerMustTransfer, "the owner must transfer ownership before leaving the team").With("team_id", req.TeamId)
+    }
+  } else {
+    // only owners and admins remove others, and only members they outrank
+    _, role, err := s.authorize(ctx, req.TeamId, ActionRemoveMember)
+    if err != nil {
+      return nil, err
+    }
+    if !outranks(role, member.AccessRole) {
+      return nil, permissionDenied("remove "+member.AccessRole, req.TeamId)
+    }
+  }
 
-  count, err := s.repo.RemoveMember(ctx, req.TeamId, req.MemberNumber)
+  expected, err := expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
+  }
+
+  count, version, err := s.repo.RemoveMember(ctx, req.TeamId, req.MemberNumber, expected)
   if err != nil {
     fmt.Fprintf(os.Stderr, "error from Repo RemoveMember: %v\n", req.TeamId)
     return nil, err
   }
 
-  // publish MemberRemoved Event here
+  // member_removed Event is written to the outbox by the repository
 
   return &v1.MemberDeleteResponse{
-    Api:    "v1",
-    Status: "Member Deleted",
-    Count:  count,
+    Api:     "v1",
+    Status:  "Member Deleted",
+    Count:   count,
+    Version: version,
   }, nil
 }
 
-func (s *handler) UpsertTeamProject(ctx context.Context, req *v1.ProjectUpsertRequest) (*v1.ProjectUpsertResponse, error) {
+func (s *handler) TransferOwnership(ctx context.Context, req *v1.TransferOwnershipRequest) (*v1.TransferOwnershipResponse, error) {
   // check api version
   if err := s.checkAPI(req.Api); err != nil {
     return nil, err
   }
 
-  // Check if user owns team correlating to req.TeamId, using req.UserId
-  owns, err := s.repo.CheckUserOwnsTeam(ctx, req.UserId, req.TeamId)
+  // Check if the caller may hand over team correlating to req.TeamId
+  userId, _, err := s.authorize(ctx, req.TeamId, ActionTransferOwnership)
   if err != nil {
-    fmt.Fprintf(os.Stderr, "Error AddMember: Repo CheckIfUserOwnsTeam: %v\n", req.TeamId)
     return nil, err
   }
-  if !owns {
-    fmt.Fprintf(os.Stderr, "user %v doesn't own team: %v\n", req.UserId, req.TeamId)
-    return nil, errors.New("invalid")
+  // the caller stays on the team under the email of their token
+  identity, err := s.caller(ctx)
+  if err != nil {
+    return nil, err
+  }
+  if req.NewOwnerId == userId {
+    return nil, domainerr.InvalidArgument(domainerr.FieldViolation{
+      Field:       "new_owner_id",
+      Description: "already owns the team",
+    })
+  }
+
+  expected, err := expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
+  }
+
+  // the repository checks the new owner is a member with room under the team cap
+  version, err := s.repo.TransferOwnership(ctx, req.TeamId, userId, identity.Email, req.NewOwnerId, expected)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo TransferOwnership: %v\n", req.TeamId)
+    return nil, err
+  }
+
+  // leader_changed Event is written to the outbox by the repository
+
+  return &v1.TransferOwnershipResponse{
+    Api:     apiVersion,
+    Status:  "Transferred",
+    TeamId:  req.TeamId,
+    OwnerId: req.NewOwnerId,
+    Version: version,
+  }, nil
+}
+
+func (s *handler) UpsertTeamProject(ctx context.Context, req *v1.ProjectUpsertRequest) (*v1.ProjectUpsertResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  // Check if the caller may change the project of team correlating to req.TeamId
+  if _, _, err := s.authorize(ctx, req.TeamId, ActionUpsertProject); err != nil {
+    return nil, err
+  }
+
+  expected, err := expectedVersion(ctx, req.ExpectedVersion)
+  if err != nil {
+    return nil, err
   }
 
   // call repo method to create project
-  _, err = s.repo.UpsertProject(ctx, req.TeamId, req.Project)
+  _, version, err := s.repo.UpsertProject(ctx, req.TeamId, req.Project, expected)
   if err != nil {
     fmt.Fprintf(os.Stderr, "error from Repo UpsertProject: %v\n", req.TeamId)
     return nil, err
   }
 
-  // publish team_created Event here
+  // project_upserted Event is written to the outbox by the repository
 
   return &v1.ProjectUpsertResponse{
+    Api:     "v1",
+    Status:  "Project Upserted",
+    Version: version,
+  }, nil
+}
+
+func (s *handler) GetTeamByTeamId(ctx context.Context, req *v1.GetByTeamIdRequest) (*v1.GetByTeamIdResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  team, err := s.repo.GetTeamByTeamId(ctx, req.Id)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo GetTeamByTeamId: %v\n", req.Id)
+    return nil, err
+  }
+
+  return &v1.GetByTeamIdResponse{
     Api:    "v1",
-    Status: "Project Upserted",
+    Status: "found",
+    Team:   team,
   }, nil
 }
 
@@ -314,14 +493,20 @@ func (s *handler) GetTeamsByUserId(ctx context.Context, req *v1.GetByUserIdReque
 }
 
 // Fetches user's teams by accessing valid jwt token sent in the headers,
+// req.Id is ignored
 func (s *handler) GetTeamsByCurrentUser(ctx context.Context, req *v1.GetByUserIdRequest) (*v1.GetByUserIdResponse, error) {
   // check api version
   if err := s.checkAPI(req.Api); err != nil {
     return nil, err
   }
 
+  userId, err := s.callerId(ctx)
+  if err != nil {
+    return nil, err
+  }
+
   // call repo method to get teams sending it id you get back from token
-  teams, err := s.repo.GetTeamsByUserId(ctx, req.Id)
+  teams, err := s.repo.GetTeamsByUserId(ctx, userId)
   if err != nil {
     // if error occured accessing db return it here
     return nil, err
@@ -331,7 +516,7 @@ func (s *handler) GetTeamsByCurrentUser(ctx context.Context, req *v1.GetByUserId
       Api:    apiVersion,
       Status: "empty",
       Teams:  teams,
-      Id:     req.Id,
+      Id:     userId,
     }, nil
   }
 
@@ -340,6 +525,7 @@ func (s *handler) GetTeamsByCurrentUser(ctx context.Context, req *v1.GetByUserId
     Api:    apiVersion,
     Status: "teams",
     Teams:  teams,
+    Id:     userId,
     // maybe in future add more data to response about the added user.
   }, nil
 }
@@ -350,9 +536,7 @@ func (s *handler) GetTeams(ctx context.Context, req *v1.GetTeamsRequest) (*v1.Ge
     return nil, err
   }
 
-  fmt.Fprintf(os.Stderr, "\npage: %v\nlimit: %v\n", req.Page, req.Limit)
-
-  teams, err := s.repo.GetTeams(ctx, req)
+  teams, nextPageToken, err := s.repo.GetTeams(ctx, req)
   if err != nil {
     fmt.Fprintf(os.Stderr, "error from Repo GetTeams:\n")
     return nil, err
@@ -367,8 +551,105 @@ func (s *handler) GetTeams(ctx context.Context, req *v1.GetTeamsRequest) (*v1.Ge
   }
 
   return &v1.GetTeamsResponse{
-    Api:    "v1",
-    Status: "teams",
-    Teams:  teams,
+    Api:           "v1",
+    Status:        "teams",
+    Teams:         teams,
+    NextPageToken: nextPageToken,
   }, nil
 }
+
+func (s *handler) SearchTeams(ctx context.Context, req *v1.SearchTeamsRequest) (*v1.SearchTeamsResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  offset, err := decodeSearchPageToken(req)
+  if err != nil {
+    return nil, err
+  }
+  size := int(req.Limit)
+  if size == 0 {
+    size = defaultPageSize
+  }
+
+  result, err := s.index.Search(ctx, search.Query{
+    Text:      req.Query,
+    Skills:    req.Skills,
+    Languages: req.Languages,
+    From:      offset,
+    Size:      size,
+    FacetSize: facetSize,
+  })
+  if err == search.ErrDisabled {
+    return nil, status.Error(codes.Unimplemented, "search is disabled on this server")
+  }
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from search index: %v\n", err)
+    return nil, err
+  }
+
+  // hits are hydrated from the repository, teams deleted or archived since
+  // they were indexed are skipped
+  ids := []string{}
+  for _, hit := range result.Hits {
+    ids = append(ids, hit.TeamId)
+  }
+  teams, err := s.repo.GetTeamsByIds(ctx, ids)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo GetTeamsByIds:\n")
+    return nil, err
+  }
+  byId := map[string]*v1.Team{}
+  for _, team := range teams {
+    byId[team.Id] = team
+  }
+
+  hits := []*v1.SearchHit{}
+  for _, hit := range result.Hits {
+    team, ok := byId[hit.TeamId]
+    if !ok || team.ArchivedAt != 0 {
+      continue
+    }
+    hits = append(hits, &v1.SearchHit{
+      Team:       team,
+      Score:      hit.Score,
+      Highlights: highlights(hit.Highlights),
+    })
+  }
+
+  res := &v1.SearchTeamsResponse{
+    Api:            apiVersion,
+    Status:         "teams",
+    Hits:           hits,
+    Total:          int64(result.Total),
+    SkillFacets:    facets(result.Skills),
+    LanguageFacets: facets(result.Languages),
+  }
+  if len(result.Hits) == 0 {
+    res.Status = "empty"
+  }
+  if next := offset + len(result.Hits); len(result.Hits) == size && uint64(next) < result.Total {
+    res.NextPageToken = encodeSearchPageToken(req, next)
+  }
+  return res, nil
+}
+
+// highlights returns the highlighted fragments of a hit ordered by field
+func highlights(fragments map[string][]string) []*v1.Highlight {
+  out := []*v1.Highlight{}
+  for _, field := range []string{"name", "project_name", "description"} {
+    if len(fragments[field]) > 0 {
+      out = append(out, &v1.Highlight{Field: field, Fragments: fragments[field]})
+    }
+  }
+  return out
+}
+
+func facets(in []search.Facet) []*v1.Facet {
+  out := []*v1.Facet{}
+  for _, facet := range in {
+    out = append(out, &v1.Facet{Term: facet.Term, Count: int64(facet.Count)})
+  }
+  return out
+}
@@ -0,0 +1,210 @@
+package v1
+
+import (
+  "context"
+  "database/sql"
+  "strconv"
+  "strings"
+  "time"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// Updates the fields of team id listed in paths with the values of patch
+// and returns the updated team. Skills are diffed so unchanged skills keep
+// their rows. The team must be at version expected unless it is 0.
+// output ON FAILURE: TEAM_NOT_FOUND, VERSION_MISMATCH, TEAM_NAME_TAKEN, INVALID_ARGUMENT when the sizes don't fit the team or the error object from whatever created the error
+func (r *teamRepository) UpdateTeam(ctx context.Context, id string, patch *v1.Team, paths []string, expected int64) (*v1.Team, error) {
+  teamStmt := `SELECT team_name, open_roles, size, auto_close_applications FROM teams WHERE id=? FOR UPDATE`
+  nameStmt := `SELECT COUNT(*) FROM teams WHERE team_name=? AND id<>?`
+  membersStmt := `SELECT COUNT(*) FROM members WHERE team_id=?`
+  updateStmt := `UPDATE teams SET team_name=?, open_roles=?, size=?, auto_close_applications=? WHERE id=?`
+  skillsStmt := `SELECT skill_name FROM skills WHERE team_id=? ORDER BY id`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return nil, err
+  }
+  defer tx.Rollback()
+
+  // lock the team, the new values are checked against its current ones
+  if _, err := nextVersion(ctx, tx, id, expected); err != nil {
+    return nil, err
+  }
+  team := &v1.Team{Id: id}
+  err = tx.QueryRowContext(ctx, teamStmt, id).Scan(&team.Name, &team.OpenRoles, &team.Size, &team.AutoCloseApplications)
+  if err == sql.ErrNoRows {
+    return nil, domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", id).With("team_id", id)
+  } else if err != nil {
+    return nil, err
+  }
+
+  masked := map[string]bool{}
+  for _, path := range paths {
+    masked[path] = true
+  }
+  if masked["skills"] {
+    team.Skills = []string{}
+    err := queryRows(ctx, tx, skillsStmt, []interface{}{id}, func(rows *sql.Rows) error {
+      var skill string
+      if err := rows.Scan(&skill); err != nil {
+        return err
+      }
+      team.Skills = append(team.Skills, skill)
+      return nil
+    })
+    if err != nil {
+      return nil, err
+    }
+  }
+  before := maskedState(team, paths)
+  if masked["name"] && !strings.EqualFold(patch.Name, team.Name) {
+    var count int
+    if err := tx.QueryRowContext(ctx, nameStmt, patch.Name, id).Scan(&count); err != nil {
+      return nil, err
+    }
+    if count > 0 {
+      return nil, domainerr.AlreadyExists(domainerr.ReasonTeamNameTaken, "team name '%s' is taken", patch.Name).With("team_name", patch.Name)
+    }
+  }
+  if masked["name"] {
+    team.Name = patch.Name
+  }
+  if masked["open_roles"] {
+    team.OpenRoles = patch.OpenRoles
+  }
+  if masked["size"] {
+    team.Size = patch.Size
+  }
+  if masked["auto_close_applications"] {
+    team.AutoCloseApplications = patch.AutoCloseApplications
+  }
+  if masked["skills"] {
+    team.Skills = distinct(patch.Skills)
+  }
+
+  // the sizes must still fit together and fit the current members
+  var members int32
+  if err := tx.QueryRowContext(ctx, membersStmt, id).Scan(&members); err != nil {
+    return nil, err
+  }
+  violations := []domainerr.FieldViolation{}
+  if team.OpenRoles > team.Size {
+    violations = append(violations, domainerr.FieldViolation{Field: "team.open_roles", Description: "must not exceed size"})
+  }
+  if team.Size < members {
+    violations = append(violations, domainerr.FieldViolation{Field: "team.size", Description: "must not be lower than the number of members"})
+  }
+  if len(violations) > 0 {
+    return nil, domainerr.InvalidArgument(violations...)
+  }
+
+  if _, err := tx.ExecContext(ctx, updateStmt, team.Name, team.OpenRoles, team.Size, team.AutoCloseApplications, id); err != nil {
+    return nil, err
+  }
+  if masked["skills"] {
+    if err := replaceSkills(ctx, tx, id, patch.Skills); err != nil {
+      return nil, err
+    }
+  }
+  // a team updated to no open roles may close its pending applications
+  if err := closeFullTeamApplications(ctx, tx, id); err != nil {
+    return nil, err
+  }
+
+  // record team_updated event in the outbox
+  event := &v1.TeamUpdated{
+    TeamId:                id,
+    Paths:                 paths,
+    Name:                  team.Name,
+    OpenRoles:             team.OpenRoles,
+    Size:                  team.Size,
+    AutoCloseApplications: team.AutoCloseApplications,
+    OccurredAt:            time.Now().Unix(),
+  }
+  if masked["skills"] {
+    event.Skills = distinct(patch.Skills)
+  }
+  if err := insertOutboxEvent(tx, TeamUpdatedTopic, event); err != nil {
+    return nil, err
+  }
+  if err := insertAuditEvent(ctx, tx, id, AuditUpdateTeam, before, maskedState(team, paths)); err != nil {
+    return nil, err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return nil, err
+  }
+
+  teamId, _ := strconv.ParseInt(id, 10, 64)
+  return r.loadTeam(ctx, teamId)
+}
+
+// replaceSkills makes skills the skills of team teamId, deleting the rows of
+// removed skills and inserting the added ones
+func replaceSkills(ctx context.Context, tx *sql.Tx, teamId string, skills []string) error {
+  selectStmt := `SELECT id, skill_name FROM skills WHERE team_id=?`
+  deleteStmt := `DELETE FROM skills WHERE id=?`
+  insertStmt := `INSERT INTO skills (skill_name, team_id) VALUES (?, ?)`
+
+  wanted := map[string]bool{}
+  for _, skill := range skills {
+    wanted[strings.ToLower(skill)] = true
+  }
+
+  current := map[string]bool{}
+  removed := []int64{}
+  err := queryRows(ctx, tx, selectStmt, []interface{}{teamId}, func(rows *sql.Rows) error {
+    var id int64
+    var skill string
+    if err := rows.Scan(&id, &skill); err != nil {
+      return err
+    }
+    key := strings.ToLower(skill)
+    if !wanted[key] || current[key] {
+      removed = append(removed, id)
+      return nil
+    }
+    current[key] = true
+    return nil
+  })
+  if err != nil {
+    return err
+  }
+
+  for _, id := range removed {
+    if _, err := tx.ExecContext(ctx, deleteStmt, id); err != nil {
+      return err
+    }
+  }
+  for _, skill := range distinct(skills) {
+    if current[strings.ToLower(skill)] {
+      continue
+    }
+    if _, err := tx.ExecContext(ctx, insertStmt, skill, teamId); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+// maskedState is the audited state of the fields of team listed in paths
+func maskedState(team *v1.Team, paths []string) auditState {
+  state := auditState{}
+  for _, path := range paths {
+    switch path {
+    case "name":
+      state[path] = team.Name
+    case "open_roles":
+      state[path] = team.OpenRoles
+    case "size":
+      state[path] = team.Size
+    case "auto_close_applications":
+      state[path] = team.AutoCloseApplications
+    case "skills":
+      state[path] = team.Skills
+    }
+  }
+  return state
+}
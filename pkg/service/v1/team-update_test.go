@@ -0,0 +1,148 @@
+package v1
+
+import (
+  "context"
+  "database/sql/driver"
+  "reflect"
+  "testing"
+
+  "github.com/DATA-DOG/go-sqlmock"
+  "github.com/golang/protobuf/proto"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// expectLockTeam expects UpdateTeam to lock team 3 named gophers with 1 open
+// role of 4
+func expectLockTeam(mock sqlmock.Sqlmock) {
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 1)
+  mock.ExpectQuery(stmt(`SELECT team_name, open_roles, size, auto_close_applications FROM teams WHERE id=? FOR UPDATE`)).WithArgs("3").
+    WillReturnRows(sqlmock.NewRows([]string{"team_name", "open_roles", "size", "auto_close_applications"}).AddRow("gophers", 1, 4, false))
+}
+
+// expectMembers expects the members of team 3 to be counted
+func expectMembers(mock sqlmock.Sqlmock, members int) {
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM members WHERE team_id=?`)).WithArgs("3").WillReturnRows(countRow(members))
+}
+
+func TestUpdateTeamChangesMaskedFieldsOnly(t *testing.T) {
+  repo, mock := newMockRepository(t)
+  outbox := &fakeOutbox{}
+  var before, after driver.Value
+
+  expectLockTeam(mock)
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM teams WHERE team_name=? AND id<>?`)).WithArgs("rustaceans", "3").WillReturnRows(countRow(0))
+  expectMembers(mock, 2)
+  // open_roles and size keep their stored values
+  mock.ExpectExec(stmt(`UPDATE teams SET team_name=?, open_roles=?, size=?, auto_close_applications=? WHERE id=?`)).
+    WithArgs("rustaceans", int32(1), int32(4), false, "3").WillReturnResult(sqlmock.NewResult(0, 1))
+  expectNoApplicationsToClose(mock, "3")
+  outbox.expect(mock, TeamUpdatedTopic)
+  mock.ExpectExec(stmt(`INSERT INTO audit_events`)).
+    WithArgs("3", sqlmock.AnyArg(), AuditUpdateTeam, capture{&before}, capture{&after}, sqlmock.AnyArg(), sqlmock.AnyArg()).
+    WillReturnResult(sqlmock.NewResult(1, 1))
+  mock.ExpectCommit()
+  expectNoTeams(mock)
+
+  patch := &v1.Team{Name: "rustaceans", OpenRoles: 9, Size: 1}
+  if _, err := repo.UpdateTeam(context.Background(), "3", patch, []string{"name"}, 0); err != nil {
+    t.Fatal(err)
+  }
+
+  if before.(string) != `{"name":"gophers"}` || after.(string) != `{"name":"rustaceans"}` {
+    t.Errorf("audited %s -> %s", before, after)
+  }
+  event := &v1.TeamUpdated{}
+  if err := proto.Unmarshal(outbox.rows[0].payload.([]byte), event); err != nil {
+    t.Fatal(err)
+  }
+  if !reflect.DeepEqual(event.Paths, []string{"name"}) || event.Name != "rustaceans" || event.Size != 4 || event.Skills != nil {
+    t.Errorf("team_updated = %v", event)
+  }
+}
+
+func TestUpdateTeamChecks(t *testing.T) {
+  tests := []struct {
+    name   string
+    patch  *v1.Team
+    paths  []string
+    expect func(mock sqlmock.Sqlmock)
+    reason string
+  }{
+    {"name taken", &v1.Team{Name: "rustaceans"}, []string{"name"}, func(mock sqlmock.Sqlmock) {
+      mock.ExpectQuery(stmt(`WHERE team_name=? AND id<>?`)).WillReturnRows(countRow(1))
+    }, domainerr.ReasonTeamNameTaken},
+    // the stored size applies when size isn't masked
+    {"open roles above the stored size", &v1.Team{OpenRoles: 5}, []string{"open_roles"}, func(mock sqlmock.Sqlmock) {
+      expectMembers(mock, 2)
+    }, domainerr.ReasonInvalidArgument},
+    {"size below the members", &v1.Team{Size: 2, OpenRoles: 0}, []string{"size", "open_roles"}, func(mock sqlmock.Sqlmock) {
+      expectMembers(mock, 3)
+    }, domainerr.ReasonInvalidArgument},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      repo, mock := newMockRepository(t)
+      expectLockTeam(mock)
+      tt.expect(mock)
+      mock.ExpectRollback()
+
+      if _, err := repo.UpdateTeam(context.Background(), "3", tt.patch, tt.paths, 0); domainerr.ReasonOf(err) != tt.reason {
+        t.Errorf("UpdateTeam() error = %v, want %s", err, tt.reason)
+      }
+    })
+  }
+}
+
+func TestUpdateTeamRenamingToAnotherCaseSkipsTheNameCheck(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  expectLockTeam(mock)
+  expectMembers(mock, 2)
+  mock.ExpectExec(stmt(`UPDATE teams SET team_name=?`)).WithArgs("Gophers", int32(1), int32(4), false, "3").WillReturnResult(sqlmock.NewResult(0, 1))
+  expectNoApplicationsToClose(mock, "3")
+  expectOutbox(mock, TeamUpdatedTopic)
+  expectAudit(mock, "3", AuditUpdateTeam)
+  mock.ExpectCommit()
+  expectNoTeams(mock)
+
+  if _, err := repo.UpdateTeam(context.Background(), "3", &v1.Team{Name: "Gophers"}, []string{"name"}, 0); err != nil {
+    t.Fatal(err)
+  }
+}
+
+func TestUpdateTeamDiffsSkills(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  expectLockTeam(mock)
+  mock.ExpectQuery(stmt(`SELECT skill_name FROM skills WHERE team_id=? ORDER BY id`)).WithArgs("3").
+    WillReturnRows(sqlmock.NewRows([]string{"skill_name"}).AddRow("Go").AddRow("sql"))
+  expectMembers(mock, 2)
+  mock.ExpectExec(stmt(`UPDATE teams SET team_name=?`)).WillReturnResult(sqlmock.NewResult(0, 1))
+  // go keeps its row, sql and the duplicate go row are deleted, rust is added
+  mock.ExpectQuery(stmt(`SELECT id, skill_name FROM skills WHERE team_id=?`)).WithArgs("3").
+    WillReturnRows(sqlmock.NewRows([]string{"id", "skill_name"}).AddRow(1, "Go").AddRow(2, "sql").AddRow(3, "go"))
+  mock.ExpectExec(stmt(`DELETE FROM skills WHERE id=?`)).WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`DELETE FROM skills WHERE id=?`)).WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`INSERT INTO skills (skill_name, team_id) VALUES (?, ?)`)).WithArgs("rust", "3").WillReturnResult(sqlmock.NewResult(4, 1))
+  expectNoApplicationsToClose(mock, "3")
+  expectOutbox(mock, TeamUpdatedTopic)
+  expectAudit(mock, "3", AuditUpdateTeam)
+  mock.ExpectCommit()
+  expectNoTeams(mock)
+
+  if _, err := repo.UpdateTeam(context.Background(), "3", &v1.Team{Skills: []string{"go", "rust", "Rust"}}, []string{"skills"}, 0); err != nil {
+    t.Fatal(err)
+  }
+}
+
+func TestMaskedState(t *testing.T) {
+  team := &v1.Team{Name: "gophers", OpenRoles: 1, Size: 4, Skills: []string{"go"}, AutoCloseApplications: true}
+  got := maskedState(team, []string{"size", "skills", "unknown"})
+  want := auditState{"size": int32(4), "skills": []string{"go"}}
+  if !reflect.DeepEqual(got, want) {
+    t.Errorf("maskedState() = %v, want %v", got, want)
+  }
+}
@@ -0,0 +1,226 @@
+package v1
+
+import (
+  "context"
+  "database/sql"
+  "strconv"
+  "time"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// DefaultTrashRetention is how long deleted teams stay in the trash unless
+// configured otherwise
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// how many teams PurgeDeletedTeams deletes per call
+const purgeBatchSize = 100
+
+// Takes team teamId out of the trash. The team must have been deleted within
+// the retention period and its leader must have room under the team cap.
+// output ON SUCCESS: *v1.Team - the restored team, error - nil
+// output ON FAILURE: TEAM_NOT_FOUND if the team isn't in the trash, TEAM_LIMIT_REACHED or the error object from whatever created the error
+func (r *teamRepository) RestoreTeam(ctx context.Context, teamId, userId string) (*v1.Team, error) {
+  teamStmt := `SELECT leader, deleted_at FROM teams WHERE id=? AND deleted_at >= ? FOR UPDATE`
+  countStmt := `SELECT COUNT(*) FROM teams WHERE leader=? AND deleted_at IS NULL`
+  restoreStmt := `UPDATE teams SET deleted_at=NULL, deleted_by=NULL, version = version + 1 WHERE id=?`
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return nil, err
+  }
+  defer tx.Rollback()
+
+  // lock the team so it isn't purged while it is restored
+  var leader string
+  var deletedAt int64
+  err = tx.QueryRowContext(ctx, teamStmt, teamId, r.purgeCutoff()).Scan(&leader, &deletedAt)
+  if err == sql.ErrNoRows {
+    return nil, domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' is not in the trash", teamId).With("team_id", teamId)
+  } else if err != nil {
+    return nil, err
+  }
+
+  // the team counts towards its leader's cap again
+  var count int
+  if err := tx.QueryRowContext(ctx, countStmt, leader).Scan(&count); err != nil {
+    return nil, err
+  }
+  if count >= maxTeamsPerUser {
+    return nil, domainerr.FailedPrecondition(domainerr.ReasonTeamLimitReached, "user '%s' already leads %d teams", leader, maxTeamsPerUser).With("user_id", leader)
+  }
+
+  if _, err := tx.ExecContext(ctx, restoreStmt, teamId); err != nil {
+    return nil, err
+  }
+  if err := touchTeam(ctx, tx, teamId); err != nil {
+    return nil, err
+  }
+
+  // record team_restored event in the outbox
+  err = insertOutboxEvent(tx, TeamRestoredTopic, &v1.TeamRestored{
+    TeamId:     teamId,
+    RestoredBy: userId,
+    OccurredAt: time.Now().Unix(),
+  })
+  if err != nil {
+    return nil, err
+  }
+  err = insertAuditEvent(ctx, tx, teamId, AuditRestoreTeam, auditState{"deleted_at": deletedAt}, nil)
+  if err != nil {
+    return nil, err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return nil, err
+  }
+
+  id, _ := strconv.ParseInt(teamId, 10, 64)
+  return r.loadTeam(ctx, id)
+}
+
+// Lists the teams led by userId that are in the trash and may still be
+// restored, most recently deleted first
+func (r *teamRepository) ListDeletedTeams(ctx context.Context, userId string) ([]*v1.DeletedTeam, error) {
+  selectStmt := `SELECT id, deleted_at, deleted_by FROM teams WHERE leader=? AND deleted_at >= ? ORDER BY deleted_at DESC, id DESC`
+
+  rows, err := r.db.QueryContext(ctx, selectStmt, userId, r.purgeCutoff())
+  if err != nil {
+    return nil, err
+  }
+  defer rows.Close()
+
+  ids := []int64{}
+  byId := map[int64]*v1.DeletedTeam{}
+  for rows.Next() {
+    var id int64
+    var deletedBy sql.NullString
+    deleted := &v1.DeletedTeam{}
+    if err := rows.Scan(&id, &deleted.DeletedAt, &deletedBy); err != nil {
+      return nil, err
+    }
+    deleted.DeletedBy = deletedBy.String
+    deleted.PurgeAt = deleted.DeletedAt + int64(r.retention/time.Second)
+    ids = append(ids, id)
+    byId[id] = deleted
+  }
+  if err := rows.Err(); err != nil {
+    return nil, err
+  }
+
+  teams, err := r.loadTeamsIn(ctx, ids, true)
+  if err != nil {
+    return nil, err
+  }
+  deleted := []*v1.DeletedTeam{}
+  for _, team := range teams {
+    id, _ := strconv.ParseInt(team.Id, 10, 64)
+    byId[id].Team = team
+    deleted = append(deleted, byId[id])
+  }
+  return deleted, nil
+}
+
+// Deletes for good a batch of the teams that have been in the trash for
+// longer than the retention period
+// output ON SUCCESS: int - number of teams purged, less than a batch once the trash is drained
+// output ON FAILURE: int - teams purged before the error, error - the error object from whatever created the error
+func (r *teamRepository) PurgeDeletedTeams(ctx context.Context) (int, error) {
+  selectStmt := `SELECT id FROM teams WHERE deleted_at < ? ORDER BY deleted_at LIMIT ?`
+
+  cutoff := r.purgeCutoff()
+  rows, err := r.db.QueryContext(ctx, selectStmt, cutoff, purgeBatchSize)
+  if err != nil {
+    return 0, err
+  }
+  ids := []int64{}
+  for rows.Next() {
+    var id int64
+    if err := rows.Scan(&id); err != nil {
+      rows.Close()
+      return 0, err
+    }
+    ids = append(ids, id)
+  }
+  rows.Close()
+  if err := rows.Err(); err != nil {
+    return 0, err
+  }
+
+  purged := 0
+  for _, id := range ids {
+    ok, err := r.purgeTeam(ctx, id, cutoff)
+    if err != nil {
+      return purged, err
+    }
+    if ok {
+      purged++
+    }
+  }
+  return purged, nil
+}
+
+// ---------------------------- HELPER FUNCTIONS -------------------------------
+
+// purgeCutoff returns the unix time before which deleted teams are purged
+func (r *teamRepository) purgeCutoff() int64 {
+  return time.Now().Add(-r.retention).Unix()
+}
+
+// purgeTeam deletes team id and everything it holds if it was deleted before
+// cutoff, false if it was restored or purged meanwhile
+func (r *teamRepository) purgeTeam(ctx context.Context, id, cutoff int64) (bool, error) {
+  teamStmt := `SELECT id FROM teams WHERE id=? AND deleted_at < ? FOR UPDATE`
+  deleteStmts := []string{
+    `DELETE FROM invitations WHERE team_id=?`,
+    `DELETE FROM applications WHERE team_id=?`,
+    `DELETE FROM tasks WHERE team_id=?`,
+    `DELETE FROM milestones WHERE team_id=?`,
+    `DELETE FROM languages WHERE team_id=?`,
+    `DELETE FROM projects WHERE team_id=?`,
+    `DELETE FROM members WHERE team_id=?`,
+    `DELETE FROM skills WHERE team_id=?`,
+    `DELETE FROM teams WHERE id=?`,
+  }
+
+  tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+  if err != nil {
+    return false, err
+  }
+  defer tx.Rollback()
+
+  // lock the team, a concurrent restore wins
+  err = tx.QueryRowContext(ctx, teamStmt, id, cutoff).Scan(&id)
+  if err == sql.ErrNoRows {
+    return false, nil
+  } else if err != nil {
+    return false, err
+  }
+
+  // rows referencing the team go first
+  for _, stmt := range deleteStmts {
+    if _, err := tx.ExecContext(ctx, stmt, id); err != nil {
+      return false, err
+    }
+  }
+
+  // record team_purged event in the outbox
+  err = insertOutboxEvent(tx, TeamPurgedTopic, &v1.TeamPurged{
+    TeamId:     strconv.FormatInt(id, 10),
+    OccurredAt: time.Now().Unix(),
+  })
+  if err != nil {
+    return false, err
+  }
+  // the trail of a purged team is kept, ending with its purge
+  err = insertAuditEvent(ctx, tx, strconv.FormatInt(id, 10), AuditPurgeTeam, nil, nil)
+  if err != nil {
+    return false, err
+  }
+
+  if err := tx.Commit(); err != nil {
+    return false, err
+  }
+  return true, nil
+}
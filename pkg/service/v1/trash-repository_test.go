@@ -0,0 +1,162 @@
+package v1
+
+import (
+  "context"
+  "database/sql/driver"
+  "testing"
+  "time"
+
+  "github.com/DATA-DOG/go-sqlmock"
+
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// expectLoadTeam expects team 3 named gophers to be loaded from the teams
+// matching trash, with nothing in it
+func expectLoadTeam(mock sqlmock.Sqlmock, trash string) {
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`FROM teams WHERE id IN (?)`+trash)).WithArgs(int64(3)).
+    WillReturnRows(sqlmock.NewRows([]string{"id", "leader", "team_name", "open_roles", "size", "last_active", "auto_close_applications", "version", "inactive_at", "archived_at"}).
+      AddRow(3, "1", "gophers", 1, 4, 1600000000, false, 5, nil, nil))
+  // without projects there is no progress to load
+  for _, table := range []string{"members", "skills", "projects", "languages"} {
+    mock.ExpectQuery(stmt(`FROM `+table+` WHERE team_id IN (?)`)).WillReturnRows(sqlmock.NewRows(nil))
+  }
+  mock.ExpectRollback()
+}
+
+func TestDeleteTeamMovesItToTheTrash(t *testing.T) {
+  repo, mock := newMockRepository(t)
+  var after driver.Value
+
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 1)
+  // nothing is deleted until the team is purged
+  mock.ExpectExec(stmt(`UPDATE teams SET deleted_at=?, deleted_by=? WHERE id=?`)).WithArgs(sqlmock.AnyArg(), "1", "3").
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM members`)).WithArgs("3").WillReturnRows(countRow(4))
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM skills`)).WithArgs("3").WillReturnRows(countRow(2))
+  expectOutbox(mock, TeamDeletedTopic)
+  mock.ExpectExec(stmt(`INSERT INTO audit_events`)).
+    WithArgs("3", sqlmock.AnyArg(), AuditDeleteTeam, sqlmock.AnyArg(), capture{&after}, sqlmock.AnyArg(), sqlmock.AnyArg()).
+    WillReturnResult(sqlmock.NewResult(1, 1))
+  mock.ExpectCommit()
+
+  deleted, members, skills, err := repo.DeleteTeam(context.Background(), "3", "1", 1)
+  if err != nil || deleted != 1 || members != 4 || skills != 2 {
+    t.Errorf("DeleteTeam() = %d, %d, %d, %v", deleted, members, skills, err)
+  }
+  if state, _ := after.(string); state == "" {
+    t.Error("the purge time isn't audited")
+  }
+}
+
+func TestCheckTeamNameTakenCountsTheTrash(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  // no deleted_at filter, the name of a deleted team stays reserved
+  mock.ExpectQuery(`^SELECT COUNT\(\*\) FROM teams WHERE team_name=\?$`).WithArgs("gophers").WillReturnRows(countRow(1))
+  if taken, err := repo.CheckTeamNameTaken(context.Background(), "gophers"); err != nil || !taken {
+    t.Errorf("CheckTeamNameTaken() = %v, %v", taken, err)
+  }
+}
+
+func TestRestoreTeam(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`SELECT leader, deleted_at FROM teams WHERE id=? AND deleted_at >= ? FOR UPDATE`)).WithArgs("3", sqlmock.AnyArg()).
+    WillReturnRows(sqlmock.NewRows([]string{"leader", "deleted_at"}).AddRow("1", 1600000000))
+  mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM teams WHERE leader=? AND deleted_at IS NULL`)).WithArgs("1").WillReturnRows(countRow(0))
+  mock.ExpectExec(stmt(`UPDATE teams SET deleted_at=NULL, deleted_by=NULL, version = version + 1 WHERE id=?`)).WithArgs("3").
+    WillReturnResult(sqlmock.NewResult(0, 1))
+  mock.ExpectExec(stmt(`UPDATE teams SET last_active=?`)).WithArgs(sqlmock.AnyArg(), "3").WillReturnResult(sqlmock.NewResult(0, 1))
+  expectOutbox(mock, TeamRestoredTopic)
+  expectAudit(mock, "3", AuditRestoreTeam)
+  mock.ExpectCommit()
+  // the restored team is out of the trash
+  expectLoadTeam(mock, ` AND deleted_at IS NULL`)
+
+  if team, err := repo.RestoreTeam(context.Background(), "3", "1"); err != nil || team.Name != "gophers" {
+    t.Errorf("RestoreTeam() = %v, %v", team, err)
+  }
+}
+
+func TestRestoreTeamChecks(t *testing.T) {
+  tests := []struct {
+    name   string
+    expect func(mock sqlmock.Sqlmock)
+    reason string
+  }{
+    // purged, past retention or never deleted
+    {"not in the trash", func(mock sqlmock.Sqlmock) {
+      mock.ExpectQuery(stmt(`SELECT leader, deleted_at FROM teams`)).WillReturnRows(sqlmock.NewRows([]string{"leader", "deleted_at"}))
+    }, domainerr.ReasonTeamNotFound},
+    {"leader at the cap", func(mock sqlmock.Sqlmock) {
+      mock.ExpectQuery(stmt(`SELECT leader, deleted_at FROM teams`)).
+        WillReturnRows(sqlmock.NewRows([]string{"leader", "deleted_at"}).AddRow("1", 1600000000))
+      mock.ExpectQuery(stmt(`SELECT COUNT(*) FROM teams WHERE leader=?`)).WillReturnRows(countRow(maxTeamsPerUser))
+    }, domainerr.ReasonTeamLimitReached},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      repo, mock := newMockRepository(t)
+      mock.ExpectBegin()
+      tt.expect(mock)
+      mock.ExpectRollback()
+
+      if _, err := repo.RestoreTeam(context.Background(), "3", "1"); domainerr.ReasonOf(err) != tt.reason {
+        t.Errorf("RestoreTeam() error = %v, want %s", err, tt.reason)
+      }
+    })
+  }
+}
+
+func TestListDeletedTeams(t *testing.T) {
+  repo, mock := newMockRepository(t)
+  repo.retention = 24 * time.Hour
+
+  mock.ExpectQuery(stmt(`SELECT id, deleted_at, deleted_by FROM teams WHERE leader=? AND deleted_at >= ?`)).WithArgs("1", sqlmock.AnyArg()).
+    WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at", "deleted_by"}).AddRow(3, 1600000000, "2"))
+  // only teams in the trash are loaded
+  expectLoadTeam(mock, ` AND deleted_at IS NOT NULL`)
+
+  deleted, err := repo.ListDeletedTeams(context.Background(), "1")
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(deleted) != 1 || deleted[0].Team.Name != "gophers" || deleted[0].DeletedBy != "2" {
+    t.Fatalf("ListDeletedTeams() = %v", deleted)
+  }
+  if deleted[0].PurgeAt != 1600000000+24*60*60 {
+    t.Errorf("purge at %d, want a day after the delete", deleted[0].PurgeAt)
+  }
+}
+
+func TestPurgeDeletedTeams(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectQuery(stmt(`SELECT id FROM teams WHERE deleted_at < ? ORDER BY deleted_at LIMIT ?`)).WithArgs(sqlmock.AnyArg(), purgeBatchSize).
+    WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(5))
+
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`SELECT id FROM teams WHERE id=? AND deleted_at < ? FOR UPDATE`)).WithArgs(int64(3), sqlmock.AnyArg()).
+    WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+  // rows referencing the team go first
+  for _, table := range []string{"invitations", "applications", "tasks", "milestones", "languages", "projects", "members", "skills"} {
+    mock.ExpectExec(stmt(`DELETE FROM ` + table + ` WHERE team_id=?`)).WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+  }
+  mock.ExpectExec(stmt(`DELETE FROM teams WHERE id=?`)).WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+  expectOutbox(mock, TeamPurgedTopic)
+  expectAudit(mock, "3", AuditPurgeTeam)
+  mock.ExpectCommit()
+
+  // team 5 was restored meanwhile
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`SELECT id FROM teams WHERE id=?`)).WithArgs(int64(5), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+  mock.ExpectRollback()
+
+  if purged, err := repo.PurgeDeletedTeams(context.Background()); err != nil || purged != 1 {
+    t.Errorf("PurgeDeletedTeams() = %d, %v, want 1", purged, err)
+  }
+}
@@ -0,0 +1,65 @@
+package v1
+
+import (
+  "context"
+  "fmt"
+  "os"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+)
+
+func (s *handler) RestoreTeam(ctx context.Context, req *v1.RestoreTeamRequest) (*v1.RestoreTeamResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  // the owner of a team in the trash still leads it
+  userId, _, err := s.authorizeIn(ctx, req.TeamId, ActionRestoreTeam, true)
+  if err != nil {
+    return nil, err
+  }
+
+  // the repository checks the team is within retention and the owner's cap
+  team, err := s.repo.RestoreTeam(ctx, req.TeamId, userId)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo RestoreTeam: %v\n", req.TeamId)
+    return nil, err
+  }
+
+  // team_restored Event is written to the outbox by the repository
+
+  return &v1.RestoreTeamResponse{
+    Api:    apiVersion,
+    Status: "Restored",
+    Team:   team,
+  }, nil
+}
+
+func (s *handler) ListDeletedTeams(ctx context.Context, req *v1.ListDeletedTeamsRequest) (*v1.ListDeletedTeamsResponse, error) {
+  // check api version
+  if err := s.checkAPI(req.Api); err != nil {
+    return nil, err
+  }
+
+  userId, err := s.callerId(ctx)
+  if err != nil {
+    return nil, err
+  }
+
+  teams, err := s.repo.ListDeletedTeams(ctx, userId)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "error from Repo ListDeletedTeams: %v\n", userId)
+    return nil, err
+  }
+
+  status := "deleted teams"
+  if len(teams) == 0 {
+    status = "empty"
+  }
+  return &v1.ListDeletedTeamsResponse{
+    Api:    apiVersion,
+    Status: status,
+    Teams:  teams,
+  }, nil
+}
@@ -0,0 +1,91 @@
+package v1
+
+import (
+  "context"
+  "errors"
+  "testing"
+
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// trashRepository is team 3 of rolesRepository in the trash, it records who
+// restores and lists and how often the trash is purged
+type trashRepository struct {
+  *rolesRepository
+  restored []string
+  listed   []string
+  batches  []int
+  purges   int
+}
+
+// GetMemberRole doesn't find team 3, its roles are only seen in the trash
+func (r *trashRepository) GetMemberRole(ctx context.Context, userId, teamId string) (string, error) {
+  return "", domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", teamId)
+}
+
+func (r *trashRepository) GetDeletedMemberRole(ctx context.Context, userId, teamId string) (string, error) {
+  return r.roles[userId], nil
+}
+
+func (r *trashRepository) RestoreTeam(ctx context.Context, teamId, userId string) (*v1.Team, error) {
+  r.restored = append(r.restored, userId)
+  return &v1.Team{Id: teamId}, nil
+}
+
+func (r *trashRepository) ListDeletedTeams(ctx context.Context, userId string) ([]*v1.DeletedTeam, error) {
+  r.listed = append(r.listed, userId)
+  return []*v1.DeletedTeam{}, nil
+}
+
+// PurgeDeletedTeams purges the next of batches, then fails
+func (r *trashRepository) PurgeDeletedTeams(ctx context.Context) (int, error) {
+  r.purges++
+  if len(r.batches) == 0 {
+    return 0, errors.New("database is down")
+  }
+  count := r.batches[0]
+  r.batches = r.batches[1:]
+  return count, nil
+}
+
+func newTrashHandler() (*handler, *trashRepository) {
+  _, roles := newRolesHandler()
+  repo := &trashRepository{rolesRepository: roles}
+  return NewTeamServiceServer(repo, nil, ""), repo
+}
+
+func TestRestoreTeamIsForOwners(t *testing.T) {
+  s, repo := newTrashHandler()
+
+  for _, caller := range []string{"2", "3", "42"} {
+    if _, err := s.RestoreTeam(as(caller), &v1.RestoreTeamRequest{Api: apiVersion, TeamId: "3"}); status.Code(err) != codes.PermissionDenied {
+      t.Errorf("RestoreTeam() by %s error = %v, want PermissionDenied", caller, err)
+    }
+  }
+  res, err := s.RestoreTeam(as("1"), &v1.RestoreTeamRequest{Api: apiVersion, TeamId: "3"})
+  if err != nil || res.Team.Id != "3" {
+    t.Fatalf("RestoreTeam() = %v, %v", res, err)
+  }
+  if len(repo.restored) != 1 || repo.restored[0] != "1" {
+    t.Errorf("restored by %v", repo.restored)
+  }
+}
+
+func TestListDeletedTeamsOfTheCaller(t *testing.T) {
+  s, repo := newTrashHandler()
+
+  if _, err := s.ListDeletedTeams(context.Background(), &v1.ListDeletedTeamsRequest{Api: apiVersion}); status.Code(err) != codes.Unauthenticated {
+    t.Errorf("anonymous ListDeletedTeams() error = %v, want Unauthenticated", err)
+  }
+  res, err := s.ListDeletedTeams(as("1"), &v1.ListDeletedTeamsRequest{Api: apiVersion})
+  if err != nil || res.Status != "empty" {
+    t.Fatalf("ListDeletedTeams() = %v, %v", res, err)
+  }
+  if len(repo.listed) != 1 || repo.listed[0] != "1" {
+    t.Errorf("listed for %v", repo.listed)
+  }
+}
@@ -0,0 +1,89 @@
+package v1
+
+import (
+  "context"
+  "database/sql"
+  "strconv"
+
+  "google.golang.org/grpc/metadata"
+
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// initialVersion is the version of a new team
+const initialVersion = 1
+
+// expectedVersionMetadata is the request metadata the REST gateway sets from
+// an If-Match header, it stands in for an unset expected_version field
+const expectedVersionMetadata = "expected-version"
+
+// expectedVersion returns the version a mutating request expects the team
+// to be at, version when set, otherwise the expected-version metadata. 0
+// means the request doesn't check the version.
+func expectedVersion(ctx context.Context, version int64) (int64, error) {
+  if version != 0 {
+    return version, nil
+  }
+  md, ok := metadata.FromIncomingContext(ctx)
+  if !ok || len(md.Get(expectedVersionMetadata)) == 0 {
+    return 0, nil
+  }
+  value := md.Get(expectedVersionMetadata)[0]
+  version, err := strconv.ParseInt(value, 10, 64)
+  if err != nil || version <= 0 {
+    return 0, domainerr.InvalidArgument(domainerr.FieldViolation{Field: "expected_version", Description: "must be a team version"})
+  }
+  return version, nil
+}
+
+// nextVersion locks team teamId within tx, checks it is at version expected
+// unless expected is 0, increments its version and marks it active. Every
+// change to a team goes through it so the version changes whenever the team
+// does. Teams in the trash are not found.
+// output ON SUCCESS: int64 - the new version of the team
+// output ON FAILURE: TEAM_NOT_FOUND, VERSION_MISMATCH or the error object from whatever created the error
+func nextVersion(ctx context.Context, tx *sql.Tx, teamId string, expected int64) (int64, error) {
+  version, err := lockVersion(ctx, tx, teamId, expected)
+  if err != nil {
+    return 0, err
+  }
+  return bumpVersion(ctx, tx, teamId, version)
+}
+
+// lockVersion is the first half of nextVersion, it locks team teamId within
+// tx and checks its version without changing it. Mutations that may turn out
+// to change nothing lock first and bump once they know they do.
+// output ON SUCCESS: int64 - the current version of the team
+// output ON FAILURE: TEAM_NOT_FOUND, VERSION_MISMATCH or the error object from whatever created the error
+func lockVersion(ctx context.Context, tx *sql.Tx, teamId string, expected int64) (int64, error) {
+  selectStmt := `SELECT version FROM teams WHERE id=? AND deleted_at IS NULL FOR UPDATE`
+
+  var version int64
+  err := tx.QueryRowContext(ctx, selectStmt, teamId).Scan(&version)
+  if err == sql.ErrNoRows {
+    return 0, domainerr.NotFound(domainerr.ReasonTeamNotFound, "team '%s' not found", teamId).With("team_id", teamId)
+  } else if err != nil {
+    return 0, err
+  }
+  if expected != 0 && expected != version {
+    return 0, domainerr.Aborted(domainerr.ReasonVersionMismatch, "team '%s' is at version %d, not %d", teamId, version, expected).
+      With("team_id", teamId).
+      With("current_version", strconv.FormatInt(version, 10))
+  }
+  return version, nil
+}
+
+// bumpVersion is the second half of nextVersion, it moves team teamId locked
+// at version on to the next version and marks it active
+func bumpVersion(ctx context.Context, tx *sql.Tx, teamId string, version int64) (int64, error) {
+  updateStmt := `UPDATE teams SET version=? WHERE id=?`
+
+  version++
+  if _, err := tx.ExecContext(ctx, updateStmt, version, teamId); err != nil {
+    return 0, err
+  }
+  if err := touchTeam(ctx, tx, teamId); err != nil {
+    return 0, err
+  }
+  return version, nil
+}
@@ -0,0 +1,150 @@
+package v1
+
+import (
+  "context"
+  "errors"
+  "testing"
+
+  "github.com/DATA-DOG/go-sqlmock"
+  "google.golang.org/genproto/protobuf/field_mask"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+
+  v1 "github.com/ckbball/dev-team/pkg/api/v1"
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// ifMatch is the context the REST gateway passes on for an If-Match header
+func ifMatch(ctx context.Context, version string) context.Context {
+  return metadata.NewIncomingContext(ctx, metadata.Pairs(expectedVersionMetadata, version))
+}
+
+func TestExpectedVersion(t *testing.T) {
+  tests := []struct {
+    name    string
+    ctx     context.Context
+    version int64
+    want    int64
+    ok      bool
+  }{
+    {"unchecked", context.Background(), 0, 0, true},
+    {"field", context.Background(), 4, 4, true},
+    {"if-match", ifMatch(context.Background(), "5"), 0, 5, true},
+    // the field wins over the header
+    {"field and if-match", ifMatch(context.Background(), "5"), 4, 4, true},
+    {"not a number", ifMatch(context.Background(), "five"), 0, 0, false},
+    {"not a version", ifMatch(context.Background(), "0"), 0, 0, false},
+  }
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      got, err := expectedVersion(tt.ctx, tt.version)
+      if got != tt.want || (err == nil) != tt.ok {
+        t.Errorf("expectedVersion() = %d, %v, want %d", got, err, tt.want)
+      }
+      if !tt.ok && domainerr.ReasonOf(err) != domainerr.ReasonInvalidArgument {
+        t.Errorf("expectedVersion() error = %v, want %s", err, domainerr.ReasonInvalidArgument)
+      }
+    })
+  }
+}
+
+func TestNextVersion(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 7)
+  mock.ExpectCommit()
+  tx, _ := repo.db.Begin()
+  if version, err := nextVersion(context.Background(), tx, "3", 7); err != nil || version != 8 {
+    t.Errorf("nextVersion() = %d, %v, want 8", version, err)
+  }
+  tx.Commit()
+
+  // 0 doesn't check the version
+  mock.ExpectBegin()
+  expectNextVersion(mock, "3", 8)
+  mock.ExpectCommit()
+  tx, _ = repo.db.Begin()
+  if version, err := nextVersion(context.Background(), tx, "3", 0); err != nil || version != 9 {
+    t.Errorf("nextVersion() = %d, %v, want 9", version, err)
+  }
+  tx.Commit()
+}
+
+func TestNextVersionChecks(t *testing.T) {
+  repo, mock := newMockRepository(t)
+
+  // the team isn't changed on a mismatch
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`SELECT version FROM teams`)).WithArgs("3").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(9))
+  mock.ExpectRollback()
+  tx, _ := repo.db.Begin()
+  _, err := nextVersion(context.Background(), tx, "3", 8)
+  tx.Rollback()
+
+  var derr *domainerr.Error
+  if !errors.As(err, &derr) || derr.Code != codes.Aborted || derr.Reason != domainerr.ReasonVersionMismatch {
+    t.Fatalf("nextVersion() error = %v, want %s", err, domainerr.ReasonVersionMismatch)
+  }
+  // clients retry against the current version
+  if derr.Metadata["current_version"] != "9" {
+    t.Errorf("metadata = %v", derr.Metadata)
+  }
+
+  // missing teams aren't found
+  mock.ExpectBegin()
+  mock.ExpectQuery(stmt(`SELECT version FROM teams`)).WithArgs("3").WillReturnRows(sqlmock.NewRows([]string{"version"}))
+  mock.ExpectRollback()
+  tx, _ = repo.db.Begin()
+  _, err = nextVersion(context.Background(), tx, "3", 0)
+  tx.Rollback()
+  if domainerr.ReasonOf(err) != domainerr.ReasonTeamNotFound {
+    t.Errorf("nextVersion() error = %v, want %s", err, domainerr.ReasonTeamNotFound)
+  }
+}
+
+// versionsRepository is team 3 of rolesRepository, it records the version
+// updates expect
+type versionsRepository struct {
+  *rolesRepository
+  expected []int64
+}
+
+func (r *versionsRepository) UpdateTeam(ctx context.Context, id string, team *v1.Team, paths []string, expected int64) (*v1.Team, error) {
+  r.expected = append(r.expected, expected)
+  return &v1.Team{Id: id, Version: expected + 1}, nil
+}
+
+func TestUpdateTeamExpectsIfMatch(t *testing.T) {
+  _, roles := newRolesHandler()
+  repo := &versionsRepository{rolesRepository: roles}
+  s := NewTeamServiceServer(repo, nil, "")
+  req := func(version int64) *v1.UpdateTeamRequest {
+    return &v1.UpdateTeamRequest{Api: apiVersion, Id: "3", Team: &v1.Team{Name: "gophers"}, UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}}, ExpectedVersion: version}
+  }
+
+  if _, err := s.UpdateTeam(ifMatch(as("2"), "5"), req(0)); err != nil {
+    t.Fatal(err)
+  }
+  if _, err := s.UpdateTeam(as("2"), req(6)); err != nil {
+    t.Fatal(err)
+  }
+  if _, err := s.UpdateTeam(ifMatch(as("2"), "five"), req(0)); status.Code(err) != codes.InvalidArgument {
+    t.Errorf("UpdateTeam() error = %v, want InvalidArgument", err)
+  }
+  if len(repo.expected) != 2 || repo.expected[0] != 5 || repo.expected[1] != 6 {
+    t.Errorf("expected versions %v", repo.expected)
+  }
+}
+
+func TestUpdateTeamWithoutAMask(t *testing.T) {
+  _, roles := newRolesHandler()
+  repo := &versionsRepository{rolesRepository: roles}
+  s := NewTeamServiceServer(repo, nil, "")
+
+  // a request without a mask updates nothing instead of panicking
+  if _, err := s.UpdateTeam(as("2"), &v1.UpdateTeamRequest{Api: apiVersion, Id: "3", ExpectedVersion: 6}); err != nil {
+    t.Fatal(err)
+  }
+}
@@ -0,0 +1,223 @@
+// Package validate checks request fields against declarative rules and
+// reports every violation at once as an InvalidArgument domain error.
+package validate
+
+import (
+  "fmt"
+  "net/mail"
+  "net/url"
+  "strconv"
+  "strings"
+  "unicode/utf8"
+
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+// Rule checks a field value and returns why it is invalid, "" if it is valid.
+// Values are strings, integers or string lists.
+type Rule func(value interface{}) string
+
+// Validator collects the violations of a message's fields
+type Validator struct {
+  prefix     string
+  violations *[]domainerr.FieldViolation
+}
+
+func New() *Validator {
+  return &Validator{violations: &[]domainerr.FieldViolation{}}
+}
+
+// Field checks value against rules, stopping at the first rule it breaks
+func (v *Validator) Field(name string, value interface{}, rules ...Rule) *Validator {
+  for _, rule := range rules {
+    if description := rule(value); description != "" {
+      v.Violation(name, description)
+      break
+    }
+  }
+  return v
+}
+
+// Check records a violation of field name unless ok, for rules involving
+// several fields
+func (v *Validator) Check(name string, ok bool, description string) *Validator {
+  if !ok {
+    v.Violation(name, description)
+  }
+  return v
+}
+
+// Violation records an invalid field
+func (v *Validator) Violation(name, description string) *Validator {
+  *v.violations = append(*v.violations, domainerr.FieldViolation{
+    Field:       v.prefix + name,
+    Description: description,
+  })
+  return v
+}
+
+// Nested returns a validator reporting fields of the message in field name
+func (v *Validator) Nested(name string) *Validator {
+  return &Validator{
+    prefix:     v.prefix + name + ".",
+    violations: v.violations,
+  }
+}
+
+// Err returns an InvalidArgument error listing every violation, nil if none
+func (v *Validator) Err() error {
+  if len(*v.violations) == 0 {
+    return nil
+  }
+  return domainerr.InvalidArgument(*v.violations...)
+}
+
+// Required rejects empty strings and lists
+func Required(value interface{}) string {
+  switch x := value.(type) {
+  case string:
+    if strings.TrimSpace(x) == "" {
+      return "is required"
+    }
+  case []string:
+    if len(x) == 0 {
+      return "is required"
+    }
+  }
+  return ""
+}
+
+// MaxLen rejects strings longer than n characters and lists of more than n items
+func MaxLen(n int) Rule {
+  return func(value interface{}) string {
+    switch x := value.(type) {
+    case string:
+      if utf8.RuneCountInString(x) > n {
+        return fmt.Sprintf("must be at most %d characters", n)
+      }
+    case []string:
+      if len(x) > n {
+        return fmt.Sprintf("must have at most %d items", n)
+      }
+    }
+    return ""
+  }
+}
+
+// Min rejects integers lower than n
+func Min(n int64) Rule {
+  return func(value interface{}) string {
+    if i, ok := toInt(value); ok && i < n {
+      return fmt.Sprintf("must be at least %d", n)
+    }
+    return ""
+  }
+}
+
+// Max rejects integers greater than n
+func Max(n int64) Rule {
+  return func(value interface{}) string {
+    if i, ok := toInt(value); ok && i > n {
+      return fmt.Sprintf("must be at most %d", n)
+    }
+    return ""
+  }
+}
+
+// Id rejects strings that aren't a positive integer id, empty strings pass
+// so ids may be optional
+func Id(value interface{}) string {
+  s, _ := value.(string)
+  if s == "" {
+    return ""
+  }
+  if id, err := strconv.ParseInt(s, 10, 64); err != nil || id <= 0 {
+    return "must be a positive integer id"
+  }
+  return ""
+}
+
+// Email rejects strings that aren't a bare email address, empty strings pass
+func Email(value interface{}) string {
+  s, _ := value.(string)
+  if s == "" {
+    return ""
+  }
+  addr, err := mail.ParseAddress(s)
+  if err != nil || addr.Address != s {
+    return "must be a valid email address"
+  }
+  return ""
+}
+
+// HostURL rejects strings that aren't an http(s) url on host, empty strings
+// pass. Urls without a scheme are accepted, e.g. github.com/user/repo.
+func HostURL(host string) Rule {
+  return func(value interface{}) string {
+    s, _ := value.(string)
+    if s == "" {
+      return ""
+    }
+    if !strings.Contains(s, "://") {
+      s = "https://" + s
+    }
+    u, err := url.Parse(s)
+    if err != nil || (u.Scheme != "http" && u.Scheme != "https") ||
+      !strings.EqualFold(strings.TrimPrefix(u.Hostname(), "www."), host) {
+      return fmt.Sprintf("must be a %s url", host)
+    }
+    return ""
+  }
+}
+
+// OneOf rejects strings other than values
+func OneOf(values ...string) Rule {
+  return func(value interface{}) string {
+    s, _ := value.(string)
+    for _, v := range values {
+      if s == v {
+        return ""
+      }
+    }
+    return fmt.Sprintf("must be one of %s", strings.Join(values, ", "))
+  }
+}
+
+// Enum rejects values missing from names, the _name map of a generated enum
+func Enum(names map[int32]string) Rule {
+  return func(value interface{}) string {
+    if i, ok := toInt(value); ok {
+      if _, ok := names[int32(i)]; !ok {
+        return "is not a known value"
+      }
+    }
+    return ""
+  }
+}
+
+// Each checks every item of a string list against rules
+func Each(rules ...Rule) Rule {
+  return func(value interface{}) string {
+    items, _ := value.([]string)
+    for i, item := range items {
+      for _, rule := range rules {
+        if description := rule(item); description != "" {
+          return fmt.Sprintf("item %d %s", i, description)
+        }
+      }
+    }
+    return ""
+  }
+}
+
+func toInt(value interface{}) (int64, bool) {
+  switch x := value.(type) {
+  case int:
+    return int64(x), true
+  case int32:
+    return int64(x), true
+  case int64:
+    return x, true
+  }
+  return 0, false
+}
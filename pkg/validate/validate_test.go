@@ -0,0 +1,92 @@
+package validate
+
+import (
+  "reflect"
+  "strings"
+  "testing"
+
+  "github.com/ckbball/dev-team/pkg/domainerr"
+)
+
+func TestRules(t *testing.T) {
+  tests := []struct {
+    name  string
+    rule  Rule
+    value interface{}
+    ok    bool
+  }{
+    {"required string", Required, "gophers", true},
+    {"required blank string", Required, "  ", false},
+    {"required list", Required, []string{"go"}, true},
+    {"required empty list", Required, []string{}, false},
+    {"max length", MaxLen(3), "abc", true},
+    {"max length exceeded", MaxLen(3), "abcd", false},
+    {"max length counts characters", MaxLen(3), "äöü", true},
+    {"max items exceeded", MaxLen(1), []string{"a", "b"}, false},
+    {"min", Min(1), int32(1), true},
+    {"min broken", Min(1), int64(0), false},
+    {"max", Max(100), 100, true},
+    {"max broken", Max(100), int64(101), false},
+    {"id", Id, "42", true},
+    {"optional id", Id, "", true},
+    {"zero id", Id, "0", false},
+    {"negative id", Id, "-3", false},
+    {"non-numeric id", Id, "3a", false},
+    {"email", Email, "m@example.com", true},
+    {"optional email", Email, "", true},
+    {"email with a name", Email, "Gopher <m@example.com>", false},
+    {"not an email", Email, "m.example.com", false},
+    {"github url", HostURL("github.com"), "https://github.com/ckbball/dev-team", true},
+    {"github url without a scheme", HostURL("github.com"), "github.com/ckbball/dev-team", true},
+    {"github url with www", HostURL("github.com"), "http://www.GitHub.com/ckbball", true},
+    {"other host", HostURL("github.com"), "https://gitlab.com/ckbball", false},
+    {"lookalike host", HostURL("github.com"), "https://github.com.evil.io/ckbball", false},
+    {"other scheme", HostURL("github.com"), "ftp://github.com/ckbball", false},
+    {"one of", OneOf("a", "b"), "b", true},
+    {"none of", OneOf("a", "b"), "c", false},
+    {"enum", Enum(map[int32]string{0: "A", 1: "B"}), int32(1), true},
+    {"unknown enum", Enum(map[int32]string{0: "A", 1: "B"}), int32(2), false},
+    {"each", Each(Required, MaxLen(2)), []string{"go", "js"}, true},
+    {"each broken", Each(Required, MaxLen(2)), []string{"go", "rust"}, false},
+  }
+  for _, tt := range tests {
+    if got := tt.rule(tt.value) == ""; got != tt.ok {
+      t.Errorf("%s: rule(%v) = %q", tt.name, tt.value, tt.rule(tt.value))
+    }
+  }
+}
+
+func TestEachNamesTheItem(t *testing.T) {
+  if got := Each(Required)([]string{"go", ""}); got != "item 1 is required" {
+    t.Errorf("Each() = %q", got)
+  }
+}
+
+func TestValidatorCollectsEveryViolation(t *testing.T) {
+  v := New()
+  v.Field("name", "", Required, MaxLen(3))
+  v.Field("size", int32(0), Min(1))
+  v.Field("id", "7", Required, Id)
+  v.Check("open_roles", false, "must not exceed size")
+  v.Nested("members[0]").Field("email", "bad", Email)
+
+  err := v.Err()
+  if domainerr.ReasonOf(err) != domainerr.ReasonInvalidArgument {
+    t.Fatalf("Err() = %v", err)
+  }
+  fields := []string{}
+  for _, violation := range err.(*domainerr.Error).Violations {
+    fields = append(fields, violation.Field)
+  }
+  // a field reports the first rule it breaks only
+  if !reflect.DeepEqual(fields, []string{"name", "size", "open_roles", "members[0].email"}) {
+    t.Errorf("violations = %v", fields)
+  }
+  if !strings.Contains(err.(*domainerr.Error).Violations[0].Description, "required") {
+    t.Errorf("name violation = %v", err.(*domainerr.Error).Violations[0])
+  }
+
+  if err := New().Field("name", "gophers", Required).Err(); err != nil {
+    t.Errorf("Err() without violations = %v", err)
+  }
+}
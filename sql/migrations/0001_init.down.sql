@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS languages;
+
+DROP TABLE IF EXISTS projects;
+
+DROP TABLE IF EXISTS skills;
+
+DROP TABLE IF EXISTS members;
+
+DROP TABLE IF EXISTS teams;
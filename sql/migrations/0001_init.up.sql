@@ -1,20 +1,6 @@
-USE team;
-
-SET FOREIGN_KEY_CHECKS = 0;
- 
-DROP TABLE IF EXISTS teams;
-
-DROP TABLE IF EXISTS projects;
-
-DROP TABLE IF EXISTS members;
-
-DROP TABLE IF EXISTS skills;
-
-DROP TABLE IF EXISTS languages;
-
-SET FOREIGN_KEY_CHECKS = 1;
-
-CREATE TABLE teams (
+-- tables created by the original sql/tables.sql, IF NOT EXISTS lets databases
+-- created by it adopt migrations
+CREATE TABLE IF NOT EXISTS teams (
     id int not null PRIMARY key auto_increment,
     leader varchar(255) not null,
     team_name varchar(25) not null,
@@ -23,7 +9,7 @@ CREATE TABLE teams (
     last_active int
 );
 
-CREATE TABLE members (
+CREATE TABLE IF NOT EXISTS members (
     id int not null PRIMARY key auto_increment,
     user_id int not null,
     member_email varchar(255) not null,
@@ -32,14 +18,14 @@ CREATE TABLE members (
     FOREIGN KEY(team_id) REFERENCES teams(id)
 );
 
-CREATE TABLE skills (
+CREATE TABLE IF NOT EXISTS skills (
     id int not null PRIMARY key auto_increment,
     skill_name varchar(100) not null,
     team_id int,
     FOREIGN KEY(team_id) REFERENCES teams(id)
 );
 
-CREATE TABLE projects (
+CREATE TABLE IF NOT EXISTS projects (
     id int not null PRIMARY key auto_increment,
     goal varchar(1200) not null,
     project_name varchar(30) not null,
@@ -50,9 +36,9 @@ CREATE TABLE projects (
     FOREIGN KEY(team_id) REFERENCES teams(id)
 );
 
-CREATE TABLE languages (
+CREATE TABLE IF NOT EXISTS languages (
     id int not null PRIMARY key auto_increment,
     lang_name varchar(100) not null,
     team_id int,
     FOREIGN KEY(team_id) REFERENCES teams(id)
-);
\ No newline at end of file
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS outbox (
+    id bigint not null PRIMARY key auto_increment,
+    event_id varchar(36) not null,
+    topic varchar(100) not null,
+    payload blob not null,
+    created_at bigint not null,
+    attempts int not null default 0,
+    next_attempt_at bigint not null,
+    published_at bigint,
+    last_error varchar(255),
+    UNIQUE KEY (event_id),
+    KEY (published_at, next_attempt_at)
+);
@@ -0,0 +1,10 @@
+-- databases created by the original sql/tables.sql already have the column,
+-- it is only added when missing
+SET @add_orphaned = (SELECT IF(COUNT(*) = 0,
+    'ALTER TABLE teams ADD COLUMN orphaned tinyint(1) not null default 0',
+    'DO 0')
+  FROM information_schema.columns
+  WHERE table_schema = DATABASE() AND table_name = 'teams' AND column_name = 'orphaned');
+PREPARE add_orphaned FROM @add_orphaned;
+EXECUTE add_orphaned;
+DEALLOCATE PREPARE add_orphaned;
@@ -0,0 +1,10 @@
+-- databases created by the original sql/tables.sql already have the column,
+-- it is only added when missing
+SET @add_access_role = (SELECT IF(COUNT(*) = 0,
+    'ALTER TABLE members ADD COLUMN access_role varchar(20) not null default ''member'' AFTER member_role',
+    'DO 0')
+  FROM information_schema.columns
+  WHERE table_schema = DATABASE() AND table_name = 'members' AND column_name = 'access_role');
+PREPARE add_access_role FROM @add_access_role;
+EXECUTE add_access_role;
+DEALLOCATE PREPARE add_access_role;
@@ -0,0 +1,11 @@
+DROP INDEX teams_size ON teams;
+
+DROP INDEX teams_open_roles ON teams;
+
+DROP INDEX teams_last_active ON teams;
+
+DROP INDEX teams_name ON teams;
+
+DROP INDEX languages_name ON languages;
+
+DROP INDEX skills_name ON skills;
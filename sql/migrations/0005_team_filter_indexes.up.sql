@@ -0,0 +1,11 @@
+CREATE INDEX skills_name ON skills (skill_name, team_id);
+
+CREATE INDEX languages_name ON languages (lang_name, team_id);
+
+CREATE INDEX teams_name ON teams (team_name);
+
+CREATE INDEX teams_last_active ON teams (last_active, id);
+
+CREATE INDEX teams_open_roles ON teams (open_roles, id);
+
+CREATE INDEX teams_size ON teams (size, id);
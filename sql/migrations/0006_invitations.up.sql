@@ -0,0 +1,17 @@
+-- invitations to join a team by email, status is pending, accepted,
+-- declined or revoked, pending invitations past expires_at are expired
+CREATE TABLE invitations (
+    id int not null PRIMARY key auto_increment,
+    team_id int not null,
+    email varchar(255) not null,
+    member_role varchar(40) not null,
+    access_role varchar(20) not null default 'member',
+    invited_by varchar(255) not null,
+    status varchar(20) not null default 'pending',
+    created_at bigint not null,
+    expires_at bigint not null,
+    responded_at bigint,
+    FOREIGN KEY(team_id) REFERENCES teams(id),
+    INDEX invitations_team_status (team_id, status),
+    INDEX invitations_email_status (email, status)
+);
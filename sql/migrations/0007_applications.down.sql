@@ -0,0 +1,3 @@
+ALTER TABLE teams DROP COLUMN auto_close_applications;
+
+DROP TABLE applications;
@@ -0,0 +1,19 @@
+-- applications to join a team, status is pending, approved, rejected,
+-- withdrawn or closed
+CREATE TABLE applications (
+    id int not null PRIMARY key auto_increment,
+    team_id int not null,
+    user_id int not null,
+    email varchar(255) not null,
+    member_role varchar(40) not null,
+    message varchar(1000) not null default '',
+    status varchar(20) not null default 'pending',
+    created_at bigint not null,
+    decided_at bigint,
+    decided_by varchar(255),
+    FOREIGN KEY(team_id) REFERENCES teams(id),
+    INDEX applications_team_status (team_id, status),
+    INDEX applications_user_status (user_id, status)
+);
+
+ALTER TABLE teams ADD COLUMN auto_close_applications tinyint(1) not null default 0;
@@ -0,0 +1,2 @@
+-- version is incremented by every change to a team for optimistic concurrency
+ALTER TABLE teams ADD COLUMN version bigint not null default 1;
@@ -0,0 +1,3 @@
+DROP INDEX teams_deleted_at ON teams;
+ALTER TABLE teams DROP COLUMN deleted_by;
+ALTER TABLE teams DROP COLUMN deleted_at;
@@ -0,0 +1,4 @@
+-- deleted teams stay in the trash until purged, their name stays reserved
+ALTER TABLE teams ADD COLUMN deleted_at bigint;
+ALTER TABLE teams ADD COLUMN deleted_by varchar(255);
+CREATE INDEX teams_deleted_at ON teams (deleted_at);
@@ -0,0 +1,15 @@
+-- append-only trail of every change to a team, rows are never updated and
+-- outlive the teams they describe
+CREATE TABLE audit_events (
+    id bigint not null PRIMARY key auto_increment,
+    team_id int not null,
+    actor varchar(255) not null,
+    action varchar(40) not null,
+    before_state text,
+    after_state text,
+    request_id varchar(64) not null default '',
+    created_at bigint not null,
+    INDEX audit_events_team (team_id, id),
+    INDEX audit_events_actor (actor, id),
+    INDEX audit_events_created (created_at, id)
+);
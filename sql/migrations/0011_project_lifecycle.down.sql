@@ -0,0 +1,7 @@
+ALTER TABLE languages DROP FOREIGN KEY languages_project;
+ALTER TABLE languages DROP COLUMN project_id;
+DROP INDEX projects_team_status ON projects;
+ALTER TABLE projects DROP COLUMN archived_at;
+ALTER TABLE projects DROP COLUMN completed_at;
+ALTER TABLE projects DROP COLUMN started_at;
+ALTER TABLE projects DROP COLUMN status;
@@ -0,0 +1,14 @@
+-- teams keep every project they worked on, status is planning, active,
+-- completed or abandoned and a team has at most one active project.
+-- Existing projects were the team's only project, they are its active one.
+ALTER TABLE projects ADD COLUMN status varchar(20) not null default 'active';
+ALTER TABLE projects ADD COLUMN started_at bigint;
+ALTER TABLE projects ADD COLUMN completed_at bigint;
+ALTER TABLE projects ADD COLUMN archived_at bigint;
+ALTER TABLE projects ALTER COLUMN status SET DEFAULT 'planning';
+CREATE INDEX projects_team_status ON projects (team_id, status);
+
+-- languages belong to a project, team_id is kept for the team filters
+ALTER TABLE languages ADD COLUMN project_id int;
+ALTER TABLE languages ADD CONSTRAINT languages_project FOREIGN KEY (project_id) REFERENCES projects(id);
+UPDATE languages l SET project_id = (SELECT MIN(p.id) FROM projects p WHERE p.team_id = l.team_id);
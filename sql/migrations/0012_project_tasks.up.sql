@@ -0,0 +1,40 @@
+-- milestones and tasks track the progress of a project, status is todo,
+-- in_progress or done and position orders them within the project from 1.
+-- assignee is the member number of the assigned member of the team.
+CREATE TABLE milestones (
+    id int not null PRIMARY key auto_increment,
+    team_id int not null,
+    project_id int not null,
+    title varchar(200) not null,
+    assignee int,
+    due_at bigint,
+    status varchar(20) not null default 'todo',
+    position int not null,
+    created_at bigint not null,
+    completed_at bigint,
+    FOREIGN KEY(team_id) REFERENCES teams(id),
+    FOREIGN KEY(project_id) REFERENCES projects(id),
+    FOREIGN KEY(assignee) REFERENCES members(id) ON DELETE SET NULL,
+    INDEX milestones_project_position (project_id, position)
+);
+
+CREATE TABLE tasks (
+    id int not null PRIMARY key auto_increment,
+    team_id int not null,
+    project_id int not null,
+    milestone_id int,
+    title varchar(200) not null,
+    assignee int,
+    due_at bigint,
+    status varchar(20) not null default 'todo',
+    position int not null,
+    created_at bigint not null,
+    completed_at bigint,
+    FOREIGN KEY(team_id) REFERENCES teams(id),
+    FOREIGN KEY(project_id) REFERENCES projects(id),
+    FOREIGN KEY(milestone_id) REFERENCES milestones(id),
+    FOREIGN KEY(assignee) REFERENCES members(id) ON DELETE SET NULL,
+    INDEX tasks_project_position (project_id, position),
+    INDEX tasks_milestone (milestone_id),
+    INDEX tasks_assignee (assignee)
+);
@@ -0,0 +1,4 @@
+DROP INDEX teams_archived_at ON teams;
+DROP INDEX teams_inactive_at ON teams;
+ALTER TABLE teams DROP COLUMN archived_at;
+ALTER TABLE teams DROP COLUMN inactive_at;
@@ -0,0 +1,9 @@
+-- last_active is kept by the service from now on, teams without one start
+-- their inactivity period now
+UPDATE teams SET last_active = UNIX_TIMESTAMP() WHERE last_active IS NULL OR last_active = 0;
+
+-- teams inactive for too long are flagged, then archived after a grace period
+ALTER TABLE teams ADD COLUMN inactive_at bigint;
+ALTER TABLE teams ADD COLUMN archived_at bigint;
+CREATE INDEX teams_inactive_at ON teams (inactive_at);
+CREATE INDEX teams_archived_at ON teams (archived_at);
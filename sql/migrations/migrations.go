@@ -0,0 +1,11 @@
+// Package migrations embeds the versioned schema migrations of the team
+// database. Files are named NNNN_description.up.sql and
+// NNNN_description.down.sql and are applied in version order by pkg/migrate.
+package migrations
+
+import (
+  "embed"
+)
+
+//go:embed *.sql
+var FS embed.FS